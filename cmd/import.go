@@ -0,0 +1,90 @@
+package main
+
+import (
+	importerDomain "ProjectManagementAPI/internal/domain/importer"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	importerService "ProjectManagementAPI/internal/usecase/importer"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// runImport выполняет "import": переносит файл экспорта сразу, без задания,
+// и печатает отчёт в stdout. Возвращает код выхода.
+//
+//	go run ./cmd import -source jira_xml -file export.xml -default-assignee admin@example.com
+func runImport(logger *slog.Logger, service *importerService.Service, args []string) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	var (
+		source    = fs.String("source", "", "jira_xml, jira_csv, trello or github")
+		file      = fs.String("file", "", "path to the export file")
+		dryRun    = fs.Bool("dry-run", false, "print the report without saving anything")
+		assignee  = fs.String("default-assignee", "", "user id or email for items without a mapped assignee")
+		project   = fs.String("project", "", "project id or name for imported tasks")
+		statusMap = fs.String("status-map", "", `JSON object, e.g. {"Ready for QA":"in_progress"}`)
+		userMap   = fs.String("user-map", "", `JSON object, e.g. {"jdoe":"john@example.com"}`)
+	)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *source == "" || *file == "" {
+		fmt.Fprintln(os.Stderr, "import: -source and -file are required")
+		fs.Usage()
+		return 2
+	}
+
+	opts := importerDomain.Options{
+		DryRun:          *dryRun,
+		DefaultAssignee: *assignee,
+		Project:         *project,
+	}
+	for name, m := range map[string]struct {
+		raw string
+		dst *map[string]string
+	}{
+		"status-map": {*statusMap, &opts.StatusMap},
+		"user-map":   {*userMap, &opts.UserMap},
+	} {
+		if m.raw == "" {
+			continue
+		}
+		if err := json.Unmarshal([]byte(m.raw), m.dst); err != nil {
+			fmt.Fprintf(os.Stderr, "import: -%s must be a JSON object of strings: %v\n", name, err)
+			return 2
+		}
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "import: %v\n", err)
+		return 1
+	}
+	defer f.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	report, err := service.Execute(ctx, *source, f, opts, nil)
+	if err != nil {
+		logger.Error("import failed", slog.String("file", *file), sl.Err(err))
+		return 1
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return 1
+	}
+
+	logger.Info("import finished",
+		slog.Bool("dry_run", report.DryRun),
+		slog.Int("created", len(report.Created)),
+		slog.Int("skipped", len(report.Skipped)),
+	)
+	return 0
+}
@@ -1,22 +1,86 @@
 package main
 
 import (
+	"ProjectManagementAPI/internal/broker"
+	"ProjectManagementAPI/internal/broker/inprocess"
+	kafkaBroker "ProjectManagementAPI/internal/broker/kafka"
+	natsBroker "ProjectManagementAPI/internal/broker/nats"
 	"ProjectManagementAPI/internal/config"
+	jobDomain "ProjectManagementAPI/internal/domain/job"
+	grpcServer "ProjectManagementAPI/internal/grpc-server"
+	auditHttp "ProjectManagementAPI/internal/http-server/handlers/audit"
+	calendarHttp "ProjectManagementAPI/internal/http-server/handlers/calendar"
+	commentHttp "ProjectManagementAPI/internal/http-server/handlers/comment"
+	digestHttp "ProjectManagementAPI/internal/http-server/handlers/digest"
+	docsHttp "ProjectManagementAPI/internal/http-server/handlers/docs"
+	filterHttp "ProjectManagementAPI/internal/http-server/handlers/filter"
+	graphqlHttp "ProjectManagementAPI/internal/http-server/handlers/graphql"
+	importerHttp "ProjectManagementAPI/internal/http-server/handlers/importer"
+	jobHttp "ProjectManagementAPI/internal/http-server/handlers/job"
+	milestoneHttp "ProjectManagementAPI/internal/http-server/handlers/milestone"
+	notificationHttp "ProjectManagementAPI/internal/http-server/handlers/notification"
+	projectHttp "ProjectManagementAPI/internal/http-server/handlers/project"
+	realtimeHttp "ProjectManagementAPI/internal/http-server/handlers/realtime"
+	recurrenceHttp "ProjectManagementAPI/internal/http-server/handlers/recurrence"
+	searchHttp "ProjectManagementAPI/internal/http-server/handlers/search"
 	taskHttp "ProjectManagementAPI/internal/http-server/handlers/task"
+	transferHttp "ProjectManagementAPI/internal/http-server/handlers/transfer"
 	userHttp "ProjectManagementAPI/internal/http-server/handlers/user"
-	mwLogger "ProjectManagementAPI/internal/http-server/middleware/logger"
+	webhookHttp "ProjectManagementAPI/internal/http-server/handlers/webhook"
+	workloadHttp "ProjectManagementAPI/internal/http-server/handlers/workload"
+	worklogHttp "ProjectManagementAPI/internal/http-server/handlers/worklog"
+	"ProjectManagementAPI/internal/http-server/openapi"
+	"ProjectManagementAPI/internal/lib/email"
 	"ProjectManagementAPI/internal/lib/logger/sl"
+	auditRepository "ProjectManagementAPI/internal/repository/postgres/audit"
+	calendarRepository "ProjectManagementAPI/internal/repository/postgres/calendar"
+	commentRepository "ProjectManagementAPI/internal/repository/postgres/comment"
+	digestRepository "ProjectManagementAPI/internal/repository/postgres/digest"
+	filterRepository "ProjectManagementAPI/internal/repository/postgres/filter"
+	importerRepository "ProjectManagementAPI/internal/repository/postgres/importer"
+	jobRepository "ProjectManagementAPI/internal/repository/postgres/job"
+	milestoneRepository "ProjectManagementAPI/internal/repository/postgres/milestone"
+	notificationRepository "ProjectManagementAPI/internal/repository/postgres/notification"
+	outboxRepository "ProjectManagementAPI/internal/repository/postgres/outbox"
+	projectRepository "ProjectManagementAPI/internal/repository/postgres/project"
+	recurrenceRepository "ProjectManagementAPI/internal/repository/postgres/recurrence"
+	searchRepository "ProjectManagementAPI/internal/repository/postgres/search"
 	taskRepository "ProjectManagementAPI/internal/repository/postgres/task"
+	transferRepository "ProjectManagementAPI/internal/repository/postgres/transfer"
 	userRepository "ProjectManagementAPI/internal/repository/postgres/user"
+	webhookRepository "ProjectManagementAPI/internal/repository/postgres/webhook"
+	workloadRepository "ProjectManagementAPI/internal/repository/postgres/workload"
+	worklogRepository "ProjectManagementAPI/internal/repository/postgres/worklog"
 	"ProjectManagementAPI/internal/storage/postgre"
+	auditService "ProjectManagementAPI/internal/usecase/audit"
+	calendarService "ProjectManagementAPI/internal/usecase/calendar"
+	commentService "ProjectManagementAPI/internal/usecase/comment"
+	digestService "ProjectManagementAPI/internal/usecase/digest"
+	filterService "ProjectManagementAPI/internal/usecase/filter"
+	importerService "ProjectManagementAPI/internal/usecase/importer"
+	jobService "ProjectManagementAPI/internal/usecase/job"
+	milestoneService "ProjectManagementAPI/internal/usecase/milestone"
+	notificationService "ProjectManagementAPI/internal/usecase/notification"
+	outboxService "ProjectManagementAPI/internal/usecase/outbox"
+	projectService "ProjectManagementAPI/internal/usecase/project"
+	realtimeService "ProjectManagementAPI/internal/usecase/realtime"
+	recurrenceService "ProjectManagementAPI/internal/usecase/recurrence"
+	searchService "ProjectManagementAPI/internal/usecase/search"
 	taskService "ProjectManagementAPI/internal/usecase/task"
+	transferService "ProjectManagementAPI/internal/usecase/transfer"
 	userService "ProjectManagementAPI/internal/usecase/user"
+	webhookService "ProjectManagementAPI/internal/usecase/webhook"
+	workloadService "ProjectManagementAPI/internal/usecase/workload"
+	worklogService "ProjectManagementAPI/internal/usecase/worklog"
+	"context"
+	"errors"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
-
-	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
 const (
@@ -47,33 +111,245 @@ func main() {
 		}
 	}(storage)
 
-	router := chi.NewRouter()
-
-	router.Use(middleware.Logger)
-	router.Use(mwLogger.New(logger))
-	router.Use(middleware.Recoverer)
-	router.Use(middleware.URLFormat)
+	apiDoc := openapi.Build(openapi.Operations())
 
 	userRepo := userRepository.NewUserRepository(storage.Db)
 	taskRepo := taskRepository.NewTaskRepository(storage.Db)
+	milestoneRepo := milestoneRepository.NewMilestoneRepository(storage.Db)
+	auditRepo := auditRepository.NewAuditRepository(storage.Db)
+	webhookRepo := webhookRepository.NewWebhookRepository(storage.Db)
+	outboxRepo := outboxRepository.NewOutboxRepository(storage.Db)
+	projectRepo := projectRepository.NewProjectRepository(storage.Db)
+	notificationRepo := notificationRepository.NewNotificationRepository(storage.Db)
+	digestRepo := digestRepository.NewDigestRepository(storage.Db)
+	jobRepo := jobRepository.NewJobRepository(storage.Db)
+	recurrenceRepo := recurrenceRepository.NewRecurrenceRepository(storage.Db)
+	worklogRepo := worklogRepository.NewWorklogRepository(storage.Db)
+	commentRepo := commentRepository.NewCommentRepository(storage.Db)
+	searchRepo := searchRepository.NewSearchRepository(storage.Db)
+	filterRepo := filterRepository.NewFilterRepository(storage.Db)
+	transferRepo := transferRepository.NewTransferRepository(storage.Db)
+	importerRepo := importerRepository.NewImporterRepository(storage.Db)
+	calendarRepo := calendarRepository.NewCalendarRepository(storage.Db)
+	workloadRepo := workloadRepository.NewWorkloadRepository(storage.Db)
+
+	transactor := postgre.NewTransactor(storage.Db)
+	auditServ := auditService.NewAuditService(auditRepo)
+	webhookServ := webhookService.NewWebhookService(webhookRepo)
+	outboxServ := outboxService.NewOutboxService(outboxRepo)
+
+	projectServ := projectService.NewProjectService(projectRepo)
+	notificationServ := notificationService.NewNotificationService(notificationRepo, userRepo, taskRepo)
+	digestServ := digestService.NewDigestService(digestRepo)
+	jobServ := jobService.NewJobService(jobRepo, cfg.Jobs.MaxAttempts)
+	worklogServ := worklogService.NewWorklogService(worklogRepo)
+	workloadServ := workloadService.NewWorkloadService(workloadRepo, workloadService.Config{
+		DefaultCapacity: cfg.Workload.DefaultCapacity,
+		MaxWeeks:        cfg.Workload.MaxWeeks,
+	})
+	commentServ := commentService.NewCommentService(commentRepo)
+	searchServ := searchService.NewSearchService(searchRepo)
+	calendarServ := calendarService.NewCalendarService(calendarRepo)
+
+	if err := searchServ.SetLanguage(context.Background(), cfg.Search.Language); err != nil {
+		logger.Error("failed to set search language", slog.String("language", cfg.Search.Language), sl.Err(err))
+		os.Exit(1)
+	}
+
+	hub := realtimeService.NewHub(cfg.Realtime.HistorySize)
+	pgBridge := realtimeService.NewPGBridge(logger, outboxRepo, hub, cfg.Realtime.Channel)
+
+	bus := inprocess.New()
+	bus.Subscribe("webhooks", webhookServ.Handle)
+	bus.Subscribe("notifications", notificationServ.Handle)
+	if cfg.Realtime.Backend == "postgres" {
+		bus.Subscribe("realtime", pgBridge.Publish)
+	} else {
+		bus.Subscribe("realtime", hub.Publish)
+	}
+
+	userServ := userService.NewUserService(userRepo, transactor, auditServ, outboxServ)
+	taskServ := taskService.NewTaskService(taskRepo, transactor, auditServ, outboxServ)
+	recurrenceServ := recurrenceService.NewRecurrenceService(logger, recurrenceRepo, taskServ, taskRepo, userServ, transactor)
+	filterServ := filterService.NewFilterService(filterRepo, taskServ)
+	bulkServ := taskService.NewBulkService(taskServ, transactor, taskService.BulkConfig{
+		MaxItems: cfg.Bulk.MaxItems,
+		Timeout:  cfg.Bulk.Timeout,
+	})
+	transferServ := transferService.NewTransferService(transferRepo, taskServ, userServ, transactor, transferService.Config{
+		MaxRows: cfg.Transfer.MaxRows,
+	})
+	importerServ := importerService.NewImporterService(importerRepo, taskServ, commentRepo, jobServ, transactor)
+	bus.Subscribe("recurrence", recurrenceServ.Handle)
+	milestoneServ := milestoneService.NewMilestoneService(milestoneRepo)
+
+	// import - разовый импорт из CLI, сервер не запускается
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		os.Exit(runImport(logger, importerServ, os.Args[2:]))
+	}
 
-	userServ := userService.NewUserService(userRepo)
-	taskServ := taskService.NewTaskService(taskRepo)
+	graphqlHandler, err := graphqlHttp.NewHandler(logger, taskServ, userServ, graphqlHttp.Config{
+		MaxComplexity: cfg.GraphQL.MaxComplexity,
+		MaxDepth:      cfg.GraphQL.MaxDepth,
+	})
+	if err != nil {
+		logger.Error("failed to build graphql schema", sl.Err(err))
+		os.Exit(1)
+	}
 
-	userHandler := userHttp.NewHandler(logger, userServ)
-	taskHandler := taskHttp.NewHandler(logger, taskServ)
+	docsHandler, err := docsHttp.NewHandler(logger, apiDoc)
+	if err != nil {
+		logger.Error("failed to encode openapi document", sl.Err(err))
+		os.Exit(1)
+	}
 
-	router.Route("/tasks", func(r chi.Router) {
-		r.Post("/", taskHandler.Create)
-		r.Delete("/{id}", taskHandler.Delete)
-		r.Get("/{id}", taskHandler.GetByID)
+	router := newRouter(logger, cfg, apiDoc, handlers{
+		user: userHttp.NewHandler(logger, userServ),
+		task: taskHttp.NewHandler(logger, taskServ, recurrenceServ, userServ),
+		bulk: taskHttp.NewBulkHandler(logger, bulkServ, taskHttp.BulkConfig{
+			Timeout: cfg.Bulk.Timeout,
+		}),
+		recurrence:   recurrenceHttp.NewHandler(logger, recurrenceServ),
+		milestone:    milestoneHttp.NewHandler(logger, milestoneServ),
+		audit:        auditHttp.NewHandler(logger, auditServ),
+		webhook:      webhookHttp.NewHandler(logger, webhookServ),
+		project:      projectHttp.NewHandler(logger, projectServ),
+		realtime:     realtimeHttp.NewHandler(logger, hub),
+		notification: notificationHttp.NewHandler(logger, notificationServ),
+		digest:       digestHttp.NewHandler(logger, digestServ),
+		job:          jobHttp.NewHandler(logger, jobServ),
+		worklog:      worklogHttp.NewHandler(logger, worklogServ),
+		workload:     workloadHttp.NewHandler(logger, workloadServ),
+		comment:      commentHttp.NewHandler(logger, commentServ),
+		search:       searchHttp.NewHandler(logger, searchServ),
+		filter:       filterHttp.NewHandler(logger, filterServ, userServ),
+		calendar:     calendarHttp.NewHandler(logger, calendarServ),
+		transfer: transferHttp.NewHandler(logger, transferServ, transferHttp.Config{
+			MaxBytes: cfg.Transfer.MaxBytes,
+			Timeout:  cfg.Transfer.Timeout,
+		}),
+		importer: importerHttp.NewHandler(logger, importerServ, importerHttp.Config{
+			MaxBytes: cfg.Transfer.MaxBytes,
+			Timeout:  cfg.Transfer.Timeout,
+		}),
+		graphql: graphqlHandler,
+		docs:    docsHandler,
 	})
 
-	router.Route("/users", func(r chi.Router) {
-		r.Post("/", userHandler.Create)
-		r.Delete("/{id}", userHandler.Delete)
-		r.Get("/{id}", userHandler.GetByID)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	publisher := broker.Fanout{bus}
+	switch cfg.Outbox.Broker {
+	case "nats":
+		natsPublisher, err := natsBroker.New(ctx, cfg.Outbox.NATS.URL, cfg.Outbox.NATS.Stream, cfg.Outbox.NATS.SubjectPrefix)
+		if err != nil {
+			logger.Error("failed to connect to nats", sl.Err(err))
+			os.Exit(1)
+		}
+		defer natsPublisher.Close()
+		publisher = append(publisher, natsPublisher)
+	case "kafka":
+		kafkaPublisher := kafkaBroker.New(cfg.Outbox.Kafka.Brokers, cfg.Outbox.Kafka.Topic)
+		defer kafkaPublisher.Close()
+		publisher = append(publisher, kafkaPublisher)
+	}
+
+	outboxRelay := outboxService.NewRelay(logger, outboxRepo, transactor, publisher, outboxService.RelayConfig{
+		PollInterval: cfg.Outbox.PollInterval,
+		BatchSize:    cfg.Outbox.BatchSize,
+		MaxAttempts:  cfg.Outbox.MaxAttempts,
+		BaseBackoff:  cfg.Outbox.BaseBackoff,
+		MaxBackoff:   cfg.Outbox.MaxBackoff,
 	})
+	go outboxRelay.Run(ctx)
+
+	if cfg.Realtime.Backend == "postgres" {
+		go postgre.Listen(ctx, cfg.Postgres.DSN, pgBridge.Channel(), pgBridge.HandleNotification, func(err error) {
+			logger.Error("realtime listener failed", sl.Err(err))
+		})
+	}
+
+	webhookDispatcher := webhookService.NewDispatcher(
+		logger,
+		webhookRepo,
+		&http.Client{Timeout: cfg.Webhooks.RequestTimeout},
+		webhookService.DispatcherConfig{
+			BatchSize:   cfg.Webhooks.BatchSize,
+			MaxAttempts: cfg.Webhooks.MaxAttempts,
+			BaseBackoff: cfg.Webhooks.BaseBackoff,
+			MaxBackoff:  cfg.Webhooks.MaxBackoff,
+		},
+	)
+
+	mailer := email.NewSMTPSender(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password, cfg.SMTP.From)
+
+	notificationWorker := notificationService.NewWorker(
+		logger,
+		notificationRepo,
+		mailer,
+		notificationService.WorkerConfig{
+			BatchSize:     cfg.Notifications.EmailBatchSize,
+			MaxAttempts:   cfg.Notifications.EmailMaxAttempts,
+			RetryInterval: cfg.Notifications.EmailRetry,
+		},
+	)
+
+	digestWorker := digestService.NewWorker(logger, digestRepo, mailer, digestService.WorkerConfig{
+		DueSoon:     cfg.Digest.DueSoon,
+		MaxAttempts: cfg.Digest.MaxAttempts,
+	})
+
+	scheduler := jobService.NewScheduler(logger, jobRepo, transactor, jobService.SchedulerConfig{
+		Workers:      cfg.Jobs.Workers,
+		PollInterval: cfg.Jobs.PollInterval,
+		Lease:        cfg.Jobs.Lease,
+		BaseBackoff:  cfg.Jobs.BaseBackoff,
+		MaxBackoff:   cfg.Jobs.MaxBackoff,
+	})
+	scheduler.Register("notifications.due_soon", func(ctx context.Context, _ jobDomain.Job) error {
+		return notificationServ.NotifyDueSoon(ctx, cfg.Notifications.DueSoonWindow)
+	})
+	scheduler.Register("digests.send", func(ctx context.Context, _ jobDomain.Job) error {
+		return digestWorker.SendDue(ctx, time.Now())
+	})
+	scheduler.Register("tasks.recurrence", func(ctx context.Context, _ jobDomain.Job) error {
+		return recurrenceServ.GenerateDue(ctx)
+	})
+	scheduler.Register("webhooks.dispatch", func(ctx context.Context, _ jobDomain.Job) error {
+		return webhookDispatcher.DispatchDue(ctx)
+	})
+	scheduler.Register("notifications.email", func(ctx context.Context, _ jobDomain.Job) error {
+		return notificationWorker.SendPending(ctx)
+	})
+	scheduler.Register(importerService.JobKind, importerServ.HandleJob)
+	scheduler.Register("purge", func(ctx context.Context, _ jobDomain.Job) error {
+		if _, err := notificationServ.Purge(ctx, cfg.Jobs.Retention); err != nil {
+			return err
+		}
+		if _, err := outboxServ.Purge(ctx, cfg.Jobs.Retention); err != nil {
+			return err
+		}
+		if _, err := webhookServ.Purge(ctx, cfg.Jobs.Retention); err != nil {
+			return err
+		}
+		_, err := jobServ.Purge(ctx, cfg.Jobs.Retention)
+		return err
+	})
+	for _, sch := range []struct{ name, spec, kind string }{
+		{"due-soon", cfg.Jobs.Schedules.DueSoon, "notifications.due_soon"},
+		{"digests", cfg.Jobs.Schedules.Digest, "digests.send"},
+		{"recurrence", cfg.Jobs.Schedules.Recurrence, "tasks.recurrence"},
+		{"purge", cfg.Jobs.Schedules.Purge, "purge"},
+		{"webhooks", cfg.Jobs.Schedules.Webhooks, "webhooks.dispatch"},
+		{"emails", cfg.Jobs.Schedules.Emails, "notifications.email"},
+	} {
+		if err := scheduler.Schedule(sch.name, sch.spec, sch.kind, nil); err != nil {
+			logger.Error("invalid job schedule", sl.Err(err), slog.String("schedule", sch.name))
+			os.Exit(1)
+		}
+	}
+	go scheduler.Run(ctx)
 
 	logger.Info("starting server", slog.String("address", cfg.HTTPServer.Address))
 
@@ -85,10 +361,41 @@ func main() {
 		IdleTimeout:  cfg.HTTPServer.IdleTimeout,
 	}
 
-	if err := srv.ListenAndServe(); err != nil {
-		logger.Error("failed to start server")
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("failed to start server", sl.Err(err))
+		}
+		stop()
+	}()
+
+	logger.Info("starting grpc server", slog.String("address", cfg.GRPCServer.Address))
+
+	lis, err := net.Listen("tcp", cfg.GRPCServer.Address)
+	if err != nil {
+		logger.Error("failed to listen grpc", sl.Err(err))
+		os.Exit(1)
 	}
 
+	grpcSrv := grpcServer.New(logger, taskServ, userServ)
+
+	go func() {
+		if err := grpcSrv.Serve(lis); err != nil {
+			logger.Error("failed to start grpc server", sl.Err(err))
+		}
+		stop()
+	}()
+
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logger.Error("failed to stop server", sl.Err(err))
+	}
+
+	grpcSrv.Shutdown(shutdownCtx)
+
 	logger.Error("server stopped")
 }
 
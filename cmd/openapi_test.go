@@ -0,0 +1,31 @@
+package main
+
+import (
+	"ProjectManagementAPI/internal/config"
+	"ProjectManagementAPI/internal/http-server/openapi"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+)
+
+// Маршрут без описания в openapi.Operations или описание без маршрута
+// ломают документацию и валидацию запросов.
+func TestRoutesMatchOpenAPI(t *testing.T) {
+	doc := openapi.Build(openapi.Operations())
+	router := newRouter(slog.New(slog.NewTextHandler(io.Discard, nil)), &config.Config{}, doc, handlers{})
+
+	if err := openapi.Check(router, doc); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckDetectsUndocumentedRoute(t *testing.T) {
+	doc := openapi.Build(openapi.Operations())
+	router := newRouter(slog.New(slog.NewTextHandler(io.Discard, nil)), &config.Config{}, doc, handlers{})
+	router.Get("/undocumented", func(http.ResponseWriter, *http.Request) {})
+
+	if err := openapi.Check(router, doc); err == nil {
+		t.Fatal("Check accepted a route missing from the document")
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"ProjectManagementAPI/internal/config"
+	auditHttp "ProjectManagementAPI/internal/http-server/handlers/audit"
+	calendarHttp "ProjectManagementAPI/internal/http-server/handlers/calendar"
+	commentHttp "ProjectManagementAPI/internal/http-server/handlers/comment"
+	digestHttp "ProjectManagementAPI/internal/http-server/handlers/digest"
+	docsHttp "ProjectManagementAPI/internal/http-server/handlers/docs"
+	filterHttp "ProjectManagementAPI/internal/http-server/handlers/filter"
+	graphqlHttp "ProjectManagementAPI/internal/http-server/handlers/graphql"
+	importerHttp "ProjectManagementAPI/internal/http-server/handlers/importer"
+	jobHttp "ProjectManagementAPI/internal/http-server/handlers/job"
+	milestoneHttp "ProjectManagementAPI/internal/http-server/handlers/milestone"
+	notificationHttp "ProjectManagementAPI/internal/http-server/handlers/notification"
+	projectHttp "ProjectManagementAPI/internal/http-server/handlers/project"
+	realtimeHttp "ProjectManagementAPI/internal/http-server/handlers/realtime"
+	recurrenceHttp "ProjectManagementAPI/internal/http-server/handlers/recurrence"
+	searchHttp "ProjectManagementAPI/internal/http-server/handlers/search"
+	taskHttp "ProjectManagementAPI/internal/http-server/handlers/task"
+	transferHttp "ProjectManagementAPI/internal/http-server/handlers/transfer"
+	userHttp "ProjectManagementAPI/internal/http-server/handlers/user"
+	webhookHttp "ProjectManagementAPI/internal/http-server/handlers/webhook"
+	workloadHttp "ProjectManagementAPI/internal/http-server/handlers/workload"
+	worklogHttp "ProjectManagementAPI/internal/http-server/handlers/worklog"
+	mwActor "ProjectManagementAPI/internal/http-server/middleware/actor"
+	mwAdmin "ProjectManagementAPI/internal/http-server/middleware/admin"
+	mwLogger "ProjectManagementAPI/internal/http-server/middleware/logger"
+	mwValidate "ProjectManagementAPI/internal/http-server/middleware/validate"
+	"ProjectManagementAPI/internal/http-server/openapi"
+	"log/slog"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// handlers - HTTP-обработчики, из которых собирается роутер.
+type handlers struct {
+	user         *userHttp.Handler
+	task         *taskHttp.Handler
+	bulk         *taskHttp.BulkHandler
+	recurrence   *recurrenceHttp.Handler
+	milestone    *milestoneHttp.Handler
+	audit        *auditHttp.Handler
+	webhook      *webhookHttp.Handler
+	project      *projectHttp.Handler
+	realtime     *realtimeHttp.Handler
+	notification *notificationHttp.Handler
+	digest       *digestHttp.Handler
+	job          *jobHttp.Handler
+	worklog      *worklogHttp.Handler
+	workload     *workloadHttp.Handler
+	comment      *commentHttp.Handler
+	search       *searchHttp.Handler
+	filter       *filterHttp.Handler
+	calendar     *calendarHttp.Handler
+	transfer     *transferHttp.Handler
+	importer     *importerHttp.Handler
+	graphql      *graphqlHttp.Handler
+	docs         *docsHttp.Handler
+}
+
+// newRouter собирает маршруты HTTP API. Каждый маршрут должен быть описан
+// в openapi.Operations, это проверяет openapi_test.go.
+func newRouter(logger *slog.Logger, cfg *config.Config, apiDoc *openapi.Document, h handlers) *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Use(middleware.RequestID)
+	router.Use(middleware.Logger)
+	router.Use(mwLogger.New(logger))
+	router.Use(middleware.Recoverer)
+	router.Use(middleware.URLFormat)
+	router.Use(mwActor.New())
+	router.Use(mwValidate.New(apiDoc))
+
+	router.Route("/tasks", func(r chi.Router) {
+		r.Post("/", h.task.Create)
+		r.Get("/", h.task.List)
+		r.Post("/bulk", h.bulk.Bulk)
+		r.Get("/export", h.transfer.ExportTasks)
+		r.Post("/import", h.transfer.ImportTasks)
+		r.Patch("/{id}", h.task.Update)
+		r.Delete("/{id}", h.task.Delete)
+		r.Get("/{id}", h.task.GetByID)
+		r.Get("/{id}/history", h.audit.TaskHistory)
+		r.Post("/{id}/assignees", h.task.AddAssignees)
+		r.Delete("/{id}/assignees/{userID}", h.task.RemoveAssignee)
+		r.Get("/{id}/recurrence", h.recurrence.Get)
+		r.Put("/{id}/recurrence", h.recurrence.Set)
+		r.Delete("/{id}/recurrence", h.recurrence.Stop)
+		r.Get("/{id}/worklogs", h.worklog.TaskWorklogs)
+		r.Get("/{id}/comments", h.comment.List)
+		r.Group(func(r chi.Router) {
+			r.Use(mwActor.Required())
+			r.Post("/{id}/comments", h.comment.Create)
+			r.Patch("/{id}/comments/{commentID}", h.comment.Update)
+			r.Delete("/{id}/comments/{commentID}", h.comment.Delete)
+		})
+		r.With(mwActor.Required()).Post("/{id}/worklogs", h.worklog.AddManual)
+		r.With(mwActor.Required()).Post("/{id}/timer/start", h.worklog.StartTimer)
+	})
+
+	router.Route("/milestones", func(r chi.Router) {
+		r.Post("/", h.milestone.Create)
+		r.Delete("/{id}", h.milestone.Delete)
+		r.Get("/{id}", h.milestone.GetByID)
+		r.Get("/{id}/burndown", h.milestone.Burndown)
+	})
+
+	router.Route("/users", func(r chi.Router) {
+		r.Post("/", h.user.Create)
+		r.Get("/export", h.transfer.ExportUsers)
+		r.Post("/import", h.transfer.ImportUsers)
+		r.Delete("/{id}", h.user.Delete)
+		r.Get("/{id}", h.user.GetByID)
+		r.Get("/{id}/tasks", h.task.ByUser)
+		r.Put("/{id}/capacity", h.workload.SetCapacity)
+	})
+
+	router.Route("/projects", func(r chi.Router) {
+		r.Post("/", h.project.Create)
+		r.Delete("/{id}", h.project.Delete)
+		r.Get("/{id}", h.project.GetByID)
+	})
+
+	router.Route("/events", func(r chi.Router) {
+		r.Get("/stream", h.realtime.Stream)
+		r.Get("/ws", h.realtime.WebSocket)
+	})
+
+	router.Route("/me", func(r chi.Router) {
+		r.Use(mwActor.Required())
+		r.Get("/notifications", h.notification.List)
+		r.Post("/notifications/read-all", h.notification.MarkAllRead)
+		r.Post("/notifications/{id}/read", h.notification.MarkRead)
+		r.Post("/notifications/{id}/unread", h.notification.MarkUnread)
+		r.Get("/notification-preferences", h.notification.Preferences)
+		r.Put("/notification-preferences", h.notification.SavePreferences)
+		r.Get("/digest-settings", h.digest.Settings)
+		r.Put("/digest-settings", h.digest.SaveSettings)
+		r.Get("/digests", h.digest.Runs)
+		r.Get("/timer", h.worklog.RunningTimer)
+		r.Post("/timer/stop", h.worklog.StopTimer)
+		r.Get("/worklogs", h.worklog.ListMine)
+		r.Delete("/worklogs/{id}", h.worklog.Delete)
+		r.Get("/calendar-token", h.calendar.Token)
+		r.Post("/calendar-token", h.calendar.Rotate)
+		r.Delete("/calendar-token", h.calendar.Revoke)
+	})
+
+	// Ссылка открывается календарём без заголовков: доступ - по токену в пути
+	router.Get("/calendar/{token}", h.calendar.Feed)
+
+	router.Get("/search", h.search.Search)
+
+	router.Route("/filters", func(r chi.Router) {
+		r.Use(mwActor.Required())
+		r.Post("/", h.filter.Create)
+		r.Get("/", h.filter.List)
+		r.Get("/{id}", h.filter.Get)
+		r.Put("/{id}", h.filter.Update)
+		r.Delete("/{id}", h.filter.Delete)
+		r.Get("/{id}/tasks", h.filter.Tasks)
+	})
+
+	router.Route("/imports", func(r chi.Router) {
+		r.Post("/", h.importer.Create)
+		r.Get("/{id}", h.importer.GetByID)
+	})
+
+	router.Route("/reports", func(r chi.Router) {
+		r.Get("/time", h.worklog.Totals)
+		r.Get("/workload", h.workload.Report)
+	})
+
+	router.Route("/admin", func(r chi.Router) {
+		r.Use(mwAdmin.New(cfg.Admin.Token))
+		r.Get("/audit", h.audit.List)
+		r.Get("/jobs", h.job.List)
+		r.Get("/jobs/{id}", h.job.GetByID)
+		r.Post("/jobs/{id}/retry", h.job.Retry)
+		r.Post("/jobs/{id}/cancel", h.job.Cancel)
+	})
+
+	router.Route("/webhooks", func(r chi.Router) {
+		r.Use(mwAdmin.New(cfg.Admin.Token))
+		r.Post("/", h.webhook.Create)
+		r.Get("/{id}", h.webhook.GetByID)
+		r.Delete("/{id}", h.webhook.Delete)
+		r.Get("/{id}/deliveries", h.webhook.Deliveries)
+		r.Post("/{id}/deliveries/{deliveryID}/redeliver", h.webhook.Redeliver)
+	})
+
+	router.Post("/graphql", h.graphql.Serve)
+
+	// URLFormat снимает расширение: маршрут /openapi отвечает на /openapi.json
+	router.Get("/openapi", h.docs.Spec)
+	router.Get("/docs", h.docs.UI)
+
+	return router
+}
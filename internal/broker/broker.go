@@ -0,0 +1,25 @@
+package broker
+
+import (
+	"ProjectManagementAPI/internal/domain/event"
+	"context"
+	"errors"
+)
+
+type Publisher interface {
+	Publish(ctx context.Context, e event.Event) error
+}
+
+// Fanout публикует событие во все брокеры по очереди.
+// При ошибке любого из них событие будет отправлено повторно во все.
+type Fanout []Publisher
+
+func (f Fanout) Publish(ctx context.Context, e event.Event) error {
+	var errs []error
+	for _, p := range f {
+		if err := p.Publish(ctx, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,47 @@
+package inprocess
+
+import (
+	"ProjectManagementAPI/internal/domain/event"
+	"context"
+	"fmt"
+	"sync"
+)
+
+type Handler func(ctx context.Context, e event.Event) error
+
+type subscription struct {
+	name    string
+	handler Handler
+}
+
+// Bus - брокер внутри процесса. Обработчики вызываются синхронно,
+// ошибка любого из них возвращается relay и приводит к повторной отправке.
+type Bus struct {
+	mu   sync.RWMutex
+	subs []subscription
+}
+
+func New() *Bus {
+	return &Bus{}
+}
+
+func (b *Bus) Subscribe(name string, h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subs = append(b.subs, subscription{name: name, handler: h})
+}
+
+func (b *Bus) Publish(ctx context.Context, e event.Event) error {
+	b.mu.RLock()
+	subs := b.subs
+	b.mu.RUnlock()
+
+	for _, s := range subs {
+		if err := s.handler(ctx, e); err != nil {
+			return fmt.Errorf("%s: %w", s.name, err)
+		}
+	}
+
+	return nil
+}
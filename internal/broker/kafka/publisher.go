@@ -0,0 +1,59 @@
+package kafka
+
+import (
+	"ProjectManagementAPI/internal/domain/event"
+	"context"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	HeaderEventID   = "event-id"
+	HeaderEventType = "event-type"
+)
+
+// Publisher пишет события в топик с ключом ID агрегата:
+// события одного агрегата попадают в одну партицию и сохраняют порядок.
+type Publisher struct {
+	writer *kafka.Writer
+}
+
+func New(brokers []string, topic string) *Publisher {
+	return &Publisher{
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			Topic:                  topic,
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequireAll,
+			AllowAutoTopicCreation: true,
+		},
+	}
+}
+
+func (p *Publisher) Publish(ctx context.Context, e event.Event) error {
+	const op = "broker.kafka.Publish"
+
+	body, err := e.Envelope()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = p.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(e.AggregateID.String()),
+		Value: body,
+		Headers: []kafka.Header{
+			{Key: HeaderEventID, Value: []byte(e.ID.String())},
+			{Key: HeaderEventType, Value: []byte(e.Type)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *Publisher) Close() error {
+	return p.writer.Close()
+}
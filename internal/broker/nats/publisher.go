@@ -0,0 +1,73 @@
+package nats
+
+import (
+	"ProjectManagementAPI/internal/domain/event"
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	HeaderEventType   = "Event-Type"
+	HeaderAggregateID = "Aggregate-Id"
+)
+
+// Publisher публикует события в JetStream. Nats-Msg-Id равен ID события,
+// поэтому повторы relay отбрасываются дедупликацией стрима.
+type Publisher struct {
+	nc     *nats.Conn
+	js     jetstream.JetStream
+	prefix string
+}
+
+func New(ctx context.Context, url, stream, prefix string) (*Publisher, error) {
+	const op = "broker.nats.New"
+
+	nc, err := nats.Connect(url)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	js, err := jetstream.New(nc)
+	if err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     stream,
+		Subjects: []string{prefix + ".>"},
+	})
+	if err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Publisher{nc: nc, js: js, prefix: prefix}, nil
+}
+
+func (p *Publisher) Publish(ctx context.Context, e event.Event) error {
+	const op = "broker.nats.Publish"
+
+	body, err := e.Envelope()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	msg := nats.NewMsg(p.prefix + "." + e.Type)
+	msg.Data = body
+	msg.Header.Set(HeaderEventType, e.Type)
+	msg.Header.Set(HeaderAggregateID, e.AggregateID.String())
+
+	if _, err := p.js.PublishMsg(ctx, msg, jetstream.WithMsgID(e.ID.String())); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *Publisher) Close() error {
+	return p.nc.Drain()
+}
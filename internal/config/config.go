@@ -9,9 +9,23 @@ import (
 )
 
 type Config struct {
-	Env        string         `yaml:"env" env-default:"local"`
-	Postgres   PostgresConfig `yaml:"postgres"`
-	HTTPServer HTTPServer     `yaml:"http_server"`
+	Env           string         `yaml:"env" env-default:"local"`
+	Postgres      PostgresConfig `yaml:"postgres"`
+	HTTPServer    HTTPServer     `yaml:"http_server"`
+	GRPCServer    GRPCServer     `yaml:"grpc_server"`
+	GraphQL       GraphQL        `yaml:"graphql"`
+	Admin         Admin          `yaml:"admin"`
+	Webhooks      Webhooks       `yaml:"webhooks"`
+	Outbox        Outbox         `yaml:"outbox"`
+	Realtime      Realtime       `yaml:"realtime"`
+	Notifications Notifications  `yaml:"notifications"`
+	Digest        Digest         `yaml:"digest"`
+	Jobs          Jobs           `yaml:"jobs"`
+	Search        Search         `yaml:"search"`
+	Bulk          Bulk           `yaml:"bulk"`
+	Transfer      Transfer       `yaml:"transfer"`
+	Workload      Workload       `yaml:"workload"`
+	SMTP          SMTP           `yaml:"smtp"`
 }
 
 type PostgresConfig struct {
@@ -24,6 +38,134 @@ type HTTPServer struct {
 	IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"60s"`
 }
 
+// GRPCServer - второй порт с gRPC API.
+type GRPCServer struct {
+	Address string `yaml:"address" env-default:"localhost:9090"`
+}
+
+// GraphQL - ограничения запросов к /graphql.
+type GraphQL struct {
+	// MaxComplexity - предел оценки стоимости запроса до выполнения
+	MaxComplexity int `yaml:"max_complexity" env-default:"1000"`
+	MaxDepth      int `yaml:"max_depth" env-default:"10"`
+}
+
+type Admin struct {
+	Token string `yaml:"token" env:"ADMIN_TOKEN"`
+}
+
+type Webhooks struct {
+	BatchSize      int           `yaml:"batch_size" env-default:"50"`
+	MaxAttempts    int           `yaml:"max_attempts" env-default:"8"`
+	RequestTimeout time.Duration `yaml:"request_timeout" env-default:"10s"`
+	BaseBackoff    time.Duration `yaml:"base_backoff" env-default:"10s"`
+	MaxBackoff     time.Duration `yaml:"max_backoff" env-default:"1h"`
+}
+
+type Outbox struct {
+	PollInterval time.Duration `yaml:"poll_interval" env-default:"1s"`
+	BatchSize    int           `yaml:"batch_size" env-default:"100"`
+	// MaxAttempts - попыток публикации до перевода события в dead
+	MaxAttempts int           `yaml:"max_attempts" env-default:"10"`
+	BaseBackoff time.Duration `yaml:"base_backoff" env-default:"1s"`
+	MaxBackoff  time.Duration `yaml:"max_backoff" env-default:"5m"`
+	// Broker - внешний брокер в дополнение к внутреннему: none, nats, kafka
+	Broker string `yaml:"broker" env-default:"none"`
+	NATS   NATS   `yaml:"nats"`
+	Kafka  Kafka  `yaml:"kafka"`
+}
+
+type NATS struct {
+	URL           string `yaml:"url" env-default:"nats://127.0.0.1:4222"`
+	Stream        string `yaml:"stream" env-default:"PM_EVENTS"`
+	SubjectPrefix string `yaml:"subject_prefix" env-default:"pm.events"`
+}
+
+type Kafka struct {
+	Brokers []string `yaml:"brokers" env-default:"127.0.0.1:9092"`
+	Topic   string   `yaml:"topic" env-default:"pm.events"`
+}
+
+type Realtime struct {
+	// Backend: local - события только этой реплики, postgres - LISTEN/NOTIFY между репликами
+	Backend     string `yaml:"backend" env-default:"local"`
+	Channel     string `yaml:"channel" env-default:"pm_events"`
+	HistorySize int    `yaml:"history_size" env-default:"1000"`
+}
+
+type Notifications struct {
+	// DueSoonWindow - за сколько до срока исполнители получают напоминание
+	DueSoonWindow    time.Duration `yaml:"due_soon_window" env-default:"24h"`
+	EmailBatchSize   int           `yaml:"email_batch_size" env-default:"50"`
+	EmailMaxAttempts int           `yaml:"email_max_attempts" env-default:"5"`
+	EmailRetry       time.Duration `yaml:"email_retry" env-default:"1m"`
+}
+
+type Digest struct {
+	// DueSoon - горизонт для раздела "скоро срок"
+	DueSoon     time.Duration `yaml:"due_soon" env-default:"48h"`
+	MaxAttempts int           `yaml:"max_attempts" env-default:"3"`
+}
+
+type Jobs struct {
+	Workers      int           `yaml:"workers" env:"JOB_WORKERS" env-default:"4"`
+	PollInterval time.Duration `yaml:"poll_interval" env-default:"1s"`
+	Lease        time.Duration `yaml:"lease" env-default:"5m"`
+	MaxAttempts  int           `yaml:"max_attempts" env-default:"5"`
+	BaseBackoff  time.Duration `yaml:"base_backoff" env-default:"10s"`
+	MaxBackoff   time.Duration `yaml:"max_backoff" env-default:"1h"`
+	// Retention - сколько хранить завершённые задания, прочитанные уведомления,
+	// опубликованные события outbox и завершённые доставки вебхуков
+	Retention time.Duration `yaml:"retention" env-default:"720h"`
+	Schedules JobSchedules  `yaml:"schedules"`
+}
+
+// JobSchedules - cron-расписания встроенных периодических заданий.
+type JobSchedules struct {
+	DueSoon    string `yaml:"due_soon" env-default:"*/5 * * * *"`
+	Digest     string `yaml:"digest" env-default:"* * * * *"`
+	Recurrence string `yaml:"recurrence" env-default:"* * * * *"`
+	Purge      string `yaml:"purge" env-default:"@daily"`
+	// Webhooks и Emails - как часто отправляются доставки вебхуков и письма уведомлений
+	Webhooks string `yaml:"webhooks" env-default:"@every 5s"`
+	Emails   string `yaml:"emails" env-default:"@every 30s"`
+}
+
+type Search struct {
+	// Language - конфигурация текстового поиска Postgres (russian, english, simple...).
+	// russian стеммит и кириллицу, и латиницу
+	Language string `yaml:"language" env:"SEARCH_LANGUAGE" env-default:"russian"`
+}
+
+// Bulk - ограничения POST /tasks/bulk.
+type Bulk struct {
+	MaxItems int           `yaml:"max_items" env-default:"100"`
+	Timeout  time.Duration `yaml:"timeout" env-default:"30s"`
+}
+
+// Transfer - ограничения импорта и экспорта файлов.
+type Transfer struct {
+	MaxRows  int   `yaml:"max_rows" env-default:"5000"`
+	MaxBytes int64 `yaml:"max_bytes" env-default:"10485760"`
+	// Timeout заменяет http_server.timeout для загрузки и выгрузки
+	Timeout time.Duration `yaml:"timeout" env-default:"5m"`
+}
+
+// Workload - отчёт о нагрузке GET /reports/workload.
+type Workload struct {
+	// DefaultCapacity - недельная ёмкость в story points, если у пользователя не задана своя
+	DefaultCapacity int `yaml:"default_capacity" env-default:"20"`
+	MaxWeeks        int `yaml:"max_weeks" env-default:"26"`
+}
+
+type SMTP struct {
+	Host     string `yaml:"host" env-default:"127.0.0.1"`
+	Port     int    `yaml:"port" env-default:"1025"`
+	Username string `yaml:"username" env:"SMTP_USERNAME"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD"`
+	From     string `yaml:"from" env-default:"noreply@localhost"`
+}
+
 func MustLoad() *Config {
 	configPath := os.Getenv("CONFIG_PATH")
 	if configPath == "" {
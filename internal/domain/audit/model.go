@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EntityTask = "task"
+	EntityUser = "user"
+)
+
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+type Event struct {
+	ID         int64
+	ActorID    *uuid.UUID
+	EntityType string
+	EntityID   uuid.UUID
+	Action     string
+	Before     json.RawMessage
+	After      json.RawMessage
+	Diff       json.RawMessage
+	RequestID  string
+	CreatedAt  time.Time
+}
+
+type Filter struct {
+	EntityType string
+	EntityID   *uuid.UUID
+	ActorID    *uuid.UUID
+	Action     string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
@@ -0,0 +1,10 @@
+package calendar
+
+import "errors"
+
+var (
+	ErrTokenNotFound   = errors.New("calendar token not found")
+	ErrInvalidKind     = errors.New("type must be event or todo")
+	ErrInvalidTimezone = errors.New("invalid timezone")
+	ErrInvalidStatus   = errors.New("invalid status")
+)
@@ -0,0 +1,49 @@
+package calendar
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Вид записей фида: VEVENT показывают все календари, VTODO - приложения
+// с поддержкой задач.
+const (
+	KindEvent = "event"
+	KindTodo  = "todo"
+)
+
+// Token - секретная ссылка на фид пользователя. Сам токен виден только
+// при выпуске, хранится его хэш.
+type Token struct {
+	UserID    uuid.UUID
+	CreatedAt time.Time
+}
+
+// Filter ограничивает фид проектами и статусами, пустой - без ограничений.
+type Filter struct {
+	ProjectIDs []uuid.UUID
+	Statuses   []string
+}
+
+// Item - задача пользователя со сроком.
+type Item struct {
+	TaskID      uuid.UUID
+	Title       string
+	Description string
+	Status      string
+	DueAt       time.Time
+	Project     string
+	Labels      []string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Feed - содержимое календаря. Location - часовой пояс, в котором срок
+// ровно в полночь считается датой без времени.
+type Feed struct {
+	UserName string
+	Kind     string
+	Location *time.Location
+	Items    []Item
+}
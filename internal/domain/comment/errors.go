@@ -0,0 +1,9 @@
+package comment
+
+import "errors"
+
+var (
+	ErrCommentNotFound = errors.New("comment not found")
+	ErrEmptyBody       = errors.New("comment body must not be empty")
+	ErrBodyTooLong     = errors.New("comment body is too long")
+)
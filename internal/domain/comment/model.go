@@ -0,0 +1,18 @@
+package comment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Comment struct {
+	ID       uuid.UUID
+	TaskID   uuid.UUID
+	AuthorID *uuid.UUID
+	Body     string
+	// AuthorName пуст, если автор удалён
+	AuthorName string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,11 @@
+package digest
+
+import "errors"
+
+var (
+	ErrSettingsNotFound = errors.New("digest settings not found")
+	ErrInvalidFrequency = errors.New("invalid digest frequency")
+	ErrInvalidTimezone  = errors.New("invalid timezone")
+	ErrInvalidSendHour  = errors.New("send hour must be between 0 and 23")
+	ErrInvalidWeekday   = errors.New("weekday must be between 0 and 6")
+)
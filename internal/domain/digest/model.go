@@ -0,0 +1,88 @@
+package digest
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	FrequencyOff    = "off"
+	FrequencyDaily  = "daily"
+	FrequencyWeekly = "weekly"
+)
+
+const (
+	RunSending = "sending"
+	RunSent    = "sent"
+	RunEmpty   = "empty"
+	RunFailed  = "failed"
+)
+
+// Settings - расписание дайджеста пользователя. SendHour и Weekday
+// задаются в часовом поясе Timezone (имя из базы IANA).
+type Settings struct {
+	UserID    uuid.UUID
+	Frequency string
+	Timezone  string
+	SendHour  int
+	Weekday   time.Weekday
+}
+
+// Recipient - включённые настройки вместе с адресом пользователя.
+type Recipient struct {
+	Settings
+	Email string
+	Name  string
+}
+
+type Item struct {
+	TaskID    uuid.UUID
+	Title     string
+	Status    string
+	DueAt     *time.Time
+	UpdatedAt time.Time
+}
+
+type Digest struct {
+	Recipient Recipient
+	Location  *time.Location
+	From      time.Time
+	To        time.Time
+	Overdue   []Item
+	DueSoon   []Item
+	Changed   []Item
+}
+
+func (d Digest) Len() int {
+	return len(d.Overdue) + len(d.DueSoon) + len(d.Changed)
+}
+
+// Run - запись об отправке дайджеста за период. Период уникален для
+// пользователя, поэтому после рестарта дайджест не уходит повторно.
+type Run struct {
+	ID        int64
+	UserID    uuid.UUID
+	Frequency string
+	PeriodKey string
+	Status    string
+	Items     int
+	Attempts  int
+	Error     string
+	CreatedAt time.Time
+	SentAt    *time.Time
+}
+
+func DefaultSettings(userID uuid.UUID) Settings {
+	return Settings{
+		UserID:    userID,
+		Frequency: FrequencyOff,
+		Timezone:  "UTC",
+		SendHour:  9,
+		Weekday:   time.Monday,
+	}
+}
+
+func ValidFrequency(f string) bool {
+	return f == FrequencyOff || f == FrequencyDaily || f == FrequencyWeekly
+}
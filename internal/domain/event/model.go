@@ -0,0 +1,154 @@
+package event
+
+import (
+	"ProjectManagementAPI/internal/domain/task"
+	"ProjectManagementAPI/internal/domain/user"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TaskCreated       = "task.created"
+	TaskUpdated       = "task.updated"
+	TaskStatusChanged = "task.status_changed"
+	TaskDeleted       = "task.deleted"
+	UserCreated       = "user.created"
+	UserDeleted       = "user.deleted"
+)
+
+const (
+	AggregateTask = "task"
+	AggregateUser = "user"
+)
+
+var Types = []string{
+	TaskCreated,
+	TaskUpdated,
+	TaskStatusChanged,
+	TaskDeleted,
+	UserCreated,
+	UserDeleted,
+}
+
+type Event struct {
+	ID            uuid.UUID
+	Type          string
+	AggregateType string
+	AggregateID   uuid.UUID
+	Payload       json.RawMessage
+	OccurredAt    time.Time
+}
+
+func ValidType(t string) bool {
+	for _, v := range Types {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
+
+type TaskPayload struct {
+	ID             string     `json:"id"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	Status         string     `json:"status"`
+	PreviousStatus string     `json:"previous_status,omitempty"`
+	StoryPoints    int        `json:"story_points"`
+	MilestoneID    string     `json:"milestone_id,omitempty"`
+	ProjectID      string     `json:"project_id,omitempty"`
+	DueAt          *time.Time `json:"due_at,omitempty"`
+	SeriesID       string     `json:"series_id,omitempty"`
+	Assignees      []string   `json:"assignees"`
+	// AddedAssignees - исполнители, назначенные этим изменением (task.updated)
+	AddedAssignees []string  `json:"added_assignees,omitempty"`
+	Labels         []string  `json:"labels,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type UserPayload struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+func New(eventType, aggregateType string, aggregateID uuid.UUID, payload any) (Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		ID:            uuid.New(),
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Payload:       data,
+		OccurredAt:    time.Now().UTC(),
+	}, nil
+}
+
+func NewTaskEvent(eventType string, t *task.Task) (Event, error) {
+	return New(eventType, AggregateTask, t.ID, NewTaskPayload(t))
+}
+
+func NewUserEvent(eventType string, u *user.User) (Event, error) {
+	return New(eventType, AggregateUser, u.ID, UserPayload{
+		ID:    u.ID.String(),
+		Email: u.Email,
+		Name:  u.Name,
+	})
+}
+
+func NewTaskPayload(t *task.Task) TaskPayload {
+	p := TaskPayload{
+		ID:          t.ID.String(),
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      t.Status,
+		StoryPoints: t.StoryPoints,
+		Assignees:   make([]string, len(t.Assignees)),
+		DueAt:       t.DueAt,
+		Labels:      t.Labels,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+	for i, a := range t.Assignees {
+		p.Assignees[i] = a.String()
+	}
+	if t.MilestoneID != nil {
+		p.MilestoneID = t.MilestoneID.String()
+	}
+	if t.ProjectID != nil {
+		p.ProjectID = t.ProjectID.String()
+	}
+	if t.SeriesID != nil {
+		p.SeriesID = t.SeriesID.String()
+	}
+	return p
+}
+
+type envelope struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// Envelope - сериализованное событие в том виде, в каком его получают
+// внешние потребители (webhooks, брокеры).
+func (e Event) Envelope() ([]byte, error) {
+	return json.Marshal(envelope{
+		ID:            e.ID.String(),
+		Type:          e.Type,
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID.String(),
+		OccurredAt:    e.OccurredAt,
+		Data:          e.Payload,
+	})
+}
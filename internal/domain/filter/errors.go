@@ -0,0 +1,9 @@
+package filter
+
+import "errors"
+
+var (
+	ErrFilterNotFound = errors.New("filter not found")
+	ErrInvalidName    = errors.New("filter name must be 1-100 characters")
+	ErrDuplicateName  = errors.New("filter with this name already exists")
+)
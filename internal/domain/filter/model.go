@@ -0,0 +1,19 @@
+package filter
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Filter - сохранённый запрос на языке фильтров задач.
+type Filter struct {
+	ID      uuid.UUID
+	OwnerID uuid.UUID
+	Name    string
+	Query   string
+	// Shared - фильтр виден всем; assignee:me в нём означает того, кто его запускает
+	Shared    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,182 @@
+package importer
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Форматы файлов экспорта внешних трекеров.
+const (
+	SourceJiraXML = "jira_xml"
+	SourceJiraCSV = "jira_csv"
+	SourceTrello  = "trello"
+	SourceGitHub  = "github"
+)
+
+// Статусы импорта.
+const (
+	StatusPending   = "pending"
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+var (
+	ErrImportNotFound = errors.New("import not found")
+	ErrInvalidSource  = errors.New("source must be jira_xml, jira_csv, trello or github")
+	ErrInvalidFile    = errors.New("invalid export file")
+	ErrInvalidOptions = errors.New("invalid import options")
+)
+
+func ValidSource(s string) bool {
+	switch s {
+	case SourceJiraXML, SourceJiraCSV, SourceTrello, SourceGitHub:
+		return true
+	}
+	return false
+}
+
+// System - внешняя система источника: у Jira XML и CSV общие ключи задач,
+// поэтому повторный импорт другим форматом тоже пропускает перенесённое.
+func System(source string) string {
+	if source == SourceJiraXML || source == SourceJiraCSV {
+		return "jira"
+	}
+	return source
+}
+
+// Person - пользователь внешней системы. Заполнено то, что есть в файле:
+// Jira отдаёт имя и id, Trello - логин и имя, GitHub - только логин.
+type Person struct {
+	ID    string
+	Login string
+	Name  string
+	Email string
+}
+
+// Keys - непустые идентификаторы в порядке надёжности для сопоставления.
+func (p Person) Keys() []string {
+	var keys []string
+	for _, k := range []string{p.Email, p.ID, p.Login, p.Name} {
+		if k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func (p Person) String() string {
+	for _, k := range []string{p.Name, p.Login, p.Email, p.ID} {
+		if k != "" {
+			return k
+		}
+	}
+	return "unknown"
+}
+
+func (p Person) IsZero() bool {
+	return len(p.Keys()) == 0
+}
+
+type Comment struct {
+	Author    Person
+	Body      string
+	CreatedAt time.Time
+}
+
+// Link - связь с другим элементом той же системы: "blocks PROJ-2".
+type Link struct {
+	Type   string
+	Target string
+}
+
+// Item - задача внешней системы, приведённая к общему виду.
+type Item struct {
+	// ExternalID уникален в системе, Key - как элемент называют люди
+	ExternalID string
+	Key        string
+	Kind       string
+	Title      string
+	// Description - текст без HTML
+	Description string
+	// Status - как в источнике; Closed - элемент завершён в источнике
+	Status      string
+	Closed      bool
+	Assignees   []Person
+	Labels      []string
+	Due         *time.Time
+	StoryPoints int
+	Comments    []Comment
+	Links       []Link
+	// Skip - причина, по которой элемент не переносится (например, pull request)
+	Skip string
+}
+
+// Options хранятся вместе с импортом и задаются при запуске.
+type Options struct {
+	DryRun bool `json:"dry_run,omitempty"`
+	// StatusMap: статус в источнике (без учёта регистра) -> todo, in_progress, done
+	StatusMap map[string]string `json:"status_map,omitempty"`
+	// UserMap: id, логин, имя или email во внешней системе -> email или UUID пользователя
+	UserMap map[string]string `json:"user_map,omitempty"`
+	// DefaultAssignee назначается, если исполнителей не удалось сопоставить
+	DefaultAssignee string `json:"default_assignee,omitempty"`
+	// Project - UUID или название проекта для всех задач
+	Project string `json:"project,omitempty"`
+}
+
+// Lower приводит ключи карт к нижнему регистру: сопоставление без учёта регистра.
+func (o Options) Lower() Options {
+	lower := func(m map[string]string) map[string]string {
+		res := make(map[string]string, len(m))
+		for k, v := range m {
+			res[strings.ToLower(strings.TrimSpace(k))] = strings.TrimSpace(v)
+		}
+		return res
+	}
+	o.StatusMap = lower(o.StatusMap)
+	o.UserMap = lower(o.UserMap)
+	return o
+}
+
+type Created struct {
+	ExternalID string `json:"external_id"`
+	Key        string `json:"key"`
+	// TaskID пуст при пробном импорте
+	TaskID string `json:"task_id,omitempty"`
+}
+
+type Skipped struct {
+	ExternalID string `json:"external_id"`
+	Key        string `json:"key"`
+	Title      string `json:"title"`
+	Reason     string `json:"reason"`
+}
+
+type Report struct {
+	Source   string    `json:"source"`
+	DryRun   bool      `json:"dry_run"`
+	Items    int       `json:"items"`
+	Created  []Created `json:"created"`
+	Skipped  []Skipped `json:"skipped"`
+	Comments int       `json:"comments"`
+	Links    int       `json:"links"`
+	// Warnings - что перенесено не полностью: несопоставленные пользователи,
+	// неизвестные статусы, отброшенные метки
+	Warnings []string `json:"warnings"`
+}
+
+// Import - запуск импорта через API, выполняется заданием imports.run.
+type Import struct {
+	ID         uuid.UUID
+	Source     string
+	Status     string
+	Options    Options
+	Report     *Report
+	Error      string
+	CreatedAt  time.Time
+	FinishedAt *time.Time
+}
@@ -0,0 +1,13 @@
+package job
+
+import "errors"
+
+var (
+	ErrJobNotFound     = errors.New("job not found")
+	ErrDuplicateJob    = errors.New("job with the same unique key is already queued")
+	ErrInvalidStatus   = errors.New("invalid job status")
+	ErrNotRetryable    = errors.New("only failed or cancelled jobs can be retried")
+	ErrNotCancellable  = errors.New("only pending or running jobs can be cancelled")
+	ErrUnknownKind     = errors.New("unknown job kind")
+	ErrInvalidSchedule = errors.New("invalid schedule")
+)
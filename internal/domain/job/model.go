@@ -0,0 +1,57 @@
+package job
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	StatusPending   = "pending"
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusCancelled = "cancelled"
+)
+
+type Job struct {
+	ID      int64
+	Kind    string
+	Payload json.RawMessage
+	Status  string
+	// UniqueKey не даёт поставить второе задание, пока первое не завершено.
+	UniqueKey   string
+	RunAt       time.Time
+	Attempts    int
+	MaxAttempts int
+	LastError   string
+	LockedBy    string
+	LockedUntil *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	FinishedAt  *time.Time
+}
+
+// Schedule - периодическое задание в формате cron (5 полей или @every).
+type Schedule struct {
+	Name      string
+	Spec      string
+	Kind      string
+	Payload   json.RawMessage
+	NextRunAt time.Time
+	LastRunAt *time.Time
+}
+
+type Filter struct {
+	Status string
+	Kind   string
+	Limit  int
+	Offset int
+}
+
+func ValidStatus(s string) bool {
+	switch s {
+	case StatusPending, StatusRunning, StatusSucceeded, StatusFailed, StatusCancelled:
+		return true
+	}
+	return false
+}
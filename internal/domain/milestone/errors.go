@@ -0,0 +1,9 @@
+package milestone
+
+import "errors"
+
+var (
+	ErrMilestoneNotFound = errors.New("milestone not found")
+	ErrInvalidTitle      = errors.New("invalid title")
+	ErrInvalidDateRange  = errors.New("end date must not be before start date")
+)
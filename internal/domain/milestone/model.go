@@ -0,0 +1,36 @@
+package milestone
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Milestone struct {
+	ID        uuid.UUID
+	Title     string
+	StartDate time.Time
+	EndDate   time.Time
+	CreatedAt time.Time
+}
+
+// TaskEstimate - задача спринта. Status и CreatedAt нужны для задач без
+// истории статусов.
+type TaskEstimate struct {
+	TaskID      uuid.UUID
+	StoryPoints int
+	Status      string
+	CreatedAt   time.Time
+}
+
+type BurndownPoint struct {
+	Date      time.Time
+	Remaining int
+	Ideal     float64
+}
+
+type Burndown struct {
+	MilestoneID uuid.UUID
+	TotalPoints int
+	Points      []BurndownPoint
+}
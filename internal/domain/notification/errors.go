@@ -0,0 +1,8 @@
+package notification
+
+import "errors"
+
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+	ErrInvalidType          = errors.New("invalid notification type")
+)
@@ -0,0 +1,65 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TypeAssigned      = "assigned"
+	TypeMentioned     = "mentioned"
+	TypeStatusChanged = "status_changed"
+	TypeDueSoon       = "due_soon"
+)
+
+var Types = []string{
+	TypeAssigned,
+	TypeMentioned,
+	TypeStatusChanged,
+	TypeDueSoon,
+}
+
+type Notification struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	Type   string
+	Title  string
+	Body   string
+	TaskID *uuid.UUID
+	// DedupeKey не даёт создать одно и то же уведомление дважды,
+	// например при повторной доставке события из outbox.
+	DedupeKey string
+	InApp     bool
+	Email     bool
+	ReadAt    *time.Time
+	CreatedAt time.Time
+}
+
+// Preference - каналы доставки для типа уведомлений.
+// Если настройка не сохранена, включены оба канала.
+type Preference struct {
+	Type  string
+	InApp bool
+	Email bool
+}
+
+type PendingEmail struct {
+	Notification
+	To       string
+	UserName string
+	Attempts int
+}
+
+func ValidType(t string) bool {
+	for _, v := range Types {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
+
+func DefaultPreference(t string) Preference {
+	return Preference{Type: t, InApp: true, Email: true}
+}
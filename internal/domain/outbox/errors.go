@@ -0,0 +1,5 @@
+package outbox
+
+import "errors"
+
+var ErrEventNotFound = errors.New("outbox event not found")
@@ -0,0 +1,16 @@
+package outbox
+
+import "ProjectManagementAPI/internal/domain/event"
+
+const (
+	StatusPending   = "pending"
+	StatusPublished = "published"
+	// StatusDead - попытки исчерпаны, событие больше не отправляется
+	StatusDead = "dead"
+)
+
+type Record struct {
+	ID       int64
+	Event    event.Event
+	Attempts int
+}
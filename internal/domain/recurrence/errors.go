@@ -0,0 +1,13 @@
+package recurrence
+
+import "errors"
+
+var (
+	ErrSeriesNotFound   = errors.New("recurrence series not found")
+	ErrNotRecurring     = errors.New("task is not recurring")
+	ErrInvalidRule      = errors.New("invalid recurrence rule")
+	ErrInvalidTimezone  = errors.New("invalid timezone")
+	ErrNoOccurrences    = errors.New("recurrence rule yields no occurrences")
+	ErrInvalidScope     = errors.New("scope must be this or future")
+	ErrFutureFieldScope = errors.New("status and due_at can only be changed for this occurrence")
+)
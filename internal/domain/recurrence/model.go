@@ -0,0 +1,38 @@
+package recurrence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ScopeThis   = "this"
+	ScopeFuture = "future"
+)
+
+// Series - повторяющаяся задача: правило RRULE и шаблон, по которому
+// создаются повторения. Время суток и часовой пояс берутся из DTStart и Timezone.
+type Series struct {
+	ID               uuid.UUID
+	RRule            string
+	DTStart          time.Time
+	Timezone         string
+	Title            string
+	Description      string
+	StoryPoints      int
+	MilestoneID      *uuid.UUID
+	ProjectID        *uuid.UUID
+	Assignees        []uuid.UUID
+	LastOccurrenceAt time.Time
+	// NextOccurrenceAt - когда будет создано следующее повторение, nil для завершённой серии
+	NextOccurrenceAt *time.Time
+	EndsBefore       *time.Time
+	Finished         bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func ValidScope(s string) bool {
+	return s == ScopeThis || s == ScopeFuture
+}
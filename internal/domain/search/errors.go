@@ -0,0 +1,9 @@
+package search
+
+import "errors"
+
+var (
+	ErrEmptyQuery      = errors.New("search query must not be empty")
+	ErrQueryTooLong    = errors.New("search query is too long")
+	ErrInvalidLanguage = errors.New("unknown text search language")
+)
@@ -0,0 +1,46 @@
+package search
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MatchTitle       = "title"
+	MatchDescription = "description"
+	MatchComments    = "comments"
+)
+
+// Query - поисковый запрос. Text разбирается как websearch_to_tsquery:
+// слова, "фраза в кавычках", or, -исключение.
+type Query struct {
+	Text        string
+	Status      string
+	ProjectID   *uuid.UUID
+	MilestoneID *uuid.UUID
+	AssigneeID  *uuid.UUID
+	Limit       int
+	Offset      int
+}
+
+// Result - найденная задача. TitleHighlight и Snippet - экранированный HTML,
+// совпадения обёрнуты в <mark>.
+type Result struct {
+	TaskID         uuid.UUID
+	Title          string
+	Status         string
+	ProjectID      *uuid.UUID
+	DueAt          *time.Time
+	Rank           float64
+	TitleHighlight string
+	Snippet        string
+	MatchedIn      []string
+}
+
+type Page struct {
+	Results []Result
+	Total   int
+	// Fuzzy - полнотекстовый поиск ничего не нашёл, результаты подобраны по похожести названия
+	Fuzzy bool
+}
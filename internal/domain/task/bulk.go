@@ -0,0 +1,71 @@
+package task
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Операции POST /tasks/bulk.
+const (
+	BulkSetStatus = "set_status"
+	BulkReassign  = "reassign"
+	BulkAddLabel  = "add_label"
+	BulkDelete    = "delete"
+)
+
+// Режимы: atomic - всё в одной транзакции, per_item - каждая операция отдельно.
+const (
+	BulkAtomic  = "atomic"
+	BulkPerItem = "per_item"
+)
+
+// Итог отдельной операции.
+const (
+	BulkResultOK         = "ok"
+	BulkResultFailed     = "failed"
+	BulkResultRolledBack = "rolled_back"
+	BulkResultSkipped    = "skipped"
+)
+
+var (
+	ErrInvalidBulkOp   = errors.New("invalid bulk operation")
+	ErrInvalidBulkMode = errors.New("bulk mode must be atomic or per_item")
+	ErrBulkEmpty       = errors.New("either operations or filter with operation is required")
+	ErrBulkTooLarge    = errors.New("too many tasks in one bulk request")
+	// ErrBulkEmptyFilter - пустой фильтр выбрал бы все задачи
+	ErrBulkEmptyFilter = errors.New("filter must not be empty with operation")
+)
+
+type BulkOp struct {
+	Op        string
+	TaskID    uuid.UUID
+	Status    string
+	Assignees []uuid.UUID
+	Label     string
+}
+
+// BulkRequest содержит либо список операций, либо фильтр на языке запросов
+// и одну операцию, которая применяется к каждой найденной задаче.
+type BulkRequest struct {
+	Mode       string
+	Operations []BulkOp
+	Filter     string
+	Operation  *BulkOp
+}
+
+type BulkResult struct {
+	Index  int
+	TaskID uuid.UUID
+	Status string
+	Err    error
+}
+
+type BulkReport struct {
+	Mode string
+	// Applied - сохранено хоть что-то; в режиме atomic false, если хоть одна операция не удалась
+	Applied   bool
+	Succeeded int
+	Failed    int
+	Results   []BulkResult
+}
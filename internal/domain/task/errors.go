@@ -3,7 +3,13 @@ package task
 import "errors"
 
 var (
-	ErrTaskNotFound = errors.New("task not found")
-	ErrInvalidTitle = errors.New("invalid title")
-	ErrNoAssignees  = errors.New("task must have at least one assignee")
+	ErrTaskNotFound       = errors.New("task not found")
+	ErrInvalidTitle       = errors.New("invalid title")
+	ErrNoAssignees        = errors.New("task must have at least one assignee")
+	ErrAssigneeNotFound   = errors.New("assignee not found")
+	ErrNotAssigned        = errors.New("user is not assigned to the task")
+	ErrInvalidStatus      = errors.New("invalid status")
+	ErrInvalidStoryPoints = errors.New("story points must not be negative")
+	ErrInvalidLabel       = errors.New("label must be 1-50 letters, digits, '-', '_' or '.'")
+	ErrTooManyLabels      = errors.New("task can have at most 20 labels")
 )
@@ -0,0 +1,46 @@
+package task
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	MaxLabels      = 20
+	MaxLabelLength = 50
+)
+
+// NormalizeLabels приводит метки к нижнему регистру, убирает повторы и
+// сортирует их.
+func NormalizeLabels(labels []string) ([]string, error) {
+	res := make([]string, 0, len(labels))
+	for _, l := range labels {
+		l = strings.ToLower(strings.TrimSpace(l))
+		if !ValidLabel(l) {
+			return nil, ErrInvalidLabel
+		}
+		res = append(res, l)
+	}
+
+	slices.Sort(res)
+	res = slices.Compact(res)
+	if len(res) > MaxLabels {
+		return nil, ErrTooManyLabels
+	}
+
+	return res, nil
+}
+
+func ValidLabel(l string) bool {
+	if l == "" || utf8.RuneCountInString(l) > MaxLabelLength {
+		return false
+	}
+	for _, r := range l {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("-_.", r) {
+			return false
+		}
+	}
+	return true
+}
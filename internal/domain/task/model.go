@@ -6,11 +6,56 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	StatusTodo       = "todo"
+	StatusInProgress = "in_progress"
+	StatusDone       = "done"
+)
+
 type Task struct {
 	ID          uuid.UUID
 	Title       string
 	Description string
 	Status      string
-	CreatedAt   time.Time
-	Assignees   []uuid.UUID
+	StoryPoints int
+	MilestoneID *uuid.UUID
+	ProjectID   *uuid.UUID
+	DueAt       *time.Time
+	// SeriesID и OccurrenceAt заданы у повторений повторяющейся задачи
+	SeriesID     *uuid.UUID
+	OccurrenceAt *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	Assignees    []uuid.UUID
+	Labels       []string
+}
+
+// Patch описывает частичное обновление задачи, nil-поля не меняются.
+// MilestoneID и ProjectID со значением uuid.Nil отвязывают задачу,
+// нулевое DueAt снимает срок.
+type Patch struct {
+	Title       *string
+	Description *string
+	Status      *string
+	StoryPoints *int
+	MilestoneID *uuid.UUID
+	ProjectID   *uuid.UUID
+	DueAt       *time.Time
+	// Labels и Assignees заменяют весь набор меток и исполнителей
+	Labels    *[]string
+	Assignees *[]uuid.UUID
+}
+
+type StatusChange struct {
+	TaskID    uuid.UUID
+	Status    string
+	ChangedAt time.Time
+}
+
+func ValidStatus(status string) bool {
+	switch status {
+	case StatusTodo, StatusInProgress, StatusDone:
+		return true
+	}
+	return false
 }
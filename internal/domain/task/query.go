@@ -0,0 +1,34 @@
+package task
+
+import "ProjectManagementAPI/internal/lib/query"
+
+// Поля языка фильтров задач.
+const (
+	FieldStatus      = "status"
+	FieldAssignee    = "assignee"
+	FieldLabel       = "label"
+	FieldProject     = "project"
+	FieldMilestone   = "milestone"
+	FieldDue         = "due"
+	FieldCreated     = "created"
+	FieldUpdated     = "updated"
+	FieldPoints      = "points"
+	FieldTitle       = "title"
+	FieldDescription = "description"
+)
+
+// QuerySchema - допустимые поля фильтра. assignee принимает me, UUID или
+// email; project и milestone - UUID или название.
+var QuerySchema = query.Schema{
+	FieldStatus:      {Kind: query.KindEnum, Values: []string{StatusTodo, StatusInProgress, StatusDone}},
+	FieldAssignee:    {Kind: query.KindRef, AllowMe: true},
+	FieldLabel:       {Kind: query.KindRef, Nullable: true},
+	FieldProject:     {Kind: query.KindRef, Nullable: true},
+	FieldMilestone:   {Kind: query.KindRef, Nullable: true},
+	FieldDue:         {Kind: query.KindDate, Nullable: true},
+	FieldCreated:     {Kind: query.KindDate},
+	FieldUpdated:     {Kind: query.KindDate},
+	FieldPoints:      {Kind: query.KindInt},
+	FieldTitle:       {Kind: query.KindText},
+	FieldDescription: {Kind: query.KindText},
+}
@@ -0,0 +1,151 @@
+package transfer
+
+import (
+	"ProjectManagementAPI/internal/domain/task"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Форматы импорта и экспорта.
+const (
+	FormatCSV    = "csv"
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+)
+
+// Поля задачи при импорте. project и milestone принимают UUID или название,
+// assignees - email или UUID через ";" или ",".
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldStatus      = "status"
+	FieldStoryPoints = "story_points"
+	FieldDueAt       = "due_at"
+	FieldProject     = "project"
+	FieldMilestone   = "milestone"
+	FieldAssignees   = "assignees"
+	FieldLabels      = "labels"
+)
+
+// Поля пользователя при импорте.
+const (
+	FieldEmail = "email"
+	FieldName  = "name"
+)
+
+var (
+	TaskFields = []string{
+		FieldTitle, FieldDescription, FieldStatus, FieldStoryPoints, FieldDueAt,
+		FieldProject, FieldMilestone, FieldAssignees, FieldLabels,
+	}
+	UserFields = []string{FieldEmail, FieldName}
+)
+
+// aliases позволяют загрузить обратно файл экспорта без mapping.
+var aliases = map[string]string{
+	"project_id":   FieldProject,
+	"milestone_id": FieldMilestone,
+}
+
+var (
+	ErrInvalidFormat  = errors.New("format must be csv, json or ndjson")
+	ErrInvalidMapping = errors.New("invalid column mapping")
+	ErrNoRows         = errors.New("no rows to import")
+	ErrTooManyRows    = errors.New("too many rows to import")
+)
+
+func ValidFormat(f string) bool {
+	switch f {
+	case FormatCSV, FormatJSON, FormatNDJSON:
+		return true
+	}
+	return false
+}
+
+// Record - строка входного файла: имя колонки (ключа) -> значение.
+// Row - номер записи с единицы, строка заголовка CSV не считается.
+type Record struct {
+	Row    int
+	Values map[string]string
+}
+
+type Options struct {
+	DryRun bool
+	// Mapping переименовывает колонки файла в поля: {"Summary": "title"}.
+	// Колонки без mapping сопоставляются по имени, неизвестные пропускаются.
+	Mapping map[string]string
+}
+
+// Columns проверяет mapping и возвращает функцию, переводящую имя колонки
+// в поле из fields ("" - колонка не импортируется).
+func (o Options) Columns(fields []string) (func(column string) string, error) {
+	known := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		known[f] = true
+	}
+
+	mapping := make(map[string]string, len(o.Mapping))
+	for column, field := range o.Mapping {
+		if !known[field] {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidMapping, field)
+		}
+		mapping[column] = field
+	}
+
+	return func(column string) string {
+		if f, ok := mapping[column]; ok {
+			return f
+		}
+		f := strings.ToLower(strings.TrimSpace(column))
+		if a, ok := aliases[f]; ok {
+			f = a
+		}
+		if known[f] {
+			return f
+		}
+		return ""
+	}, nil
+}
+
+// SplitList разбирает многозначную ячейку: значения через ";" или ",".
+func SplitList(s string) []string {
+	parts := strings.FieldsFunc(s, func(r rune) bool { return r == ';' || r == ',' })
+	res := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+type RowError struct {
+	Row     int
+	Field   string
+	Message string
+}
+
+// Report - итог импорта. Импорт атомарный: при любой ошибке в строках
+// ничего не сохраняется, Committed=false.
+type Report struct {
+	Rows      int
+	DryRun    bool
+	Committed bool
+	Created   []uuid.UUID
+	Errors    []RowError
+}
+
+// Ref - найденная по UUID или имени сущность: Key - имя в нижнем регистре.
+type Ref struct {
+	ID  uuid.UUID
+	Key string
+}
+
+// TaskRow - задача при экспорте: исполнители заменены на их email.
+type TaskRow struct {
+	Task      *task.Task
+	Assignees []string
+}
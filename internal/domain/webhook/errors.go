@@ -0,0 +1,10 @@
+package webhook
+
+import "errors"
+
+var (
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+	ErrInvalidURL           = errors.New("invalid webhook url")
+	ErrInvalidEventType     = errors.New("invalid event type")
+)
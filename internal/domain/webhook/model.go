@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryDead      = "dead"
+)
+
+type Subscription struct {
+	ID         uuid.UUID
+	URL        string
+	Secret     string
+	EventTypes []string
+	Active     bool
+	CreatedAt  time.Time
+}
+
+type Delivery struct {
+	ID             int64
+	SubscriptionID uuid.UUID
+	EventID        uuid.UUID
+	EventType      string
+	Payload        json.RawMessage
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastError      string
+	ResponseCode   int
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+// PendingDelivery - доставка вместе с адресом и секретом подписки.
+type PendingDelivery struct {
+	Delivery
+	URL    string
+	Secret string
+}
@@ -0,0 +1,9 @@
+package workload
+
+import "errors"
+
+var (
+	ErrInvalidRange    = errors.New("from must be before to")
+	ErrPeriodTooLong   = errors.New("workload period is too long")
+	ErrInvalidCapacity = errors.New("weekly capacity must not be negative")
+)
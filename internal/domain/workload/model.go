@@ -0,0 +1,58 @@
+package workload
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// User - пользователь с личной недельной ёмкостью в story points.
+// Capacity равен nil, если задана ёмкость по умолчанию.
+type User struct {
+	ID       uuid.UUID
+	Name     string
+	Email    string
+	Capacity *int
+}
+
+// Assignment - задача пользователя, попавшая в отчёт.
+type Assignment struct {
+	UserID      uuid.UUID
+	TaskID      uuid.UUID
+	Status      string
+	StoryPoints int
+	DueAt       *time.Time
+	// Assignees - сколько всего исполнителей у задачи, между ними делится оценка
+	Assignees int
+}
+
+// Load - число задач и оценка в story points.
+type Load struct {
+	Tasks  int
+	Effort float64
+}
+
+// Week - незавершённые задачи со сроком на неделе, начинающейся в Start.
+type Week struct {
+	Start time.Time
+	Load
+	Overallocated bool
+}
+
+// UserLoad - нагрузка одного пользователя за период.
+type UserLoad struct {
+	User User
+	// Capacity - действующая ёмкость: личная или по умолчанию
+	Capacity int
+	ByStatus map[string]Load
+	Weeks    []Week
+	// Unscheduled - незавершённые задачи без срока
+	Unscheduled   Load
+	Overallocated bool
+}
+
+type Report struct {
+	From  time.Time
+	To    time.Time
+	Users []UserLoad
+}
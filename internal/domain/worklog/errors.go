@@ -0,0 +1,13 @@
+package worklog
+
+import "errors"
+
+var (
+	ErrWorklogNotFound = errors.New("worklog not found")
+	ErrTimerRunning    = errors.New("another timer is already running")
+	ErrNoRunningTimer  = errors.New("no running timer")
+	ErrInvalidDuration = errors.New("duration must be positive")
+	ErrInvalidRange    = errors.New("from must be before to")
+	ErrInvalidGroupBy  = errors.New("group_by must be task, user or project")
+	ErrStartedInFuture = errors.New("started_at must not be in the future")
+)
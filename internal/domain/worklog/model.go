@@ -0,0 +1,53 @@
+package worklog
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SourceTimer  = "timer"
+	SourceManual = "manual"
+)
+
+const (
+	GroupByTask    = "task"
+	GroupByUser    = "user"
+	GroupByProject = "project"
+)
+
+type Worklog struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	TaskID    uuid.UUID
+	StartedAt time.Time
+	// Duration равен nil, пока таймер запущен
+	Duration  *time.Duration
+	Note      string
+	Source    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Filter struct {
+	UserID *uuid.UUID
+	TaskID *uuid.UUID
+	From   *time.Time
+	To     *time.Time
+	Limit  int
+	Offset int
+}
+
+// Total - суммарное время по задаче, пользователю или проекту за период.
+// Для записей без проекта ID равен nil.
+type Total struct {
+	ID       *uuid.UUID
+	Name     string
+	Duration time.Duration
+	Entries  int
+}
+
+func ValidGroupBy(g string) bool {
+	return g == GroupByTask || g == GroupByUser || g == GroupByProject
+}
@@ -0,0 +1,872 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: pm/v1/task.proto
+
+package pmv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TaskStatus int32
+
+const (
+	TaskStatus_TASK_STATUS_UNSPECIFIED TaskStatus = 0
+	TaskStatus_TASK_STATUS_TODO        TaskStatus = 1
+	TaskStatus_TASK_STATUS_IN_PROGRESS TaskStatus = 2
+	TaskStatus_TASK_STATUS_DONE        TaskStatus = 3
+)
+
+// Enum value maps for TaskStatus.
+var (
+	TaskStatus_name = map[int32]string{
+		0: "TASK_STATUS_UNSPECIFIED",
+		1: "TASK_STATUS_TODO",
+		2: "TASK_STATUS_IN_PROGRESS",
+		3: "TASK_STATUS_DONE",
+	}
+	TaskStatus_value = map[string]int32{
+		"TASK_STATUS_UNSPECIFIED": 0,
+		"TASK_STATUS_TODO":        1,
+		"TASK_STATUS_IN_PROGRESS": 2,
+		"TASK_STATUS_DONE":        3,
+	}
+)
+
+func (x TaskStatus) Enum() *TaskStatus {
+	p := new(TaskStatus)
+	*p = x
+	return p
+}
+
+func (x TaskStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_pm_v1_task_proto_enumTypes[0].Descriptor()
+}
+
+func (TaskStatus) Type() protoreflect.EnumType {
+	return &file_pm_v1_task_proto_enumTypes[0]
+}
+
+func (x TaskStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskStatus.Descriptor instead.
+func (TaskStatus) EnumDescriptor() ([]byte, []int) {
+	return file_pm_v1_task_proto_rawDescGZIP(), []int{0}
+}
+
+type Task struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Status      TaskStatus             `protobuf:"varint,4,opt,name=status,proto3,enum=pm.v1.TaskStatus" json:"status,omitempty"`
+	StoryPoints int32                  `protobuf:"varint,5,opt,name=story_points,json=storyPoints,proto3" json:"story_points,omitempty"`
+	MilestoneId *string                `protobuf:"bytes,6,opt,name=milestone_id,json=milestoneId,proto3,oneof" json:"milestone_id,omitempty"`
+	ProjectId   *string                `protobuf:"bytes,7,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	DueAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	// series_id и occurrence_at заданы у повторений повторяющейся задачи
+	SeriesId      *string                `protobuf:"bytes,9,opt,name=series_id,json=seriesId,proto3,oneof" json:"series_id,omitempty"`
+	OccurrenceAt  *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=occurrence_at,json=occurrenceAt,proto3" json:"occurrence_at,omitempty"`
+	Assignees     []string               `protobuf:"bytes,11,rep,name=assignees,proto3" json:"assignees,omitempty"`
+	Labels        []string               `protobuf:"bytes,12,rep,name=labels,proto3" json:"labels,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_pm_v1_task_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_pm_v1_task_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_pm_v1_task_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Task) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Task) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Task) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Task) GetStatus() TaskStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func (x *Task) GetStoryPoints() int32 {
+	if x != nil {
+		return x.StoryPoints
+	}
+	return 0
+}
+
+func (x *Task) GetMilestoneId() string {
+	if x != nil && x.MilestoneId != nil {
+		return *x.MilestoneId
+	}
+	return ""
+}
+
+func (x *Task) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *Task) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+func (x *Task) GetSeriesId() string {
+	if x != nil && x.SeriesId != nil {
+		return *x.SeriesId
+	}
+	return ""
+}
+
+func (x *Task) GetOccurrenceAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurrenceAt
+	}
+	return nil
+}
+
+func (x *Task) GetAssignees() []string {
+	if x != nil {
+		return x.Assignees
+	}
+	return nil
+}
+
+func (x *Task) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Task) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Task) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Status        TaskStatus             `protobuf:"varint,3,opt,name=status,proto3,enum=pm.v1.TaskStatus" json:"status,omitempty"`
+	StoryPoints   int32                  `protobuf:"varint,4,opt,name=story_points,json=storyPoints,proto3" json:"story_points,omitempty"`
+	MilestoneId   *string                `protobuf:"bytes,5,opt,name=milestone_id,json=milestoneId,proto3,oneof" json:"milestone_id,omitempty"`
+	ProjectId     *string                `protobuf:"bytes,6,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	DueAt         *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	Assignees     []string               `protobuf:"bytes,8,rep,name=assignees,proto3" json:"assignees,omitempty"`
+	Labels        []string               `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskRequest) Reset() {
+	*x = CreateTaskRequest{}
+	mi := &file_pm_v1_task_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskRequest) ProtoMessage() {}
+
+func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pm_v1_task_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_pm_v1_task_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateTaskRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetStatus() TaskStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func (x *CreateTaskRequest) GetStoryPoints() int32 {
+	if x != nil {
+		return x.StoryPoints
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetMilestoneId() string {
+	if x != nil && x.MilestoneId != nil {
+		return *x.MilestoneId
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+func (x *CreateTaskRequest) GetAssignees() []string {
+	if x != nil {
+		return x.Assignees
+	}
+	return nil
+}
+
+func (x *CreateTaskRequest) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type GetTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_pm_v1_task_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pm_v1_task_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_pm_v1_task_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// StringList отличает "не менять" (поле не задано) от пустого набора.
+type StringList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StringList) Reset() {
+	*x = StringList{}
+	mi := &file_pm_v1_task_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StringList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StringList) ProtoMessage() {}
+
+func (x *StringList) ProtoReflect() protoreflect.Message {
+	mi := &file_pm_v1_task_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StringList.ProtoReflect.Descriptor instead.
+func (*StringList) Descriptor() ([]byte, []int) {
+	return file_pm_v1_task_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StringList) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// UpdateTaskRequest меняет только заданные поля. Пустые milestone_id и
+// project_id отвязывают задачу, clear_due_at снимает срок.
+type UpdateTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Status        *TaskStatus            `protobuf:"varint,4,opt,name=status,proto3,enum=pm.v1.TaskStatus,oneof" json:"status,omitempty"`
+	StoryPoints   *int32                 `protobuf:"varint,5,opt,name=story_points,json=storyPoints,proto3,oneof" json:"story_points,omitempty"`
+	MilestoneId   *string                `protobuf:"bytes,6,opt,name=milestone_id,json=milestoneId,proto3,oneof" json:"milestone_id,omitempty"`
+	ProjectId     *string                `protobuf:"bytes,7,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	DueAt         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	ClearDueAt    bool                   `protobuf:"varint,9,opt,name=clear_due_at,json=clearDueAt,proto3" json:"clear_due_at,omitempty"`
+	Labels        *StringList            `protobuf:"bytes,10,opt,name=labels,proto3" json:"labels,omitempty"`
+	Assignees     *StringList            `protobuf:"bytes,11,opt,name=assignees,proto3" json:"assignees,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskRequest) Reset() {
+	*x = UpdateTaskRequest{}
+	mi := &file_pm_v1_task_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskRequest) ProtoMessage() {}
+
+func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pm_v1_task_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_pm_v1_task_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetStatus() TaskStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func (x *UpdateTaskRequest) GetStoryPoints() int32 {
+	if x != nil && x.StoryPoints != nil {
+		return *x.StoryPoints
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetMilestoneId() string {
+	if x != nil && x.MilestoneId != nil {
+		return *x.MilestoneId
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetClearDueAt() bool {
+	if x != nil {
+		return x.ClearDueAt
+	}
+	return false
+}
+
+func (x *UpdateTaskRequest) GetLabels() *StringList {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetAssignees() *StringList {
+	if x != nil {
+		return x.Assignees
+	}
+	return nil
+}
+
+type DeleteTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskRequest) Reset() {
+	*x = DeleteTaskRequest{}
+	mi := &file_pm_v1_task_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskRequest) ProtoMessage() {}
+
+func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pm_v1_task_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_pm_v1_task_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_pm_v1_task_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pm_v1_task_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_pm_v1_task_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListTasksRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_pm_v1_task_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pm_v1_task_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_pm_v1_task_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+var File_pm_v1_task_proto protoreflect.FileDescriptor
+
+const file_pm_v1_task_proto_rawDesc = "" +
+	"\n" +
+	"\x10pm/v1/task.proto\x12\x05pm.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd8\x04\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12)\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x11.pm.v1.TaskStatusR\x06status\x12!\n" +
+	"\fstory_points\x18\x05 \x01(\x05R\vstoryPoints\x12&\n" +
+	"\fmilestone_id\x18\x06 \x01(\tH\x00R\vmilestoneId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"project_id\x18\a \x01(\tH\x01R\tprojectId\x88\x01\x01\x121\n" +
+	"\x06due_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x05dueAt\x12 \n" +
+	"\tseries_id\x18\t \x01(\tH\x02R\bseriesId\x88\x01\x01\x12?\n" +
+	"\roccurrence_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\foccurrenceAt\x12\x1c\n" +
+	"\tassignees\x18\v \x03(\tR\tassignees\x12\x16\n" +
+	"\x06labels\x18\f \x03(\tR\x06labels\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x0f\n" +
+	"\r_milestone_idB\r\n" +
+	"\v_project_idB\f\n" +
+	"\n" +
+	"_series_id\"\xee\x02\n" +
+	"\x11CreateTaskRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12)\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x11.pm.v1.TaskStatusR\x06status\x12!\n" +
+	"\fstory_points\x18\x04 \x01(\x05R\vstoryPoints\x12&\n" +
+	"\fmilestone_id\x18\x05 \x01(\tH\x00R\vmilestoneId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"project_id\x18\x06 \x01(\tH\x01R\tprojectId\x88\x01\x01\x121\n" +
+	"\x06due_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x05dueAt\x12\x1c\n" +
+	"\tassignees\x18\b \x03(\tR\tassignees\x12\x16\n" +
+	"\x06labels\x18\t \x03(\tR\x06labelsB\x0f\n" +
+	"\r_milestone_idB\r\n" +
+	"\v_project_id\" \n" +
+	"\x0eGetTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"$\n" +
+	"\n" +
+	"StringList\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\"\x90\x04\n" +
+	"\x11UpdateTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12.\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x11.pm.v1.TaskStatusH\x02R\x06status\x88\x01\x01\x12&\n" +
+	"\fstory_points\x18\x05 \x01(\x05H\x03R\vstoryPoints\x88\x01\x01\x12&\n" +
+	"\fmilestone_id\x18\x06 \x01(\tH\x04R\vmilestoneId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"project_id\x18\a \x01(\tH\x05R\tprojectId\x88\x01\x01\x121\n" +
+	"\x06due_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x05dueAt\x12 \n" +
+	"\fclear_due_at\x18\t \x01(\bR\n" +
+	"clearDueAt\x12)\n" +
+	"\x06labels\x18\n" +
+	" \x01(\v2\x11.pm.v1.StringListR\x06labels\x12/\n" +
+	"\tassignees\x18\v \x01(\v2\x11.pm.v1.StringListR\tassigneesB\b\n" +
+	"\x06_titleB\x0e\n" +
+	"\f_descriptionB\t\n" +
+	"\a_statusB\x0f\n" +
+	"\r_story_pointsB\x0f\n" +
+	"\r_milestone_idB\r\n" +
+	"\v_project_id\"#\n" +
+	"\x11DeleteTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"V\n" +
+	"\x10ListTasksRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"6\n" +
+	"\x11ListTasksResponse\x12!\n" +
+	"\x05tasks\x18\x01 \x03(\v2\v.pm.v1.TaskR\x05tasks*r\n" +
+	"\n" +
+	"TaskStatus\x12\x1b\n" +
+	"\x17TASK_STATUS_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10TASK_STATUS_TODO\x10\x01\x12\x1b\n" +
+	"\x17TASK_STATUS_IN_PROGRESS\x10\x02\x12\x14\n" +
+	"\x10TASK_STATUS_DONE\x10\x032\xa6\x02\n" +
+	"\vTaskService\x123\n" +
+	"\n" +
+	"CreateTask\x12\x18.pm.v1.CreateTaskRequest\x1a\v.pm.v1.Task\x12-\n" +
+	"\aGetTask\x12\x15.pm.v1.GetTaskRequest\x1a\v.pm.v1.Task\x123\n" +
+	"\n" +
+	"UpdateTask\x12\x18.pm.v1.UpdateTaskRequest\x1a\v.pm.v1.Task\x12>\n" +
+	"\n" +
+	"DeleteTask\x12\x18.pm.v1.DeleteTaskRequest\x1a\x16.google.protobuf.Empty\x12>\n" +
+	"\tListTasks\x12\x17.pm.v1.ListTasksRequest\x1a\x18.pm.v1.ListTasksResponseB:Z8ProjectManagementAPI/internal/grpc-server/gen/pm/v1;pmv1b\x06proto3"
+
+var (
+	file_pm_v1_task_proto_rawDescOnce sync.Once
+	file_pm_v1_task_proto_rawDescData []byte
+)
+
+func file_pm_v1_task_proto_rawDescGZIP() []byte {
+	file_pm_v1_task_proto_rawDescOnce.Do(func() {
+		file_pm_v1_task_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pm_v1_task_proto_rawDesc), len(file_pm_v1_task_proto_rawDesc)))
+	})
+	return file_pm_v1_task_proto_rawDescData
+}
+
+var file_pm_v1_task_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_pm_v1_task_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_pm_v1_task_proto_goTypes = []any{
+	(TaskStatus)(0),               // 0: pm.v1.TaskStatus
+	(*Task)(nil),                  // 1: pm.v1.Task
+	(*CreateTaskRequest)(nil),     // 2: pm.v1.CreateTaskRequest
+	(*GetTaskRequest)(nil),        // 3: pm.v1.GetTaskRequest
+	(*StringList)(nil),            // 4: pm.v1.StringList
+	(*UpdateTaskRequest)(nil),     // 5: pm.v1.UpdateTaskRequest
+	(*DeleteTaskRequest)(nil),     // 6: pm.v1.DeleteTaskRequest
+	(*ListTasksRequest)(nil),      // 7: pm.v1.ListTasksRequest
+	(*ListTasksResponse)(nil),     // 8: pm.v1.ListTasksResponse
+	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 10: google.protobuf.Empty
+}
+var file_pm_v1_task_proto_depIdxs = []int32{
+	0,  // 0: pm.v1.Task.status:type_name -> pm.v1.TaskStatus
+	9,  // 1: pm.v1.Task.due_at:type_name -> google.protobuf.Timestamp
+	9,  // 2: pm.v1.Task.occurrence_at:type_name -> google.protobuf.Timestamp
+	9,  // 3: pm.v1.Task.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 4: pm.v1.Task.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: pm.v1.CreateTaskRequest.status:type_name -> pm.v1.TaskStatus
+	9,  // 6: pm.v1.CreateTaskRequest.due_at:type_name -> google.protobuf.Timestamp
+	0,  // 7: pm.v1.UpdateTaskRequest.status:type_name -> pm.v1.TaskStatus
+	9,  // 8: pm.v1.UpdateTaskRequest.due_at:type_name -> google.protobuf.Timestamp
+	4,  // 9: pm.v1.UpdateTaskRequest.labels:type_name -> pm.v1.StringList
+	4,  // 10: pm.v1.UpdateTaskRequest.assignees:type_name -> pm.v1.StringList
+	1,  // 11: pm.v1.ListTasksResponse.tasks:type_name -> pm.v1.Task
+	2,  // 12: pm.v1.TaskService.CreateTask:input_type -> pm.v1.CreateTaskRequest
+	3,  // 13: pm.v1.TaskService.GetTask:input_type -> pm.v1.GetTaskRequest
+	5,  // 14: pm.v1.TaskService.UpdateTask:input_type -> pm.v1.UpdateTaskRequest
+	6,  // 15: pm.v1.TaskService.DeleteTask:input_type -> pm.v1.DeleteTaskRequest
+	7,  // 16: pm.v1.TaskService.ListTasks:input_type -> pm.v1.ListTasksRequest
+	1,  // 17: pm.v1.TaskService.CreateTask:output_type -> pm.v1.Task
+	1,  // 18: pm.v1.TaskService.GetTask:output_type -> pm.v1.Task
+	1,  // 19: pm.v1.TaskService.UpdateTask:output_type -> pm.v1.Task
+	10, // 20: pm.v1.TaskService.DeleteTask:output_type -> google.protobuf.Empty
+	8,  // 21: pm.v1.TaskService.ListTasks:output_type -> pm.v1.ListTasksResponse
+	17, // [17:22] is the sub-list for method output_type
+	12, // [12:17] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_pm_v1_task_proto_init() }
+func file_pm_v1_task_proto_init() {
+	if File_pm_v1_task_proto != nil {
+		return
+	}
+	file_pm_v1_task_proto_msgTypes[0].OneofWrappers = []any{}
+	file_pm_v1_task_proto_msgTypes[1].OneofWrappers = []any{}
+	file_pm_v1_task_proto_msgTypes[4].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pm_v1_task_proto_rawDesc), len(file_pm_v1_task_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pm_v1_task_proto_goTypes,
+		DependencyIndexes: file_pm_v1_task_proto_depIdxs,
+		EnumInfos:         file_pm_v1_task_proto_enumTypes,
+		MessageInfos:      file_pm_v1_task_proto_msgTypes,
+	}.Build()
+	File_pm_v1_task_proto = out.File
+	file_pm_v1_task_proto_goTypes = nil
+	file_pm_v1_task_proto_depIdxs = nil
+}
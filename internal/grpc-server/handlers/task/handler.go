@@ -0,0 +1,409 @@
+package task
+
+import (
+	milestoneDomain "ProjectManagementAPI/internal/domain/milestone"
+	projectDomain "ProjectManagementAPI/internal/domain/project"
+	taskDomain "ProjectManagementAPI/internal/domain/task"
+	pmv1 "ProjectManagementAPI/internal/grpc-server/gen/pm/v1"
+	"ProjectManagementAPI/internal/grpc-server/rpcerr"
+	"ProjectManagementAPI/internal/lib/api/validate"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"ProjectManagementAPI/internal/lib/query"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Service interface {
+	Create(ctx context.Context, t *taskDomain.Task) (uuid.UUID, error)
+	Update(ctx context.Context, id uuid.UUID, p taskDomain.Patch) (*taskDomain.Task, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetByID(ctx context.Context, id uuid.UUID) (*taskDomain.Task, error)
+	Find(ctx context.Context, q string, limit, offset int) ([]*taskDomain.Task, error)
+}
+
+// Handler - TaskService поверх того же сервиса задач, что и HTTP.
+type Handler struct {
+	pmv1.UnimplementedTaskServiceServer
+
+	log     *slog.Logger
+	service Service
+}
+
+func NewHandler(log *slog.Logger, service Service) *Handler {
+	return &Handler{
+		log:     log,
+		service: service,
+	}
+}
+
+var statuses = map[pmv1.TaskStatus]string{
+	pmv1.TaskStatus_TASK_STATUS_TODO:        taskDomain.StatusTodo,
+	pmv1.TaskStatus_TASK_STATUS_IN_PROGRESS: taskDomain.StatusInProgress,
+	pmv1.TaskStatus_TASK_STATUS_DONE:        taskDomain.StatusDone,
+}
+
+// createRequest проверяется теми же правилами, что и POST /tasks.
+type createRequest struct {
+	Title       string   `json:"title" validate:"required"`
+	Description string   `json:"description" validate:"required"`
+	Status      string   `json:"status" validate:"required,oneof=todo in_progress done"`
+	StoryPoints int      `json:"story_points" validate:"min=0"`
+	MilestoneID string   `json:"milestone_id" validate:"omitempty,uuid4"`
+	ProjectID   string   `json:"project_id" validate:"omitempty,uuid4"`
+	Assignees   []string `json:"assignees" validate:"required,min=1,dive,uuid4"`
+	Labels      []string `json:"labels" validate:"max=20"`
+}
+
+func (h *Handler) CreateTask(ctx context.Context, in *pmv1.CreateTaskRequest) (*pmv1.Task, error) {
+	const op = "grpc/task.CreateTask"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(ctx)),
+	)
+
+	req := createRequest{
+		Title:       in.GetTitle(),
+		Description: in.GetDescription(),
+		Status:      statusFromProto(in.GetStatus()),
+		StoryPoints: int(in.GetStoryPoints()),
+		MilestoneID: in.GetMilestoneId(),
+		ProjectID:   in.GetProjectId(),
+		Assignees:   in.GetAssignees(),
+		Labels:      in.GetLabels(),
+	}
+
+	if err := validate.Struct(req); err != nil {
+		var validateErr validator.ValidationErrors
+		errors.As(err, &validateErr)
+		return nil, rpcerr.Invalid(ctx, validateErr)
+	}
+
+	t := &taskDomain.Task{
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      req.Status,
+		StoryPoints: req.StoryPoints,
+		Assignees:   make([]uuid.UUID, len(req.Assignees)),
+		Labels:      req.Labels,
+	}
+	for i, a := range req.Assignees {
+		t.Assignees[i] = uuid.MustParse(a)
+	}
+	if in.DueAt != nil {
+		dueAt := in.GetDueAt().AsTime()
+		t.DueAt = &dueAt
+	}
+	if req.MilestoneID != "" {
+		milestoneID := uuid.MustParse(req.MilestoneID)
+		t.MilestoneID = &milestoneID
+	}
+	if req.ProjectID != "" {
+		projectID := uuid.MustParse(req.ProjectID)
+		t.ProjectID = &projectID
+	}
+
+	id, err := h.service.Create(ctx, t)
+	if err != nil {
+		if st := statusFromErr(ctx, err); st != nil {
+			return nil, st
+		}
+		log.Error("create failed", sl.Err(err))
+		return nil, rpcerr.Error(ctx, codes.Internal, "failed to create task")
+	}
+
+	task, err := h.service.GetByID(ctx, id)
+	if err != nil {
+		log.Error("get failed", sl.Err(err))
+		return nil, rpcerr.Error(ctx, codes.Internal, "failed to get")
+	}
+
+	return toProto(task), nil
+}
+
+func (h *Handler) GetTask(ctx context.Context, in *pmv1.GetTaskRequest) (*pmv1.Task, error) {
+	const op = "grpc/task.GetTask"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(ctx)),
+	)
+
+	id, err := uuid.Parse(in.GetId())
+	if err != nil {
+		return nil, rpcerr.Error(ctx, codes.InvalidArgument, "invalid id")
+	}
+
+	task, err := h.service.GetByID(ctx, id)
+
+	if errors.Is(err, taskDomain.ErrTaskNotFound) {
+		log.Info("task not found", slog.String("task_id", id.String()))
+		return nil, rpcerr.Error(ctx, codes.NotFound, "task not found")
+	}
+
+	if err != nil {
+		log.Error("get failed", sl.Err(err))
+		return nil, rpcerr.Error(ctx, codes.Internal, "failed to get")
+	}
+
+	return toProto(task), nil
+}
+
+// updateRequest проверяется теми же правилами, что и PATCH /tasks/{id}.
+type updateRequest struct {
+	Title       *string   `json:"title" validate:"omitempty,min=1"`
+	Status      *string   `json:"status" validate:"omitempty,oneof=todo in_progress done"`
+	StoryPoints *int      `json:"story_points" validate:"omitempty,min=0"`
+	MilestoneID *string   `json:"milestone_id" validate:"omitempty,uuid4"`
+	ProjectID   *string   `json:"project_id" validate:"omitempty,uuid4"`
+	Labels      *[]string `json:"labels" validate:"omitempty,max=20"`
+	Assignees   *[]string `json:"assignees" validate:"omitempty,min=1,dive,uuid4"`
+}
+
+func (h *Handler) UpdateTask(ctx context.Context, in *pmv1.UpdateTaskRequest) (*pmv1.Task, error) {
+	const op = "grpc/task.UpdateTask"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(ctx)),
+	)
+
+	id, err := uuid.Parse(in.GetId())
+	if err != nil {
+		return nil, rpcerr.Error(ctx, codes.InvalidArgument, "invalid id")
+	}
+
+	req := updateRequest{
+		Title:     in.Title,
+		Labels:    values(in.GetLabels()),
+		Assignees: values(in.GetAssignees()),
+	}
+	if in.Status != nil {
+		s := statusFromProto(in.GetStatus())
+		req.Status = &s
+	}
+	if in.StoryPoints != nil {
+		sp := int(in.GetStoryPoints())
+		req.StoryPoints = &sp
+	}
+	// Пустая строка отвязывает задачу и проверку uuid4 не проходит
+	if in.GetMilestoneId() != "" {
+		req.MilestoneID = in.MilestoneId
+	}
+	if in.GetProjectId() != "" {
+		req.ProjectID = in.ProjectId
+	}
+
+	if err := validate.Struct(req); err != nil {
+		var validateErr validator.ValidationErrors
+		errors.As(err, &validateErr)
+		return nil, rpcerr.Invalid(ctx, validateErr)
+	}
+
+	patch := taskDomain.Patch{
+		Title:       req.Title,
+		Description: in.Description,
+		Status:      req.Status,
+		StoryPoints: req.StoryPoints,
+		MilestoneID: optionalID(in.MilestoneId),
+		ProjectID:   optionalID(in.ProjectId),
+		Labels:      req.Labels,
+	}
+	if req.Assignees != nil {
+		assignees := make([]uuid.UUID, len(*req.Assignees))
+		for i, a := range *req.Assignees {
+			assignees[i] = uuid.MustParse(a)
+		}
+		patch.Assignees = &assignees
+	}
+	switch {
+	case in.GetClearDueAt():
+		patch.DueAt = &time.Time{}
+	case in.DueAt != nil:
+		dueAt := in.GetDueAt().AsTime()
+		patch.DueAt = &dueAt
+	}
+
+	task, err := h.service.Update(ctx, id, patch)
+	if err != nil {
+		if st := statusFromErr(ctx, err); st != nil {
+			return nil, st
+		}
+		log.Error("update failed", sl.Err(err))
+		return nil, rpcerr.Error(ctx, codes.Internal, "failed to update task")
+	}
+
+	return toProto(task), nil
+}
+
+func (h *Handler) DeleteTask(ctx context.Context, in *pmv1.DeleteTaskRequest) (*emptypb.Empty, error) {
+	const op = "grpc/task.DeleteTask"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(ctx)),
+	)
+
+	id, err := uuid.Parse(in.GetId())
+	if err != nil {
+		return nil, rpcerr.Error(ctx, codes.InvalidArgument, "invalid id")
+	}
+
+	err = h.service.Delete(ctx, id)
+
+	if errors.Is(err, taskDomain.ErrTaskNotFound) {
+		return nil, rpcerr.Error(ctx, codes.NotFound, "task not found")
+	}
+
+	if err != nil {
+		log.Error("delete failed", sl.Err(err))
+		return nil, rpcerr.Error(ctx, codes.Internal, "failed to delete")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) ListTasks(ctx context.Context, in *pmv1.ListTasksRequest) (*pmv1.ListTasksResponse, error) {
+	const op = "grpc/task.ListTasks"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(ctx)),
+	)
+
+	if in.GetLimit() < 0 {
+		return nil, rpcerr.Error(ctx, codes.InvalidArgument, "invalid limit")
+	}
+	if in.GetOffset() < 0 {
+		return nil, rpcerr.Error(ctx, codes.InvalidArgument, "invalid offset")
+	}
+
+	tasks, err := h.service.Find(ctx, in.GetQuery(), int(in.GetLimit()), int(in.GetOffset()))
+
+	var queryErr *query.Error
+	if errors.As(err, &queryErr) {
+		return nil, rpcerr.Error(ctx, codes.InvalidArgument, "invalid query: "+queryErr.Error())
+	}
+
+	if err != nil {
+		log.Error("list failed", sl.Err(err))
+		return nil, rpcerr.Error(ctx, codes.Internal, "failed to list tasks")
+	}
+
+	res := &pmv1.ListTasksResponse{Tasks: make([]*pmv1.Task, len(tasks))}
+	for i, t := range tasks {
+		res.Tasks[i] = toProto(t)
+	}
+	return res, nil
+}
+
+// statusFromErr переводит доменные ошибки создания и изменения в коды gRPC,
+// для прочих ошибок возвращает nil.
+func statusFromErr(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, taskDomain.ErrTaskNotFound):
+		return rpcerr.Error(ctx, codes.NotFound, "task not found")
+	case errors.Is(err, milestoneDomain.ErrMilestoneNotFound):
+		return rpcerr.Error(ctx, codes.NotFound, "milestone not found")
+	case errors.Is(err, projectDomain.ErrProjectNotFound):
+		return rpcerr.Error(ctx, codes.NotFound, "project not found")
+	case errors.Is(err, taskDomain.ErrAssigneeNotFound):
+		return rpcerr.Error(ctx, codes.NotFound, "assignee not found")
+	case errors.Is(err, taskDomain.ErrNoAssignees), errors.Is(err, taskDomain.ErrInvalidTitle),
+		errors.Is(err, taskDomain.ErrInvalidStatus), errors.Is(err, taskDomain.ErrInvalidStoryPoints),
+		errors.Is(err, taskDomain.ErrInvalidLabel), errors.Is(err, taskDomain.ErrTooManyLabels):
+		return rpcerr.Error(ctx, codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// statusFromProto: неизвестное значение уходит как есть и не проходит oneof.
+func statusFromProto(s pmv1.TaskStatus) string {
+	if s == pmv1.TaskStatus_TASK_STATUS_UNSPECIFIED {
+		return ""
+	}
+	if v, ok := statuses[s]; ok {
+		return v
+	}
+	return s.String()
+}
+
+func statusToProto(s string) pmv1.TaskStatus {
+	for k, v := range statuses {
+		if v == s {
+			return k
+		}
+	}
+	return pmv1.TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func values(l *pmv1.StringList) *[]string {
+	if l == nil {
+		return nil
+	}
+	v := l.GetValues()
+	if v == nil {
+		v = []string{}
+	}
+	return &v
+}
+
+// optionalID: пустая строка - uuid.Nil, то есть отвязка. Значение уже проверено.
+func optionalID(v *string) *uuid.UUID {
+	if v == nil {
+		return nil
+	}
+	if *v == "" {
+		return &uuid.Nil
+	}
+	id := uuid.MustParse(*v)
+	return &id
+}
+
+func optionalString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func optionalTime(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func toProto(task *taskDomain.Task) *pmv1.Task {
+	assignees := make([]string, len(task.Assignees))
+	for i, a := range task.Assignees {
+		assignees[i] = a.String()
+	}
+
+	return &pmv1.Task{
+		Id:           task.ID.String(),
+		Title:        task.Title,
+		Description:  task.Description,
+		Status:       statusToProto(task.Status),
+		StoryPoints:  int32(task.StoryPoints),
+		MilestoneId:  optionalString(task.MilestoneID),
+		ProjectId:    optionalString(task.ProjectID),
+		DueAt:        optionalTime(task.DueAt),
+		SeriesId:     optionalString(task.SeriesID),
+		OccurrenceAt: optionalTime(task.OccurrenceAt),
+		Assignees:    assignees,
+		Labels:       task.Labels,
+		CreatedAt:    timestamppb.New(task.CreatedAt),
+		UpdatedAt:    timestamppb.New(task.UpdatedAt),
+	}
+}
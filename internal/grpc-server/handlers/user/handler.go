@@ -0,0 +1,130 @@
+package user
+
+import (
+	userDomain "ProjectManagementAPI/internal/domain/user"
+	pmv1 "ProjectManagementAPI/internal/grpc-server/gen/pm/v1"
+	"ProjectManagementAPI/internal/grpc-server/rpcerr"
+	"ProjectManagementAPI/internal/lib/api/validate"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type Service interface {
+	Create(ctx context.Context, email, name string) (uuid.UUID, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetByID(ctx context.Context, id uuid.UUID) (*userDomain.User, error)
+}
+
+// Handler - UserService поверх того же сервиса пользователей, что и HTTP.
+type Handler struct {
+	pmv1.UnimplementedUserServiceServer
+
+	log     *slog.Logger
+	service Service
+}
+
+func NewHandler(log *slog.Logger, service Service) *Handler {
+	return &Handler{
+		log:     log,
+		service: service,
+	}
+}
+
+type createRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Name  string `json:"name" validate:"required"`
+}
+
+func (h *Handler) CreateUser(ctx context.Context, in *pmv1.CreateUserRequest) (*pmv1.User, error) {
+	const op = "grpc/user.CreateUser"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(ctx)),
+	)
+
+	req := createRequest{Email: in.GetEmail(), Name: in.GetName()}
+
+	if err := validate.Struct(req); err != nil {
+		var validateErr validator.ValidationErrors
+		errors.As(err, &validateErr)
+		return nil, rpcerr.Invalid(ctx, validateErr)
+	}
+
+	id, err := h.service.Create(ctx, req.Email, req.Name)
+
+	if errors.Is(err, userDomain.ErrEmailAlreadyExists) {
+		log.Info("email already exists", slog.String("email", req.Email))
+		return nil, rpcerr.Error(ctx, codes.AlreadyExists, "email already exists")
+	}
+
+	if err != nil {
+		log.Error("create failed", sl.Err(err))
+		return nil, rpcerr.Error(ctx, codes.Internal, "failed to create user")
+	}
+
+	return &pmv1.User{Id: id.String(), Email: req.Email, Name: req.Name}, nil
+}
+
+func (h *Handler) GetUser(ctx context.Context, in *pmv1.GetUserRequest) (*pmv1.User, error) {
+	const op = "grpc/user.GetUser"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(ctx)),
+	)
+
+	id, err := uuid.Parse(in.GetId())
+	if err != nil {
+		return nil, rpcerr.Error(ctx, codes.InvalidArgument, "invalid id")
+	}
+
+	user, err := h.service.GetByID(ctx, id)
+
+	if errors.Is(err, userDomain.ErrUserNotFound) {
+		log.Info("user not found", slog.String("user_id", id.String()))
+		return nil, rpcerr.Error(ctx, codes.NotFound, "user not found")
+	}
+
+	if err != nil {
+		log.Error("get failed", sl.Err(err))
+		return nil, rpcerr.Error(ctx, codes.Internal, "failed to get")
+	}
+
+	return &pmv1.User{Id: user.ID.String(), Email: user.Email, Name: user.Name}, nil
+}
+
+func (h *Handler) DeleteUser(ctx context.Context, in *pmv1.DeleteUserRequest) (*emptypb.Empty, error) {
+	const op = "grpc/user.DeleteUser"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(ctx)),
+	)
+
+	id, err := uuid.Parse(in.GetId())
+	if err != nil {
+		return nil, rpcerr.Error(ctx, codes.InvalidArgument, "invalid id")
+	}
+
+	err = h.service.Delete(ctx, id)
+
+	if errors.Is(err, userDomain.ErrUserNotFound) {
+		return nil, rpcerr.Error(ctx, codes.NotFound, "user not found")
+	}
+
+	if err != nil {
+		log.Error("delete failed", sl.Err(err))
+		return nil, rpcerr.Error(ctx, codes.Internal, "failed to delete")
+	}
+
+	return &emptypb.Empty{}, nil
+}
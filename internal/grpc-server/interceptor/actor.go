@@ -0,0 +1,32 @@
+package interceptor
+
+import (
+	"ProjectManagementAPI/internal/lib/actor"
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ActorKey - метаданные с пользователем, аналог заголовка X-User-ID.
+const ActorKey = "x-user-id"
+
+// Actor кладёт в контекст пользователя из метаданных x-user-id.
+// Вызовы без него проходят анонимно.
+func Actor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		v := first(ctx, ActorKey)
+		if v == "" {
+			return handler(ctx, req)
+		}
+
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid "+ActorKey+" metadata")
+		}
+
+		return handler(actor.WithID(ctx, id), req)
+	}
+}
@@ -0,0 +1,44 @@
+package interceptor
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Logger пишет "request completed" с методом, кодом и длительностью, как mwLogger.
+func Logger(log *slog.Logger) grpc.UnaryServerInterceptor {
+	log = log.With(slog.String("component", "interceptor/logger"))
+
+	log.Info("logger interceptor enable")
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var addr string
+		if p, ok := peer.FromContext(ctx); ok {
+			addr = p.Addr.String()
+		}
+
+		entry := log.With(
+			slog.String("method", info.FullMethod),
+			slog.String("remote_addr", addr),
+			slog.String("user-agent", first(ctx, "user-agent")),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+		)
+
+		t1 := time.Now()
+
+		res, err := handler(ctx, req)
+
+		entry.Info("request completed",
+			slog.String("code", status.Code(err).String()),
+			slog.String("duration", time.Since(t1).String()),
+		)
+
+		return res, err
+	}
+}
@@ -0,0 +1,31 @@
+package interceptor
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Recoverer превращает панику в обработчике в codes.Internal, как middleware.Recoverer.
+func Recoverer(log *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res any, err error) {
+		defer func() {
+			if rvr := recover(); rvr != nil {
+				log.Error("panic recovered",
+					slog.String("method", info.FullMethod),
+					slog.String("request_id", middleware.GetReqID(ctx)),
+					slog.Any("panic", rvr),
+					slog.String("stack", string(debug.Stack())),
+				)
+				err = status.Error(codes.Internal, "internal error")
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
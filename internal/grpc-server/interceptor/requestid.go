@@ -0,0 +1,40 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDKey - метаданные с идентификатором запроса, аналог X-Request-Id.
+const RequestIDKey = "x-request-id"
+
+// RequestID берёт идентификатор из метаданных или создаёт новый и кладёт его
+// под ключом chi, чтобы middleware.GetReqID работал так же, как в HTTP.
+func RequestID() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		id := first(ctx, RequestIDKey)
+		if id == "" {
+			id = uuid.NewString()
+		}
+
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, id))
+
+		return handler(context.WithValue(ctx, middleware.RequestIDKey, id), req)
+	}
+}
+
+// first - первое значение ключа во входящих метаданных.
+func first(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
@@ -0,0 +1,66 @@
+// Package rpcerr собирает gRPC-статусы с сообщениями на языке из метаданных
+// accept-language, как resp.JSON для HTTP.
+package rpcerr
+
+import (
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/i18n"
+	"context"
+	"strconv"
+	"strings"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Error - статус с переведённым сообщением msg.
+func Error(ctx context.Context, c codes.Code, msg string) error {
+	return status.Error(c, i18n.Message(translator(ctx), msg))
+}
+
+// Invalid - InvalidArgument с ошибками полей в errdetails.BadRequest.
+// Поля записываются путём protobuf: assignees[0].
+func Invalid(ctx context.Context, errs validator.ValidationErrors) error {
+	r := resp.ValidationError(errs).Localize(translator(ctx))
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, len(r.Fields))
+	for i, f := range r.Fields {
+		violations[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       fieldPath(f.Field),
+			Description: f.Message,
+			Reason:      f.Rule,
+		}
+	}
+
+	st := status.New(codes.InvalidArgument, r.Error)
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+func translator(ctx context.Context) ut.Translator {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return i18n.FromHeader(strings.Join(md.Get("accept-language"), ","))
+}
+
+// fieldPath переводит JSON pointer в путь protobuf: /assignees/0 -> assignees[0].
+func fieldPath(pointer string) string {
+	var b strings.Builder
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		if _, err := strconv.Atoi(token); err == nil {
+			b.WriteString("[" + token + "]")
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(token)
+	}
+	return b.String()
+}
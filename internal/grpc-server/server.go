@@ -0,0 +1,71 @@
+// Package grpcserver - gRPC API пользователей и задач рядом с REST. Обработчики
+// вызывают те же сервисы, что и HTTP, поэтому правила и ошибки общие.
+package grpcserver
+
+//go:generate protoc -I ../../api/proto --go_out=../.. --go_opt=module=ProjectManagementAPI --go-grpc_out=../.. --go-grpc_opt=module=ProjectManagementAPI pm/v1/task.proto pm/v1/user.proto
+
+import (
+	pmv1 "ProjectManagementAPI/internal/grpc-server/gen/pm/v1"
+	taskGrpc "ProjectManagementAPI/internal/grpc-server/handlers/task"
+	userGrpc "ProjectManagementAPI/internal/grpc-server/handlers/user"
+	"ProjectManagementAPI/internal/grpc-server/interceptor"
+	"context"
+	"log/slog"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+type Server struct {
+	srv    *grpc.Server
+	health *health.Server
+}
+
+// New регистрирует TaskService, UserService, grpc.health.v1 и reflection.
+// Порядок перехватчиков повторяет middleware роутера.
+func New(log *slog.Logger, tasks taskGrpc.Service, users userGrpc.Service) *Server {
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		interceptor.RequestID(),
+		interceptor.Logger(log),
+		interceptor.Recoverer(log),
+		interceptor.Actor(),
+	))
+
+	pmv1.RegisterTaskServiceServer(srv, taskGrpc.NewHandler(log, tasks))
+	pmv1.RegisterUserServiceServer(srv, userGrpc.NewHandler(log, users))
+
+	healthSrv := health.NewServer()
+	for _, name := range []string{pmv1.TaskService_ServiceDesc.ServiceName, pmv1.UserService_ServiceDesc.ServiceName} {
+		healthSrv.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(srv, healthSrv)
+
+	reflection.Register(srv)
+
+	return &Server{srv: srv, health: healthSrv}
+}
+
+func (s *Server) Serve(lis net.Listener) error {
+	return s.srv.Serve(lis)
+}
+
+// Shutdown переводит health в NOT_SERVING и ждёт текущие вызовы,
+// по истечении ctx обрывает их.
+func (s *Server) Shutdown(ctx context.Context) {
+	s.health.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		s.srv.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.srv.Stop()
+	}
+}
@@ -0,0 +1,183 @@
+package audit
+
+import (
+	auditDomain "ProjectManagementAPI/internal/domain/audit"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	History(ctx context.Context, entityType string, entityID uuid.UUID, limit, offset int) ([]auditDomain.Event, error)
+	List(ctx context.Context, f auditDomain.Filter) ([]auditDomain.Event, error)
+}
+
+type Handler struct {
+	log     *slog.Logger
+	service Service
+}
+
+func NewHandler(log *slog.Logger, service Service) *Handler {
+	return &Handler{
+		log:     log,
+		service: service,
+	}
+}
+
+type Event struct {
+	ID         int64           `json:"id"`
+	ActorID    string          `json:"actor_id,omitempty"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Action     string          `json:"action"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	Diff       json.RawMessage `json:"diff,omitempty"`
+	RequestID  string          `json:"request_id,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+type ListResponse struct {
+	resp.Response
+	Events []Event `json:"events"`
+}
+
+func (h *Handler) TaskHistory(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/audit.TaskHistory"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	idStr := chi.URLParam(r, "id")
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	limit, offset, ok := parsePage(r)
+	if !ok {
+		resp.JSON(w, r, resp.Error("invalid pagination"))
+		return
+	}
+
+	events, err := h.service.History(r.Context(), auditDomain.EntityTask, id, limit, offset)
+	if err != nil {
+		log.Error("history failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to get history"))
+		return
+	}
+
+	render.JSON(w, r, ListResponse{
+		Response: resp.OK(),
+		Events:   toEvents(events),
+	})
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/audit.List"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	q := r.URL.Query()
+
+	f := auditDomain.Filter{
+		EntityType: q.Get("entity_type"),
+		Action:     q.Get("action"),
+	}
+
+	var ok bool
+	if f.Limit, f.Offset, ok = parsePage(r); !ok {
+		resp.JSON(w, r, resp.Error("invalid pagination"))
+		return
+	}
+
+	for name, dst := range map[string]**uuid.UUID{"entity_id": &f.EntityID, "actor_id": &f.ActorID} {
+		if v := q.Get(name); v != "" {
+			id, err := uuid.Parse(v)
+			if err != nil {
+				resp.JSON(w, r, resp.Error("invalid "+name))
+				return
+			}
+			*dst = &id
+		}
+	}
+
+	for name, dst := range map[string]**time.Time{"from": &f.From, "to": &f.To} {
+		if v := q.Get(name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				resp.JSON(w, r, resp.Error("invalid "+name+", expected RFC 3339"))
+				return
+			}
+			*dst = &t
+		}
+	}
+
+	events, err := h.service.List(r.Context(), f)
+	if err != nil {
+		log.Error("list failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to list audit events"))
+		return
+	}
+
+	render.JSON(w, r, ListResponse{
+		Response: resp.OK(),
+		Events:   toEvents(events),
+	})
+}
+
+func parsePage(r *http.Request) (limit, offset int, ok bool) {
+	q := r.URL.Query()
+
+	var err error
+	if v := q.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 0 {
+			return 0, 0, false
+		}
+	}
+	if v := q.Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			return 0, 0, false
+		}
+	}
+
+	return limit, offset, true
+}
+
+func toEvents(events []auditDomain.Event) []Event {
+	res := make([]Event, len(events))
+	for i, e := range events {
+		res[i] = Event{
+			ID:         e.ID,
+			EntityType: e.EntityType,
+			EntityID:   e.EntityID.String(),
+			Action:     e.Action,
+			Before:     e.Before,
+			After:      e.After,
+			Diff:       e.Diff,
+			RequestID:  e.RequestID,
+			CreatedAt:  e.CreatedAt,
+		}
+		if e.ActorID != nil {
+			res[i].ActorID = e.ActorID.String()
+		}
+	}
+	return res
+}
@@ -0,0 +1,241 @@
+package calendar
+
+import (
+	calendarDomain "ProjectManagementAPI/internal/domain/calendar"
+	userDomain "ProjectManagementAPI/internal/domain/user"
+	"ProjectManagementAPI/internal/lib/actor"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	Rotate(ctx context.Context, userID uuid.UUID) (string, *calendarDomain.Token, error)
+	Token(ctx context.Context, userID uuid.UUID) (*calendarDomain.Token, error)
+	Revoke(ctx context.Context, userID uuid.UUID) error
+	Feed(ctx context.Context, token, kind, timezone string, f calendarDomain.Filter) (*calendarDomain.Feed, error)
+}
+
+type Handler struct {
+	log     *slog.Logger
+	service Service
+}
+
+func NewHandler(log *slog.Logger, service Service) *Handler {
+	return &Handler{
+		log:     log,
+		service: service,
+	}
+}
+
+type TokenResponse struct {
+	resp.Response
+	// Token и URL возвращаются только при выпуске
+	Token     string    `json:"token,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Feed: GET /calendar/{token}.ics?type=event|todo&project=&status=&tz=.
+// project и status - списки через запятую. Календари не показывают тело
+// ошибки, поэтому ошибки отдаются с HTTP-статусом.
+func (h *Handler) Feed(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/calendar.Feed"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	if format, _ := r.Context().Value(middleware.URLFormatCtxKey).(string); format != "" && format != "ics" {
+		render.Status(r, http.StatusNotFound)
+		resp.JSON(w, r, resp.Error("calendar not found"))
+		return
+	}
+
+	q := r.URL.Query()
+
+	var f calendarDomain.Filter
+	for _, v := range splitList(q["project"]) {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			resp.JSON(w, r, resp.Error("invalid project"))
+			return
+		}
+		f.ProjectIDs = append(f.ProjectIDs, id)
+	}
+	f.Statuses = splitList(q["status"])
+
+	feed, err := h.service.Feed(r.Context(), chi.URLParam(r, "token"), q.Get("type"), q.Get("tz"), f)
+
+	if errors.Is(err, calendarDomain.ErrTokenNotFound) {
+		render.Status(r, http.StatusNotFound)
+		resp.JSON(w, r, resp.Error("calendar not found"))
+		return
+	}
+
+	if errors.Is(err, calendarDomain.ErrInvalidKind) || errors.Is(err, calendarDomain.ErrInvalidTimezone) ||
+		errors.Is(err, calendarDomain.ErrInvalidStatus) {
+		render.Status(r, http.StatusBadRequest)
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+
+	if err != nil {
+		log.Error("failed to build feed", sl.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		resp.JSON(w, r, resp.Error("failed to build calendar"))
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := writeFeed(&buf, feed); err != nil {
+		log.Error("failed to encode feed", sl.Err(err))
+		render.Status(r, http.StatusInternalServerError)
+		resp.JSON(w, r, resp.Error("failed to build calendar"))
+		return
+	}
+
+	// Календари опрашивают фид постоянно, ETag избавляет от повторной загрузки
+	sum := sha256.Sum256(buf.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="tasks.ics"`)
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	w.Header().Set("ETag", etag)
+
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	if _, err := buf.WriteTo(w); err != nil {
+		log.Debug("failed to write feed", sl.Err(err))
+	}
+}
+
+func (h *Handler) Token(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/calendar.Token"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, _ := actor.FromContext(r.Context())
+
+	t, err := h.service.Token(r.Context(), userID)
+
+	if errors.Is(err, calendarDomain.ErrTokenNotFound) {
+		resp.JSON(w, r, resp.Error("calendar token not found"))
+		return
+	}
+
+	if err != nil {
+		log.Error("failed to get token", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to get calendar token"))
+		return
+	}
+
+	render.JSON(w, r, TokenResponse{
+		Response:  resp.OK(),
+		CreatedAt: t.CreatedAt,
+	})
+}
+
+// Rotate выпускает токен; прежняя ссылка сразу перестаёт работать.
+func (h *Handler) Rotate(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/calendar.Rotate"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, _ := actor.FromContext(r.Context())
+
+	token, t, err := h.service.Rotate(r.Context(), userID)
+
+	if errors.Is(err, userDomain.ErrUserNotFound) {
+		resp.JSON(w, r, resp.Error("user not found"))
+		return
+	}
+
+	if err != nil {
+		log.Error("failed to rotate token", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to rotate calendar token"))
+		return
+	}
+
+	log.Info("calendar token rotated", slog.String("user_id", userID.String()))
+
+	render.JSON(w, r, TokenResponse{
+		Response:  resp.OK(),
+		Token:     token,
+		URL:       feedURL(r, token),
+		CreatedAt: t.CreatedAt,
+	})
+}
+
+func (h *Handler) Revoke(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/calendar.Revoke"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, _ := actor.FromContext(r.Context())
+
+	err := h.service.Revoke(r.Context(), userID)
+
+	if errors.Is(err, calendarDomain.ErrTokenNotFound) {
+		resp.JSON(w, r, resp.Error("calendar token not found"))
+		return
+	}
+
+	if err != nil {
+		log.Error("failed to revoke token", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to revoke calendar token"))
+		return
+	}
+
+	render.JSON(w, r, resp.OK())
+}
+
+// feedURL строит ссылку от адреса запроса, учитывая прокси с TLS.
+func feedURL(r *http.Request, token string) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + "/calendar/" + token + ".ics"
+}
+
+// splitList принимает и повторяющийся параметр, и список через запятую.
+func splitList(values []string) []string {
+	var res []string
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				res = append(res, part)
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,110 @@
+package calendar
+
+import (
+	calendarDomain "ProjectManagementAPI/internal/domain/calendar"
+	taskDomain "ProjectManagementAPI/internal/domain/task"
+	"ProjectManagementAPI/internal/lib/ical"
+	"io"
+	"strings"
+	"time"
+)
+
+var todoStatuses = map[string]string{
+	taskDomain.StatusTodo:       "NEEDS-ACTION",
+	taskDomain.StatusInProgress: "IN-PROCESS",
+	taskDomain.StatusDone:       "COMPLETED",
+}
+
+func writeFeed(w io.Writer, feed *calendarDomain.Feed) error {
+	enc := ical.NewEncoder(w)
+
+	enc.Begin("VCALENDAR")
+	enc.Prop("VERSION", "2.0")
+	enc.Prop("PRODID", "-//ProjectManagementAPI//Tasks//EN")
+	enc.Prop("CALSCALE", "GREGORIAN")
+	enc.Prop("METHOD", "PUBLISH")
+	enc.Text("X-WR-CALNAME", "Tasks: "+feed.UserName)
+	// time.Local не имеет имени IANA, такой пояс не указывается
+	if name := feed.Location.String(); name != "Local" {
+		enc.Text("X-WR-TIMEZONE", name)
+	}
+	enc.Prop("REFRESH-INTERVAL;VALUE=DURATION", "PT1H")
+	enc.Prop("X-PUBLISHED-TTL", "PT1H")
+
+	for _, it := range feed.Items {
+		if feed.Kind == calendarDomain.KindTodo {
+			writeTodo(enc, feed.Location, it)
+		} else {
+			writeEvent(enc, feed.Location, it)
+		}
+	}
+
+	enc.End("VCALENDAR")
+	return enc.Flush()
+}
+
+// writeEvent пишет срок событием: без длительности для срока со временем,
+// на весь день для срока-даты. TRANSPARENT - срок не занимает время.
+func writeEvent(enc *ical.Encoder, loc *time.Location, it calendarDomain.Item) {
+	enc.Begin("VEVENT")
+	writeCommon(enc, it)
+	if day, ok := allDay(it.DueAt, loc); ok {
+		enc.Prop("DTSTART;VALUE=DATE", ical.Date(day))
+		enc.Prop("DTEND;VALUE=DATE", ical.Date(day.AddDate(0, 0, 1)))
+	} else {
+		enc.Prop("DTSTART", ical.DateTime(it.DueAt))
+	}
+	enc.Prop("TRANSP", "TRANSPARENT")
+	enc.End("VEVENT")
+}
+
+func writeTodo(enc *ical.Encoder, loc *time.Location, it calendarDomain.Item) {
+	enc.Begin("VTODO")
+	writeCommon(enc, it)
+	if day, ok := allDay(it.DueAt, loc); ok {
+		enc.Prop("DUE;VALUE=DATE", ical.Date(day))
+	} else {
+		enc.Prop("DUE", ical.DateTime(it.DueAt))
+	}
+	enc.Prop("STATUS", todoStatuses[it.Status])
+	if it.Status == taskDomain.StatusDone {
+		enc.Prop("PERCENT-COMPLETE", "100")
+	}
+	enc.End("VTODO")
+}
+
+func writeCommon(enc *ical.Encoder, it calendarDomain.Item) {
+	enc.Prop("UID", it.TaskID.String())
+	enc.Prop("DTSTAMP", ical.DateTime(it.UpdatedAt))
+	enc.Prop("CREATED", ical.DateTime(it.CreatedAt))
+	enc.Prop("LAST-MODIFIED", ical.DateTime(it.UpdatedAt))
+	enc.Text("SUMMARY", it.Title)
+	enc.Text("DESCRIPTION", description(it))
+	if len(it.Labels) > 0 {
+		categories := make([]string, len(it.Labels))
+		for i, l := range it.Labels {
+			categories[i] = ical.Escape(l)
+		}
+		enc.Prop("CATEGORIES", strings.Join(categories, ","))
+	}
+}
+
+func description(it calendarDomain.Item) string {
+	lines := []string{"Status: " + it.Status}
+	if it.Project != "" {
+		lines = append(lines, "Project: "+it.Project)
+	}
+	if desc := strings.TrimSpace(it.Description); desc != "" {
+		lines = append(lines, "", desc)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// allDay: срок ровно в полночь часового пояса фида - это дата без времени.
+func allDay(due time.Time, loc *time.Location) (time.Time, bool) {
+	local := due.In(loc)
+	if local.Hour() != 0 || local.Minute() != 0 || local.Second() != 0 || local.Nanosecond() != 0 {
+		return time.Time{}, false
+	}
+	return local, true
+}
@@ -0,0 +1,113 @@
+package calendar
+
+import (
+	calendarDomain "ProjectManagementAPI/internal/domain/calendar"
+	taskDomain "ProjectManagementAPI/internal/domain/task"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestAllDay(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		due  time.Time
+		want bool
+	}{
+		{time.Date(2026, 3, 2, 0, 0, 0, 0, berlin), true},
+		// полночь в Берлине - 23:00 UTC предыдущего дня
+		{time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC), true},
+		{time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), false},
+		{time.Date(2026, 3, 2, 0, 0, 1, 0, berlin), false},
+		{time.Date(2026, 3, 2, 0, 0, 0, 1, berlin), false},
+	} {
+		day, ok := allDay(tc.due, berlin)
+		if ok != tc.want {
+			t.Errorf("allDay(%v) = %v, want %v", tc.due, ok, tc.want)
+		}
+		if ok && (day.Day() != 2 || day.Location() != berlin) {
+			t.Errorf("allDay(%v) day = %v, want 2 March in Berlin", tc.due, day)
+		}
+	}
+}
+
+// feed пишет фид и склеивает перенесённые строки.
+func feed(t *testing.T, kind string, items ...calendarDomain.Item) string {
+	t.Helper()
+
+	var sb strings.Builder
+	if err := writeFeed(&sb, &calendarDomain.Feed{UserName: "Ann, Lee", Kind: kind, Location: time.UTC, Items: items}); err != nil {
+		t.Fatal(err)
+	}
+	return strings.ReplaceAll(sb.String(), "\r\n ", "")
+}
+
+func TestWriteFeed(t *testing.T) {
+	created := time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC)
+	item := calendarDomain.Item{
+		TaskID:      uuid.MustParse("11111111-2222-3333-4444-555555555555"),
+		Title:       "Release; v1, final",
+		Description: "Check\nnotes",
+		Status:      taskDomain.StatusDone,
+		DueAt:       time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		Project:     "Core",
+		Labels:      []string{"release", "a,b"},
+		CreatedAt:   created,
+		UpdatedAt:   created.Add(time.Hour),
+	}
+	timed := item
+	timed.DueAt = time.Date(2026, 3, 2, 15, 30, 0, 0, time.UTC)
+
+	event := feed(t, calendarDomain.KindEvent, item, timed)
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"X-WR-CALNAME:Tasks: Ann\\, Lee\r\n",
+		"X-WR-TIMEZONE:UTC\r\n",
+		"UID:11111111-2222-3333-4444-555555555555\r\n",
+		"DTSTAMP:20260201T110000Z\r\n",
+		"SUMMARY:Release\\; v1\\, final\r\n",
+		"DESCRIPTION:Status: done\\nProject: Core\\n\\nCheck\\nnotes\r\n",
+		"CATEGORIES:release,a\\,b\r\n",
+		"DTSTART;VALUE=DATE:20260302\r\nDTEND;VALUE=DATE:20260303\r\n",
+		"DTSTART:20260302T153000Z\r\nTRANSP:TRANSPARENT\r\n",
+	} {
+		if !strings.Contains(event, want) {
+			t.Errorf("event feed has no %q:\n%s", want, event)
+		}
+	}
+	if strings.Count(event, "BEGIN:VEVENT") != 2 || !strings.HasSuffix(event, "END:VCALENDAR\r\n") {
+		t.Errorf("event feed:\n%s", event)
+	}
+
+	todo := feed(t, calendarDomain.KindTodo, item, timed)
+	for _, want := range []string{
+		"BEGIN:VTODO\r\n",
+		"DUE;VALUE=DATE:20260302\r\n",
+		"DUE:20260302T153000Z\r\n",
+		"STATUS:COMPLETED\r\nPERCENT-COMPLETE:100\r\n",
+	} {
+		if !strings.Contains(todo, want) {
+			t.Errorf("todo feed has no %q:\n%s", want, todo)
+		}
+	}
+	if strings.Contains(todo, "VEVENT") {
+		t.Errorf("todo feed has events:\n%s", todo)
+	}
+}
+
+// Для пояса сервера без имени IANA X-WR-TIMEZONE не пишется.
+func TestWriteFeedLocalZone(t *testing.T) {
+	var sb strings.Builder
+	if err := writeFeed(&sb, &calendarDomain.Feed{Kind: calendarDomain.KindEvent, Location: time.Local}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(sb.String(), "X-WR-TIMEZONE") {
+		t.Errorf("feed names the Local zone:\n%s", sb.String())
+	}
+}
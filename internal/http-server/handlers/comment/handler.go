@@ -0,0 +1,259 @@
+package comment
+
+import (
+	commentDomain "ProjectManagementAPI/internal/domain/comment"
+	taskDomain "ProjectManagementAPI/internal/domain/task"
+	"ProjectManagementAPI/internal/lib/actor"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/api/validate"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	Create(ctx context.Context, authorID, taskID uuid.UUID, body string) (*commentDomain.Comment, error)
+	List(ctx context.Context, taskID uuid.UUID, limit, offset int) ([]commentDomain.Comment, error)
+	Update(ctx context.Context, authorID, taskID, id uuid.UUID, body string) (*commentDomain.Comment, error)
+	Delete(ctx context.Context, authorID, taskID, id uuid.UUID) error
+}
+
+type Handler struct {
+	log     *slog.Logger
+	service Service
+}
+
+func NewHandler(log *slog.Logger, service Service) *Handler {
+	return &Handler{
+		log:     log,
+		service: service,
+	}
+}
+
+type Comment struct {
+	ID         string    `json:"id"`
+	TaskID     string    `json:"task_id"`
+	AuthorID   string    `json:"author_id,omitempty"`
+	AuthorName string    `json:"author_name,omitempty"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type CommentResponse struct {
+	resp.Response
+	Comment Comment `json:"comment"`
+}
+
+type ListResponse struct {
+	resp.Response
+	Comments []Comment `json:"comments"`
+}
+
+type BodyRequest struct {
+	Body string `json:"body" validate:"required"`
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/comment.Create"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	authorID, _ := actor.FromContext(r.Context())
+
+	taskID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	req, ok := decodeBody(w, r, log)
+	if !ok {
+		return
+	}
+
+	c, err := h.service.Create(r.Context(), authorID, taskID, req.Body)
+	h.respond(w, r, log, c, err, "failed to create comment")
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/comment.List"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	taskID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	q := r.URL.Query()
+	var limit, offset int
+	if v := q.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 0 {
+			resp.JSON(w, r, resp.Error("invalid limit"))
+			return
+		}
+	}
+	if v := q.Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			resp.JSON(w, r, resp.Error("invalid offset"))
+			return
+		}
+	}
+
+	list, err := h.service.List(r.Context(), taskID, limit, offset)
+	if err != nil {
+		log.Error("list failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to list comments"))
+		return
+	}
+
+	res := make([]Comment, len(list))
+	for i := range list {
+		res[i] = toComment(&list[i])
+	}
+
+	render.JSON(w, r, ListResponse{
+		Response: resp.OK(),
+		Comments: res,
+	})
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/comment.Update"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	authorID, _ := actor.FromContext(r.Context())
+
+	taskID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "commentID"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid comment id"))
+		return
+	}
+
+	req, ok := decodeBody(w, r, log)
+	if !ok {
+		return
+	}
+
+	c, err := h.service.Update(r.Context(), authorID, taskID, id, req.Body)
+	h.respond(w, r, log, c, err, "failed to update comment")
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/comment.Delete"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	authorID, _ := actor.FromContext(r.Context())
+
+	taskID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "commentID"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid comment id"))
+		return
+	}
+
+	err = h.service.Delete(r.Context(), authorID, taskID, id)
+
+	if errors.Is(err, commentDomain.ErrCommentNotFound) {
+		resp.JSON(w, r, resp.Error("comment not found"))
+		return
+	}
+
+	if err != nil {
+		log.Error("delete failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to delete comment"))
+		return
+	}
+
+	render.JSON(w, r, resp.OK())
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, log *slog.Logger) (BodyRequest, bool) {
+	var req BodyRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("decode error", sl.Err(err))
+		resp.JSON(w, r, resp.Error("invalid request"))
+		return req, false
+	}
+
+	if err := validate.Struct(req); err != nil {
+		var validateErr validator.ValidationErrors
+		errors.As(err, &validateErr)
+		resp.JSON(w, r, resp.ValidationError(validateErr))
+		return req, false
+	}
+
+	return req, true
+}
+
+func (h *Handler) respond(w http.ResponseWriter, r *http.Request, log *slog.Logger, c *commentDomain.Comment,
+	err error, msg string) {
+	switch {
+	case errors.Is(err, taskDomain.ErrTaskNotFound):
+		resp.JSON(w, r, resp.Error("task not found"))
+	case errors.Is(err, commentDomain.ErrCommentNotFound), errors.Is(err, commentDomain.ErrEmptyBody),
+		errors.Is(err, commentDomain.ErrBodyTooLong):
+		resp.JSON(w, r, resp.Error(err.Error()))
+	case err != nil:
+		log.Error(msg, sl.Err(err))
+		resp.JSON(w, r, resp.Error(msg))
+	default:
+		render.JSON(w, r, CommentResponse{
+			Response: resp.OK(),
+			Comment:  toComment(c),
+		})
+	}
+}
+
+func toComment(c *commentDomain.Comment) Comment {
+	res := Comment{
+		ID:         c.ID.String(),
+		TaskID:     c.TaskID.String(),
+		AuthorName: c.AuthorName,
+		Body:       c.Body,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+	if c.AuthorID != nil {
+		res.AuthorID = c.AuthorID.String()
+	}
+	return res
+}
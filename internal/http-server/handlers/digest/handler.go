@@ -0,0 +1,210 @@
+package digest
+
+import (
+	digestDomain "ProjectManagementAPI/internal/domain/digest"
+	"ProjectManagementAPI/internal/lib/actor"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/api/validate"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	Settings(ctx context.Context, userID uuid.UUID) (*digestDomain.Settings, error)
+	SaveSettings(ctx context.Context, s *digestDomain.Settings) error
+	Runs(ctx context.Context, userID uuid.UUID, limit, offset int) ([]digestDomain.Run, error)
+}
+
+type Handler struct {
+	log     *slog.Logger
+	service Service
+}
+
+func NewHandler(log *slog.Logger, service Service) *Handler {
+	return &Handler{
+		log:     log,
+		service: service,
+	}
+}
+
+type SettingsRequest struct {
+	Frequency string `json:"frequency" validate:"required,oneof=off daily weekly"`
+	Timezone  string `json:"timezone" validate:"required"`
+	SendHour  *int   `json:"send_hour" validate:"required,min=0,max=23"`
+	// Weekday - день отправки weekly-дайджеста, 0 - воскресенье
+	Weekday *int `json:"weekday" validate:"omitempty,min=0,max=6"`
+}
+
+type SettingsResponse struct {
+	resp.Response
+	Frequency string `json:"frequency"`
+	Timezone  string `json:"timezone"`
+	SendHour  int    `json:"send_hour"`
+	Weekday   int    `json:"weekday"`
+}
+
+func (h *Handler) Settings(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/digest.Settings"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, _ := actor.FromContext(r.Context())
+
+	s, err := h.service.Settings(r.Context(), userID)
+	if err != nil {
+		log.Error("get settings failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to get digest settings"))
+		return
+	}
+
+	render.JSON(w, r, toSettingsResponse(s))
+}
+
+func (h *Handler) SaveSettings(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/digest.SaveSettings"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, _ := actor.FromContext(r.Context())
+
+	var req SettingsRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("decode error", sl.Err(err))
+		resp.JSON(w, r, resp.Error("invalid request"))
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		var validateErr validator.ValidationErrors
+		errors.As(err, &validateErr)
+		resp.JSON(w, r, resp.ValidationError(validateErr))
+		return
+	}
+
+	s := &digestDomain.Settings{
+		UserID:    userID,
+		Frequency: req.Frequency,
+		Timezone:  req.Timezone,
+		SendHour:  *req.SendHour,
+		Weekday:   time.Monday,
+	}
+	if req.Weekday != nil {
+		s.Weekday = time.Weekday(*req.Weekday)
+	}
+
+	err := h.service.SaveSettings(r.Context(), s)
+
+	if errors.Is(err, digestDomain.ErrInvalidFrequency) || errors.Is(err, digestDomain.ErrInvalidTimezone) ||
+		errors.Is(err, digestDomain.ErrInvalidSendHour) || errors.Is(err, digestDomain.ErrInvalidWeekday) {
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+
+	if err != nil {
+		log.Error("save settings failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to save digest settings"))
+		return
+	}
+
+	render.JSON(w, r, toSettingsResponse(s))
+}
+
+type Run struct {
+	ID        int64      `json:"id"`
+	Frequency string     `json:"frequency"`
+	Period    string     `json:"period"`
+	Status    string     `json:"status"`
+	Items     int        `json:"items"`
+	Attempts  int        `json:"attempts"`
+	Error     string     `json:"error,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	SentAt    *time.Time `json:"sent_at,omitempty"`
+}
+
+type RunsResponse struct {
+	resp.Response
+	Runs []Run `json:"runs"`
+}
+
+func (h *Handler) Runs(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/digest.Runs"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, _ := actor.FromContext(r.Context())
+	q := r.URL.Query()
+
+	var (
+		limit, offset int
+		err           error
+	)
+	if v := q.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 0 {
+			resp.JSON(w, r, resp.Error("invalid limit"))
+			return
+		}
+	}
+	if v := q.Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			resp.JSON(w, r, resp.Error("invalid offset"))
+			return
+		}
+	}
+
+	runs, err := h.service.Runs(r.Context(), userID, limit, offset)
+	if err != nil {
+		log.Error("list runs failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to list digests"))
+		return
+	}
+
+	res := make([]Run, len(runs))
+	for i, run := range runs {
+		res[i] = Run{
+			ID:        run.ID,
+			Frequency: run.Frequency,
+			Period:    run.PeriodKey,
+			Status:    run.Status,
+			Items:     run.Items,
+			Attempts:  run.Attempts,
+			Error:     run.Error,
+			CreatedAt: run.CreatedAt,
+			SentAt:    run.SentAt,
+		}
+	}
+
+	render.JSON(w, r, RunsResponse{
+		Response: resp.OK(),
+		Runs:     res,
+	})
+}
+
+func toSettingsResponse(s *digestDomain.Settings) SettingsResponse {
+	return SettingsResponse{
+		Response:  resp.OK(),
+		Frequency: s.Frequency,
+		Timezone:  s.Timezone,
+		SendHour:  s.SendHour,
+		Weekday:   int(s.Weekday),
+	}
+}
@@ -0,0 +1,54 @@
+package docs
+
+import (
+	"ProjectManagementAPI/internal/http-server/openapi"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	_ "embed"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+//go:embed docs.html
+var page []byte
+
+type Handler struct {
+	log  *slog.Logger
+	spec []byte
+}
+
+// NewHandler сериализует документ один раз: он не меняется после запуска.
+func NewHandler(log *slog.Logger, doc *openapi.Document) (*Handler, error) {
+	spec, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{
+		log:  log,
+		spec: spec,
+	}, nil
+}
+
+// Spec: GET /openapi.json.
+func (h *Handler) Spec(w http.ResponseWriter, r *http.Request) {
+	h.write(w, r, "handlers/docs.Spec", "application/json", h.spec)
+}
+
+// UI: GET /docs - Swagger UI, читающий /openapi.json.
+func (h *Handler) UI(w http.ResponseWriter, r *http.Request) {
+	h.write(w, r, "handlers/docs.UI", "text/html; charset=utf-8", page)
+}
+
+func (h *Handler) write(w http.ResponseWriter, r *http.Request, op, contentType string, body []byte) {
+	w.Header().Set("Content-Type", contentType)
+	if _, err := w.Write(body); err != nil {
+		h.log.Debug("failed to write response",
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.Err(err),
+		)
+	}
+}
@@ -0,0 +1,320 @@
+package filter
+
+import (
+	filterDomain "ProjectManagementAPI/internal/domain/filter"
+	taskDomain "ProjectManagementAPI/internal/domain/task"
+	taskHttp "ProjectManagementAPI/internal/http-server/handlers/task"
+	"ProjectManagementAPI/internal/lib/actor"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/api/validate"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"ProjectManagementAPI/internal/lib/query"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	Save(ctx context.Context, f *filterDomain.Filter) error
+	Get(ctx context.Context, userID, id uuid.UUID) (*filterDomain.Filter, error)
+	List(ctx context.Context, userID uuid.UUID) ([]filterDomain.Filter, error)
+	Delete(ctx context.Context, ownerID, id uuid.UUID) error
+	Run(ctx context.Context, userID, id uuid.UUID, limit, offset int) ([]*taskDomain.Task, error)
+}
+
+type Handler struct {
+	log     *slog.Logger
+	service Service
+	users   taskHttp.Users
+}
+
+func NewHandler(log *slog.Logger, service Service, users taskHttp.Users) *Handler {
+	return &Handler{
+		log:     log,
+		service: service,
+		users:   users,
+	}
+}
+
+type Filter struct {
+	ID        string    `json:"id"`
+	OwnerID   string    `json:"owner_id"`
+	Name      string    `json:"name"`
+	Query     string    `json:"query"`
+	Shared    bool      `json:"shared"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type FilterResponse struct {
+	resp.Response
+	Filter Filter `json:"filter"`
+}
+
+type ListResponse struct {
+	resp.Response
+	Filters []Filter `json:"filters"`
+}
+
+type SaveRequest struct {
+	Name   string `json:"name" validate:"required,max=100"`
+	Query  string `json:"query"`
+	Shared bool   `json:"shared"`
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	h.save(w, r, "handlers/filter.Create", uuid.Nil)
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	h.save(w, r, "handlers/filter.Update", id)
+}
+
+func (h *Handler) save(w http.ResponseWriter, r *http.Request, op string, id uuid.UUID) {
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, _ := actor.FromContext(r.Context())
+
+	var req SaveRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("decode error", sl.Err(err))
+		resp.JSON(w, r, resp.Error("invalid request"))
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		var validateErr validator.ValidationErrors
+		errors.As(err, &validateErr)
+		resp.JSON(w, r, resp.ValidationError(validateErr))
+		return
+	}
+
+	f := &filterDomain.Filter{
+		ID:      id,
+		OwnerID: userID,
+		Name:    req.Name,
+		Query:   req.Query,
+		Shared:  req.Shared,
+	}
+
+	err := h.service.Save(r.Context(), f)
+
+	var queryErr *query.Error
+	if errors.As(err, &queryErr) {
+		resp.JSON(w, r, resp.Error("invalid query: "+queryErr.Error()))
+		return
+	}
+
+	if errors.Is(err, filterDomain.ErrFilterNotFound) || errors.Is(err, filterDomain.ErrInvalidName) ||
+		errors.Is(err, filterDomain.ErrDuplicateName) {
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+
+	if err != nil {
+		log.Error("save failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to save filter"))
+		return
+	}
+
+	render.JSON(w, r, FilterResponse{
+		Response: resp.OK(),
+		Filter:   toFilter(f),
+	})
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/filter.Get"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, _ := actor.FromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	f, err := h.service.Get(r.Context(), userID, id)
+
+	if errors.Is(err, filterDomain.ErrFilterNotFound) {
+		resp.JSON(w, r, resp.Error("filter not found"))
+		return
+	}
+
+	if err != nil {
+		log.Error("get failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to get filter"))
+		return
+	}
+
+	render.JSON(w, r, FilterResponse{
+		Response: resp.OK(),
+		Filter:   toFilter(f),
+	})
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/filter.List"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, _ := actor.FromContext(r.Context())
+
+	list, err := h.service.List(r.Context(), userID)
+	if err != nil {
+		log.Error("list failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to list filters"))
+		return
+	}
+
+	res := make([]Filter, len(list))
+	for i := range list {
+		res[i] = toFilter(&list[i])
+	}
+
+	render.JSON(w, r, ListResponse{
+		Response: resp.OK(),
+		Filters:  res,
+	})
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/filter.Delete"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, _ := actor.FromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	err = h.service.Delete(r.Context(), userID, id)
+
+	if errors.Is(err, filterDomain.ErrFilterNotFound) {
+		resp.JSON(w, r, resp.Error("filter not found"))
+		return
+	}
+
+	if err != nil {
+		log.Error("delete failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to delete filter"))
+		return
+	}
+
+	render.JSON(w, r, resp.OK())
+}
+
+// Tasks выполняет сохранённый фильтр. include и fields - как в GET /tasks.
+func (h *Handler) Tasks(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/filter.Tasks"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, _ := actor.FromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	q := r.URL.Query()
+	var limit, offset int
+	if v := q.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 0 {
+			resp.JSON(w, r, resp.Error("invalid limit"))
+			return
+		}
+	}
+	if v := q.Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			resp.JSON(w, r, resp.Error("invalid offset"))
+			return
+		}
+	}
+
+	view, err := taskHttp.ParseView(r)
+	if err != nil {
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+
+	tasks, err := h.service.Run(r.Context(), userID, id, limit, offset)
+
+	if errors.Is(err, filterDomain.ErrFilterNotFound) {
+		resp.JSON(w, r, resp.Error("filter not found"))
+		return
+	}
+
+	var queryErr *query.Error
+	if errors.As(err, &queryErr) {
+		resp.JSON(w, r, resp.Error("invalid query: "+queryErr.Error()))
+		return
+	}
+
+	if err != nil {
+		log.Error("run failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to run filter"))
+		return
+	}
+
+	items := taskHttp.ToTasks(tasks)
+	if err := view.Embed(r.Context(), h.users, tasks, items); err != nil {
+		log.Error("run assignees failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to run filter"))
+		return
+	}
+
+	view.Render(w, r, taskHttp.ListResponse{
+		Response: resp.OK(),
+		Tasks:    items,
+	})
+}
+
+func toFilter(f *filterDomain.Filter) Filter {
+	return Filter{
+		ID:        f.ID.String(),
+		OwnerID:   f.OwnerID.String(),
+		Name:      f.Name,
+		Query:     f.Query,
+		Shared:    f.Shared,
+		CreatedAt: f.CreatedAt,
+		UpdatedAt: f.UpdatedAt,
+	}
+}
@@ -0,0 +1,107 @@
+package filter
+
+import (
+	taskDomain "ProjectManagementAPI/internal/domain/task"
+	userDomain "ProjectManagementAPI/internal/domain/user"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// fakeService - фильтр из одной задачи с одним исполнителем.
+type fakeService struct {
+	Service
+	task *taskDomain.Task
+}
+
+func (s *fakeService) Run(context.Context, uuid.UUID, uuid.UUID, int, int) ([]*taskDomain.Task, error) {
+	return []*taskDomain.Task{s.task}, nil
+}
+
+type fakeUsers struct {
+	users []*userDomain.User
+	calls int
+}
+
+func (u *fakeUsers) GetByID(context.Context, uuid.UUID) (*userDomain.User, error) {
+	return nil, nil
+}
+
+func (u *fakeUsers) GetByIDs(context.Context, []uuid.UUID) ([]*userDomain.User, error) {
+	u.calls++
+	return u.users, nil
+}
+
+func runFilter(t *testing.T, users *fakeUsers, rawQuery string) map[string]any {
+	t.Helper()
+
+	user := &userDomain.User{ID: uuid.New(), Email: "john@example.com", Name: "John"}
+	users.users = []*userDomain.User{user}
+	service := &fakeService{task: &taskDomain.Task{
+		ID:        uuid.New(),
+		Title:     "Task",
+		Status:    "todo",
+		Assignees: []uuid.UUID{user.ID},
+	}}
+
+	router := chi.NewRouter()
+	router.Get("/filters/{id}/tasks", NewHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), service, users).Tasks)
+
+	req := httptest.NewRequest(http.MethodGet, "/filters/"+uuid.NewString()+"/tasks?"+rawQuery, nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var res map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatalf("decode %s: %v", rec.Body, err)
+	}
+	return res
+}
+
+func TestTasksIncludesAssignees(t *testing.T) {
+	users := &fakeUsers{}
+	res := runFilter(t, users, "include=assignees")
+
+	tasks, _ := res["tasks"].([]any)
+	if len(tasks) != 1 {
+		t.Fatalf("response = %v", res)
+	}
+	embedded, _ := tasks[0].(map[string]any)["assignee_users"].([]any)
+	if len(embedded) != 1 || embedded[0].(map[string]any)["email"] != "john@example.com" {
+		t.Errorf("assignee_users = %v", embedded)
+	}
+	if users.calls != 1 {
+		t.Errorf("GetByIDs calls = %d, want 1", users.calls)
+	}
+}
+
+func TestTasksSparseFields(t *testing.T) {
+	users := &fakeUsers{}
+	res := runFilter(t, users, "fields=title")
+
+	tasks, _ := res["tasks"].([]any)
+	if len(tasks) != 1 {
+		t.Fatalf("response = %v", res)
+	}
+	task := tasks[0].(map[string]any)
+	if len(task) != 2 || task["id"] == nil || task["title"] != "Task" {
+		t.Errorf("task = %v, want only id and title", task)
+	}
+	if users.calls != 0 {
+		t.Errorf("GetByIDs calls = %d, want 0", users.calls)
+	}
+}
+
+func TestTasksRejectsUnknownInclude(t *testing.T) {
+	res := runFilter(t, &fakeUsers{}, "include=comments")
+	if res["status"] != "Error" {
+		t.Errorf("response = %v", res)
+	}
+}
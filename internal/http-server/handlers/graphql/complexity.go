@@ -0,0 +1,536 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Оценка стоимости запроса до выполнения. Каждое поле стоит 1; поле со
+// страницей (tasks) умножает стоимость вложенных полей на first. Остальные
+// списки считаются одним элементом: assignees ограничены размером задачи.
+
+var errSyntax = errors.New("syntax error")
+
+// paginated - поля со страницей и их first по умолчанию.
+var paginated = map[string]int{
+	"tasks": defaultFirst,
+}
+
+type selection struct {
+	name     string
+	first    argValue
+	children []selection
+	// spread - имя фрагмента в ...Name
+	spread string
+}
+
+// argValue - значение first: число или переменная.
+type argValue struct {
+	set      bool
+	n        int
+	variable string
+}
+
+type operation struct {
+	selections []selection
+	// defaults - целые значения переменных по умолчанию
+	defaults map[string]int
+}
+
+type document struct {
+	operations map[string]operation
+	fragments  map[string][]selection
+}
+
+// complexity оценивает операцию operationName; без имени и при нескольких
+// операциях берётся самая дорогая. Оценка прекращается, как только превышен
+// limit: тогда результат - нижняя граница стоимости, больше limit.
+func complexity(query, operationName string, variables map[string]any, limit int) (int, error) {
+	doc, err := parseDocument(query)
+	if err != nil {
+		return 0, err
+	}
+
+	var ops []operation
+	if op, ok := doc.operations[operationName]; ok {
+		ops = append(ops, op)
+	} else {
+		for _, op := range doc.operations {
+			ops = append(ops, op)
+		}
+	}
+
+	res := 0
+	for _, op := range ops {
+		e := estimator{
+			doc:       doc,
+			op:        op,
+			variables: variables,
+			limit:     limit,
+			visiting:  map[string]bool{},
+			fragments: map[string]int{},
+		}
+		if res = max(res, e.cost(op.selections)); res > limit {
+			break
+		}
+	}
+	return res, nil
+}
+
+type estimator struct {
+	doc       *document
+	op        operation
+	variables map[string]any
+	limit     int
+	visiting  map[string]bool
+	// fragments - уже посчитанная стоимость фрагментов: first зависит только
+	// от переменных операции, так что в её пределах стоимость фрагмента одна
+	fragments map[string]int
+}
+
+func (e *estimator) cost(sels []selection) int {
+	total := 0
+	for _, s := range sels {
+		switch {
+		case s.spread != "":
+			total += e.fragment(s.spread)
+		case s.name == "":
+			total += e.cost(s.children)
+		default:
+			children := e.cost(s.children)
+			if def, ok := paginated[s.name]; ok {
+				// children не больше limit+1, first не больше maxFirst - переполнения нет
+				children = min(children, e.limit+1) * e.first(s.first, def)
+			}
+			total += 1 + children
+		}
+		if total > e.limit {
+			return total
+		}
+	}
+	return total
+}
+
+func (e *estimator) fragment(name string) int {
+	if c, ok := e.fragments[name]; ok {
+		return c
+	}
+	// Цикл фрагментов отклонит валидация схемы
+	if e.visiting[name] {
+		return 0
+	}
+
+	e.visiting[name] = true
+	c := e.cost(e.doc.fragments[name])
+	delete(e.visiting, name)
+
+	e.fragments[name] = c
+	return c
+}
+
+// first - значение first, приведённое к [0, maxFirst]: больше maxFirst
+// резолвер всё равно не примет.
+func (e *estimator) first(v argValue, def int) int {
+	return min(max(e.rawFirst(v, def), 0), maxFirst)
+}
+
+func (e *estimator) rawFirst(v argValue, def int) int {
+	switch {
+	case !v.set:
+		return def
+	case v.variable == "":
+		return v.n
+	}
+
+	switch n := e.variables[v.variable].(type) {
+	case float64:
+		return int(min(max(n, 0), maxFirst))
+	case json.Number:
+		if i, err := n.Int64(); err == nil {
+			return int(min(max(i, 0), maxFirst))
+		}
+	}
+	if n, ok := e.op.defaults[v.variable]; ok {
+		return n
+	}
+	return def
+}
+
+// parseDocument разбирает исполняемый документ GraphQL ровно настолько,
+// насколько нужно для оценки: поля, фрагменты и аргумент first.
+func parseDocument(src string) (*document, error) {
+	p := &parser{lex: lexer{src: src}}
+	p.advance()
+
+	doc := &document{operations: map[string]operation{}, fragments: map[string][]selection{}}
+	for p.tok.kind != tokEOF {
+		if p.err != nil {
+			return nil, p.err
+		}
+
+		switch {
+		case p.tok.is(tokPunct, "{"):
+			doc.operations[""] = operation{selections: p.selectionSet()}
+		case p.tok.is(tokName, "fragment"):
+			p.advance()
+			name := p.name()
+			p.expectName("on")
+			p.name()
+			p.directives()
+			doc.fragments[name] = p.selectionSet()
+		case p.tok.is(tokName, "query"), p.tok.is(tokName, "mutation"), p.tok.is(tokName, "subscription"):
+			p.advance()
+			var name string
+			if p.tok.kind == tokName {
+				name = p.name()
+			}
+			op := operation{defaults: p.variableDefinitions()}
+			p.directives()
+			op.selections = p.selectionSet()
+			doc.operations[name] = op
+		default:
+			p.fail()
+		}
+	}
+
+	if p.err != nil {
+		return nil, p.err
+	}
+	return doc, nil
+}
+
+type parser struct {
+	lex lexer
+	tok token
+	err error
+}
+
+func (p *parser) advance() {
+	if p.err != nil {
+		p.tok = token{kind: tokEOF}
+		return
+	}
+	p.tok, p.err = p.lex.next()
+}
+
+func (p *parser) fail() {
+	if p.err == nil {
+		p.err = fmt.Errorf("%w at offset %d", errSyntax, p.lex.pos)
+	}
+	p.tok = token{kind: tokEOF}
+}
+
+func (p *parser) name() string {
+	if p.tok.kind != tokName {
+		p.fail()
+		return ""
+	}
+	s := p.tok.text
+	p.advance()
+	return s
+}
+
+func (p *parser) expect(punct string) {
+	if !p.tok.is(tokPunct, punct) {
+		p.fail()
+		return
+	}
+	p.advance()
+}
+
+func (p *parser) expectName(name string) {
+	if !p.tok.is(tokName, name) {
+		p.fail()
+		return
+	}
+	p.advance()
+}
+
+func (p *parser) selectionSet() []selection {
+	p.expect("{")
+
+	var sels []selection
+	for !p.tok.is(tokPunct, "}") && p.tok.kind != tokEOF {
+		sels = append(sels, p.selection())
+	}
+	p.expect("}")
+	return sels
+}
+
+func (p *parser) selection() selection {
+	if p.tok.is(tokPunct, "...") {
+		p.advance()
+		if p.tok.kind == tokName && p.tok.text != "on" {
+			s := selection{spread: p.name()}
+			p.directives()
+			return s
+		}
+		if p.tok.is(tokName, "on") {
+			p.advance()
+			p.name()
+		}
+		p.directives()
+		return selection{children: p.selectionSet()}
+	}
+
+	s := selection{name: p.name()}
+	if p.tok.is(tokPunct, ":") {
+		p.advance()
+		s.name = p.name()
+	}
+
+	if p.tok.is(tokPunct, "(") {
+		p.advance()
+		for !p.tok.is(tokPunct, ")") && p.tok.kind != tokEOF {
+			arg := p.name()
+			p.expect(":")
+			if v := p.value(); arg == "first" {
+				s.first = v
+			}
+		}
+		p.expect(")")
+	}
+
+	p.directives()
+	if p.tok.is(tokPunct, "{") {
+		s.children = p.selectionSet()
+	}
+	return s
+}
+
+func (p *parser) variableDefinitions() map[string]int {
+	defaults := map[string]int{}
+	if !p.tok.is(tokPunct, "(") {
+		return defaults
+	}
+	p.advance()
+
+	for !p.tok.is(tokPunct, ")") && p.tok.kind != tokEOF {
+		p.expect("$")
+		name := p.name()
+		p.expect(":")
+		p.typeRef()
+		if p.tok.is(tokPunct, "=") {
+			p.advance()
+			if v := p.value(); v.set && v.variable == "" {
+				defaults[name] = v.n
+			}
+		}
+		p.directives()
+	}
+	p.expect(")")
+	return defaults
+}
+
+func (p *parser) typeRef() {
+	if p.tok.is(tokPunct, "[") {
+		p.advance()
+		p.typeRef()
+		p.expect("]")
+	} else {
+		p.name()
+	}
+	if p.tok.is(tokPunct, "!") {
+		p.advance()
+	}
+}
+
+func (p *parser) directives() {
+	for p.tok.is(tokPunct, "@") {
+		p.advance()
+		p.name()
+		if p.tok.is(tokPunct, "(") {
+			p.advance()
+			for !p.tok.is(tokPunct, ")") && p.tok.kind != tokEOF {
+				p.name()
+				p.expect(":")
+				p.value()
+			}
+			p.expect(")")
+		}
+	}
+}
+
+// value пропускает значение аргумента и возвращает его, если это целое или переменная.
+func (p *parser) value() argValue {
+	switch {
+	case p.tok.is(tokPunct, "$"):
+		p.advance()
+		return argValue{set: true, variable: p.name()}
+	case p.tok.kind == tokInt:
+		n, err := strconv.Atoi(p.tok.text)
+		if err != nil {
+			p.fail()
+			return argValue{}
+		}
+		p.advance()
+		return argValue{set: true, n: n}
+	case p.tok.kind == tokName, p.tok.kind == tokFloat, p.tok.kind == tokString:
+		p.advance()
+	case p.tok.is(tokPunct, "["):
+		p.advance()
+		for !p.tok.is(tokPunct, "]") && p.tok.kind != tokEOF {
+			p.value()
+		}
+		p.expect("]")
+	case p.tok.is(tokPunct, "{"):
+		p.advance()
+		for !p.tok.is(tokPunct, "}") && p.tok.kind != tokEOF {
+			p.name()
+			p.expect(":")
+			p.value()
+		}
+		p.expect("}")
+	default:
+		p.fail()
+	}
+	return argValue{}
+}
+
+const (
+	tokEOF = iota
+	tokName
+	tokInt
+	tokFloat
+	tokString
+	tokPunct
+)
+
+type token struct {
+	kind int
+	text string
+}
+
+func (t token) is(kind int, text string) bool {
+	return t.kind == kind && t.text == text
+}
+
+type lexer struct {
+	src string
+	pos int
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+	if l.pos >= len(l.src) {
+		return token{kind: tokEOF}, nil
+	}
+
+	start := l.pos
+	c := l.src[l.pos]
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		l.pos += 3
+		return token{kind: tokPunct, text: "..."}, nil
+	case strings.IndexByte("!$&()[]{}:=@|", c) >= 0:
+		l.pos++
+		return token{kind: tokPunct, text: string(c)}, nil
+	case c == '_' || isLetter(c):
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.pos++
+		}
+		return token{kind: tokName, text: l.src[start:l.pos]}, nil
+	case c == '-' || isDigit(c):
+		return l.number()
+	case c == '"':
+		return l.string()
+	}
+	return token{}, fmt.Errorf("%w: unexpected %q at offset %d", errSyntax, c, l.pos)
+}
+
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			l.pos++
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' && l.src[l.pos] != '\r' {
+				l.pos++
+			}
+		case strings.HasPrefix(l.src[l.pos:], "\uFEFF"):
+			l.pos += len("\uFEFF")
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) number() (token, error) {
+	start := l.pos
+	kind := tokInt
+	if l.src[l.pos] == '-' {
+		l.pos++
+	}
+	l.digits()
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		kind = tokFloat
+		l.pos++
+		l.digits()
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		kind = tokFloat
+		l.pos++
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.pos++
+		}
+		l.digits()
+	}
+
+	text := l.src[start:l.pos]
+	if text == "-" || strings.HasSuffix(text, ".") {
+		return token{}, fmt.Errorf("%w: invalid number at offset %d", errSyntax, start)
+	}
+	return token{kind: kind, text: text}, nil
+}
+
+func (l *lexer) digits() {
+	for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+		l.pos++
+	}
+}
+
+func (l *lexer) string() (token, error) {
+	start := l.pos
+	if strings.HasPrefix(l.src[l.pos:], `"""`) {
+		l.pos += 3
+		for l.pos < len(l.src) {
+			switch {
+			case strings.HasPrefix(l.src[l.pos:], `\"""`):
+				l.pos += 4
+			case strings.HasPrefix(l.src[l.pos:], `"""`):
+				l.pos += 3
+				return token{kind: tokString, text: l.src[start:l.pos]}, nil
+			default:
+				l.pos++
+			}
+		}
+		return token{}, fmt.Errorf("%w: unterminated string at offset %d", errSyntax, start)
+	}
+
+	l.pos++
+	for l.pos < len(l.src) {
+		switch l.src[l.pos] {
+		case '\\':
+			l.pos += 2
+		case '"':
+			l.pos++
+			return token{kind: tokString, text: l.src[start:l.pos]}, nil
+		case '\n', '\r':
+			return token{}, fmt.Errorf("%w: unterminated string at offset %d", errSyntax, start)
+		default:
+			l.pos++
+		}
+	}
+	return token{}, fmt.Errorf("%w: unterminated string at offset %d", errSyntax, start)
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
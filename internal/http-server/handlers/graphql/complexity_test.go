@@ -0,0 +1,79 @@
+package graphql
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestComplexity(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		query     string
+		variables map[string]any
+		want      int
+	}{
+		{"fields", `{ user(id: "1") { id name } }`, nil, 3},
+		{"default first", `{ tasks { nodes { id } } }`, nil, 1 + 20*2},
+		{"literal first", `{ tasks(first: 5) { nodes { id } } }`, nil, 1 + 5*2},
+		{"variable first", `query($n: Int) { tasks(first: $n) { nodes { id } } }`, map[string]any{"n": float64(3)}, 1 + 3*2},
+		{"variable default", `query($n: Int = 4) { tasks(first: $n) { nodes { id } } }`, nil, 1 + 4*2},
+		{"first capped", `{ tasks(first: 100000) { nodes { id } } }`, nil, 1 + 100*2},
+		{"variable capped", `query($n: Int) { tasks(first: $n) { nodes { id } } }`, map[string]any{"n": 1e300}, 1 + 100*2},
+		{"fragment", `{ user(id: "1") { ...F } task(id: "1") { assignees { ...F } } } fragment F on User { id name }`, nil, 3 + 4},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := complexity(tc.query, "", tc.variables, 1000)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("complexity = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestComplexityStopsPastLimit(t *testing.T) {
+	// Вложенные tasks без предела дали бы 100^10 и переполнение
+	q := strings.Repeat("tasks(first: 100) { nodes { ", 10) + "id" + strings.Repeat(" } }", 10)
+	got, err := complexity("{ "+q+" }", "", nil, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got <= 1000 || got > 1000*maxFirst*2 {
+		t.Errorf("complexity = %d, want just above 1000", got)
+	}
+}
+
+// Фрагменты, каждый из которых дважды ссылается на следующий: без
+// запоминания обход растёт как 2^n.
+func TestComplexityMemoizesFragments(t *testing.T) {
+	const n = 40
+
+	var b strings.Builder
+	b.WriteString(`{ task(id: "1") { ...F0 } }`)
+	for i := range n {
+		b.WriteString(" fragment F" + strconv.Itoa(i) + " on Task { id ")
+		if i+1 < n {
+			b.WriteString("a: assignees { tasks(first: 1) { nodes { ...F" + strconv.Itoa(i+1) + " } } } b: assignees { tasks(first: 1) { nodes { ...F" + strconv.Itoa(i+1) + " } } }")
+		}
+		b.WriteString(" }")
+	}
+
+	done := make(chan int, 1)
+	go func() {
+		got, _ := complexity(b.String(), "", nil, 1<<40)
+		done <- got
+	}()
+
+	select {
+	case got := <-done:
+		if got <= 1<<30 {
+			t.Errorf("complexity = %d, want exponential cost", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("complexity did not finish: fragments are not memoized")
+	}
+}
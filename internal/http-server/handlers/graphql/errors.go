@@ -0,0 +1,62 @@
+package graphql
+
+import (
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/i18n"
+	"context"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+// Коды в extensions.code ошибок GraphQL.
+const (
+	codeBadInput   = "BAD_USER_INPUT"
+	codeNotFound   = "NOT_FOUND"
+	codeConflict   = "CONFLICT"
+	codeTooComplex = "QUERY_TOO_COMPLEX"
+	codeInternal   = "INTERNAL_SERVER_ERROR"
+)
+
+// gqlError попадает в errors ответа; graphql-go берёт extensions из метода Extensions.
+type gqlError struct {
+	msg    string
+	code   string
+	fields []resp.FieldError
+}
+
+func (e *gqlError) Error() string {
+	return e.msg
+}
+
+func (e *gqlError) Extensions() map[string]any {
+	ext := map[string]any{"code": e.code}
+	if len(e.fields) > 0 {
+		ext["fields"] = e.fields
+	}
+	return ext
+}
+
+// fail - ошибка с сообщением на языке запроса.
+func fail(ctx context.Context, code, msg string) error {
+	return &gqlError{msg: i18n.Message(translator(ctx), msg), code: code}
+}
+
+// invalid - ошибки полей входного объекта, field - JSON pointer внутри input.
+func invalid(ctx context.Context, errs validator.ValidationErrors) error {
+	r := resp.ValidationError(errs).Localize(translator(ctx))
+	return &gqlError{msg: r.Error, code: codeBadInput, fields: r.Fields}
+}
+
+type translatorKey struct{}
+
+func withTranslator(ctx context.Context, t ut.Translator) context.Context {
+	return context.WithValue(ctx, translatorKey{}, t)
+}
+
+func translator(ctx context.Context) ut.Translator {
+	if t, ok := ctx.Value(translatorKey{}).(ut.Translator); ok {
+		return t
+	}
+	return i18n.English()
+}
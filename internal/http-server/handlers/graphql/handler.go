@@ -0,0 +1,170 @@
+// Package graphql - эндпоинт /graphql поверх сервисов задач и пользователей.
+// Исполнители задач загружаются пачками, стоимость запроса ограничена до выполнения.
+package graphql
+
+import (
+	taskDomain "ProjectManagementAPI/internal/domain/task"
+	userDomain "ProjectManagementAPI/internal/domain/user"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/api/validate"
+	"ProjectManagementAPI/internal/lib/i18n"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/graph-gophers/graphql-go"
+	gqlErrors "github.com/graph-gophers/graphql-go/errors"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+type TaskService interface {
+	Create(ctx context.Context, t *taskDomain.Task) (uuid.UUID, error)
+	Update(ctx context.Context, id uuid.UUID, p taskDomain.Patch) (*taskDomain.Task, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetByID(ctx context.Context, id uuid.UUID) (*taskDomain.Task, error)
+	Find(ctx context.Context, q string, limit, offset int) ([]*taskDomain.Task, error)
+}
+
+type UserService interface {
+	Create(ctx context.Context, email, name string) (uuid.UUID, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*userDomain.User, error)
+}
+
+// Config - ограничения запроса: оценка стоимости и глубина вложенности.
+type Config struct {
+	MaxComplexity int
+	MaxDepth      int
+}
+
+type Handler struct {
+	log           *slog.Logger
+	schema        *graphql.Schema
+	users         UserService
+	maxComplexity int
+}
+
+func NewHandler(log *slog.Logger, tasks TaskService, users UserService, cfg Config) (*Handler, error) {
+	schema, err := graphql.ParseSchema(schemaSDL, &resolver{log: log, tasks: tasks, users: users},
+		graphql.MaxDepth(cfg.MaxDepth),
+		graphql.PanicHandler(panicHandler{log: log}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("parse graphql schema: %w", err)
+	}
+
+	return &Handler{
+		log:           log,
+		schema:        schema,
+		users:         users,
+		maxComplexity: cfg.MaxComplexity,
+	}, nil
+}
+
+// Request - тело POST /graphql. Клиенты часто присылают null в
+// operationName и variables, поэтому поля допускают null.
+type Request struct {
+	Query         string          `json:"query" validate:"required"`
+	OperationName *string         `json:"operationName,omitempty"`
+	Variables     json.RawMessage `json:"variables,omitempty"`
+	// Extensions присылают некоторые клиенты, сервер их не использует
+	Extensions json.RawMessage `json:"extensions,omitempty"`
+}
+
+// Serve выполняет запрос. Как и в GraphQL-серверах вообще, ошибки запроса
+// возвращаются в errors со статусом 200, код - в extensions.code.
+func (h *Handler) Serve(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/graphql.Serve"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var req Request
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("decode error", sl.Err(err))
+		resp.JSON(w, r, resp.Error("invalid request"))
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		var validateErr validator.ValidationErrors
+		errors.As(err, &validateErr)
+		resp.JSON(w, r, resp.ValidationError(validateErr))
+		return
+	}
+
+	var variables map[string]any
+	if len(req.Variables) > 0 {
+		if err := json.Unmarshal(req.Variables, &variables); err != nil {
+			resp.JSON(w, r, resp.Error("invalid request"))
+			return
+		}
+	}
+
+	var operationName string
+	if req.OperationName != nil {
+		operationName = *req.OperationName
+	}
+
+	t := i18n.FromHeader(r.Header.Get("Accept-Language"))
+	w.Header().Set("Content-Language", t.Locale())
+	ctx := withTranslator(r.Context(), t)
+
+	cost, err := complexity(req.Query, operationName, variables, h.maxComplexity)
+	if err != nil {
+		// Синтаксическую ошибку подробнее опишет разбор схемы
+		if errs := h.schema.Validate(req.Query); len(errs) > 0 {
+			render.JSON(w, r, &graphql.Response{Errors: errs})
+			return
+		}
+		log.Error("complexity failed", sl.Err(err))
+		render.JSON(w, r, errorResponse(fail(ctx, codeInternal, "failed to estimate query complexity")))
+		return
+	}
+
+	if cost > h.maxComplexity {
+		log.Info("query too complex", slog.Int("complexity", cost))
+		res := errorResponse(fail(ctx, codeTooComplex, "query is too complex"))
+		res.Errors[0].Extensions["complexity"] = cost
+		res.Errors[0].Extensions["maxComplexity"] = h.maxComplexity
+		render.JSON(w, r, res)
+		return
+	}
+
+	ctx = withLoaders(ctx, newLoaders(h.users))
+
+	render.JSON(w, r, h.schema.Exec(ctx, req.Query, operationName, variables))
+}
+
+func errorResponse(err error) *graphql.Response {
+	var e *gqlError
+	errors.As(err, &e)
+	return &graphql.Response{Errors: []*gqlErrors.QueryError{{Message: e.msg, Extensions: e.Extensions()}}}
+}
+
+// panicHandler пишет панику резолвера в лог и отдаёт клиенту общую ошибку.
+type panicHandler struct {
+	log *slog.Logger
+}
+
+func (p panicHandler) MakePanicError(ctx context.Context, value any) *gqlErrors.QueryError {
+	p.log.Error("panic recovered",
+		slog.String("request_id", middleware.GetReqID(ctx)),
+		slog.Any("panic", value),
+	)
+	return &gqlErrors.QueryError{Message: "internal error", Extensions: map[string]any{"code": codeInternal}}
+}
@@ -0,0 +1,92 @@
+package graphql
+
+import (
+	userDomain "ProjectManagementAPI/internal/domain/user"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/graph-gophers/dataloader"
+)
+
+type loadersKey struct{}
+
+// loaders живут один запрос: кэш не переживает изменения между запросами.
+type loaders struct {
+	users *dataloader.Loader
+}
+
+func newLoaders(users UserService) *loaders {
+	return &loaders{
+		users: dataloader.NewBatchedLoader(usersBatch(users), dataloader.WithWait(2*time.Millisecond)),
+	}
+}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// usersBatch - один запрос users.GetByIDs на все ключи пачки.
+func usersBatch(users UserService) dataloader.BatchFunc {
+	return func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+		ids := make([]uuid.UUID, len(keys))
+		for i, k := range keys {
+			ids[i] = k.Raw().(uuid.UUID)
+		}
+
+		res := make([]*dataloader.Result, len(keys))
+
+		found, err := users.GetByIDs(ctx, ids)
+		if err != nil {
+			for i := range res {
+				res[i] = &dataloader.Result{Error: err}
+			}
+			return res
+		}
+
+		byID := make(map[uuid.UUID]*userDomain.User, len(found))
+		for _, u := range found {
+			byID[u.ID] = u
+		}
+		for i, id := range ids {
+			if u, ok := byID[id]; ok {
+				res[i] = &dataloader.Result{Data: u}
+			} else {
+				res[i] = &dataloader.Result{Error: userDomain.ErrUserNotFound}
+			}
+		}
+		return res
+	}
+}
+
+type userKey uuid.UUID
+
+func (k userKey) String() string { return uuid.UUID(k).String() }
+func (k userKey) Raw() any       { return uuid.UUID(k) }
+
+func userKeys(ids []uuid.UUID) dataloader.Keys {
+	keys := make(dataloader.Keys, len(ids))
+	for i, id := range ids {
+		keys[i] = userKey(id)
+	}
+	return keys
+}
+
+// loadUser ставит пользователя в ближайшую пачку и ждёт её.
+func (l *loaders) loadUser(ctx context.Context, id uuid.UUID) (*userDomain.User, error) {
+	v, err := l.users.Load(ctx, userKey(id))()
+	if err != nil {
+		return nil, err
+	}
+	return v.(*userDomain.User), nil
+}
+
+// primeAssignees заранее ставит исполнителей всей страницы в одну пачку,
+// чтобы резолверы assignees не дробили её по MaxParallelism.
+func (l *loaders) primeAssignees(ctx context.Context, ids []uuid.UUID) {
+	l.users.LoadMany(ctx, userKeys(ids))
+}
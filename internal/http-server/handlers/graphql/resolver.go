@@ -0,0 +1,428 @@
+package graphql
+
+import (
+	milestoneDomain "ProjectManagementAPI/internal/domain/milestone"
+	projectDomain "ProjectManagementAPI/internal/domain/project"
+	taskDomain "ProjectManagementAPI/internal/domain/task"
+	userDomain "ProjectManagementAPI/internal/domain/user"
+	"ProjectManagementAPI/internal/lib/api/validate"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"ProjectManagementAPI/internal/lib/query"
+	"context"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/graph-gophers/graphql-go"
+)
+
+const (
+	// defaultFirst совпадает с first по умолчанию в schema.graphql
+	defaultFirst = 20
+	maxFirst     = 100
+)
+
+// resolver - корневой резолвер Query и Mutation.
+type resolver struct {
+	log   *slog.Logger
+	tasks TaskService
+	users UserService
+}
+
+func (r *resolver) logger(ctx context.Context, op string) *slog.Logger {
+	return r.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(ctx)),
+	)
+}
+
+type idArgs struct {
+	ID graphql.ID
+}
+
+// pageArgs: query и first заданы по умолчанию в схеме.
+type pageArgs struct {
+	Query string
+	First int32
+	After *string
+}
+
+// Task: несуществующая задача - null без ошибки.
+func (r *resolver) Task(ctx context.Context, args idArgs) (*taskResolver, error) {
+	const op = "handlers/graphql.Task"
+
+	id, err := uuid.Parse(string(args.ID))
+	if err != nil {
+		return nil, fail(ctx, codeBadInput, "invalid id")
+	}
+
+	task, err := r.tasks.GetByID(ctx, id)
+
+	if errors.Is(err, taskDomain.ErrTaskNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		r.logger(ctx, op).Error("get failed", sl.Err(err))
+		return nil, fail(ctx, codeInternal, "failed to get")
+	}
+
+	return &taskResolver{root: r, task: task}, nil
+}
+
+// User: несуществующий пользователь - null без ошибки.
+func (r *resolver) User(ctx context.Context, args idArgs) (*userResolver, error) {
+	const op = "handlers/graphql.User"
+
+	id, err := uuid.Parse(string(args.ID))
+	if err != nil {
+		return nil, fail(ctx, codeBadInput, "invalid id")
+	}
+
+	user, err := loadersFrom(ctx).loadUser(ctx, id)
+
+	if errors.Is(err, userDomain.ErrUserNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		r.logger(ctx, op).Error("get failed", sl.Err(err))
+		return nil, fail(ctx, codeInternal, "failed to get")
+	}
+
+	return &userResolver{root: r, user: user}, nil
+}
+
+func (r *resolver) Tasks(ctx context.Context, args pageArgs) (*taskConnection, error) {
+	return r.page(ctx, args.Query, args)
+}
+
+// page - страница задач по смещению. Курсор непрозрачен для клиента,
+// берётся из endCursor или edges.cursor.
+func (r *resolver) page(ctx context.Context, q string, args pageArgs) (*taskConnection, error) {
+	const op = "handlers/graphql.page"
+
+	first := int(args.First)
+	if first < 1 || first > maxFirst {
+		return nil, fail(ctx, codeBadInput, "first must be between 1 and 100")
+	}
+
+	offset := 0
+	if args.After != nil {
+		n, ok := decodeCursor(*args.After)
+		if !ok {
+			return nil, fail(ctx, codeBadInput, "invalid cursor")
+		}
+		offset = n + 1
+	}
+
+	// Лишняя задача показывает, есть ли следующая страница
+	tasks, err := r.tasks.Find(ctx, q, first+1, offset)
+
+	var queryErr *query.Error
+	if errors.As(err, &queryErr) {
+		return nil, fail(ctx, codeBadInput, "invalid query: "+queryErr.Error())
+	}
+
+	if err != nil {
+		r.logger(ctx, op).Error("list failed", sl.Err(err))
+		return nil, fail(ctx, codeInternal, "failed to list tasks")
+	}
+
+	conn := &taskConnection{hasNext: len(tasks) > first}
+	if conn.hasNext {
+		tasks = tasks[:first]
+	}
+
+	if graphql.HasSelectedField(ctx, "nodes.assignees") || graphql.HasSelectedField(ctx, "edges.node.assignees") {
+		var ids []uuid.UUID
+		for _, t := range tasks {
+			ids = append(ids, t.Assignees...)
+		}
+		loadersFrom(ctx).primeAssignees(ctx, ids)
+	}
+
+	conn.edges = make([]*taskEdge, len(tasks))
+	for i, t := range tasks {
+		conn.edges[i] = &taskEdge{cursor: encodeCursor(offset + i), node: &taskResolver{root: r, task: t}}
+	}
+
+	return conn, nil
+}
+
+type createTaskInput struct {
+	Title       string        `json:"title" validate:"required"`
+	Description string        `json:"description" validate:"required"`
+	Status      string        `json:"status"`
+	StoryPoints int32         `json:"storyPoints" validate:"min=0"`
+	MilestoneID *graphql.ID   `json:"milestoneId"`
+	ProjectID   *graphql.ID   `json:"projectId"`
+	DueAt       *graphql.Time `json:"dueAt"`
+	Assignees   []graphql.ID  `json:"assignees" validate:"min=1,dive,uuid4"`
+	Labels      []string      `json:"labels" validate:"max=20"`
+}
+
+func (r *resolver) CreateTask(ctx context.Context, args struct{ Input createTaskInput }) (*taskResolver, error) {
+	const op = "handlers/graphql.CreateTask"
+
+	in := args.Input
+	if err := validate.Struct(in); err != nil {
+		var validateErr validator.ValidationErrors
+		errors.As(err, &validateErr)
+		return nil, invalid(ctx, validateErr)
+	}
+
+	t := &taskDomain.Task{
+		Title:       in.Title,
+		Description: in.Description,
+		Status:      strings.ToLower(in.Status),
+		Assignees:   make([]uuid.UUID, len(in.Assignees)),
+		StoryPoints: int(in.StoryPoints),
+		Labels:      in.Labels,
+	}
+	if in.DueAt != nil {
+		t.DueAt = &in.DueAt.Time
+	}
+	for i, a := range in.Assignees {
+		t.Assignees[i] = uuid.MustParse(string(a))
+	}
+
+	var err error
+	if t.MilestoneID, err = parseID(in.MilestoneID); err != nil {
+		return nil, fail(ctx, codeBadInput, "invalid milestone id")
+	}
+	if t.ProjectID, err = parseID(in.ProjectID); err != nil {
+		return nil, fail(ctx, codeBadInput, "invalid project id")
+	}
+
+	id, err := r.tasks.Create(ctx, t)
+	if err != nil {
+		if gqlErr := taskError(ctx, err); gqlErr != nil {
+			return nil, gqlErr
+		}
+		r.logger(ctx, op).Error("create failed", sl.Err(err))
+		return nil, fail(ctx, codeInternal, "failed to create task")
+	}
+
+	task, err := r.tasks.GetByID(ctx, id)
+	if err != nil {
+		r.logger(ctx, op).Error("get failed", sl.Err(err))
+		return nil, fail(ctx, codeInternal, "failed to get")
+	}
+
+	return &taskResolver{root: r, task: task}, nil
+}
+
+type updateTaskInput struct {
+	Title       *string          `json:"title" validate:"omitempty,min=1"`
+	Description *string          `json:"description"`
+	Status      *string          `json:"status"`
+	StoryPoints *int32           `json:"storyPoints" validate:"omitempty,min=0"`
+	MilestoneID graphql.NullID   `json:"milestoneId"`
+	ProjectID   graphql.NullID   `json:"projectId"`
+	DueAt       graphql.NullTime `json:"dueAt"`
+	Labels      *[]string        `json:"labels" validate:"omitnil,max=20"`
+	Assignees   *[]graphql.ID    `json:"assignees" validate:"omitnil,min=1,dive,uuid4"`
+}
+
+func (r *resolver) UpdateTask(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input updateTaskInput
+}) (*taskResolver, error) {
+	const op = "handlers/graphql.UpdateTask"
+
+	id, err := uuid.Parse(string(args.ID))
+	if err != nil {
+		return nil, fail(ctx, codeBadInput, "invalid id")
+	}
+
+	in := args.Input
+	if err := validate.Struct(in); err != nil {
+		var validateErr validator.ValidationErrors
+		errors.As(err, &validateErr)
+		return nil, invalid(ctx, validateErr)
+	}
+
+	patch := taskDomain.Patch{
+		Title:       in.Title,
+		Description: in.Description,
+		Labels:      in.Labels,
+	}
+	if in.Status != nil {
+		status := strings.ToLower(*in.Status)
+		patch.Status = &status
+	}
+	if in.StoryPoints != nil {
+		sp := int(*in.StoryPoints)
+		patch.StoryPoints = &sp
+	}
+	if patch.MilestoneID, err = parseNullID(in.MilestoneID); err != nil {
+		return nil, fail(ctx, codeBadInput, "invalid milestone id")
+	}
+	if patch.ProjectID, err = parseNullID(in.ProjectID); err != nil {
+		return nil, fail(ctx, codeBadInput, "invalid project id")
+	}
+	if in.DueAt.Set {
+		var dueAt time.Time
+		if in.DueAt.Value != nil {
+			dueAt = in.DueAt.Value.Time
+		}
+		patch.DueAt = &dueAt
+	}
+	if in.Assignees != nil {
+		assignees := make([]uuid.UUID, len(*in.Assignees))
+		for i, a := range *in.Assignees {
+			assignees[i] = uuid.MustParse(string(a))
+		}
+		patch.Assignees = &assignees
+	}
+
+	task, err := r.tasks.Update(ctx, id, patch)
+	if err != nil {
+		if gqlErr := taskError(ctx, err); gqlErr != nil {
+			return nil, gqlErr
+		}
+		r.logger(ctx, op).Error("update failed", sl.Err(err))
+		return nil, fail(ctx, codeInternal, "failed to update task")
+	}
+
+	return &taskResolver{root: r, task: task}, nil
+}
+
+func (r *resolver) DeleteTask(ctx context.Context, args idArgs) (bool, error) {
+	const op = "handlers/graphql.DeleteTask"
+
+	id, err := uuid.Parse(string(args.ID))
+	if err != nil {
+		return false, fail(ctx, codeBadInput, "invalid id")
+	}
+
+	err = r.tasks.Delete(ctx, id)
+
+	if errors.Is(err, taskDomain.ErrTaskNotFound) {
+		return false, fail(ctx, codeNotFound, "task not found")
+	}
+
+	if err != nil {
+		r.logger(ctx, op).Error("delete failed", sl.Err(err))
+		return false, fail(ctx, codeInternal, "failed to delete")
+	}
+
+	return true, nil
+}
+
+type createUserInput struct {
+	Email string `json:"email" validate:"required,email"`
+	Name  string `json:"name" validate:"required"`
+}
+
+func (r *resolver) CreateUser(ctx context.Context, args struct{ Input createUserInput }) (*userResolver, error) {
+	const op = "handlers/graphql.CreateUser"
+
+	in := args.Input
+	if err := validate.Struct(in); err != nil {
+		var validateErr validator.ValidationErrors
+		errors.As(err, &validateErr)
+		return nil, invalid(ctx, validateErr)
+	}
+
+	id, err := r.users.Create(ctx, in.Email, in.Name)
+
+	if errors.Is(err, userDomain.ErrEmailAlreadyExists) {
+		return nil, fail(ctx, codeConflict, "email already exists")
+	}
+
+	if err != nil {
+		r.logger(ctx, op).Error("create failed", sl.Err(err))
+		return nil, fail(ctx, codeInternal, "failed to create user")
+	}
+
+	return &userResolver{root: r, user: &userDomain.User{ID: id, Email: in.Email, Name: in.Name}}, nil
+}
+
+func (r *resolver) DeleteUser(ctx context.Context, args idArgs) (bool, error) {
+	const op = "handlers/graphql.DeleteUser"
+
+	id, err := uuid.Parse(string(args.ID))
+	if err != nil {
+		return false, fail(ctx, codeBadInput, "invalid id")
+	}
+
+	err = r.users.Delete(ctx, id)
+
+	if errors.Is(err, userDomain.ErrUserNotFound) {
+		return false, fail(ctx, codeNotFound, "user not found")
+	}
+
+	if err != nil {
+		r.logger(ctx, op).Error("delete failed", sl.Err(err))
+		return false, fail(ctx, codeInternal, "failed to delete")
+	}
+
+	return true, nil
+}
+
+// taskError переводит доменные ошибки создания и изменения задачи,
+// для прочих ошибок возвращает nil.
+func taskError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, taskDomain.ErrTaskNotFound):
+		return fail(ctx, codeNotFound, "task not found")
+	case errors.Is(err, milestoneDomain.ErrMilestoneNotFound):
+		return fail(ctx, codeNotFound, "milestone not found")
+	case errors.Is(err, projectDomain.ErrProjectNotFound):
+		return fail(ctx, codeNotFound, "project not found")
+	case errors.Is(err, taskDomain.ErrAssigneeNotFound):
+		return fail(ctx, codeNotFound, "assignee not found")
+	case errors.Is(err, taskDomain.ErrNoAssignees), errors.Is(err, taskDomain.ErrInvalidTitle),
+		errors.Is(err, taskDomain.ErrInvalidStatus), errors.Is(err, taskDomain.ErrInvalidStoryPoints),
+		errors.Is(err, taskDomain.ErrInvalidLabel), errors.Is(err, taskDomain.ErrTooManyLabels):
+		return fail(ctx, codeBadInput, err.Error())
+	}
+	return nil
+}
+
+func parseID(v *graphql.ID) (*uuid.UUID, error) {
+	if v == nil {
+		return nil, nil
+	}
+	id, err := uuid.Parse(string(*v))
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// parseNullID: null отвязывает задачу (uuid.Nil), отсутствие поля ничего не меняет.
+func parseNullID(v graphql.NullID) (*uuid.UUID, error) {
+	if !v.Set {
+		return nil, nil
+	}
+	if v.Value == nil {
+		return &uuid.Nil, nil
+	}
+	return parseID(v.Value)
+}
+
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("offset:" + strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, bool) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, false
+	}
+	v, ok := strings.CutPrefix(string(b), "offset:")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.Atoi(v)
+	return n, err == nil && n >= 0
+}
@@ -0,0 +1,130 @@
+package graphql
+
+import (
+	taskDomain "ProjectManagementAPI/internal/domain/task"
+	userDomain "ProjectManagementAPI/internal/domain/user"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/graph-gophers/graphql-go"
+)
+
+type taskResolver struct {
+	root *resolver
+	task *taskDomain.Task
+}
+
+func (t *taskResolver) ID() graphql.ID           { return graphql.ID(t.task.ID.String()) }
+func (t *taskResolver) Title() string            { return t.task.Title }
+func (t *taskResolver) Description() string      { return t.task.Description }
+func (t *taskResolver) Status() string           { return strings.ToUpper(t.task.Status) }
+func (t *taskResolver) StoryPoints() int32       { return int32(t.task.StoryPoints) }
+func (t *taskResolver) MilestoneID() *graphql.ID { return optionalID(t.task.MilestoneID) }
+func (t *taskResolver) ProjectID() *graphql.ID   { return optionalID(t.task.ProjectID) }
+func (t *taskResolver) DueAt() *graphql.Time     { return optionalTime(t.task.DueAt) }
+func (t *taskResolver) SeriesID() *graphql.ID    { return optionalID(t.task.SeriesID) }
+func (t *taskResolver) OccurrenceAt() *graphql.Time {
+	return optionalTime(t.task.OccurrenceAt)
+}
+func (t *taskResolver) CreatedAt() graphql.Time { return graphql.Time{Time: t.task.CreatedAt} }
+func (t *taskResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: t.task.UpdatedAt} }
+
+func (t *taskResolver) Labels() []string {
+	if t.task.Labels == nil {
+		return []string{}
+	}
+	return t.task.Labels
+}
+
+// Assignees берёт пользователей через загрузчик: исполнители всех задач
+// запроса приходят одним users.GetByIDs.
+func (t *taskResolver) Assignees(ctx context.Context) ([]*userResolver, error) {
+	const op = "handlers/graphql.Assignees"
+
+	users, errs := loadersFrom(ctx).users.LoadMany(ctx, userKeys(t.task.Assignees))()
+
+	res := make([]*userResolver, 0, len(users))
+	for i, u := range users {
+		if i < len(errs) && errs[i] != nil {
+			t.root.logger(ctx, op).Error("load failed", sl.Err(errs[i]))
+			return nil, fail(ctx, codeInternal, "failed to get")
+		}
+		res = append(res, &userResolver{root: t.root, user: u.(*userDomain.User)})
+	}
+	return res, nil
+}
+
+type userResolver struct {
+	root *resolver
+	user *userDomain.User
+}
+
+func (u *userResolver) ID() graphql.ID { return graphql.ID(u.user.ID.String()) }
+func (u *userResolver) Email() string  { return u.user.Email }
+func (u *userResolver) Name() string   { return u.user.Name }
+
+// Tasks - задачи пользователя как исполнителя, запрос пользователя добавляется в скобках.
+func (u *userResolver) Tasks(ctx context.Context, args pageArgs) (*taskConnection, error) {
+	q := "assignee:" + u.user.ID.String()
+	if strings.TrimSpace(args.Query) != "" {
+		q += " (" + args.Query + ")"
+	}
+	return u.root.page(ctx, q, args)
+}
+
+type taskConnection struct {
+	edges   []*taskEdge
+	hasNext bool
+}
+
+func (c *taskConnection) Edges() []*taskEdge { return c.edges }
+
+func (c *taskConnection) Nodes() []*taskResolver {
+	nodes := make([]*taskResolver, len(c.edges))
+	for i, e := range c.edges {
+		nodes[i] = e.node
+	}
+	return nodes
+}
+
+func (c *taskConnection) PageInfo() *pageInfo {
+	p := &pageInfo{hasNext: c.hasNext}
+	if len(c.edges) > 0 {
+		p.endCursor = &c.edges[len(c.edges)-1].cursor
+	}
+	return p
+}
+
+type taskEdge struct {
+	cursor string
+	node   *taskResolver
+}
+
+func (e *taskEdge) Cursor() string      { return e.cursor }
+func (e *taskEdge) Node() *taskResolver { return e.node }
+
+type pageInfo struct {
+	hasNext   bool
+	endCursor *string
+}
+
+func (p *pageInfo) HasNextPage() bool  { return p.hasNext }
+func (p *pageInfo) EndCursor() *string { return p.endCursor }
+
+func optionalID(id *uuid.UUID) *graphql.ID {
+	if id == nil {
+		return nil
+	}
+	v := graphql.ID(id.String())
+	return &v
+}
+
+func optionalTime(t *time.Time) *graphql.Time {
+	if t == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *t}
+}
@@ -0,0 +1,189 @@
+package importer
+
+import (
+	importerDomain "ProjectManagementAPI/internal/domain/importer"
+	jobDomain "ProjectManagementAPI/internal/domain/job"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	Start(ctx context.Context, source string, file []byte, opts importerDomain.Options) (*importerDomain.Import, *jobDomain.Job, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*importerDomain.Import, error)
+}
+
+type Config struct {
+	MaxBytes int64
+	// Timeout заменяет таймаут чтения сервера на время загрузки файла
+	Timeout time.Duration
+}
+
+type Handler struct {
+	log     *slog.Logger
+	service Service
+	cfg     Config
+}
+
+func NewHandler(log *slog.Logger, service Service, cfg Config) *Handler {
+	return &Handler{
+		log:     log,
+		service: service,
+		cfg:     cfg,
+	}
+}
+
+type Import struct {
+	ID         string                 `json:"id"`
+	Source     string                 `json:"source"`
+	Status     string                 `json:"status"`
+	Options    importerDomain.Options `json:"options"`
+	Report     *importerDomain.Report `json:"report,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+	FinishedAt *time.Time             `json:"finished_at,omitempty"`
+}
+
+type ImportResponse struct {
+	resp.Response
+	Import Import `json:"import"`
+	// JobID - задание imports.run, см. /admin/jobs/{id}
+	JobID int64 `json:"job_id,omitempty"`
+}
+
+// Create: POST /imports?source=jira_xml&dry_run=true&default_assignee=&project=
+// &status_map={"Ready for QA":"in_progress"}&user_map={"jdoe":"john@example.com"}.
+// Тело - файл экспорта; импорт выполняется заданием, итог - в GET /imports/{id}.
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/importer.Create"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	q := r.URL.Query()
+
+	opts := importerDomain.Options{
+		DefaultAssignee: q.Get("default_assignee"),
+		Project:         q.Get("project"),
+	}
+	if v := q.Get("dry_run"); v != "" {
+		var err error
+		if opts.DryRun, err = strconv.ParseBool(v); err != nil {
+			resp.JSON(w, r, resp.Error("invalid dry_run"))
+			return
+		}
+	}
+	for name, dst := range map[string]*map[string]string{"status_map": &opts.StatusMap, "user_map": &opts.UserMap} {
+		if v := q.Get(name); v != "" {
+			if err := json.Unmarshal([]byte(v), dst); err != nil {
+				resp.JSON(w, r, resp.Error(name+" must be a JSON object of strings"))
+				return
+			}
+		}
+	}
+
+	if err := http.NewResponseController(w).SetReadDeadline(time.Now().Add(h.cfg.Timeout)); err != nil {
+		log.Warn("failed to extend read deadline", sl.Err(err))
+	}
+
+	file, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.cfg.MaxBytes))
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		resp.JSON(w, r, resp.Error(fmt.Sprintf("file is larger than %d bytes", tooLarge.Limit)))
+		return
+	}
+
+	if err != nil {
+		log.Error("failed to read file", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to read file"))
+		return
+	}
+
+	if len(file) == 0 {
+		resp.JSON(w, r, resp.Error("request body must contain the export file"))
+		return
+	}
+
+	imp, j, err := h.service.Start(r.Context(), q.Get("source"), file, opts)
+
+	if errors.Is(err, importerDomain.ErrInvalidSource) || errors.Is(err, importerDomain.ErrInvalidOptions) {
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+
+	if err != nil {
+		log.Error("failed to start import", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to start import"))
+		return
+	}
+
+	log.Info("import queued", slog.String("id", imp.ID.String()), slog.String("source", imp.Source))
+
+	render.JSON(w, r, ImportResponse{
+		Response: resp.OK(),
+		Import:   toImport(imp),
+		JobID:    j.ID,
+	})
+}
+
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/importer.GetByID"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	imp, err := h.service.GetByID(r.Context(), id)
+
+	if errors.Is(err, importerDomain.ErrImportNotFound) {
+		resp.JSON(w, r, resp.Error("import not found"))
+		return
+	}
+
+	if err != nil {
+		log.Error("failed to get import", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to get import"))
+		return
+	}
+
+	render.JSON(w, r, ImportResponse{
+		Response: resp.OK(),
+		Import:   toImport(imp),
+	})
+}
+
+func toImport(imp *importerDomain.Import) Import {
+	return Import{
+		ID:         imp.ID.String(),
+		Source:     imp.Source,
+		Status:     imp.Status,
+		Options:    imp.Options,
+		Report:     imp.Report,
+		Error:      imp.Error,
+		CreatedAt:  imp.CreatedAt,
+		FinishedAt: imp.FinishedAt,
+	}
+}
@@ -0,0 +1,186 @@
+package job
+
+import (
+	jobDomain "ProjectManagementAPI/internal/domain/job"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type Service interface {
+	GetByID(ctx context.Context, id int64) (*jobDomain.Job, error)
+	List(ctx context.Context, f jobDomain.Filter) ([]jobDomain.Job, error)
+	Retry(ctx context.Context, id int64) (*jobDomain.Job, error)
+	Cancel(ctx context.Context, id int64) (*jobDomain.Job, error)
+}
+
+type Handler struct {
+	log     *slog.Logger
+	service Service
+}
+
+func NewHandler(log *slog.Logger, service Service) *Handler {
+	return &Handler{
+		log:     log,
+		service: service,
+	}
+}
+
+type Job struct {
+	ID          int64           `json:"id"`
+	Kind        string          `json:"kind"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      string          `json:"status"`
+	UniqueKey   string          `json:"unique_key,omitempty"`
+	RunAt       time.Time       `json:"run_at"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	LastError   string          `json:"last_error,omitempty"`
+	LockedBy    string          `json:"locked_by,omitempty"`
+	LockedUntil *time.Time      `json:"locked_until,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	FinishedAt  *time.Time      `json:"finished_at,omitempty"`
+}
+
+type ListResponse struct {
+	resp.Response
+	Jobs []Job `json:"jobs"`
+}
+
+type JobResponse struct {
+	resp.Response
+	Job Job `json:"job"`
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/job.List"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	q := r.URL.Query()
+
+	f := jobDomain.Filter{
+		Status: q.Get("status"),
+		Kind:   q.Get("kind"),
+	}
+
+	var err error
+	if v := q.Get("limit"); v != "" {
+		if f.Limit, err = strconv.Atoi(v); err != nil || f.Limit < 0 {
+			resp.JSON(w, r, resp.Error("invalid limit"))
+			return
+		}
+	}
+	if v := q.Get("offset"); v != "" {
+		if f.Offset, err = strconv.Atoi(v); err != nil || f.Offset < 0 {
+			resp.JSON(w, r, resp.Error("invalid offset"))
+			return
+		}
+	}
+
+	jobs, err := h.service.List(r.Context(), f)
+
+	if errors.Is(err, jobDomain.ErrInvalidStatus) {
+		resp.JSON(w, r, resp.Error("invalid status"))
+		return
+	}
+
+	if err != nil {
+		log.Error("list failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to list jobs"))
+		return
+	}
+
+	res := make([]Job, len(jobs))
+	for i, j := range jobs {
+		res[i] = toJob(j)
+	}
+
+	render.JSON(w, r, ListResponse{
+		Response: resp.OK(),
+		Jobs:     res,
+	})
+}
+
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, "handlers/job.GetByID", h.service.GetByID)
+}
+
+func (h *Handler) Retry(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, "handlers/job.Retry", h.service.Retry)
+}
+
+func (h *Handler) Cancel(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, "handlers/job.Cancel", h.service.Cancel)
+}
+
+func (h *Handler) respond(w http.ResponseWriter, r *http.Request, op string,
+	fn func(ctx context.Context, id int64) (*jobDomain.Job, error)) {
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	j, err := fn(r.Context(), id)
+
+	if errors.Is(err, jobDomain.ErrJobNotFound) {
+		resp.JSON(w, r, resp.Error("job not found"))
+		return
+	}
+
+	if errors.Is(err, jobDomain.ErrNotRetryable) || errors.Is(err, jobDomain.ErrNotCancellable) ||
+		errors.Is(err, jobDomain.ErrDuplicateJob) {
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+
+	if err != nil {
+		log.Error("job operation failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to process job"))
+		return
+	}
+
+	render.JSON(w, r, JobResponse{
+		Response: resp.OK(),
+		Job:      toJob(*j),
+	})
+}
+
+func toJob(j jobDomain.Job) Job {
+	return Job{
+		ID:          j.ID,
+		Kind:        j.Kind,
+		Payload:     j.Payload,
+		Status:      j.Status,
+		UniqueKey:   j.UniqueKey,
+		RunAt:       j.RunAt,
+		Attempts:    j.Attempts,
+		MaxAttempts: j.MaxAttempts,
+		LastError:   j.LastError,
+		LockedBy:    j.LockedBy,
+		LockedUntil: j.LockedUntil,
+		CreatedAt:   j.CreatedAt,
+		UpdatedAt:   j.UpdatedAt,
+		FinishedAt:  j.FinishedAt,
+	}
+}
@@ -0,0 +1,237 @@
+package milestone
+
+import (
+	milestoneDomain "ProjectManagementAPI/internal/domain/milestone"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/api/validate"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+const dateLayout = "2006-01-02"
+
+type Service interface {
+	Create(ctx context.Context, title string, start, end time.Time) (uuid.UUID, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetByID(ctx context.Context, id uuid.UUID) (*milestoneDomain.Milestone, error)
+	Burndown(ctx context.Context, id uuid.UUID) (*milestoneDomain.Burndown, error)
+}
+
+type Handler struct {
+	log     *slog.Logger
+	service Service
+}
+
+func NewHandler(log *slog.Logger, service Service) *Handler {
+	return &Handler{
+		log:     log,
+		service: service,
+	}
+}
+
+type CreateRequest struct {
+	Title     string `json:"title" validate:"required"`
+	StartDate string `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate   string `json:"end_date" validate:"required,datetime=2006-01-02"`
+}
+
+type CreateResponse struct {
+	resp.Response
+	ID string `json:"id"`
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/milestone.Create"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var req CreateRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("decode error", sl.Err(err))
+		resp.JSON(w, r, resp.Error("invalid request"))
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		var validateErr validator.ValidationErrors
+		errors.As(err, &validateErr)
+		resp.JSON(w, r, resp.ValidationError(validateErr))
+		return
+	}
+
+	start, _ := time.Parse(dateLayout, req.StartDate)
+	end, _ := time.Parse(dateLayout, req.EndDate)
+
+	id, err := h.service.Create(r.Context(), req.Title, start, end)
+
+	if errors.Is(err, milestoneDomain.ErrInvalidDateRange) {
+		resp.JSON(w, r, resp.Error("end date must not be before start date"))
+		return
+	}
+
+	if errors.Is(err, milestoneDomain.ErrInvalidTitle) {
+		resp.JSON(w, r, resp.Error("invalid title"))
+		return
+	}
+
+	if err != nil {
+		log.Error("create failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to create milestone"))
+		return
+	}
+
+	render.JSON(w, r, CreateResponse{
+		Response: resp.OK(),
+		ID:       id.String(),
+	})
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/milestone.Delete"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	idStr := chi.URLParam(r, "id")
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	err = h.service.Delete(r.Context(), id)
+
+	if errors.Is(err, milestoneDomain.ErrMilestoneNotFound) {
+		resp.JSON(w, r, resp.Error("milestone not found"))
+		return
+	}
+
+	if err != nil {
+		log.Error("delete failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to delete"))
+		return
+	}
+
+	render.JSON(w, r, resp.OK())
+}
+
+type GetByIDResponse struct {
+	resp.Response
+	Title     string `json:"title"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/milestone.GetByID"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	idStr := chi.URLParam(r, "id")
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	m, err := h.service.GetByID(r.Context(), id)
+
+	if errors.Is(err, milestoneDomain.ErrMilestoneNotFound) {
+		log.Info("milestone not found", slog.String("milestone_id", id.String()))
+		resp.JSON(w, r, resp.Error("milestone not found"))
+		return
+	}
+
+	if err != nil {
+		log.Error("get failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to get"))
+		return
+	}
+
+	render.JSON(w, r, GetByIDResponse{
+		Response:  resp.OK(),
+		Title:     m.Title,
+		StartDate: m.StartDate.Format(dateLayout),
+		EndDate:   m.EndDate.Format(dateLayout),
+	})
+}
+
+type BurndownPoint struct {
+	Date      string  `json:"date"`
+	Remaining int     `json:"remaining"`
+	Ideal     float64 `json:"ideal"`
+}
+
+type BurndownResponse struct {
+	resp.Response
+	MilestoneID string          `json:"milestone_id"`
+	TotalPoints int             `json:"total_points"`
+	Points      []BurndownPoint `json:"points"`
+}
+
+func (h *Handler) Burndown(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/milestone.Burndown"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	idStr := chi.URLParam(r, "id")
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	burndown, err := h.service.Burndown(r.Context(), id)
+
+	if errors.Is(err, milestoneDomain.ErrMilestoneNotFound) {
+		resp.JSON(w, r, resp.Error("milestone not found"))
+		return
+	}
+
+	if err != nil {
+		log.Error("burndown failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to get burndown"))
+		return
+	}
+
+	points := make([]BurndownPoint, len(burndown.Points))
+	for i, p := range burndown.Points {
+		points[i] = BurndownPoint{
+			Date:      p.Date.Format(dateLayout),
+			Remaining: p.Remaining,
+			Ideal:     p.Ideal,
+		}
+	}
+
+	render.JSON(w, r, BurndownResponse{
+		Response:    resp.OK(),
+		MilestoneID: burndown.MilestoneID.String(),
+		TotalPoints: burndown.TotalPoints,
+		Points:      points,
+	})
+}
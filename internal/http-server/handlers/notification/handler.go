@@ -0,0 +1,279 @@
+package notification
+
+import (
+	notificationDomain "ProjectManagementAPI/internal/domain/notification"
+	"ProjectManagementAPI/internal/lib/actor"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/api/validate"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	List(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]notificationDomain.Notification, int, error)
+	SetRead(ctx context.Context, userID, id uuid.UUID, read bool) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID) error
+	Preferences(ctx context.Context, userID uuid.UUID) ([]notificationDomain.Preference, error)
+	SavePreferences(ctx context.Context, userID uuid.UUID, prefs []notificationDomain.Preference) error
+}
+
+type Handler struct {
+	log     *slog.Logger
+	service Service
+}
+
+func NewHandler(log *slog.Logger, service Service) *Handler {
+	return &Handler{
+		log:     log,
+		service: service,
+	}
+}
+
+type Notification struct {
+	ID        string     `json:"id"`
+	Type      string     `json:"type"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	TaskID    string     `json:"task_id,omitempty"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type ListResponse struct {
+	resp.Response
+	Unread        int            `json:"unread"`
+	Notifications []Notification `json:"notifications"`
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/notification.List"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, _ := actor.FromContext(r.Context())
+	q := r.URL.Query()
+
+	var (
+		limit, offset int
+		unreadOnly    bool
+		err           error
+	)
+	if v := q.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 0 {
+			resp.JSON(w, r, resp.Error("invalid limit"))
+			return
+		}
+	}
+	if v := q.Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			resp.JSON(w, r, resp.Error("invalid offset"))
+			return
+		}
+	}
+	if v := q.Get("unread"); v != "" {
+		if unreadOnly, err = strconv.ParseBool(v); err != nil {
+			resp.JSON(w, r, resp.Error("invalid unread"))
+			return
+		}
+	}
+
+	list, unread, err := h.service.List(r.Context(), userID, unreadOnly, limit, offset)
+	if err != nil {
+		log.Error("list failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to list notifications"))
+		return
+	}
+
+	res := make([]Notification, len(list))
+	for i, n := range list {
+		res[i] = Notification{
+			ID:        n.ID.String(),
+			Type:      n.Type,
+			Title:     n.Title,
+			Body:      n.Body,
+			ReadAt:    n.ReadAt,
+			CreatedAt: n.CreatedAt,
+		}
+		if n.TaskID != nil {
+			res[i].TaskID = n.TaskID.String()
+		}
+	}
+
+	render.JSON(w, r, ListResponse{
+		Response:      resp.OK(),
+		Unread:        unread,
+		Notifications: res,
+	})
+}
+
+func (h *Handler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	h.setRead(w, r, true)
+}
+
+func (h *Handler) MarkUnread(w http.ResponseWriter, r *http.Request) {
+	h.setRead(w, r, false)
+}
+
+func (h *Handler) setRead(w http.ResponseWriter, r *http.Request, read bool) {
+	const op = "handlers/notification.SetRead"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, _ := actor.FromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	err = h.service.SetRead(r.Context(), userID, id, read)
+
+	if errors.Is(err, notificationDomain.ErrNotificationNotFound) {
+		resp.JSON(w, r, resp.Error("notification not found"))
+		return
+	}
+
+	if err != nil {
+		log.Error("set read failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to update notification"))
+		return
+	}
+
+	render.JSON(w, r, resp.OK())
+}
+
+func (h *Handler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/notification.MarkAllRead"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, _ := actor.FromContext(r.Context())
+
+	if err := h.service.MarkAllRead(r.Context(), userID); err != nil {
+		log.Error("mark all read failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to update notifications"))
+		return
+	}
+
+	render.JSON(w, r, resp.OK())
+}
+
+type Preference struct {
+	Type  string `json:"type" validate:"required"`
+	InApp bool   `json:"in_app"`
+	Email bool   `json:"email"`
+}
+
+type PreferencesRequest struct {
+	Preferences []Preference `json:"preferences" validate:"required,dive"`
+}
+
+type PreferencesResponse struct {
+	resp.Response
+	Preferences []Preference `json:"preferences"`
+}
+
+func (h *Handler) Preferences(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/notification.Preferences"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, _ := actor.FromContext(r.Context())
+
+	prefs, err := h.service.Preferences(r.Context(), userID)
+	if err != nil {
+		log.Error("preferences failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to get preferences"))
+		return
+	}
+
+	render.JSON(w, r, toPreferencesResponse(prefs))
+}
+
+func (h *Handler) SavePreferences(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/notification.SavePreferences"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, _ := actor.FromContext(r.Context())
+
+	var req PreferencesRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("decode error", sl.Err(err))
+		resp.JSON(w, r, resp.Error("invalid request"))
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		var validateErr validator.ValidationErrors
+		errors.As(err, &validateErr)
+		resp.JSON(w, r, resp.ValidationError(validateErr))
+		return
+	}
+
+	prefs := make([]notificationDomain.Preference, len(req.Preferences))
+	for i, p := range req.Preferences {
+		prefs[i] = notificationDomain.Preference{Type: p.Type, InApp: p.InApp, Email: p.Email}
+	}
+
+	err := h.service.SavePreferences(r.Context(), userID, prefs)
+
+	if errors.Is(err, notificationDomain.ErrInvalidType) {
+		resp.JSON(w, r, resp.Error("invalid notification type"))
+		return
+	}
+
+	if err != nil {
+		log.Error("save preferences failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to save preferences"))
+		return
+	}
+
+	saved, err := h.service.Preferences(r.Context(), userID)
+	if err != nil {
+		log.Error("preferences failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to get preferences"))
+		return
+	}
+
+	render.JSON(w, r, toPreferencesResponse(saved))
+}
+
+func toPreferencesResponse(prefs []notificationDomain.Preference) PreferencesResponse {
+	res := make([]Preference, len(prefs))
+	for i, p := range prefs {
+		res[i] = Preference{Type: p.Type, InApp: p.InApp, Email: p.Email}
+	}
+	return PreferencesResponse{
+		Response:    resp.OK(),
+		Preferences: res,
+	}
+}
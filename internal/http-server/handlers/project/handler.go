@@ -0,0 +1,160 @@
+package project
+
+import (
+	projectDomain "ProjectManagementAPI/internal/domain/project"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/api/validate"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	Create(ctx context.Context, name string) (uuid.UUID, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetByID(ctx context.Context, id uuid.UUID) (*projectDomain.Project, error)
+}
+
+type Handler struct {
+	log     *slog.Logger
+	service Service
+}
+
+func NewHandler(log *slog.Logger, service Service) *Handler {
+	return &Handler{
+		log:     log,
+		service: service,
+	}
+}
+
+type CreateRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type CreateResponse struct {
+	resp.Response
+	ID string `json:"id"`
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/project.Create"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var req CreateRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("decode error", sl.Err(err))
+		resp.JSON(w, r, resp.Error("invalid request"))
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		var validateErr validator.ValidationErrors
+		errors.As(err, &validateErr)
+		resp.JSON(w, r, resp.ValidationError(validateErr))
+		return
+	}
+
+	id, err := h.service.Create(r.Context(), req.Name)
+
+	if errors.Is(err, projectDomain.ErrInvalidName) {
+		resp.JSON(w, r, resp.Error("invalid name"))
+		return
+	}
+
+	if err != nil {
+		log.Error("create failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to create project"))
+		return
+	}
+
+	render.JSON(w, r, CreateResponse{
+		Response: resp.OK(),
+		ID:       id.String(),
+	})
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/project.Delete"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	idStr := chi.URLParam(r, "id")
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	err = h.service.Delete(r.Context(), id)
+
+	if errors.Is(err, projectDomain.ErrProjectNotFound) {
+		resp.JSON(w, r, resp.Error("project not found"))
+		return
+	}
+
+	if err != nil {
+		log.Error("delete failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to delete"))
+		return
+	}
+
+	render.JSON(w, r, resp.OK())
+}
+
+type GetByIDResponse struct {
+	resp.Response
+	Name string `json:"name"`
+}
+
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/project.GetByID"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	idStr := chi.URLParam(r, "id")
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	p, err := h.service.GetByID(r.Context(), id)
+
+	if errors.Is(err, projectDomain.ErrProjectNotFound) {
+		log.Info("project not found", slog.String("project_id", id.String()))
+		resp.JSON(w, r, resp.Error("project not found"))
+		return
+	}
+
+	if err != nil {
+		log.Error("get failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to get"))
+		return
+	}
+
+	render.JSON(w, r, GetByIDResponse{
+		Response: resp.OK(),
+		Name:     p.Name,
+	})
+}
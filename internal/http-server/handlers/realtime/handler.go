@@ -0,0 +1,251 @@
+package realtime
+
+import (
+	"ProjectManagementAPI/internal/lib/actor"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"ProjectManagementAPI/internal/usecase/realtime"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	heartbeatInterval = 15 * time.Second
+	writeWait         = 10 * time.Second
+	pongWait          = 60 * time.Second
+
+	// resetEvent сообщает клиенту, что пропущенные события недоступны
+	// и состояние нужно перечитать через REST.
+	resetEvent = "reset"
+)
+
+type Hub interface {
+	Subscribe(f realtime.Filter, lastEventID string) (*realtime.Subscription, []realtime.Message, bool)
+	Unsubscribe(s *realtime.Subscription)
+}
+
+type Handler struct {
+	log      *slog.Logger
+	hub      Hub
+	upgrader websocket.Upgrader
+}
+
+func NewHandler(log *slog.Logger, hub Hub) *Handler {
+	return &Handler{
+		log: log,
+		hub: hub,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 4096,
+		},
+	}
+}
+
+// Stream - Server-Sent Events: GET /events/stream?project_id=&assignee=&task_id=
+func (h *Handler) Stream(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/realtime.Stream"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	filter, err := parseFilter(r)
+	if err != nil {
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+
+	rc := http.NewResponseController(w)
+	// Поток живёт дольше WriteTimeout сервера
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		log.Error("failed to reset write deadline", sl.Err(err))
+		resp.JSON(w, r, resp.Error("streaming is not supported"))
+		return
+	}
+
+	sub, missed, ok := h.hub.Subscribe(filter, lastEventID)
+	defer h.hub.Unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprint(w, "retry: 3000\n\n")
+	if !ok {
+		fmt.Fprintf(w, "event: %s\ndata: {}\n\n", resetEvent)
+	}
+	for _, m := range missed {
+		writeSSE(w, m)
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case m, open := <-sub.C:
+			if !open {
+				return
+			}
+			writeSSE(w, m)
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeSSE(w http.ResponseWriter, m realtime.Message) {
+	fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", m.ID, m.Type, m.Data)
+}
+
+type wsMessage struct {
+	ID   string          `json:"id,omitempty"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// WebSocket - те же события через WebSocket: GET /events/ws?project_id=&assignee=&task_id=&last_event_id=
+func (h *Handler) WebSocket(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/realtime.WebSocket"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	filter, err := parseFilter(r)
+	if err != nil {
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Info("websocket upgrade failed", sl.Err(err))
+		return
+	}
+	defer conn.Close()
+
+	sub, missed, ok := h.hub.Subscribe(filter, r.URL.Query().Get("last_event_id"))
+	defer h.hub.Unsubscribe(sub)
+
+	// Читаем только control-фреймы, чтобы заметить закрытие соединения
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(pongWait))
+		})
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	write := func(m wsMessage) error {
+		_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+		return conn.WriteJSON(m)
+	}
+
+	if !ok {
+		if err := write(wsMessage{Type: resetEvent}); err != nil {
+			return
+		}
+	}
+	for _, m := range missed {
+		if err := write(wsMessage{ID: m.ID, Type: m.Type, Data: m.Data}); err != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case m, open := <-sub.C:
+			if !open {
+				_ = conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "too slow"),
+					time.Now().Add(writeWait))
+				return
+			}
+			if err := write(wsMessage{ID: m.ID, Type: m.Type, Data: m.Data}); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func parseFilter(r *http.Request) (realtime.Filter, error) {
+	q := r.URL.Query()
+
+	var f realtime.Filter
+
+	if v := q.Get("project_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return f, errors.New("invalid project_id")
+		}
+		f.ProjectID = id.String()
+	}
+
+	if v := q.Get("assignee"); v != "" {
+		if v == "me" {
+			id, ok := actor.FromContext(r.Context())
+			if !ok {
+				return f, errors.New("assignee=me requires X-User-ID header")
+			}
+			f.AssigneeID = id.String()
+		} else {
+			id, err := uuid.Parse(v)
+			if err != nil {
+				return f, errors.New("invalid assignee")
+			}
+			f.AssigneeID = id.String()
+		}
+	}
+
+	if v := q.Get("task_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return f, errors.New("invalid task_id")
+		}
+		f.TaskID = &id
+	}
+
+	return f, nil
+}
@@ -0,0 +1,165 @@
+package recurrence
+
+import (
+	recurrenceDomain "ProjectManagementAPI/internal/domain/recurrence"
+	taskDomain "ProjectManagementAPI/internal/domain/task"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/api/validate"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	Set(ctx context.Context, taskID uuid.UUID, rule, timezone string) (*recurrenceDomain.Series, error)
+	Get(ctx context.Context, taskID uuid.UUID) (*recurrenceDomain.Series, []time.Time, error)
+	Stop(ctx context.Context, taskID uuid.UUID) error
+}
+
+type Handler struct {
+	log     *slog.Logger
+	service Service
+}
+
+func NewHandler(log *slog.Logger, service Service) *Handler {
+	return &Handler{
+		log:     log,
+		service: service,
+	}
+}
+
+type SetRequest struct {
+	// RRule - подмножество RFC 5545, например FREQ=WEEKLY;BYDAY=MO;COUNT=10
+	RRule    string `json:"rrule" validate:"required"`
+	Timezone string `json:"timezone"`
+}
+
+type Response struct {
+	resp.Response
+	SeriesID         string      `json:"series_id"`
+	RRule            string      `json:"rrule"`
+	DTStart          time.Time   `json:"dtstart"`
+	Timezone         string      `json:"timezone"`
+	LastOccurrenceAt time.Time   `json:"last_occurrence_at"`
+	NextOccurrenceAt *time.Time  `json:"next_occurrence_at,omitempty"`
+	Finished         bool        `json:"finished"`
+	Upcoming         []time.Time `json:"upcoming,omitempty"`
+}
+
+func (h *Handler) Set(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/recurrence.Set"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	var req SetRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("decode error", sl.Err(err))
+		resp.JSON(w, r, resp.Error("invalid request"))
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		var validateErr validator.ValidationErrors
+		errors.As(err, &validateErr)
+		resp.JSON(w, r, resp.ValidationError(validateErr))
+		return
+	}
+
+	if _, err := h.service.Set(r.Context(), id, req.RRule, req.Timezone); err != nil {
+		h.error(w, r, log, err, "failed to set recurrence")
+		return
+	}
+
+	h.get(w, r, log, id)
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/recurrence.Get"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	h.get(w, r, log, id)
+}
+
+func (h *Handler) Stop(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/recurrence.Stop"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	if err := h.service.Stop(r.Context(), id); err != nil {
+		h.error(w, r, log, err, "failed to stop recurrence")
+		return
+	}
+
+	render.JSON(w, r, resp.OK())
+}
+
+func (h *Handler) get(w http.ResponseWriter, r *http.Request, log *slog.Logger, id uuid.UUID) {
+	series, upcoming, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		h.error(w, r, log, err, "failed to get recurrence")
+		return
+	}
+
+	render.JSON(w, r, Response{
+		Response:         resp.OK(),
+		SeriesID:         series.ID.String(),
+		RRule:            series.RRule,
+		DTStart:          series.DTStart,
+		Timezone:         series.Timezone,
+		LastOccurrenceAt: series.LastOccurrenceAt,
+		NextOccurrenceAt: series.NextOccurrenceAt,
+		Finished:         series.Finished,
+		Upcoming:         upcoming,
+	})
+}
+
+func (h *Handler) error(w http.ResponseWriter, r *http.Request, log *slog.Logger, err error, msg string) {
+	switch {
+	case errors.Is(err, taskDomain.ErrTaskNotFound):
+		resp.JSON(w, r, resp.Error("task not found"))
+	case errors.Is(err, recurrenceDomain.ErrNotRecurring), errors.Is(err, recurrenceDomain.ErrInvalidRule),
+		errors.Is(err, recurrenceDomain.ErrInvalidTimezone), errors.Is(err, recurrenceDomain.ErrSeriesNotFound):
+		resp.JSON(w, r, resp.Error(err.Error()))
+	default:
+		log.Error(msg, sl.Err(err))
+		resp.JSON(w, r, resp.Error(msg))
+	}
+}
@@ -0,0 +1,148 @@
+package search
+
+import (
+	searchDomain "ProjectManagementAPI/internal/domain/search"
+	taskDomain "ProjectManagementAPI/internal/domain/task"
+	"ProjectManagementAPI/internal/lib/actor"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	Search(ctx context.Context, q searchDomain.Query) (*searchDomain.Page, error)
+}
+
+type Handler struct {
+	log     *slog.Logger
+	service Service
+}
+
+func NewHandler(log *slog.Logger, service Service) *Handler {
+	return &Handler{
+		log:     log,
+		service: service,
+	}
+}
+
+type Result struct {
+	TaskID         string     `json:"task_id"`
+	Title          string     `json:"title"`
+	Status         string     `json:"status"`
+	ProjectID      string     `json:"project_id,omitempty"`
+	DueAt          *time.Time `json:"due_at,omitempty"`
+	Rank           float64    `json:"rank"`
+	TitleHighlight string     `json:"title_highlight"`
+	Snippet        string     `json:"snippet"`
+	MatchedIn      []string   `json:"matched_in"`
+}
+
+type SearchResponse struct {
+	resp.Response
+	Total   int      `json:"total"`
+	Fuzzy   bool     `json:"fuzzy"`
+	Results []Result `json:"results"`
+}
+
+func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/search.Search"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	q := r.URL.Query()
+
+	query := searchDomain.Query{
+		Text:   q.Get("q"),
+		Status: q.Get("status"),
+	}
+
+	var err error
+	if v := q.Get("limit"); v != "" {
+		if query.Limit, err = strconv.Atoi(v); err != nil || query.Limit < 0 {
+			resp.JSON(w, r, resp.Error("invalid limit"))
+			return
+		}
+	}
+	if v := q.Get("offset"); v != "" {
+		if query.Offset, err = strconv.Atoi(v); err != nil || query.Offset < 0 {
+			resp.JSON(w, r, resp.Error("invalid offset"))
+			return
+		}
+	}
+
+	for name, dst := range map[string]**uuid.UUID{"project_id": &query.ProjectID, "milestone_id": &query.MilestoneID} {
+		if v := q.Get(name); v != "" {
+			id, err := uuid.Parse(v)
+			if err != nil {
+				resp.JSON(w, r, resp.Error("invalid "+name))
+				return
+			}
+			*dst = &id
+		}
+	}
+
+	if v := q.Get("assignee"); v != "" {
+		var id uuid.UUID
+		if v == "me" {
+			var ok bool
+			if id, ok = actor.FromContext(r.Context()); !ok {
+				resp.JSON(w, r, resp.Error("assignee=me requires X-User-ID header"))
+				return
+			}
+		} else if id, err = uuid.Parse(v); err != nil {
+			resp.JSON(w, r, resp.Error("invalid assignee"))
+			return
+		}
+		query.AssigneeID = &id
+	}
+
+	page, err := h.service.Search(r.Context(), query)
+
+	if errors.Is(err, searchDomain.ErrEmptyQuery) || errors.Is(err, searchDomain.ErrQueryTooLong) ||
+		errors.Is(err, taskDomain.ErrInvalidStatus) {
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+
+	if err != nil {
+		log.Error("search failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to search"))
+		return
+	}
+
+	results := make([]Result, len(page.Results))
+	for i, res := range page.Results {
+		results[i] = Result{
+			TaskID:         res.TaskID.String(),
+			Title:          res.Title,
+			Status:         res.Status,
+			DueAt:          res.DueAt,
+			Rank:           res.Rank,
+			TitleHighlight: res.TitleHighlight,
+			Snippet:        res.Snippet,
+			MatchedIn:      res.MatchedIn,
+		}
+		if res.ProjectID != nil {
+			results[i].ProjectID = res.ProjectID.String()
+		}
+	}
+
+	render.JSON(w, r, SearchResponse{
+		Response: resp.OK(),
+		Total:    page.Total,
+		Fuzzy:    page.Fuzzy,
+		Results:  results,
+	})
+}
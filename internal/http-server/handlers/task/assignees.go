@@ -0,0 +1,196 @@
+package task
+
+import (
+	taskDomain "ProjectManagementAPI/internal/domain/task"
+	userDomain "ProjectManagementAPI/internal/domain/user"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/api/validate"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type AddAssigneesRequest struct {
+	Assignees []string `json:"assignees" validate:"required,min=1,dive,uuid4"`
+}
+
+// AddAssignees добавляет исполнителей: POST /tasks/{id}/assignees. Уже назначенные пропускаются.
+func (h *Handler) AddAssignees(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/task.AddAssignees"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	var req AddAssigneesRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("decode error", sl.Err(err))
+		resp.JSON(w, r, resp.Error("invalid request"))
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		var validateErr validator.ValidationErrors
+		errors.As(err, &validateErr)
+		resp.JSON(w, r, resp.ValidationError(validateErr))
+		return
+	}
+
+	userIDs := make([]uuid.UUID, len(req.Assignees))
+	for i, a := range req.Assignees {
+		if userIDs[i], err = uuid.Parse(a); err != nil {
+			resp.JSON(w, r, resp.Error("invalid assignee"))
+			return
+		}
+	}
+
+	task, err := h.service.AddAssignees(r.Context(), id, userIDs)
+	h.renderAssignees(w, r, log, task, err)
+}
+
+// RemoveAssignee снимает исполнителя: DELETE /tasks/{id}/assignees/{userID}.
+// Последнего исполнителя снять нельзя.
+func (h *Handler) RemoveAssignee(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/task.RemoveAssignee"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid user_id"))
+		return
+	}
+
+	task, err := h.service.RemoveAssignee(r.Context(), id, userID)
+	h.renderAssignees(w, r, log, task, err)
+}
+
+func (h *Handler) renderAssignees(w http.ResponseWriter, r *http.Request, log *slog.Logger, task *taskDomain.Task, err error) {
+	if errors.Is(err, taskDomain.ErrTaskNotFound) {
+		resp.JSON(w, r, resp.Error("task not found"))
+		return
+	}
+
+	if errors.Is(err, taskDomain.ErrAssigneeNotFound) {
+		resp.JSON(w, r, resp.Error("assignee not found"))
+		return
+	}
+
+	if errors.Is(err, taskDomain.ErrNoAssignees) || errors.Is(err, taskDomain.ErrNotAssigned) {
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+
+	if err != nil {
+		log.Error("update assignees failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to update task"))
+		return
+	}
+
+	render.JSON(w, r, toGetByIDResponse(task))
+}
+
+// ByUser отдаёт задачи, где пользователь - исполнитель:
+// GET /users/{id}/tasks?status=todo,in_progress. include и fields - как у List.
+func (h *Handler) ByUser(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/task.ByUser"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	q := r.URL.Query()
+
+	var limit, offset int
+	if v := q.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 0 {
+			resp.JSON(w, r, resp.Error("invalid limit"))
+			return
+		}
+	}
+	if v := q.Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			resp.JSON(w, r, resp.Error("invalid offset"))
+			return
+		}
+	}
+
+	filter := "assignee:" + userID.String()
+	if statuses := splitList(q.Get("status")); len(statuses) > 0 {
+		for _, s := range statuses {
+			if !taskDomain.ValidStatus(s) {
+				resp.JSON(w, r, resp.Error("invalid status"))
+				return
+			}
+		}
+		filter += " status:" + strings.Join(statuses, ",")
+	}
+
+	view, err := ParseView(r)
+	if err != nil {
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+
+	if _, err := h.users.GetByID(r.Context(), userID); err != nil {
+		if errors.Is(err, userDomain.ErrUserNotFound) {
+			resp.JSON(w, r, resp.Error("user not found"))
+			return
+		}
+		log.Error("get user failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to list tasks"))
+		return
+	}
+
+	tasks, err := h.service.Find(r.Context(), filter, limit, offset)
+	if err != nil {
+		log.Error("list failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to list tasks"))
+		return
+	}
+
+	items := ToTasks(tasks)
+	if err := view.Embed(r.Context(), h.users, tasks, items); err != nil {
+		log.Error("list assignees failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to list tasks"))
+		return
+	}
+
+	view.Render(w, r, ListResponse{
+		Response: resp.OK(),
+		Tasks:    items,
+	})
+}
@@ -0,0 +1,207 @@
+package task
+
+import (
+	taskDomain "ProjectManagementAPI/internal/domain/task"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/api/validate"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"ProjectManagementAPI/internal/lib/query"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type BulkRunner interface {
+	Run(ctx context.Context, req taskDomain.BulkRequest) (*taskDomain.BulkReport, error)
+}
+
+type BulkConfig struct {
+	// Timeout - предел выполнения пачки (bulk.timeout), больше http_server.timeout
+	Timeout time.Duration
+}
+
+// bulkWriteMargin - запас на запись ответа после выполнения пачки.
+const bulkWriteMargin = 5 * time.Second
+
+type BulkHandler struct {
+	log     *slog.Logger
+	service BulkRunner
+	cfg     BulkConfig
+}
+
+func NewBulkHandler(log *slog.Logger, service BulkRunner, cfg BulkConfig) *BulkHandler {
+	return &BulkHandler{
+		log:     log,
+		service: service,
+		cfg:     cfg,
+	}
+}
+
+type BulkOperation struct {
+	Op        string   `json:"op" validate:"required,oneof=set_status reassign add_label delete"`
+	TaskID    string   `json:"task_id" validate:"omitempty,uuid"`
+	Status    string   `json:"status"`
+	Assignees []string `json:"assignees" validate:"dive,uuid"`
+	Label     string   `json:"label"`
+}
+
+// BulkRequest: либо operations, либо filter (язык фильтров) и operation без task_id.
+type BulkRequest struct {
+	Mode       string          `json:"mode" validate:"omitempty,oneof=atomic per_item"`
+	Operations []BulkOperation `json:"operations" validate:"dive"`
+	Filter     string          `json:"filter"`
+	Operation  *BulkOperation  `json:"operation"`
+}
+
+type BulkItem struct {
+	Index  int    `json:"index"`
+	TaskID string `json:"task_id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type BulkResponse struct {
+	resp.Response
+	Mode      string     `json:"mode"`
+	Applied   bool       `json:"applied"`
+	Succeeded int        `json:"succeeded"`
+	Failed    int        `json:"failed"`
+	Results   []BulkItem `json:"results"`
+}
+
+func (h *BulkHandler) Bulk(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/task.Bulk"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var req BulkRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("decode error", sl.Err(err))
+		resp.JSON(w, r, resp.Error("invalid request"))
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		var validateErr validator.ValidationErrors
+		errors.As(err, &validateErr)
+		resp.JSON(w, r, resp.ValidationError(validateErr))
+		return
+	}
+
+	bulk := taskDomain.BulkRequest{
+		Mode:       req.Mode,
+		Filter:     req.Filter,
+		Operations: make([]taskDomain.BulkOp, len(req.Operations)),
+	}
+	for i, o := range req.Operations {
+		bulk.Operations[i] = toBulkOp(o)
+	}
+	if req.Operation != nil {
+		if req.Operation.TaskID != "" {
+			resp.JSON(w, r, resp.Error("operation with filter must not have task_id"))
+			return
+		}
+		o := toBulkOp(*req.Operation)
+		bulk.Operation = &o
+	}
+
+	// Пачка может выполняться дольше http_server.timeout: без продления клиент
+	// не получит результат уже применённых изменений
+	deadline := time.Now().Add(h.cfg.Timeout + bulkWriteMargin)
+	if err := http.NewResponseController(w).SetWriteDeadline(deadline); err != nil {
+		log.Warn("failed to extend write deadline", sl.Err(err))
+	}
+
+	report, err := h.service.Run(r.Context(), bulk)
+
+	var queryErr *query.Error
+	if errors.As(err, &queryErr) {
+		resp.JSON(w, r, resp.Error("invalid filter: "+queryErr.Error()))
+		return
+	}
+
+	if errors.Is(err, taskDomain.ErrBulkEmptyFilter) {
+		resp.JSON(w, r, resp.Invalid([]resp.FieldError{
+			resp.NewFieldError(resp.InBody, "/filter", "required", nil, "rule.required"),
+		}))
+		return
+	}
+
+	if errors.Is(err, taskDomain.ErrInvalidBulkMode) || errors.Is(err, taskDomain.ErrBulkEmpty) ||
+		errors.Is(err, taskDomain.ErrBulkTooLarge) {
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+
+	if err != nil {
+		log.Error("bulk failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to run bulk operation"))
+		return
+	}
+
+	res := BulkResponse{
+		Response:  resp.OK(),
+		Mode:      report.Mode,
+		Applied:   report.Applied,
+		Succeeded: report.Succeeded,
+		Failed:    report.Failed,
+		Results:   make([]BulkItem, len(report.Results)),
+	}
+	for i, item := range report.Results {
+		res.Results[i] = BulkItem{
+			Index:  item.Index,
+			TaskID: item.TaskID.String(),
+			Status: item.Status,
+		}
+		if item.Err != nil {
+			res.Results[i].Error = h.itemError(log, item)
+			if !report.Applied {
+				res.Response = resp.Error(fmt.Sprintf("operation %d failed, no changes were applied", item.Index))
+			}
+		}
+	}
+
+	render.JSON(w, r, res)
+}
+
+func (h *BulkHandler) itemError(log *slog.Logger, item taskDomain.BulkResult) string {
+	for _, known := range []error{
+		taskDomain.ErrTaskNotFound, taskDomain.ErrInvalidStatus, taskDomain.ErrNoAssignees,
+		taskDomain.ErrInvalidLabel, taskDomain.ErrTooManyLabels, taskDomain.ErrInvalidBulkOp,
+		taskDomain.ErrAssigneeNotFound,
+	} {
+		if errors.Is(item.Err, known) {
+			return item.Err.Error()
+		}
+	}
+
+	log.Error("bulk item failed", slog.Int("index", item.Index), sl.Err(item.Err))
+	return "internal error"
+}
+
+func toBulkOp(o BulkOperation) taskDomain.BulkOp {
+	op := taskDomain.BulkOp{
+		Op:     o.Op,
+		Status: o.Status,
+		Label:  o.Label,
+	}
+	// Формат UUID уже проверен валидатором
+	op.TaskID, _ = uuid.Parse(o.TaskID)
+	for _, a := range o.Assignees {
+		id, _ := uuid.Parse(a)
+		op.Assignees = append(op.Assignees, id)
+	}
+	return op
+}
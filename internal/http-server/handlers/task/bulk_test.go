@@ -0,0 +1,85 @@
+package task
+
+import (
+	taskDomain "ProjectManagementAPI/internal/domain/task"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// slowBulk выполняет пачку дольше таймаута записи сервера.
+type slowBulk struct {
+	delay time.Duration
+}
+
+func (s slowBulk) Run(context.Context, taskDomain.BulkRequest) (*taskDomain.BulkReport, error) {
+	time.Sleep(s.delay)
+	return &taskDomain.BulkReport{
+		Mode:      taskDomain.BulkAtomic,
+		Applied:   true,
+		Succeeded: 1,
+		Results:   []taskDomain.BulkResult{{TaskID: uuid.New(), Status: taskDomain.BulkResultOK}},
+	}, nil
+}
+
+func TestBulkOutlivesServerWriteTimeout(t *testing.T) {
+	h := NewBulkHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), slowBulk{delay: 300 * time.Millisecond},
+		BulkConfig{Timeout: time.Second})
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(h.Bulk))
+	srv.Config.WriteTimeout = 100 * time.Millisecond
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	body := `{"operations":[{"op":"delete","task_id":"` + uuid.NewString() + `"}]}`
+	res, err := http.Post(srv.URL, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer res.Body.Close()
+
+	var got BulkResponse
+	if err := json.NewDecoder(res.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !got.Applied || got.Succeeded != 1 || len(got.Results) != 1 {
+		t.Errorf("response = %+v", got)
+	}
+}
+
+// emptyFilterBulk отвечает так же, как BulkService на пустой фильтр.
+type emptyFilterBulk struct{}
+
+func (emptyFilterBulk) Run(context.Context, taskDomain.BulkRequest) (*taskDomain.BulkReport, error) {
+	return nil, taskDomain.ErrBulkEmptyFilter
+}
+
+func TestBulkEmptyFilterIsFieldError(t *testing.T) {
+	h := NewBulkHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), emptyFilterBulk{}, BulkConfig{Timeout: time.Second})
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks/bulk", strings.NewReader(`{"filter":"","operation":{"op":"delete"}}`))
+	rec := httptest.NewRecorder()
+	h.Bulk(rec, req)
+
+	var got struct {
+		Status string `json:"status"`
+		Fields []struct {
+			Field string `json:"field"`
+			Rule  string `json:"rule"`
+		} `json:"fields"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode %s: %v", rec.Body, err)
+	}
+	if got.Status != "Error" || len(got.Fields) != 1 || got.Fields[0].Field != "/filter" || got.Fields[0].Rule != "required" {
+		t.Errorf("response = %s", rec.Body)
+	}
+}
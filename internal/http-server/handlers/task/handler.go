@@ -1,13 +1,20 @@
 package task
 
 import (
+	milestoneDomain "ProjectManagementAPI/internal/domain/milestone"
+	projectDomain "ProjectManagementAPI/internal/domain/project"
+	recurrenceDomain "ProjectManagementAPI/internal/domain/recurrence"
 	taskDomain "ProjectManagementAPI/internal/domain/task"
 	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/api/validate"
 	"ProjectManagementAPI/internal/lib/logger/sl"
+	"ProjectManagementAPI/internal/lib/query"
 	"context"
 	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -17,28 +24,47 @@ import (
 )
 
 type Service interface {
-	Create(ctx context.Context, title, description, status string, assignees []uuid.UUID) (uuid.UUID, error)
+	Create(ctx context.Context, t *taskDomain.Task) (uuid.UUID, error)
+	Update(ctx context.Context, id uuid.UUID, p taskDomain.Patch) (*taskDomain.Task, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetByID(ctx context.Context, id uuid.UUID) (*taskDomain.Task, error)
+	Find(ctx context.Context, q string, limit, offset int) ([]*taskDomain.Task, error)
+	AddAssignees(ctx context.Context, id uuid.UUID, userIDs []uuid.UUID) (*taskDomain.Task, error)
+	RemoveAssignee(ctx context.Context, id, userID uuid.UUID) (*taskDomain.Task, error)
+}
+
+// Recurrence меняет повторяющиеся задачи со scope=future.
+type Recurrence interface {
+	UpdateFuture(ctx context.Context, id uuid.UUID, p taskDomain.Patch) (*taskDomain.Task, error)
+	DeleteFuture(ctx context.Context, id uuid.UUID) error
 }
 
 type Handler struct {
-	log     *slog.Logger
-	service Service
+	log        *slog.Logger
+	service    Service
+	recurrence Recurrence
+	users      Users
 }
 
-func NewHandler(log *slog.Logger, service Service) *Handler {
+func NewHandler(log *slog.Logger, service Service, recurrence Recurrence, users Users) *Handler {
 	return &Handler{
-		log:     log,
-		service: service,
+		log:        log,
+		service:    service,
+		recurrence: recurrence,
+		users:      users,
 	}
 }
 
 type CreateRequest struct {
-	Title       string   `json:"title" validate:"required"`
-	Description string   `json:"description" validate:"required"`
-	Status      string   `json:"status" validate:"required"`
-	Assignees   []string `json:"assignees" validate:"required,min=1,dive,uuid4"`
+	Title       string     `json:"title" validate:"required"`
+	Description string     `json:"description" validate:"required"`
+	Status      string     `json:"status" validate:"required,oneof=todo in_progress done"`
+	StoryPoints int        `json:"story_points" validate:"min=0"`
+	MilestoneID string     `json:"milestone_id" validate:"omitempty,uuid4"`
+	ProjectID   string     `json:"project_id" validate:"omitempty,uuid4"`
+	DueAt       *time.Time `json:"due_at"`
+	Assignees   []string   `json:"assignees" validate:"required,min=1,dive,uuid4"`
+	Labels      []string   `json:"labels" validate:"max=20"`
 }
 
 type CreateResponse struct {
@@ -58,12 +84,14 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 
 	if err := render.DecodeJSON(r.Body, &req); err != nil {
 		log.Error("decode error", sl.Err(err))
-		render.JSON(w, r, resp.Error("invalid request"))
+		resp.JSON(w, r, resp.Error("invalid request"))
 		return
 	}
 
-	if err := validator.New().Struct(req); err != nil {
-		render.JSON(w, r, resp.Error("validation error"))
+	if err := validate.Struct(req); err != nil {
+		var validateErr validator.ValidationErrors
+		errors.As(err, &validateErr)
+		resp.JSON(w, r, resp.ValidationError(validateErr))
 		return
 	}
 
@@ -71,27 +99,75 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 	for _, a := range req.Assignees {
 		id, err := uuid.Parse(a)
 		if err != nil {
-			render.JSON(w, r, resp.Error("invalid assignee UUID: "+a))
+			resp.JSON(w, r, resp.Error("invalid assignee UUID: "+a))
 			return
 		}
 		assigneeUUIDs = append(assigneeUUIDs, id)
 	}
 
-	id, err := h.service.Create(r.Context(), req.Title, req.Description, req.Status, assigneeUUIDs)
+	t := &taskDomain.Task{
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      req.Status,
+		StoryPoints: req.StoryPoints,
+		DueAt:       req.DueAt,
+		Assignees:   assigneeUUIDs,
+		Labels:      req.Labels,
+	}
+
+	if req.MilestoneID != "" {
+		milestoneID, err := uuid.Parse(req.MilestoneID)
+		if err != nil {
+			resp.JSON(w, r, resp.Error("invalid milestone id"))
+			return
+		}
+		t.MilestoneID = &milestoneID
+	}
+
+	if req.ProjectID != "" {
+		projectID, err := uuid.Parse(req.ProjectID)
+		if err != nil {
+			resp.JSON(w, r, resp.Error("invalid project id"))
+			return
+		}
+		t.ProjectID = &projectID
+	}
+
+	id, err := h.service.Create(r.Context(), t)
 
 	if errors.Is(err, taskDomain.ErrNoAssignees) {
-		render.JSON(w, r, resp.Error("task must have at least one assignee"))
+		resp.JSON(w, r, resp.Error("task must have at least one assignee"))
 		return
 	}
 
 	if errors.Is(err, taskDomain.ErrInvalidTitle) {
-		render.JSON(w, r, resp.Error("invalid title"))
+		resp.JSON(w, r, resp.Error("invalid title"))
+		return
+	}
+
+	if errors.Is(err, taskDomain.ErrAssigneeNotFound) {
+		resp.JSON(w, r, resp.Error("assignee not found"))
+		return
+	}
+
+	if errors.Is(err, taskDomain.ErrInvalidLabel) || errors.Is(err, taskDomain.ErrTooManyLabels) {
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+
+	if errors.Is(err, milestoneDomain.ErrMilestoneNotFound) {
+		resp.JSON(w, r, resp.Error("milestone not found"))
+		return
+	}
+
+	if errors.Is(err, projectDomain.ErrProjectNotFound) {
+		resp.JSON(w, r, resp.Error("project not found"))
 		return
 	}
 
 	if err != nil {
 		log.Error("create failed", sl.Err(err))
-		render.JSON(w, r, resp.Error("failed to create task"))
+		resp.JSON(w, r, resp.Error("failed to create task"))
 		return
 	}
 
@@ -113,32 +189,82 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		render.JSON(w, r, resp.Error("invalid id"))
+		resp.JSON(w, r, resp.Error("invalid id"))
 		return
 	}
 
-	err = h.service.Delete(r.Context(), id)
+	scope, ok := parseScope(r)
+	if !ok {
+		resp.JSON(w, r, resp.Error(recurrenceDomain.ErrInvalidScope.Error()))
+		return
+	}
+
+	if scope == recurrenceDomain.ScopeFuture {
+		err = h.recurrence.DeleteFuture(r.Context(), id)
+	} else {
+		err = h.service.Delete(r.Context(), id)
+	}
 
 	if errors.Is(err, taskDomain.ErrTaskNotFound) {
-		render.JSON(w, r, resp.Error("task not found"))
+		resp.JSON(w, r, resp.Error("task not found"))
+		return
+	}
+
+	if errors.Is(err, recurrenceDomain.ErrNotRecurring) {
+		resp.JSON(w, r, resp.Error(err.Error()))
 		return
 	}
 
 	if err != nil {
 		log.Error("delete failed", sl.Err(err))
-		render.JSON(w, r, resp.Error("failed to delete"))
+		resp.JSON(w, r, resp.Error("failed to delete"))
 		return
 	}
 
 	render.JSON(w, r, resp.OK())
 }
 
+// Task - задача в ответах.
+type Task struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	StoryPoints int        `json:"story_points"`
+	MilestoneID string     `json:"milestone_id,omitempty"`
+	ProjectID   string     `json:"project_id,omitempty"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	// SeriesID и OccurrenceAt заданы у повторяющихся задач
+	SeriesID     string     `json:"series_id,omitempty"`
+	OccurrenceAt *time.Time `json:"occurrence_at,omitempty"`
+	Assignees    []string   `json:"assignees"`
+	Labels       []string   `json:"labels"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	// AssigneeUsers заполняется по ?include=assignees
+	AssigneeUsers []Assignee `json:"assignee_users,omitempty"`
+}
+
+// GetByIDResponse плоский: поле status задачи перекрывает status ответа.
 type GetByIDResponse struct {
 	resp.Response
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Status      string   `json:"status"`
-	Assignees   []string `json:"assignees"`
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	StoryPoints int        `json:"story_points"`
+	MilestoneID string     `json:"milestone_id,omitempty"`
+	ProjectID   string     `json:"project_id,omitempty"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	// SeriesID и OccurrenceAt заданы у повторяющихся задач
+	SeriesID     string     `json:"series_id,omitempty"`
+	OccurrenceAt *time.Time `json:"occurrence_at,omitempty"`
+	Assignees    []string   `json:"assignees"`
+	Labels       []string   `json:"labels"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	// AssigneeUsers заполняется по ?include=assignees
+	AssigneeUsers []Assignee `json:"assignee_users,omitempty"`
 }
 
 func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
@@ -153,7 +279,13 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		render.JSON(w, r, resp.Error("invalid id"))
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	view, err := ParseView(r)
+	if err != nil {
+		resp.JSON(w, r, resp.Error(err.Error()))
 		return
 	}
 
@@ -161,26 +293,303 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 
 	if errors.Is(err, taskDomain.ErrTaskNotFound) {
 		log.Info("task not found", slog.String("task_id", id.String()))
-		render.JSON(w, r, resp.Error("task not found"))
+		resp.JSON(w, r, resp.Error("task not found"))
 		return
 	}
 
 	if err != nil {
 		log.Error("get failed", sl.Err(err))
-		render.JSON(w, r, resp.Error("failed to get"))
+		resp.JSON(w, r, resp.Error("failed to get"))
+		return
+	}
+
+	res := toGetByIDResponse(task)
+
+	items := []Task{{}}
+	if err := view.Embed(r.Context(), h.users, []*taskDomain.Task{task}, items); err != nil {
+		log.Error("get assignees failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to get"))
+		return
+	}
+	res.AssigneeUsers = items[0].AssigneeUsers
+
+	view.Render(w, r, res)
+}
+
+type UpdateRequest struct {
+	Title       *string `json:"title" validate:"omitempty,min=1"`
+	Description *string `json:"description"`
+	Status      *string `json:"status" validate:"omitempty,oneof=todo in_progress done"`
+	StoryPoints *int    `json:"story_points" validate:"omitempty,min=0"`
+	// Пустая строка в milestone_id или project_id отвязывает задачу
+	MilestoneID *string `json:"milestone_id"`
+	ProjectID   *string `json:"project_id"`
+	// due_at в формате RFC 3339, пустая строка снимает срок
+	DueAt *string `json:"due_at"`
+	// labels заменяет весь набор меток
+	Labels *[]string `json:"labels" validate:"omitempty,max=20"`
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/task.Update"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	idStr := chi.URLParam(r, "id")
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	var req UpdateRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("decode error", sl.Err(err))
+		resp.JSON(w, r, resp.Error("invalid request"))
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		var validateErr validator.ValidationErrors
+		errors.As(err, &validateErr)
+		resp.JSON(w, r, resp.ValidationError(validateErr))
+		return
+	}
+
+	patch := taskDomain.Patch{
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      req.Status,
+		StoryPoints: req.StoryPoints,
+		Labels:      req.Labels,
+	}
+
+	if patch.MilestoneID, err = parseOptionalID(req.MilestoneID); err != nil {
+		resp.JSON(w, r, resp.Error("invalid milestone id"))
+		return
+	}
+
+	if patch.ProjectID, err = parseOptionalID(req.ProjectID); err != nil {
+		resp.JSON(w, r, resp.Error("invalid project id"))
+		return
+	}
+
+	if req.DueAt != nil {
+		var dueAt time.Time
+		if *req.DueAt != "" {
+			if dueAt, err = time.Parse(time.RFC3339, *req.DueAt); err != nil {
+				resp.JSON(w, r, resp.Error("invalid due_at, expected RFC 3339"))
+				return
+			}
+		}
+		patch.DueAt = &dueAt
+	}
+
+	scope, ok := parseScope(r)
+	if !ok {
+		resp.JSON(w, r, resp.Error(recurrenceDomain.ErrInvalidScope.Error()))
 		return
 	}
 
+	var task *taskDomain.Task
+	if scope == recurrenceDomain.ScopeFuture {
+		task, err = h.recurrence.UpdateFuture(r.Context(), id, patch)
+	} else {
+		task, err = h.service.Update(r.Context(), id, patch)
+	}
+
+	if errors.Is(err, taskDomain.ErrTaskNotFound) {
+		resp.JSON(w, r, resp.Error("task not found"))
+		return
+	}
+
+	if errors.Is(err, milestoneDomain.ErrMilestoneNotFound) {
+		resp.JSON(w, r, resp.Error("milestone not found"))
+		return
+	}
+
+	if errors.Is(err, projectDomain.ErrProjectNotFound) {
+		resp.JSON(w, r, resp.Error("project not found"))
+		return
+	}
+
+	if errors.Is(err, taskDomain.ErrInvalidTitle) || errors.Is(err, taskDomain.ErrInvalidStatus) ||
+		errors.Is(err, taskDomain.ErrInvalidStoryPoints) || errors.Is(err, recurrenceDomain.ErrNotRecurring) ||
+		errors.Is(err, recurrenceDomain.ErrFutureFieldScope) || errors.Is(err, taskDomain.ErrInvalidLabel) ||
+		errors.Is(err, taskDomain.ErrTooManyLabels) {
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+
+	if err != nil {
+		log.Error("update failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to update task"))
+		return
+	}
+
+	render.JSON(w, r, toGetByIDResponse(task))
+}
+
+type ListResponse struct {
+	resp.Response
+	Tasks []Task `json:"tasks"`
+}
+
+// List отдаёт задачи по запросу на языке фильтров: GET /tasks?q=status:todo assignee:me.
+// ?include=assignees и ?fields= - как у GetByID.
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/task.List"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	q := r.URL.Query()
+
+	var limit, offset int
+	var err error
+	if v := q.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 0 {
+			resp.JSON(w, r, resp.Error("invalid limit"))
+			return
+		}
+	}
+	if v := q.Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			resp.JSON(w, r, resp.Error("invalid offset"))
+			return
+		}
+	}
+
+	view, err := ParseView(r)
+	if err != nil {
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+
+	tasks, err := h.service.Find(r.Context(), q.Get("q"), limit, offset)
+
+	var queryErr *query.Error
+	if errors.As(err, &queryErr) {
+		resp.JSON(w, r, resp.Error("invalid query: "+queryErr.Error()))
+		return
+	}
+
+	if err != nil {
+		log.Error("list failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to list tasks"))
+		return
+	}
+
+	items := ToTasks(tasks)
+	if err := view.Embed(r.Context(), h.users, tasks, items); err != nil {
+		log.Error("list assignees failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to list tasks"))
+		return
+	}
+
+	view.Render(w, r, ListResponse{
+		Response: resp.OK(),
+		Tasks:    items,
+	})
+}
+
+// ToTasks используется и для сохранённых фильтров.
+func ToTasks(tasks []*taskDomain.Task) []Task {
+	items := make([]Task, len(tasks))
+	for i, t := range tasks {
+		items[i] = toTask(t)
+	}
+	return items
+}
+
+func toGetByIDResponse(task *taskDomain.Task) GetByIDResponse {
+	t := toTask(task)
+	return GetByIDResponse{
+		Response:     resp.OK(),
+		ID:           t.ID,
+		Title:        t.Title,
+		Description:  t.Description,
+		Status:       t.Status,
+		StoryPoints:  t.StoryPoints,
+		MilestoneID:  t.MilestoneID,
+		ProjectID:    t.ProjectID,
+		DueAt:        t.DueAt,
+		SeriesID:     t.SeriesID,
+		OccurrenceAt: t.OccurrenceAt,
+		Assignees:    t.Assignees,
+		Labels:       t.Labels,
+		CreatedAt:    t.CreatedAt,
+		UpdatedAt:    t.UpdatedAt,
+	}
+}
+
+func toTask(task *taskDomain.Task) Task {
 	assigneeIDs := make([]string, len(task.Assignees))
 	for i, a := range task.Assignees {
 		assigneeIDs[i] = a.String()
 	}
 
-	render.JSON(w, r, GetByIDResponse{
-		Response:    resp.OK(),
-		Title:       task.Title,
-		Description: task.Description,
-		Status:      task.Status,
-		Assignees:   assigneeIDs,
-	})
+	labels := task.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+
+	res := Task{
+		ID:           task.ID.String(),
+		Title:        task.Title,
+		Description:  task.Description,
+		Status:       task.Status,
+		StoryPoints:  task.StoryPoints,
+		DueAt:        task.DueAt,
+		OccurrenceAt: task.OccurrenceAt,
+		Assignees:    assigneeIDs,
+		Labels:       labels,
+		CreatedAt:    task.CreatedAt,
+		UpdatedAt:    task.UpdatedAt,
+	}
+	if task.MilestoneID != nil {
+		res.MilestoneID = task.MilestoneID.String()
+	}
+	if task.ProjectID != nil {
+		res.ProjectID = task.ProjectID.String()
+	}
+	if task.SeriesID != nil {
+		res.SeriesID = task.SeriesID.String()
+	}
+
+	return res
+}
+
+// parseOptionalID разбирает nullable-ссылку из PATCH: пустая строка
+// превращается в uuid.Nil, что означает отвязку.
+func parseOptionalID(v *string) (*uuid.UUID, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if *v == "" {
+		return &uuid.Nil, nil
+	}
+
+	id, err := uuid.Parse(*v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+// parseScope читает ?scope=this|future для повторяющихся задач, по умолчанию this.
+func parseScope(r *http.Request) (string, bool) {
+	scope := r.URL.Query().Get("scope")
+	if scope == "" {
+		return recurrenceDomain.ScopeThis, true
+	}
+	return scope, recurrenceDomain.ValidScope(scope)
 }
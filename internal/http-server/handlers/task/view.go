@@ -0,0 +1,214 @@
+package task
+
+import (
+	taskDomain "ProjectManagementAPI/internal/domain/task"
+	userDomain "ProjectManagementAPI/internal/domain/user"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// IncludeAssignees - значение ?include=, встраивающее исполнителей.
+const IncludeAssignees = "assignees"
+
+var (
+	errInvalidInclude = errors.New("include must be assignees")
+	errInvalidFields  = errors.New("fields must list task fields")
+)
+
+// fieldNames - поля задачи, допустимые в ?fields=. id отдаётся всегда.
+var fieldNames = jsonNames(reflect.TypeFor[Task]())
+
+// Users - пользователи для встроенных исполнителей и задач пользователя.
+type Users interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*userDomain.User, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*userDomain.User, error)
+}
+
+// Assignee - исполнитель, встроенный в задачу по ?include=assignees.
+type Assignee struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+// View - представление задач в ответе: ?include=assignees и ?fields=title,status.
+type View struct {
+	assignees bool
+	// fields пустой - все поля
+	fields map[string]bool
+}
+
+// ParseView читает include и fields из запроса.
+func ParseView(r *http.Request) (View, error) {
+	q := r.URL.Query()
+
+	var v View
+
+	for _, inc := range splitList(q.Get("include")) {
+		if inc != IncludeAssignees {
+			return View{}, errInvalidInclude
+		}
+		v.assignees = true
+	}
+
+	for _, f := range splitList(q.Get("fields")) {
+		if !fieldNames[f] {
+			return View{}, errInvalidFields
+		}
+		if v.fields == nil {
+			v.fields = map[string]bool{"id": true}
+		}
+		v.fields[f] = true
+	}
+
+	return v, nil
+}
+
+// keep - оставить ли поле задачи в ответе. Встроенные исполнители
+// запрошены явно через include и не отбрасываются.
+func (v View) keep(name string) bool {
+	return v.fields == nil || v.fields[name] || name == "assignee_users" || !fieldNames[name]
+}
+
+// Embed подставляет исполнителей в задачи одним users.GetByIDs на весь ответ.
+// Удалённые пользователи пропускаются.
+func (v View) Embed(ctx context.Context, users Users, tasks []*taskDomain.Task, out []Task) error {
+	if !v.assignees {
+		return nil
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	var ids []uuid.UUID
+	for _, t := range tasks {
+		for _, a := range t.Assignees {
+			if !seen[a] {
+				seen[a] = true
+				ids = append(ids, a)
+			}
+		}
+	}
+
+	var found []*userDomain.User
+	if len(ids) > 0 {
+		var err error
+		if found, err = users.GetByIDs(ctx, ids); err != nil {
+			return err
+		}
+	}
+
+	byID := make(map[uuid.UUID]*userDomain.User, len(found))
+	for _, u := range found {
+		byID[u.ID] = u
+	}
+
+	for i, t := range tasks {
+		out[i].AssigneeUsers = make([]Assignee, 0, len(t.Assignees))
+		for _, a := range t.Assignees {
+			if u, ok := byID[a]; ok {
+				out[i].AssigneeUsers = append(out[i].AssigneeUsers, Assignee{
+					ID:    u.ID.String(),
+					Email: u.Email,
+					Name:  u.Name,
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
+// Render отдаёт ответ, оставляя в задачах только поля из ?fields=.
+func (v View) Render(w http.ResponseWriter, r *http.Request, res any) {
+	if v.fields == nil {
+		render.JSON(w, r, res)
+		return
+	}
+
+	sparse, err := v.sparse(res)
+	if err != nil {
+		resp.JSON(w, r, resp.Error("failed to get"))
+		return
+	}
+
+	render.JSON(w, r, sparse)
+}
+
+func (v View) sparse(res any) (any, error) {
+	switch res := res.(type) {
+	case ListResponse:
+		tasks := make([]map[string]json.RawMessage, len(res.Tasks))
+		for i, t := range res.Tasks {
+			m, err := v.filter(t)
+			if err != nil {
+				return nil, err
+			}
+			tasks[i] = m
+		}
+		return struct {
+			resp.Response
+			Tasks []map[string]json.RawMessage `json:"tasks"`
+		}{res.Response, tasks}, nil
+	case GetByIDResponse:
+		m, err := v.filter(res)
+		if err != nil {
+			return nil, err
+		}
+		// status задачи перекрывает status ответа, без него остаётся OK
+		if _, ok := m["status"]; !ok {
+			m["status"], _ = json.Marshal(res.Response.Status)
+		}
+		return m, nil
+	default:
+		return res, nil
+	}
+}
+
+func (v View) filter(x any) (map[string]json.RawMessage, error) {
+	b, err := json.Marshal(x)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+
+	for k := range m {
+		if !v.keep(k) {
+			delete(m, k)
+		}
+	}
+
+	return m, nil
+}
+
+func splitList(s string) []string {
+	var res []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// jsonNames собирает JSON-имена полей структуры.
+func jsonNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool, t.NumField())
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
@@ -0,0 +1,127 @@
+package transfer
+
+import (
+	transferDomain "ProjectManagementAPI/internal/domain/transfer"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// readRecords разбирает файл импорта. CSV читается с заголовком, JSON -
+// массив объектов, NDJSON - по объекту на строку. Массивы в JSON
+// превращаются в ячейку со значениями через ";".
+func readRecords(r io.Reader, format string) ([]transferDomain.Record, error) {
+	switch format {
+	case transferDomain.FormatCSV:
+		return readCSV(r)
+	case transferDomain.FormatJSON:
+		dec := json.NewDecoder(r)
+		dec.UseNumber()
+		var objects []map[string]any
+		if err := dec.Decode(&objects); err != nil {
+			return nil, err
+		}
+		records := make([]transferDomain.Record, len(objects))
+		for i, obj := range objects {
+			rec, err := toRecord(i+1, obj)
+			if err != nil {
+				return nil, err
+			}
+			records[i] = rec
+		}
+		return records, nil
+	case transferDomain.FormatNDJSON:
+		dec := json.NewDecoder(r)
+		dec.UseNumber()
+		var records []transferDomain.Record
+		for {
+			var obj map[string]any
+			err := dec.Decode(&obj)
+			if errors.Is(err, io.EOF) {
+				return records, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("record %d: %w", len(records)+1, err)
+			}
+			rec, err := toRecord(len(records)+1, obj)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, rec)
+		}
+	}
+	return nil, transferDomain.ErrInvalidFormat
+}
+
+func readCSV(r io.Reader) ([]transferDomain.Record, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// BOM оставляет Excel при сохранении в UTF-8
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	var records []transferDomain.Record
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rec := transferDomain.Record{Row: len(records) + 1, Values: make(map[string]string, len(header))}
+		for i, col := range header {
+			rec.Values[col] = row[i]
+		}
+		records = append(records, rec)
+	}
+}
+
+func toRecord(row int, obj map[string]any) (transferDomain.Record, error) {
+	rec := transferDomain.Record{Row: row, Values: make(map[string]string, len(obj))}
+	for k, v := range obj {
+		s, err := cell(v)
+		if err != nil {
+			return rec, fmt.Errorf("record %d: field %q: %w", row, k, err)
+		}
+		rec.Values[k] = s
+	}
+	return rec, nil
+}
+
+func cell(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			if _, nested := item.([]any); nested {
+				return "", errors.New("nested arrays are not supported")
+			}
+			s, err := cell(item)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = s
+		}
+		return strings.Join(parts, ";"), nil
+	}
+	return "", errors.New("nested objects are not supported")
+}
@@ -0,0 +1,194 @@
+package transfer
+
+import (
+	transferDomain "ProjectManagementAPI/internal/domain/transfer"
+	userDomain "ProjectManagementAPI/internal/domain/user"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	taskColumns = []string{
+		"id", "title", "description", "status", "story_points", "due_at", "project_id", "milestone_id",
+		"assignees", "labels", "created_at", "updated_at",
+	}
+	userColumns = []string{"id", "email", "name"}
+)
+
+// Task - строка экспорта задач. Колонки совпадают с полями импорта, поэтому
+// выгруженный файл можно загрузить обратно без mapping.
+type Task struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	StoryPoints int        `json:"story_points"`
+	DueAt       *time.Time `json:"due_at"`
+	ProjectID   string     `json:"project_id"`
+	MilestoneID string     `json:"milestone_id"`
+	Assignees   []string   `json:"assignees"`
+	Labels      []string   `json:"labels"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (t Task) csv() []string {
+	var due string
+	if t.DueAt != nil {
+		due = t.DueAt.Format(time.RFC3339)
+	}
+	return []string{
+		t.ID, t.Title, t.Description, t.Status, strconv.Itoa(t.StoryPoints), due, t.ProjectID, t.MilestoneID,
+		strings.Join(t.Assignees, ";"), strings.Join(t.Labels, ";"),
+		t.CreatedAt.Format(time.RFC3339), t.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func toTask(row transferDomain.TaskRow) Task {
+	t := row.Task
+	res := Task{
+		ID:          t.ID.String(),
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      t.Status,
+		StoryPoints: t.StoryPoints,
+		DueAt:       t.DueAt,
+		Assignees:   row.Assignees,
+		Labels:      t.Labels,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+	if res.Labels == nil {
+		res.Labels = []string{}
+	}
+	if t.ProjectID != nil {
+		res.ProjectID = t.ProjectID.String()
+	}
+	if t.MilestoneID != nil {
+		res.MilestoneID = t.MilestoneID.String()
+	}
+	return res
+}
+
+type User struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+func (u User) csv() []string {
+	return []string{u.ID, u.Email, u.Name}
+}
+
+func toUser(u *userDomain.User) User {
+	return User{ID: u.ID.String(), Email: u.Email, Name: u.Name}
+}
+
+type row interface {
+	csv() []string
+}
+
+// exporter пишет строки в ответ в выбранном формате. Заголовки ответа
+// уходят с первой строкой: ошибка до неё отдаётся обычным JSON-ответом.
+type exporter struct {
+	w        http.ResponseWriter
+	rc       *http.ResponseController
+	format   string
+	filename string
+	columns  []string
+	cw       *csv.Writer
+	started  bool
+	n        int
+}
+
+func newExporter(w http.ResponseWriter, format, filename string, columns []string) *exporter {
+	return &exporter{
+		w:        w,
+		rc:       http.NewResponseController(w),
+		format:   format,
+		filename: filename,
+		columns:  columns,
+	}
+}
+
+func (e *exporter) start() error {
+	e.started = true
+
+	contentType := map[string]string{
+		transferDomain.FormatCSV:    "text/csv; charset=utf-8",
+		transferDomain.FormatJSON:   "application/json",
+		transferDomain.FormatNDJSON: "application/x-ndjson",
+	}[e.format]
+	e.w.Header().Set("Content-Type", contentType)
+	e.w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, e.filename, e.format))
+	e.w.WriteHeader(http.StatusOK)
+
+	switch e.format {
+	case transferDomain.FormatCSV:
+		e.cw = csv.NewWriter(e.w)
+		return e.cw.Write(e.columns)
+	case transferDomain.FormatJSON:
+		_, err := fmt.Fprint(e.w, "[")
+		return err
+	}
+	return nil
+}
+
+func (e *exporter) write(r row) error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+
+	if e.format == transferDomain.FormatCSV {
+		e.n++
+		return e.cw.Write(r.csv())
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	switch {
+	case e.format == transferDomain.FormatNDJSON:
+		b = append(b, '\n')
+	case e.n > 0:
+		b = append([]byte(",\n"), b...)
+	default:
+		b = append([]byte("\n"), b...)
+	}
+	e.n++
+	_, err = e.w.Write(b)
+	return err
+}
+
+// flush отправляет клиенту накопленное после каждой порции.
+func (e *exporter) flush() error {
+	if e.cw != nil {
+		e.cw.Flush()
+		if err := e.cw.Error(); err != nil {
+			return err
+		}
+	}
+	return e.rc.Flush()
+}
+
+func (e *exporter) finish() error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+	if e.format == transferDomain.FormatJSON {
+		if _, err := fmt.Fprint(e.w, "\n]\n"); err != nil {
+			return err
+		}
+	}
+	return e.flush()
+}
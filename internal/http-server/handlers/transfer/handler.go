@@ -0,0 +1,272 @@
+package transfer
+
+import (
+	transferDomain "ProjectManagementAPI/internal/domain/transfer"
+	userDomain "ProjectManagementAPI/internal/domain/user"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"ProjectManagementAPI/internal/lib/query"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type Service interface {
+	ImportTasks(ctx context.Context, records []transferDomain.Record, opts transferDomain.Options) (*transferDomain.Report, error)
+	ImportUsers(ctx context.Context, records []transferDomain.Record, opts transferDomain.Options) (*transferDomain.Report, error)
+	ExportTasks(ctx context.Context, q string, fn func(rows []transferDomain.TaskRow) error) error
+	ExportUsers(ctx context.Context, fn func(users []*userDomain.User) error) error
+}
+
+type Config struct {
+	MaxBytes int64
+	// Timeout заменяет таймауты сервера на время загрузки и выгрузки
+	Timeout time.Duration
+}
+
+type Handler struct {
+	log     *slog.Logger
+	service Service
+	cfg     Config
+}
+
+func NewHandler(log *slog.Logger, service Service, cfg Config) *Handler {
+	return &Handler{
+		log:     log,
+		service: service,
+		cfg:     cfg,
+	}
+}
+
+type RowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type ImportResponse struct {
+	resp.Response
+	DryRun    bool       `json:"dry_run"`
+	Committed bool       `json:"committed"`
+	Rows      int        `json:"rows"`
+	Created   []string   `json:"created,omitempty"`
+	Errors    []RowError `json:"errors,omitempty"`
+}
+
+// ImportTasks: POST /tasks/import?format=csv&dry_run=true&mapping={"Summary":"title"}.
+func (h *Handler) ImportTasks(w http.ResponseWriter, r *http.Request) {
+	h.importFile(w, r, "handlers/transfer.ImportTasks", h.service.ImportTasks)
+}
+
+// ImportUsers: POST /users/import, параметры как у ImportTasks.
+func (h *Handler) ImportUsers(w http.ResponseWriter, r *http.Request) {
+	h.importFile(w, r, "handlers/transfer.ImportUsers", h.service.ImportUsers)
+}
+
+type importFunc func(ctx context.Context, records []transferDomain.Record, opts transferDomain.Options) (*transferDomain.Report, error)
+
+func (h *Handler) importFile(w http.ResponseWriter, r *http.Request, op string, run importFunc) {
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	h.extendDeadlines(w, log)
+
+	q := r.URL.Query()
+
+	format := q.Get("format")
+	if format == "" {
+		format = formatFromContentType(r.Header.Get("Content-Type"))
+	}
+	if !transferDomain.ValidFormat(format) {
+		resp.JSON(w, r, resp.Error(transferDomain.ErrInvalidFormat.Error()))
+		return
+	}
+
+	var opts transferDomain.Options
+	if v := q.Get("dry_run"); v != "" {
+		var err error
+		if opts.DryRun, err = strconv.ParseBool(v); err != nil {
+			resp.JSON(w, r, resp.Error("invalid dry_run"))
+			return
+		}
+	}
+	if v := q.Get("mapping"); v != "" {
+		if err := json.Unmarshal([]byte(v), &opts.Mapping); err != nil {
+			resp.JSON(w, r, resp.Error(`mapping must be a JSON object {"column": "field"}`))
+			return
+		}
+	}
+
+	records, err := readRecords(http.MaxBytesReader(w, r.Body, h.cfg.MaxBytes), format)
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		resp.JSON(w, r, resp.Error(fmt.Sprintf("file is larger than %d bytes", tooLarge.Limit)))
+		return
+	}
+
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid "+format+": "+err.Error()))
+		return
+	}
+
+	report, err := run(r.Context(), records, opts)
+
+	if errors.Is(err, transferDomain.ErrNoRows) || errors.Is(err, transferDomain.ErrTooManyRows) ||
+		errors.Is(err, transferDomain.ErrInvalidMapping) {
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+
+	if err != nil {
+		log.Error("import failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to import"))
+		return
+	}
+
+	res := ImportResponse{
+		Response:  resp.OK(),
+		DryRun:    report.DryRun,
+		Committed: report.Committed,
+		Rows:      report.Rows,
+	}
+	for _, id := range report.Created {
+		res.Created = append(res.Created, id.String())
+	}
+	for _, e := range report.Errors {
+		res.Errors = append(res.Errors, RowError{Row: e.Row, Field: e.Field, Message: e.Message})
+	}
+	if len(res.Errors) > 0 {
+		res.Response = resp.Error(fmt.Sprintf("%d errors in rows, nothing was imported", len(res.Errors)))
+	}
+
+	render.JSON(w, r, res)
+}
+
+func formatFromContentType(v string) string {
+	mt, _, _ := mime.ParseMediaType(v)
+	switch mt {
+	case "application/json":
+		return transferDomain.FormatJSON
+	case "application/x-ndjson":
+		return transferDomain.FormatNDJSON
+	}
+	return transferDomain.FormatCSV
+}
+
+// ExportTasks: GET /tasks/export?format=csv|json|ndjson&q=status:todo.
+// Задачи читаются и отправляются порциями, без загрузки всей выборки в память.
+func (h *Handler) ExportTasks(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/transfer.ExportTasks"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	format, ok := exportFormat(w, r)
+	if !ok {
+		return
+	}
+	h.extendDeadlines(w, log)
+
+	e := newExporter(w, format, "tasks", taskColumns)
+	err := h.service.ExportTasks(r.Context(), r.URL.Query().Get("q"), func(rows []transferDomain.TaskRow) error {
+		for _, row := range rows {
+			if err := e.write(toTask(row)); err != nil {
+				return err
+			}
+		}
+		return e.flush()
+	})
+	if err == nil {
+		err = e.finish()
+	}
+
+	var queryErr *query.Error
+	switch {
+	case err == nil:
+	case e.started:
+		// Ответ уже начат: остаётся оборвать его
+		log.Error("export interrupted", sl.Err(err))
+	case errors.As(err, &queryErr):
+		resp.JSON(w, r, resp.Error("invalid query: "+queryErr.Error()))
+	default:
+		log.Error("export failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to export tasks"))
+	}
+}
+
+// ExportUsers: GET /users/export?format=csv|json|ndjson.
+func (h *Handler) ExportUsers(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/transfer.ExportUsers"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	format, ok := exportFormat(w, r)
+	if !ok {
+		return
+	}
+	h.extendDeadlines(w, log)
+
+	e := newExporter(w, format, "users", userColumns)
+	err := h.service.ExportUsers(r.Context(), func(users []*userDomain.User) error {
+		for _, u := range users {
+			if err := e.write(toUser(u)); err != nil {
+				return err
+			}
+		}
+		return e.flush()
+	})
+	if err == nil {
+		err = e.finish()
+	}
+
+	switch {
+	case err == nil:
+	case e.started:
+		log.Error("export interrupted", sl.Err(err))
+	default:
+		log.Error("export failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to export users"))
+	}
+}
+
+func exportFormat(w http.ResponseWriter, r *http.Request) (string, bool) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = transferDomain.FormatCSV
+	}
+	if !transferDomain.ValidFormat(format) {
+		resp.JSON(w, r, resp.Error(transferDomain.ErrInvalidFormat.Error()))
+		return "", false
+	}
+	return format, true
+}
+
+// extendDeadlines продлевает таймауты сервера: большой файл не успевает
+// загрузиться или выгрузиться за http_server.timeout.
+func (h *Handler) extendDeadlines(w http.ResponseWriter, log *slog.Logger) {
+	rc := http.NewResponseController(w)
+	deadline := time.Now().Add(h.cfg.Timeout)
+	if err := rc.SetReadDeadline(deadline); err != nil {
+		log.Warn("failed to extend read deadline", sl.Err(err))
+	}
+	if err := rc.SetWriteDeadline(deadline); err != nil {
+		log.Warn("failed to extend write deadline", sl.Err(err))
+	}
+}
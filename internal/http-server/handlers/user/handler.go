@@ -3,6 +3,7 @@ package user
 import (
 	userDomain "ProjectManagementAPI/internal/domain/user"
 	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/api/validate"
 	"ProjectManagementAPI/internal/lib/logger/sl"
 	"context"
 	"errors"
@@ -56,14 +57,14 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 
 	if err := render.DecodeJSON(r.Body, &req); err != nil {
 		log.Error("decode error", sl.Err(err))
-		render.JSON(w, r, resp.Error("invalid request"))
+		resp.JSON(w, r, resp.Error("invalid request"))
 		return
 	}
 
-	if err := validator.New().Struct(req); err != nil {
+	if err := validate.Struct(req); err != nil {
 		var validateErr validator.ValidationErrors
 		errors.As(err, &validateErr)
-		render.JSON(w, r, resp.ValidationError(validateErr))
+		resp.JSON(w, r, resp.ValidationError(validateErr))
 		return
 	}
 
@@ -71,13 +72,13 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 
 	if errors.Is(err, userDomain.ErrEmailAlreadyExists) {
 		log.Info("email already exists", slog.String("email", req.Email))
-		render.JSON(w, r, resp.Error("email already exists"))
+		resp.JSON(w, r, resp.Error("email already exists"))
 		return
 	}
 
 	if err != nil {
 		log.Error("create failed", sl.Err(err))
-		render.JSON(w, r, resp.Error("failed to create user"))
+		resp.JSON(w, r, resp.Error("failed to create user"))
 		return
 	}
 
@@ -99,20 +100,20 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		render.JSON(w, r, resp.Error("invalid id"))
+		resp.JSON(w, r, resp.Error("invalid id"))
 		return
 	}
 
 	err = h.service.Delete(r.Context(), id)
 
 	if errors.Is(err, userDomain.ErrUserNotFound) {
-		render.JSON(w, r, resp.Error("user not found"))
+		resp.JSON(w, r, resp.Error("user not found"))
 		return
 	}
 
 	if err != nil {
 		log.Error("delete failed", sl.Err(err))
-		render.JSON(w, r, resp.Error("failed to delete"))
+		resp.JSON(w, r, resp.Error("failed to delete"))
 		return
 	}
 
@@ -137,7 +138,7 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		render.JSON(w, r, resp.Error("invalid id"))
+		resp.JSON(w, r, resp.Error("invalid id"))
 		return
 	}
 
@@ -145,13 +146,13 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 
 	if errors.Is(err, userDomain.ErrUserNotFound) {
 		log.Info("user not found", slog.String("user_id", id.String()))
-		render.JSON(w, r, resp.Error("user not found"))
+		resp.JSON(w, r, resp.Error("user not found"))
 		return
 	}
 
 	if err != nil {
 		log.Error("get failed", sl.Err(err))
-		render.JSON(w, r, resp.Error("failed to get"))
+		resp.JSON(w, r, resp.Error("failed to get"))
 		return
 	}
 
@@ -0,0 +1,283 @@
+package webhook
+
+import (
+	webhookDomain "ProjectManagementAPI/internal/domain/webhook"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/api/validate"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	Create(ctx context.Context, url, secret string, eventTypes []string) (*webhookDomain.Subscription, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*webhookDomain.Subscription, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	Deliveries(ctx context.Context, subscriptionID uuid.UUID, limit, offset int) ([]webhookDomain.Delivery, error)
+	Redeliver(ctx context.Context, subscriptionID uuid.UUID, deliveryID int64) error
+}
+
+type Handler struct {
+	log     *slog.Logger
+	service Service
+}
+
+func NewHandler(log *slog.Logger, service Service) *Handler {
+	return &Handler{
+		log:     log,
+		service: service,
+	}
+}
+
+type CreateRequest struct {
+	URL    string   `json:"url" validate:"required,url"`
+	Secret string   `json:"secret" validate:"omitempty,min=16"`
+	Events []string `json:"events" validate:"required,min=1"`
+}
+
+type CreateResponse struct {
+	resp.Response
+	ID     string `json:"id"`
+	Secret string `json:"secret"`
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/webhook.Create"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	var req CreateRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("decode error", sl.Err(err))
+		resp.JSON(w, r, resp.Error("invalid request"))
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		var validateErr validator.ValidationErrors
+		errors.As(err, &validateErr)
+		resp.JSON(w, r, resp.ValidationError(validateErr))
+		return
+	}
+
+	sub, err := h.service.Create(r.Context(), req.URL, req.Secret, req.Events)
+
+	if errors.Is(err, webhookDomain.ErrInvalidURL) || errors.Is(err, webhookDomain.ErrInvalidEventType) {
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+
+	if err != nil {
+		log.Error("create failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to create webhook"))
+		return
+	}
+
+	render.JSON(w, r, CreateResponse{
+		Response: resp.OK(),
+		ID:       sub.ID.String(),
+		Secret:   sub.Secret,
+	})
+}
+
+type GetByIDResponse struct {
+	resp.Response
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/webhook.GetByID"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	sub, err := h.service.GetByID(r.Context(), id)
+
+	if errors.Is(err, webhookDomain.ErrSubscriptionNotFound) {
+		resp.JSON(w, r, resp.Error("webhook not found"))
+		return
+	}
+
+	if err != nil {
+		log.Error("get failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to get"))
+		return
+	}
+
+	render.JSON(w, r, GetByIDResponse{
+		Response:  resp.OK(),
+		URL:       sub.URL,
+		Events:    sub.EventTypes,
+		Active:    sub.Active,
+		CreatedAt: sub.CreatedAt,
+	})
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/webhook.Delete"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	err = h.service.Delete(r.Context(), id)
+
+	if errors.Is(err, webhookDomain.ErrSubscriptionNotFound) {
+		resp.JSON(w, r, resp.Error("webhook not found"))
+		return
+	}
+
+	if err != nil {
+		log.Error("delete failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to delete"))
+		return
+	}
+
+	render.JSON(w, r, resp.OK())
+}
+
+type Delivery struct {
+	ID            int64           `json:"id"`
+	EventID       string          `json:"event_id"`
+	EventType     string          `json:"event_type"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	LastError     string          `json:"last_error,omitempty"`
+	ResponseCode  int             `json:"response_code,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty"`
+}
+
+type DeliveriesResponse struct {
+	resp.Response
+	Deliveries []Delivery `json:"deliveries"`
+}
+
+func (h *Handler) Deliveries(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/webhook.Deliveries"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	if limit < 0 || offset < 0 {
+		resp.JSON(w, r, resp.Error("invalid pagination"))
+		return
+	}
+
+	deliveries, err := h.service.Deliveries(r.Context(), id, limit, offset)
+
+	if errors.Is(err, webhookDomain.ErrSubscriptionNotFound) {
+		resp.JSON(w, r, resp.Error("webhook not found"))
+		return
+	}
+
+	if err != nil {
+		log.Error("deliveries failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to get deliveries"))
+		return
+	}
+
+	res := make([]Delivery, len(deliveries))
+	for i, d := range deliveries {
+		res[i] = Delivery{
+			ID:            d.ID,
+			EventID:       d.EventID.String(),
+			EventType:     d.EventType,
+			Payload:       d.Payload,
+			Status:        d.Status,
+			Attempts:      d.Attempts,
+			NextAttemptAt: d.NextAttemptAt,
+			LastError:     d.LastError,
+			ResponseCode:  d.ResponseCode,
+			CreatedAt:     d.CreatedAt,
+			DeliveredAt:   d.DeliveredAt,
+		}
+	}
+
+	render.JSON(w, r, DeliveriesResponse{
+		Response:   resp.OK(),
+		Deliveries: res,
+	})
+}
+
+func (h *Handler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/webhook.Redeliver"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	deliveryID, err := strconv.ParseInt(chi.URLParam(r, "deliveryID"), 10, 64)
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid delivery id"))
+		return
+	}
+
+	err = h.service.Redeliver(r.Context(), id, deliveryID)
+
+	if errors.Is(err, webhookDomain.ErrDeliveryNotFound) {
+		resp.JSON(w, r, resp.Error("dead delivery not found"))
+		return
+	}
+
+	if err != nil {
+		log.Error("redeliver failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to redeliver"))
+		return
+	}
+
+	render.JSON(w, r, resp.OK())
+}
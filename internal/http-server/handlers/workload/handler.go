@@ -0,0 +1,278 @@
+package workload
+
+import (
+	taskDomain "ProjectManagementAPI/internal/domain/task"
+	userDomain "ProjectManagementAPI/internal/domain/user"
+	workloadDomain "ProjectManagementAPI/internal/domain/workload"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/api/validate"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+var statuses = []string{taskDomain.StatusTodo, taskDomain.StatusInProgress, taskDomain.StatusDone}
+
+type Service interface {
+	Report(ctx context.Context, from, to *time.Time) (*workloadDomain.Report, error)
+	SetCapacity(ctx context.Context, userID uuid.UUID, capacity *int) error
+	DefaultCapacity() int
+}
+
+type Handler struct {
+	log     *slog.Logger
+	service Service
+}
+
+func NewHandler(log *slog.Logger, service Service) *Handler {
+	return &Handler{
+		log:     log,
+		service: service,
+	}
+}
+
+// Load - число задач и их оценка в story points.
+type Load struct {
+	Tasks  int     `json:"tasks"`
+	Effort float64 `json:"effort"`
+}
+
+type Week struct {
+	// Start - понедельник недели, YYYY-MM-DD
+	Start string `json:"start"`
+	Load
+	Overallocated bool `json:"overallocated"`
+}
+
+type User struct {
+	ID             string          `json:"id"`
+	Name           string          `json:"name"`
+	Email          string          `json:"email"`
+	WeeklyCapacity int             `json:"weekly_capacity"`
+	ByStatus       map[string]Load `json:"by_status"`
+	// Weeks - незавершённые задачи по неделе срока
+	Weeks []Week `json:"weeks"`
+	// Unscheduled - незавершённые задачи без срока
+	Unscheduled   Load `json:"unscheduled"`
+	Overallocated bool `json:"overallocated"`
+}
+
+type ReportResponse struct {
+	resp.Response
+	// From и To - первый и последний день отчёта
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Users []User `json:"users"`
+}
+
+// Report отдаёт нагрузку пользователей по статусам и неделям; ?format=csv
+// выгружает её в CSV, строка на пользователя.
+func (h *Handler) Report(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/workload.Report"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	q := r.URL.Query()
+
+	var from, to *time.Time
+	for name, dst := range map[string]**time.Time{"from": &from, "to": &to} {
+		v := q.Get(name)
+		if v == "" {
+			continue
+		}
+		t, err := time.ParseInLocation(time.DateOnly, v, time.Local)
+		if err != nil {
+			resp.JSON(w, r, resp.Error(fmt.Sprintf("invalid %s, expected YYYY-MM-DD", name)))
+			return
+		}
+		if name == "to" {
+			t = t.AddDate(0, 0, 1)
+		}
+		*dst = &t
+	}
+
+	report, err := h.service.Report(r.Context(), from, to)
+
+	if errors.Is(err, workloadDomain.ErrInvalidRange) || errors.Is(err, workloadDomain.ErrPeriodTooLong) {
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+
+	if err != nil {
+		log.Error("report failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to build workload report"))
+		return
+	}
+
+	res := toReport(report)
+
+	if q.Get("format") == "csv" {
+		writeCSV(w, res)
+		return
+	}
+
+	render.JSON(w, r, res)
+}
+
+type CapacityRequest struct {
+	// WeeklyCapacity - story points в неделю, null возвращает значение по умолчанию
+	WeeklyCapacity *int `json:"weekly_capacity" validate:"omitnil,min=0"`
+}
+
+type CapacityResponse struct {
+	resp.Response
+	WeeklyCapacity int  `json:"weekly_capacity"`
+	Default        bool `json:"default"`
+}
+
+// SetCapacity задаёт недельную ёмкость пользователя: PUT /users/{id}/capacity.
+func (h *Handler) SetCapacity(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/workload.SetCapacity"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	var req CapacityRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("decode error", sl.Err(err))
+		resp.JSON(w, r, resp.Error("invalid request"))
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		var validateErr validator.ValidationErrors
+		errors.As(err, &validateErr)
+		resp.JSON(w, r, resp.ValidationError(validateErr))
+		return
+	}
+
+	err = h.service.SetCapacity(r.Context(), id, req.WeeklyCapacity)
+
+	if errors.Is(err, userDomain.ErrUserNotFound) {
+		resp.JSON(w, r, resp.Error("user not found"))
+		return
+	}
+
+	if errors.Is(err, workloadDomain.ErrInvalidCapacity) {
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+
+	if err != nil {
+		log.Error("set capacity failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to save capacity"))
+		return
+	}
+
+	res := CapacityResponse{
+		Response:       resp.OK(),
+		WeeklyCapacity: h.service.DefaultCapacity(),
+		Default:        req.WeeklyCapacity == nil,
+	}
+	if req.WeeklyCapacity != nil {
+		res.WeeklyCapacity = *req.WeeklyCapacity
+	}
+
+	render.JSON(w, r, res)
+}
+
+func toReport(report *workloadDomain.Report) ReportResponse {
+	res := ReportResponse{
+		Response: resp.OK(),
+		From:     report.From.Format(time.DateOnly),
+		To:       report.To.AddDate(0, 0, -1).Format(time.DateOnly),
+		Users:    make([]User, len(report.Users)),
+	}
+
+	for i, u := range report.Users {
+		user := User{
+			ID:             u.User.ID.String(),
+			Name:           u.User.Name,
+			Email:          u.User.Email,
+			WeeklyCapacity: u.Capacity,
+			ByStatus:       make(map[string]Load, len(statuses)),
+			Weeks:          make([]Week, len(u.Weeks)),
+			Unscheduled:    toLoad(u.Unscheduled),
+			Overallocated:  u.Overallocated,
+		}
+		for _, s := range statuses {
+			user.ByStatus[s] = toLoad(u.ByStatus[s])
+		}
+		for j, wk := range u.Weeks {
+			user.Weeks[j] = Week{
+				Start:         wk.Start.Format(time.DateOnly),
+				Load:          toLoad(wk.Load),
+				Overallocated: wk.Overallocated,
+			}
+		}
+		res.Users[i] = user
+	}
+
+	return res
+}
+
+// toLoad округляет оценку до сотых: доли появляются при нескольких исполнителях.
+func toLoad(l workloadDomain.Load) Load {
+	return Load{Tasks: l.Tasks, Effort: math.Round(l.Effort*100) / 100}
+}
+
+func writeCSV(w http.ResponseWriter, res ReportResponse) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="workload-%s-%s.csv"`, res.From, res.To))
+
+	header := []string{"user_id", "name", "email", "weekly_capacity", "overallocated"}
+	for _, s := range statuses {
+		header = append(header, s+"_tasks", s+"_effort")
+	}
+	header = append(header, "unscheduled_tasks", "unscheduled_effort")
+	if len(res.Users) > 0 {
+		for _, wk := range res.Users[0].Weeks {
+			header = append(header, wk.Start+"_tasks", wk.Start+"_effort")
+		}
+	}
+
+	cw := csv.NewWriter(w)
+	_ = cw.Write(header)
+	for _, u := range res.Users {
+		row := []string{u.ID, u.Name, u.Email, strconv.Itoa(u.WeeklyCapacity), strconv.FormatBool(u.Overallocated)}
+		for _, s := range statuses {
+			row = appendLoad(row, u.ByStatus[s])
+		}
+		row = appendLoad(row, u.Unscheduled)
+		for _, wk := range u.Weeks {
+			row = appendLoad(row, wk.Load)
+		}
+		_ = cw.Write(row)
+	}
+	cw.Flush()
+}
+
+func appendLoad(row []string, l Load) []string {
+	return append(row, strconv.Itoa(l.Tasks), strconv.FormatFloat(l.Effort, 'f', 2, 64))
+}
@@ -0,0 +1,451 @@
+package worklog
+
+import (
+	taskDomain "ProjectManagementAPI/internal/domain/task"
+	worklogDomain "ProjectManagementAPI/internal/domain/worklog"
+	"ProjectManagementAPI/internal/lib/actor"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/api/validate"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	StartTimer(ctx context.Context, userID, taskID uuid.UUID, note string) (*worklogDomain.Worklog, error)
+	StopTimer(ctx context.Context, userID uuid.UUID, note *string) (*worklogDomain.Worklog, error)
+	RunningTimer(ctx context.Context, userID uuid.UUID) (*worklogDomain.Worklog, error)
+	AddManual(ctx context.Context, userID, taskID uuid.UUID, startedAt time.Time, duration time.Duration,
+		note string) (*worklogDomain.Worklog, error)
+	List(ctx context.Context, f worklogDomain.Filter) ([]worklogDomain.Worklog, error)
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+	Totals(ctx context.Context, groupBy string, f worklogDomain.Filter) ([]worklogDomain.Total, error)
+}
+
+type Handler struct {
+	log     *slog.Logger
+	service Service
+}
+
+func NewHandler(log *slog.Logger, service Service) *Handler {
+	return &Handler{
+		log:     log,
+		service: service,
+	}
+}
+
+type Worklog struct {
+	ID              string    `json:"id"`
+	UserID          string    `json:"user_id"`
+	TaskID          string    `json:"task_id"`
+	StartedAt       time.Time `json:"started_at"`
+	DurationSeconds *int64    `json:"duration_seconds"`
+	Running         bool      `json:"running"`
+	Note            string    `json:"note"`
+	Source          string    `json:"source"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type WorklogResponse struct {
+	resp.Response
+	Worklog Worklog `json:"worklog"`
+}
+
+type ListResponse struct {
+	resp.Response
+	Worklogs []Worklog `json:"worklogs"`
+}
+
+type TimerRequest struct {
+	Note *string `json:"note"`
+}
+
+func (h *Handler) StartTimer(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/worklog.StartTimer"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, _ := actor.FromContext(r.Context())
+
+	taskID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	var req TimerRequest
+	if r.ContentLength != 0 {
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("decode error", sl.Err(err))
+			resp.JSON(w, r, resp.Error("invalid request"))
+			return
+		}
+	}
+
+	var note string
+	if req.Note != nil {
+		note = *req.Note
+	}
+
+	wl, err := h.service.StartTimer(r.Context(), userID, taskID, note)
+	h.respond(w, r, log, wl, err, "failed to start timer")
+}
+
+func (h *Handler) StopTimer(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/worklog.StopTimer"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, _ := actor.FromContext(r.Context())
+
+	var req TimerRequest
+	if r.ContentLength != 0 {
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("decode error", sl.Err(err))
+			resp.JSON(w, r, resp.Error("invalid request"))
+			return
+		}
+	}
+
+	wl, err := h.service.StopTimer(r.Context(), userID, req.Note)
+	h.respond(w, r, log, wl, err, "failed to stop timer")
+}
+
+func (h *Handler) RunningTimer(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/worklog.RunningTimer"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, _ := actor.FromContext(r.Context())
+
+	wl, err := h.service.RunningTimer(r.Context(), userID)
+	h.respond(w, r, log, wl, err, "failed to get timer")
+}
+
+type ManualRequest struct {
+	StartedAt time.Time `json:"started_at" validate:"required"`
+	// Duration в формате Go: 1h30m, 45m
+	Duration string `json:"duration" validate:"required"`
+	Note     string `json:"note"`
+}
+
+func (h *Handler) AddManual(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/worklog.AddManual"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, _ := actor.FromContext(r.Context())
+
+	taskID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	var req ManualRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("decode error", sl.Err(err))
+		resp.JSON(w, r, resp.Error("invalid request"))
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		var validateErr validator.ValidationErrors
+		errors.As(err, &validateErr)
+		resp.JSON(w, r, resp.ValidationError(validateErr))
+		return
+	}
+
+	duration, err := time.ParseDuration(req.Duration)
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid duration, expected e.g. 1h30m"))
+		return
+	}
+
+	wl, err := h.service.AddManual(r.Context(), userID, taskID, req.StartedAt, duration, req.Note)
+	h.respond(w, r, log, wl, err, "failed to add worklog")
+}
+
+// TaskWorklogs возвращает записи по задаче, ListMine - записи текущего пользователя.
+func (h *Handler) TaskWorklogs(w http.ResponseWriter, r *http.Request) {
+	taskID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	h.list(w, r, "handlers/worklog.TaskWorklogs", worklogDomain.Filter{TaskID: &taskID})
+}
+
+func (h *Handler) ListMine(w http.ResponseWriter, r *http.Request) {
+	userID, _ := actor.FromContext(r.Context())
+
+	h.list(w, r, "handlers/worklog.ListMine", worklogDomain.Filter{UserID: &userID})
+}
+
+func (h *Handler) list(w http.ResponseWriter, r *http.Request, op string, f worklogDomain.Filter) {
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	if err := parseFilter(r, &f); err != nil {
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+
+	list, err := h.service.List(r.Context(), f)
+
+	if errors.Is(err, worklogDomain.ErrInvalidRange) {
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+
+	if err != nil {
+		log.Error("list failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to list worklogs"))
+		return
+	}
+
+	res := make([]Worklog, len(list))
+	for i := range list {
+		res[i] = toWorklog(&list[i])
+	}
+
+	render.JSON(w, r, ListResponse{
+		Response: resp.OK(),
+		Worklogs: res,
+	})
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/worklog.Delete"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	userID, _ := actor.FromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		resp.JSON(w, r, resp.Error("invalid id"))
+		return
+	}
+
+	err = h.service.Delete(r.Context(), userID, id)
+
+	if errors.Is(err, worklogDomain.ErrWorklogNotFound) {
+		resp.JSON(w, r, resp.Error("worklog not found"))
+		return
+	}
+
+	if err != nil {
+		log.Error("delete failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to delete worklog"))
+		return
+	}
+
+	render.JSON(w, r, resp.OK())
+}
+
+type Total struct {
+	ID              string  `json:"id,omitempty"`
+	Name            string  `json:"name"`
+	DurationSeconds int64   `json:"duration_seconds"`
+	Hours           float64 `json:"hours"`
+	Entries         int     `json:"entries"`
+}
+
+type TotalsResponse struct {
+	resp.Response
+	GroupBy string  `json:"group_by"`
+	Totals  []Total `json:"totals"`
+}
+
+// Totals отдаёт суммарное время за период; ?format=csv выгружает его в CSV.
+func (h *Handler) Totals(w http.ResponseWriter, r *http.Request) {
+	const op = "handlers/worklog.Totals"
+
+	log := h.log.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	q := r.URL.Query()
+	groupBy := q.Get("group_by")
+	if groupBy == "" {
+		groupBy = worklogDomain.GroupByTask
+	}
+
+	var f worklogDomain.Filter
+	if err := parseFilter(r, &f); err != nil {
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+	for name, dst := range map[string]**uuid.UUID{"user_id": &f.UserID, "task_id": &f.TaskID} {
+		if v := q.Get(name); v != "" {
+			id, err := uuid.Parse(v)
+			if err != nil {
+				resp.JSON(w, r, resp.Error("invalid "+name))
+				return
+			}
+			*dst = &id
+		}
+	}
+
+	totals, err := h.service.Totals(r.Context(), groupBy, f)
+
+	if errors.Is(err, worklogDomain.ErrInvalidGroupBy) || errors.Is(err, worklogDomain.ErrInvalidRange) {
+		resp.JSON(w, r, resp.Error(err.Error()))
+		return
+	}
+
+	if err != nil {
+		log.Error("totals failed", sl.Err(err))
+		resp.JSON(w, r, resp.Error("failed to aggregate worklogs"))
+		return
+	}
+
+	res := make([]Total, len(totals))
+	for i, t := range totals {
+		res[i] = Total{
+			Name:            t.Name,
+			DurationSeconds: int64(t.Duration.Seconds()),
+			Hours:           float64(int64(t.Duration.Hours()*100+0.5)) / 100,
+			Entries:         t.Entries,
+		}
+		if t.ID != nil {
+			res[i].ID = t.ID.String()
+		}
+	}
+
+	if q.Get("format") == "csv" {
+		writeCSV(w, groupBy, res)
+		return
+	}
+
+	render.JSON(w, r, TotalsResponse{
+		Response: resp.OK(),
+		GroupBy:  groupBy,
+		Totals:   res,
+	})
+}
+
+func writeCSV(w http.ResponseWriter, groupBy string, totals []Total) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="time-by-%s.csv"`, groupBy))
+
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{groupBy + "_id", "name", "duration_seconds", "hours", "entries"})
+	for _, t := range totals {
+		_ = cw.Write([]string{
+			t.ID,
+			t.Name,
+			strconv.FormatInt(t.DurationSeconds, 10),
+			strconv.FormatFloat(t.Hours, 'f', 2, 64),
+			strconv.Itoa(t.Entries),
+		})
+	}
+	cw.Flush()
+}
+
+func (h *Handler) respond(w http.ResponseWriter, r *http.Request, log *slog.Logger, wl *worklogDomain.Worklog,
+	err error, msg string) {
+	switch {
+	case errors.Is(err, taskDomain.ErrTaskNotFound):
+		resp.JSON(w, r, resp.Error("task not found"))
+	case errors.Is(err, worklogDomain.ErrTimerRunning), errors.Is(err, worklogDomain.ErrNoRunningTimer),
+		errors.Is(err, worklogDomain.ErrInvalidDuration), errors.Is(err, worklogDomain.ErrStartedInFuture):
+		resp.JSON(w, r, resp.Error(err.Error()))
+	case err != nil:
+		log.Error(msg, sl.Err(err))
+		resp.JSON(w, r, resp.Error(msg))
+	default:
+		render.JSON(w, r, WorklogResponse{
+			Response: resp.OK(),
+			Worklog:  toWorklog(wl),
+		})
+	}
+}
+
+// parseFilter читает from, to, limit и offset. from и to принимают RFC 3339
+// или дату YYYY-MM-DD; дата в to включается целиком.
+func parseFilter(r *http.Request, f *worklogDomain.Filter) error {
+	q := r.URL.Query()
+
+	for name, dst := range map[string]**time.Time{"from": &f.From, "to": &f.To} {
+		v := q.Get(name)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			if t, err = time.Parse(time.DateOnly, v); err != nil {
+				return fmt.Errorf("invalid %s, expected RFC 3339 or YYYY-MM-DD", name)
+			}
+			if name == "to" {
+				t = t.AddDate(0, 0, 1)
+			}
+		}
+		*dst = &t
+	}
+
+	var err error
+	if v := q.Get("limit"); v != "" {
+		if f.Limit, err = strconv.Atoi(v); err != nil || f.Limit < 0 {
+			return errors.New("invalid limit")
+		}
+	}
+	if v := q.Get("offset"); v != "" {
+		if f.Offset, err = strconv.Atoi(v); err != nil || f.Offset < 0 {
+			return errors.New("invalid offset")
+		}
+	}
+
+	return nil
+}
+
+func toWorklog(wl *worklogDomain.Worklog) Worklog {
+	res := Worklog{
+		ID:        wl.ID.String(),
+		UserID:    wl.UserID.String(),
+		TaskID:    wl.TaskID.String(),
+		StartedAt: wl.StartedAt,
+		Running:   wl.Duration == nil,
+		Note:      wl.Note,
+		Source:    wl.Source,
+		CreatedAt: wl.CreatedAt,
+	}
+	if wl.Duration != nil {
+		seconds := int64(wl.Duration.Seconds())
+		res.DurationSeconds = &seconds
+	}
+	return res
+}
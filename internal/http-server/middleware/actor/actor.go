@@ -0,0 +1,55 @@
+package actor
+
+import (
+	"ProjectManagementAPI/internal/lib/actor"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+const Header = "X-User-ID"
+
+// New кладёт в контекст пользователя из заголовка X-User-ID.
+// Запросы без заголовка проходят анонимно.
+func New() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			v := r.Header.Get(Header)
+			if v == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			id, err := uuid.Parse(v)
+			if err != nil {
+				render.Status(r, http.StatusBadRequest)
+				resp.JSON(w, r, resp.Error("invalid "+Header+" header"))
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(actor.WithID(r.Context(), id)))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// Required отклоняет запросы без пользователя в контексте.
+// Ставится после New на маршруты вида /me.
+func Required() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := actor.FromContext(r.Context()); !ok {
+				render.Status(r, http.StatusUnauthorized)
+				resp.JSON(w, r, resp.Error(Header+" header is required"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
@@ -0,0 +1,30 @@
+package admin
+
+import (
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+const Header = "X-Admin-Token"
+
+// New пропускает только запросы с токеном администратора из конфига.
+// Пустой токен закрывает admin-эндпоинты полностью.
+func New(token string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			got := r.Header.Get(Header)
+			if token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				render.Status(r, http.StatusForbidden)
+				resp.JSON(w, r, resp.Error("forbidden"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
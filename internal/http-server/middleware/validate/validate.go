@@ -0,0 +1,136 @@
+package validate
+
+import (
+	"ProjectManagementAPI/internal/http-server/openapi"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// MaxBodyBytes - наибольший размер проверяемого JSON-тела.
+const MaxBodyBytes = 1 << 20
+
+// New проверяет параметры и JSON-тело запроса по схеме операции из документа
+// до обработчика. Ставится после middleware.URLFormat: маршрут ищется по пути
+// без расширения. Запросы к неописанным маршрутам проходят без проверки.
+// Тело операции с JSON-телом проверяется без Content-Type или с
+// application/json, с другим Content-Type запрос отклоняется с 415:
+// обработчики декодируют тело как JSON независимо от заголовка. Загрузки
+// файлов читают обработчики со своим лимитом.
+func New(doc *openapi.Document) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			rctx := chi.RouteContext(r.Context())
+			if rctx == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			path := rctx.RoutePath
+			if path == "" {
+				path = r.URL.Path
+			}
+
+			// Find заполняет переданный контекст, рабочий контекст роутера не трогаем
+			found := chi.NewRouteContext()
+			op := doc.Lookup(r.Method, rctx.Routes.Find(found, r.Method, path))
+			if op == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			params := make(map[string]string, len(found.URLParams.Keys))
+			for i, key := range found.URLParams.Keys {
+				params[key] = found.URLParams.Values[i]
+			}
+
+			errs := doc.ValidateParams(op, r.URL.Query(), params)
+
+			schema := op.JSONBody()
+			if schema != nil && !isJSON(r) {
+				render.Status(r, http.StatusUnsupportedMediaType)
+				resp.JSON(w, r, resp.Error("Content-Type must be application/json"))
+				return
+			}
+
+			if schema != nil {
+				body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodyBytes))
+
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					render.Status(r, http.StatusRequestEntityTooLarge)
+					resp.JSON(w, r, resp.Error(fmt.Sprintf("request body is larger than %d bytes", tooLarge.Limit)))
+					return
+				}
+
+				if err != nil {
+					render.Status(r, http.StatusBadRequest)
+					resp.JSON(w, r, resp.Error("failed to read request"))
+					return
+				}
+				r.Body = io.NopCloser(bytes.NewReader(body))
+
+				switch {
+				case len(bytes.TrimSpace(body)) == 0:
+					if op.RequestBody.Required {
+						errs = append(errs, resp.FieldError{
+							In: openapi.InBody, Rule: "required", Message: "body is required",
+						})
+					}
+				default:
+					v, ok := decode(body)
+					if !ok {
+						render.Status(r, http.StatusBadRequest)
+						resp.JSON(w, r, resp.Error("invalid request"))
+						return
+					}
+					errs = append(errs, doc.ValidateBody(schema, v)...)
+				}
+			}
+
+			if len(errs) > 0 {
+				render.Status(r, http.StatusBadRequest)
+				resp.JSON(w, r, resp.Invalid(errs))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// isJSON - тело запроса объявлено как JSON или Content-Type не указан.
+func isJSON(r *http.Request) bool {
+	ct := r.Header.Get("Content-Type")
+	if ct == "" {
+		return true
+	}
+	media, _, err := mime.ParseMediaType(ct)
+	return err == nil && media == openapi.MediaJSON
+}
+
+// decode разбирает ровно одно JSON-значение; числа остаются json.Number,
+// чтобы отличать целые от дробных.
+func decode(body []byte) (any, bool) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, false
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, false
+	}
+	return v, true
+}
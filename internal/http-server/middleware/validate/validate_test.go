@@ -0,0 +1,146 @@
+package validate
+
+import (
+	"ProjectManagementAPI/internal/http-server/openapi"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type createRequest struct {
+	Title string `json:"title" validate:"required"`
+	Ref   string `json:"ref,omitempty" validate:"omitempty,uuid"`
+}
+
+type result struct {
+	Status string `json:"status"`
+	Fields []struct {
+		Field string `json:"field"`
+		Rule  string `json:"rule"`
+		Value any    `json:"value"`
+	} `json:"fields"`
+}
+
+// newRouter - JSON-операция и загрузка файла; обработчики возвращают
+// прочитанное тело, чтобы проверить, что middleware его не съело.
+func newRouter() http.Handler {
+	doc := openapi.Build([]openapi.Operation{
+		{Method: http.MethodPost, Path: "/items", Body: createRequest{}},
+		{Method: http.MethodPost, Path: "/items/import", Upload: []string{openapi.MediaJSON, "text/csv"}},
+	})
+
+	echo := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(w, r.Body)
+	}
+
+	router := chi.NewRouter()
+	router.Use(New(doc))
+	router.Post("/items", echo)
+	router.Post("/items/import", echo)
+	return router
+}
+
+func post(path, contentType, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	rec := httptest.NewRecorder()
+	newRouter().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestValidatesJSONBody(t *testing.T) {
+	rec := post("/items", "application/json; charset=utf-8", `{"title":"a","extra":1}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400: %s", rec.Code, rec.Body)
+	}
+
+	var res result
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Fields) != 1 || res.Fields[0].Rule != "unknown" || res.Fields[0].Field != "/extra" {
+		t.Errorf("fields = %+v", res.Fields)
+	}
+}
+
+func TestPassesValidBodyToHandler(t *testing.T) {
+	const body = `{"title":"a"}`
+	rec := post("/items", "application/json", body)
+	if rec.Code != http.StatusOK || rec.Body.String() != body {
+		t.Errorf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+}
+
+func TestSkipsUploads(t *testing.T) {
+	for _, tc := range []struct{ contentType, body string }{
+		{"application/json", `[{"title":"a"},{"title":"b"}]`},
+		{"text/csv", "title\na\n"},
+	} {
+		rec := post("/items/import", tc.contentType, tc.body)
+		if rec.Code != http.StatusOK || rec.Body.String() != tc.body {
+			t.Errorf("%s: status = %d, body = %s", tc.contentType, rec.Code, rec.Body)
+		}
+	}
+}
+
+// Обработчик декодирует тело как JSON при любом Content-Type, поэтому
+// другой тип не должен обходить проверку схемы.
+func TestRejectsNonJSONContentType(t *testing.T) {
+	for _, contentType := range []string{"text/plain", "application/x-www-form-urlencoded"} {
+		rec := post("/items", contentType, `{"title":"a","extra":1}`)
+		if rec.Code != http.StatusUnsupportedMediaType {
+			t.Errorf("%s: status = %d, want 415: %s", contentType, rec.Code, rec.Body)
+		}
+	}
+}
+
+func TestValidatesBodyWithoutContentType(t *testing.T) {
+	rec := post("/items", "", `{"title":"a","extra":1}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400: %s", rec.Code, rec.Body)
+	}
+
+	const body = `{"title":"a"}`
+	rec = post("/items", "", body)
+	if rec.Code != http.StatusOK || rec.Body.String() != body {
+		t.Errorf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+}
+
+func TestRejectsLargeBody(t *testing.T) {
+	body := `{"title":"` + strings.Repeat("a", MaxBodyBytes) + `"}`
+	rec := post("/items", "application/json", body)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want 413", rec.Code)
+	}
+}
+
+func TestDoesNotEchoBody(t *testing.T) {
+	rec := post("/items", "application/json", `[`+strings.Repeat(`{"title":"a"},`, 100)+`{"title":"a"}]`)
+
+	var res result
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Fields) != 1 || res.Fields[0].Rule != "type" || res.Fields[0].Value != nil {
+		t.Errorf("fields = %+v", res.Fields)
+	}
+
+	rec = post("/items", "application/json", `{"title":"a","ref":"`+strings.Repeat("x", 1000)+`"}`)
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Fields) != 1 || res.Fields[0].Field != "/ref" {
+		t.Fatalf("fields = %+v", res.Fields)
+	}
+	if v, _ := res.Fields[0].Value.(string); v != strings.Repeat("x", 64)+"…" {
+		t.Errorf("value = %q, want 64 characters", v)
+	}
+}
@@ -0,0 +1,61 @@
+package openapi
+
+import (
+	"errors"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Check сверяет маршруты роутера с документом и перечисляет расхождения:
+// маршруты без описания и описания без маршрута.
+func Check(routes chi.Routes, doc *Document) error {
+	routed := make(map[string]bool)
+	err := chi.Walk(routes, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		routed[method+" "+normalize(route)] = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	specified := make(map[string]bool)
+	for p, item := range doc.Paths {
+		for method := range item {
+			specified[strings.ToUpper(method)+" "+normalize(p)] = true
+		}
+	}
+
+	var problems []string
+	for r := range routed {
+		if !specified[r] {
+			problems = append(problems, "not in spec: "+r)
+		}
+	}
+	for s := range specified {
+		if !routed[s] {
+			problems = append(problems, "not routed: "+s)
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+
+	sort.Strings(problems)
+	return errors.New(strings.Join(problems, "; "))
+}
+
+// normalize убирает завершающий слэш вложенных роутеров и расширение,
+// которое снимает middleware.URLFormat (/calendar/{token}.ics).
+func normalize(route string) string {
+	if route != "/" {
+		route = strings.TrimSuffix(route, "/")
+	}
+	if ext := path.Ext(route); ext != "" && !strings.Contains(ext, "}") {
+		route = strings.TrimSuffix(route, ext)
+	}
+	return route
+}
@@ -0,0 +1,346 @@
+package openapi
+
+import (
+	auditHttp "ProjectManagementAPI/internal/http-server/handlers/audit"
+	calendarHttp "ProjectManagementAPI/internal/http-server/handlers/calendar"
+	commentHttp "ProjectManagementAPI/internal/http-server/handlers/comment"
+	digestHttp "ProjectManagementAPI/internal/http-server/handlers/digest"
+	filterHttp "ProjectManagementAPI/internal/http-server/handlers/filter"
+	graphqlHttp "ProjectManagementAPI/internal/http-server/handlers/graphql"
+	importerHttp "ProjectManagementAPI/internal/http-server/handlers/importer"
+	jobHttp "ProjectManagementAPI/internal/http-server/handlers/job"
+	milestoneHttp "ProjectManagementAPI/internal/http-server/handlers/milestone"
+	notificationHttp "ProjectManagementAPI/internal/http-server/handlers/notification"
+	projectHttp "ProjectManagementAPI/internal/http-server/handlers/project"
+	recurrenceHttp "ProjectManagementAPI/internal/http-server/handlers/recurrence"
+	searchHttp "ProjectManagementAPI/internal/http-server/handlers/search"
+	taskHttp "ProjectManagementAPI/internal/http-server/handlers/task"
+	transferHttp "ProjectManagementAPI/internal/http-server/handlers/transfer"
+	userHttp "ProjectManagementAPI/internal/http-server/handlers/user"
+	webhookHttp "ProjectManagementAPI/internal/http-server/handlers/webhook"
+	workloadHttp "ProjectManagementAPI/internal/http-server/handlers/workload"
+	worklogHttp "ProjectManagementAPI/internal/http-server/handlers/worklog"
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"net/http"
+)
+
+func param(name, typ, format, description string) Param {
+	return Param{Name: name, Description: description, Schema: &Schema{Type: typ, Format: format}}
+}
+
+func enum(name, description string, values ...string) Param {
+	p := param(name, "string", "", description)
+	for _, v := range values {
+		p.Schema.Enum = append(p.Schema.Enum, v)
+	}
+	return p
+}
+
+func page() []Param {
+	zero := 0.0
+	return []Param{
+		{Name: "limit", Schema: &Schema{Type: "integer", Minimum: &zero}},
+		{Name: "offset", Schema: &Schema{Type: "integer", Minimum: &zero}},
+	}
+}
+
+func period(description string) []Param {
+	return []Param{param("from", "string", "", description), param("to", "string", "", description)}
+}
+
+func int64Path(name string) Param {
+	return Param{Name: name, In: "path", Schema: &Schema{Type: "integer", Format: "int64"}}
+}
+
+func uuidPath(names ...string) []Param {
+	var params []Param
+	for _, name := range names {
+		params = append(params, Param{Name: name, In: "path", Schema: &Schema{Type: "string", Format: "uuid"}})
+	}
+	return params
+}
+
+func with(params ...[]Param) []Param {
+	var res []Param
+	for _, p := range params {
+		res = append(res, p...)
+	}
+	return res
+}
+
+const (
+	rangeDescription = "RFC 3339 или YYYY-MM-DD, дата в to включается целиком"
+	importFormat     = "csv, json или ndjson; без параметра - по Content-Type"
+)
+
+// Operations перечисляет все маршруты сервера. Check сверяет список с роутером.
+func Operations() []Operation {
+	ok := resp.Response{}
+	importMedia := []string{MediaCSV, MediaJSON, MediaNDJSON}
+	scope := []Param{enum("scope", "для повторяющихся задач: эта или эта и следующие", "this", "future")}
+	exportMedia := []string{MediaCSV, MediaNDJSON}
+	taskView := []Param{
+		enum("include", "встроить исполнителей в assignee_users", taskHttp.IncludeAssignees),
+		param("fields", "string", "", "поля задачи через запятую, например title,status; id отдаётся всегда"),
+	}
+
+	return []Operation{
+		// tasks
+		{Method: http.MethodPost, Path: "/tasks", Tag: "tasks", Summary: "Создать задачу",
+			Body: taskHttp.CreateRequest{}, Response: taskHttp.CreateResponse{}},
+		{Method: http.MethodGet, Path: "/tasks", Tag: "tasks", Summary: "Список задач по запросу на языке фильтров",
+			Params:   with([]Param{param("q", "string", "", "запрос, например status:todo assignee:me")}, page(), taskView),
+			Response: taskHttp.ListResponse{}},
+		{Method: http.MethodPost, Path: "/tasks/bulk", Tag: "tasks", Summary: "Массовые операции над задачами",
+			Body: taskHttp.BulkRequest{}, Response: taskHttp.BulkResponse{}},
+		{Method: http.MethodGet, Path: "/tasks/export", Tag: "transfer", Summary: "Выгрузка задач",
+			Params: []Param{
+				enum("format", "", "csv", "json", "ndjson"),
+				param("q", "string", "", "запрос на языке фильтров"),
+			},
+			Response: []transferHttp.Task{}, Produces: exportMedia},
+		{Method: http.MethodPost, Path: "/tasks/import", Tag: "transfer", Summary: "Загрузка задач из файла",
+			Params: []Param{
+				enum("format", importFormat, "csv", "json", "ndjson"),
+				param("dry_run", "boolean", "", ""),
+				param("mapping", "string", "", `JSON: колонка файла -> поле, {"Summary":"title"}`),
+			},
+			Upload: importMedia, Response: transferHttp.ImportResponse{}},
+		{Method: http.MethodPatch, Path: "/tasks/{id}", Tag: "tasks", Summary: "Изменить задачу",
+			Params: with(uuidPath("id"), scope), Body: taskHttp.UpdateRequest{}, Response: taskHttp.GetByIDResponse{}},
+		{Method: http.MethodDelete, Path: "/tasks/{id}", Tag: "tasks", Summary: "Удалить задачу",
+			Params: with(uuidPath("id"), scope), Response: ok},
+		{Method: http.MethodGet, Path: "/tasks/{id}", Tag: "tasks", Summary: "Получить задачу",
+			Params: with(uuidPath("id"), taskView), Response: taskHttp.GetByIDResponse{}},
+		{Method: http.MethodGet, Path: "/tasks/{id}/history", Tag: "tasks", Summary: "История изменений задачи",
+			Params: with(uuidPath("id"), page()), Response: auditHttp.ListResponse{}},
+		{Method: http.MethodPost, Path: "/tasks/{id}/assignees", Tag: "tasks", Summary: "Добавить исполнителей",
+			Params: uuidPath("id"), Body: taskHttp.AddAssigneesRequest{}, Response: taskHttp.GetByIDResponse{}},
+		{Method: http.MethodDelete, Path: "/tasks/{id}/assignees/{userID}", Tag: "tasks", Summary: "Снять исполнителя",
+			Params: uuidPath("id", "userID"), Response: taskHttp.GetByIDResponse{}},
+		{Method: http.MethodGet, Path: "/tasks/{id}/recurrence", Tag: "recurrence", Summary: "Правило повторения",
+			Params: uuidPath("id"), Response: recurrenceHttp.Response{}},
+		{Method: http.MethodPut, Path: "/tasks/{id}/recurrence", Tag: "recurrence", Summary: "Задать правило повторения",
+			Params: uuidPath("id"), Body: recurrenceHttp.SetRequest{}, Response: recurrenceHttp.Response{}},
+		{Method: http.MethodDelete, Path: "/tasks/{id}/recurrence", Tag: "recurrence", Summary: "Остановить повторение",
+			Params: uuidPath("id"), Response: ok},
+		{Method: http.MethodGet, Path: "/tasks/{id}/worklogs", Tag: "worklogs", Summary: "Учёт времени по задаче",
+			Params: with(uuidPath("id"), period(rangeDescription), page()), Response: worklogHttp.ListResponse{}},
+		{Method: http.MethodGet, Path: "/tasks/{id}/comments", Tag: "comments", Summary: "Комментарии задачи",
+			Params: with(uuidPath("id"), page()), Response: commentHttp.ListResponse{}},
+		{Method: http.MethodPost, Path: "/tasks/{id}/comments", Tag: "comments", Summary: "Добавить комментарий",
+			Auth: AuthActor, Params: uuidPath("id"), Body: commentHttp.BodyRequest{}, Response: commentHttp.CommentResponse{}},
+		{Method: http.MethodPatch, Path: "/tasks/{id}/comments/{commentID}", Tag: "comments", Summary: "Изменить комментарий",
+			Auth: AuthActor, Params: uuidPath("id", "commentID"), Body: commentHttp.BodyRequest{},
+			Response: commentHttp.CommentResponse{}},
+		{Method: http.MethodDelete, Path: "/tasks/{id}/comments/{commentID}", Tag: "comments", Summary: "Удалить комментарий",
+			Auth: AuthActor, Params: uuidPath("id", "commentID"), Response: ok},
+		{Method: http.MethodPost, Path: "/tasks/{id}/worklogs", Tag: "worklogs", Summary: "Добавить запись времени вручную",
+			Auth: AuthActor, Params: uuidPath("id"), Body: worklogHttp.ManualRequest{}, Response: worklogHttp.WorklogResponse{}},
+		{Method: http.MethodPost, Path: "/tasks/{id}/timer/start", Tag: "worklogs", Summary: "Запустить таймер",
+			Auth: AuthActor, Params: uuidPath("id"), Body: worklogHttp.TimerRequest{}, OptionalBody: true,
+			Response: worklogHttp.WorklogResponse{}},
+
+		// milestones
+		{Method: http.MethodPost, Path: "/milestones", Tag: "milestones", Summary: "Создать веху",
+			Body: milestoneHttp.CreateRequest{}, Response: milestoneHttp.CreateResponse{}},
+		{Method: http.MethodDelete, Path: "/milestones/{id}", Tag: "milestones", Summary: "Удалить веху",
+			Params: uuidPath("id"), Response: ok},
+		{Method: http.MethodGet, Path: "/milestones/{id}", Tag: "milestones", Summary: "Получить веху",
+			Params: uuidPath("id"), Response: milestoneHttp.GetByIDResponse{}},
+		{Method: http.MethodGet, Path: "/milestones/{id}/burndown", Tag: "milestones", Summary: "Burndown вехи",
+			Params: uuidPath("id"), Response: milestoneHttp.BurndownResponse{}},
+
+		// users
+		{Method: http.MethodPost, Path: "/users", Tag: "users", Summary: "Создать пользователя",
+			Body: userHttp.CreateRequest{}, Response: userHttp.CreateResponse{}},
+		{Method: http.MethodGet, Path: "/users/export", Tag: "transfer", Summary: "Выгрузка пользователей",
+			Params:   []Param{enum("format", "", "csv", "json", "ndjson")},
+			Response: []transferHttp.User{}, Produces: exportMedia},
+		{Method: http.MethodPost, Path: "/users/import", Tag: "transfer", Summary: "Загрузка пользователей из файла",
+			Params: []Param{
+				enum("format", importFormat, "csv", "json", "ndjson"),
+				param("dry_run", "boolean", "", ""),
+				param("mapping", "string", "", `JSON: колонка файла -> поле`),
+			},
+			Upload: importMedia, Response: transferHttp.ImportResponse{}},
+		{Method: http.MethodDelete, Path: "/users/{id}", Tag: "users", Summary: "Удалить пользователя",
+			Params: uuidPath("id"), Response: ok},
+		{Method: http.MethodGet, Path: "/users/{id}", Tag: "users", Summary: "Получить пользователя",
+			Params: uuidPath("id"), Response: userHttp.GetByIDResponse{}},
+		{Method: http.MethodPut, Path: "/users/{id}/capacity", Tag: "reports", Summary: "Недельная ёмкость пользователя",
+			Params: uuidPath("id"), Body: workloadHttp.CapacityRequest{}, Response: workloadHttp.CapacityResponse{}},
+		{Method: http.MethodGet, Path: "/users/{id}/tasks", Tag: "users", Summary: "Задачи пользователя как исполнителя",
+			Params: with(uuidPath("id"), []Param{param("status", "string", "", "статусы через запятую: todo,in_progress")},
+				page(), taskView),
+			Response: taskHttp.ListResponse{}},
+
+		// projects
+		{Method: http.MethodPost, Path: "/projects", Tag: "projects", Summary: "Создать проект",
+			Body: projectHttp.CreateRequest{}, Response: projectHttp.CreateResponse{}},
+		{Method: http.MethodDelete, Path: "/projects/{id}", Tag: "projects", Summary: "Удалить проект",
+			Params: uuidPath("id"), Response: ok},
+		{Method: http.MethodGet, Path: "/projects/{id}", Tag: "projects", Summary: "Получить проект",
+			Params: uuidPath("id"), Response: projectHttp.GetByIDResponse{}},
+
+		// events
+		{Method: http.MethodGet, Path: "/events/stream", Tag: "events", Summary: "Поток событий (Server-Sent Events)",
+			Params: []Param{
+				param("project_id", "string", "uuid", ""),
+				param("assignee", "string", "", "UUID или me"),
+				param("task_id", "string", "uuid", ""),
+				param("last_event_id", "string", "", "то же, что заголовок Last-Event-ID"),
+			},
+			Produces: []string{"text/event-stream"}},
+		{Method: http.MethodGet, Path: "/events/ws", Tag: "events", Summary: "Поток событий (WebSocket)",
+			Params: []Param{
+				param("project_id", "string", "uuid", ""),
+				param("assignee", "string", "", "UUID или me"),
+				param("task_id", "string", "uuid", ""),
+			}},
+
+		// me
+		{Method: http.MethodGet, Path: "/me/notifications", Tag: "notifications", Summary: "Уведомления",
+			Auth: AuthActor, Params: with([]Param{param("unread", "boolean", "", "только непрочитанные")}, page()),
+			Response: notificationHttp.ListResponse{}},
+		{Method: http.MethodPost, Path: "/me/notifications/read-all", Tag: "notifications", Summary: "Прочитать все",
+			Auth: AuthActor, Response: ok},
+		{Method: http.MethodPost, Path: "/me/notifications/{id}/read", Tag: "notifications", Summary: "Отметить прочитанным",
+			Auth: AuthActor, Params: uuidPath("id"), Response: ok},
+		{Method: http.MethodPost, Path: "/me/notifications/{id}/unread", Tag: "notifications", Summary: "Отметить непрочитанным",
+			Auth: AuthActor, Params: uuidPath("id"), Response: ok},
+		{Method: http.MethodGet, Path: "/me/notification-preferences", Tag: "notifications", Summary: "Настройки уведомлений",
+			Auth: AuthActor, Response: notificationHttp.PreferencesResponse{}},
+		{Method: http.MethodPut, Path: "/me/notification-preferences", Tag: "notifications", Summary: "Сохранить настройки уведомлений",
+			Auth: AuthActor, Body: notificationHttp.PreferencesRequest{}, Response: notificationHttp.PreferencesResponse{}},
+		{Method: http.MethodGet, Path: "/me/digest-settings", Tag: "digests", Summary: "Настройки дайджеста",
+			Auth: AuthActor, Response: digestHttp.SettingsResponse{}},
+		{Method: http.MethodPut, Path: "/me/digest-settings", Tag: "digests", Summary: "Сохранить настройки дайджеста",
+			Auth: AuthActor, Body: digestHttp.SettingsRequest{}, Response: digestHttp.SettingsResponse{}},
+		{Method: http.MethodGet, Path: "/me/digests", Tag: "digests", Summary: "Отправленные дайджесты",
+			Auth: AuthActor, Params: page(), Response: digestHttp.RunsResponse{}},
+		{Method: http.MethodGet, Path: "/me/timer", Tag: "worklogs", Summary: "Запущенный таймер",
+			Auth: AuthActor, Response: worklogHttp.WorklogResponse{}},
+		{Method: http.MethodPost, Path: "/me/timer/stop", Tag: "worklogs", Summary: "Остановить таймер",
+			Auth: AuthActor, Body: worklogHttp.TimerRequest{}, OptionalBody: true, Response: worklogHttp.WorklogResponse{}},
+		{Method: http.MethodGet, Path: "/me/worklogs", Tag: "worklogs", Summary: "Мои записи времени",
+			Auth: AuthActor, Params: with(period(rangeDescription), page()), Response: worklogHttp.ListResponse{}},
+		{Method: http.MethodDelete, Path: "/me/worklogs/{id}", Tag: "worklogs", Summary: "Удалить запись времени",
+			Auth: AuthActor, Params: uuidPath("id"), Response: ok},
+		{Method: http.MethodGet, Path: "/me/calendar-token", Tag: "calendar", Summary: "Дата выпуска токена календаря",
+			Auth: AuthActor, Response: calendarHttp.TokenResponse{}},
+		{Method: http.MethodPost, Path: "/me/calendar-token", Tag: "calendar", Summary: "Выпустить токен календаря",
+			Auth: AuthActor, Response: calendarHttp.TokenResponse{}},
+		{Method: http.MethodDelete, Path: "/me/calendar-token", Tag: "calendar", Summary: "Отозвать токен календаря",
+			Auth: AuthActor, Response: ok},
+
+		// calendar, search
+		{Method: http.MethodGet, Path: "/calendar/{token}.ics", Tag: "calendar", Summary: "Фид iCalendar",
+			Params: []Param{
+				enum("type", "", "event", "todo"),
+				param("project", "string", "", "UUID проектов через запятую"),
+				param("status", "string", "", "статусы через запятую"),
+				param("tz", "string", "", "часовой пояс IANA"),
+			},
+			Produces: []string{"text/calendar"}},
+		{Method: http.MethodGet, Path: "/search", Tag: "search", Summary: "Полнотекстовый поиск",
+			Params: with([]Param{
+				{Name: "q", Required: true, Schema: &Schema{Type: "string"}},
+				enum("status", "", "todo", "in_progress", "done"),
+				param("project_id", "string", "uuid", ""),
+				param("milestone_id", "string", "uuid", ""),
+				param("assignee", "string", "", "UUID или me"),
+			}, page()),
+			Response: searchHttp.SearchResponse{}},
+
+		// filters
+		{Method: http.MethodPost, Path: "/filters", Tag: "filters", Summary: "Сохранить фильтр",
+			Auth: AuthActor, Body: filterHttp.SaveRequest{}, Response: filterHttp.FilterResponse{}},
+		{Method: http.MethodGet, Path: "/filters", Tag: "filters", Summary: "Мои и общие фильтры",
+			Auth: AuthActor, Response: filterHttp.ListResponse{}},
+		{Method: http.MethodGet, Path: "/filters/{id}", Tag: "filters", Summary: "Получить фильтр",
+			Auth: AuthActor, Params: uuidPath("id"), Response: filterHttp.FilterResponse{}},
+		{Method: http.MethodPut, Path: "/filters/{id}", Tag: "filters", Summary: "Изменить фильтр",
+			Auth: AuthActor, Params: uuidPath("id"), Body: filterHttp.SaveRequest{}, Response: filterHttp.FilterResponse{}},
+		{Method: http.MethodDelete, Path: "/filters/{id}", Tag: "filters", Summary: "Удалить фильтр",
+			Auth: AuthActor, Params: uuidPath("id"), Response: ok},
+		{Method: http.MethodGet, Path: "/filters/{id}/tasks", Tag: "filters", Summary: "Задачи по фильтру",
+			Auth: AuthActor, Params: with(uuidPath("id"), page(), taskView), Response: taskHttp.ListResponse{}},
+
+		// imports, reports
+		{Method: http.MethodPost, Path: "/imports", Tag: "imports", Summary: "Импорт из Jira, Trello или GitHub",
+			Params: []Param{
+				{Name: "source", Required: true, Schema: enum("", "", "jira_xml", "jira_csv", "trello", "github").Schema},
+				param("dry_run", "boolean", "", ""),
+				param("default_assignee", "string", "", "UUID или email"),
+				param("project", "string", "", "UUID или название"),
+				param("status_map", "string", "", `JSON, {"Ready for QA":"in_progress"}`),
+				param("user_map", "string", "", `JSON, {"jdoe":"john@example.com"}`),
+			},
+			Upload: []string{"application/xml", MediaCSV, MediaJSON}, Response: importerHttp.ImportResponse{}},
+		{Method: http.MethodGet, Path: "/imports/{id}", Tag: "imports", Summary: "Статус и отчёт импорта",
+			Params: uuidPath("id"), Response: importerHttp.ImportResponse{}},
+		{Method: http.MethodGet, Path: "/reports/time", Tag: "worklogs", Summary: "Отчёт по времени",
+			Params: with([]Param{
+				enum("group_by", "", "task", "user", "project"),
+				param("user_id", "string", "uuid", ""),
+				param("task_id", "string", "uuid", ""),
+				enum("format", "", "json", "csv"),
+			}, period(rangeDescription)),
+			Response: worklogHttp.TotalsResponse{}, Produces: []string{MediaCSV}},
+		{Method: http.MethodGet, Path: "/reports/workload", Tag: "reports", Summary: "Нагрузка пользователей по статусам и неделям",
+			Params: []Param{
+				param("from", "string", "date", "YYYY-MM-DD, по умолчанию - текущая неделя"),
+				param("to", "string", "date", "YYYY-MM-DD включительно, по умолчанию - 4 недели от from"),
+				enum("format", "", "json", "csv"),
+			},
+			Response: workloadHttp.ReportResponse{}, Produces: []string{MediaCSV}},
+
+		// admin
+		{Method: http.MethodGet, Path: "/admin/audit", Tag: "admin", Summary: "Журнал аудита",
+			Auth: AuthAdmin, Params: with([]Param{
+				param("entity_type", "string", "", ""),
+				param("entity_id", "string", "uuid", ""),
+				param("actor_id", "string", "uuid", ""),
+				param("action", "string", "", ""),
+				param("from", "string", "date-time", ""),
+				param("to", "string", "date-time", ""),
+			}, page()),
+			Response: auditHttp.ListResponse{}},
+		{Method: http.MethodGet, Path: "/admin/jobs", Tag: "admin", Summary: "Фоновые задания",
+			Auth: AuthAdmin, Params: with([]Param{
+				enum("status", "", "pending", "running", "succeeded", "failed", "cancelled"),
+				param("kind", "string", "", ""),
+			}, page()),
+			Response: jobHttp.ListResponse{}},
+		{Method: http.MethodGet, Path: "/admin/jobs/{id}", Tag: "admin", Summary: "Получить задание",
+			Auth: AuthAdmin, Params: []Param{int64Path("id")}, Response: jobHttp.JobResponse{}},
+		{Method: http.MethodPost, Path: "/admin/jobs/{id}/retry", Tag: "admin", Summary: "Повторить задание",
+			Auth: AuthAdmin, Params: []Param{int64Path("id")}, Response: jobHttp.JobResponse{}},
+		{Method: http.MethodPost, Path: "/admin/jobs/{id}/cancel", Tag: "admin", Summary: "Отменить задание",
+			Auth: AuthAdmin, Params: []Param{int64Path("id")}, Response: jobHttp.JobResponse{}},
+
+		// webhooks
+		{Method: http.MethodPost, Path: "/webhooks", Tag: "webhooks", Summary: "Подписаться на события",
+			Auth: AuthAdmin, Body: webhookHttp.CreateRequest{}, Response: webhookHttp.CreateResponse{}},
+		{Method: http.MethodGet, Path: "/webhooks/{id}", Tag: "webhooks", Summary: "Получить подписку",
+			Auth: AuthAdmin, Params: uuidPath("id"), Response: webhookHttp.GetByIDResponse{}},
+		{Method: http.MethodDelete, Path: "/webhooks/{id}", Tag: "webhooks", Summary: "Удалить подписку",
+			Auth: AuthAdmin, Params: uuidPath("id"), Response: ok},
+		{Method: http.MethodGet, Path: "/webhooks/{id}/deliveries", Tag: "webhooks", Summary: "Доставки подписки",
+			Auth: AuthAdmin, Params: with(uuidPath("id"), page()), Response: webhookHttp.DeliveriesResponse{}},
+		{Method: http.MethodPost, Path: "/webhooks/{id}/deliveries/{deliveryID}/redeliver", Tag: "webhooks",
+			Summary: "Повторить доставку", Auth: AuthAdmin, Params: with(uuidPath("id"), []Param{int64Path("deliveryID")}),
+			Response: ok},
+
+		// graphql
+		{Method: http.MethodPost, Path: "/graphql", Tag: "graphql",
+			Summary: "Запрос GraphQL: задачи, пользователи, исполнители", Body: graphqlHttp.Request{},
+			Response: map[string]any{}},
+
+		// docs
+		{Method: http.MethodGet, Path: "/openapi.json", Tag: "docs", Summary: "Этот документ",
+			Response: map[string]any{}},
+		{Method: http.MethodGet, Path: "/docs", Tag: "docs", Summary: "Swagger UI", Produces: []string{"text/html"}},
+	}
+}
@@ -0,0 +1,292 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Schema - подмножество JSON Schema 2020-12, которое используется в OpenAPI 3.1.
+type Schema struct {
+	Ref         string
+	Type        string
+	Nullable    bool
+	Format      string
+	Description string
+	Enum        []any
+	AnyOf       []*Schema
+	Properties  map[string]*Schema
+	Required    []string
+	// Closed запрещает поля не из Properties, AdditionalProperties - схема значений словаря
+	Closed               bool
+	AdditionalProperties *Schema
+	Items                *Schema
+	Minimum              *float64
+	Maximum              *float64
+	MinLength            *int
+	MaxLength            *int
+	MinItems             *int
+	MaxItems             *int
+}
+
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	m := make(map[string]any)
+	switch {
+	case s.Ref != "" && s.Nullable:
+		m["oneOf"] = []any{map[string]string{"$ref": s.Ref}, map[string]string{"type": "null"}}
+	case s.Ref != "":
+		m["$ref"] = s.Ref
+	}
+	switch {
+	case s.Type != "" && s.Nullable:
+		m["type"] = []string{s.Type, "null"}
+	case s.Type != "":
+		m["type"] = s.Type
+	}
+	if s.Format != "" {
+		m["format"] = s.Format
+	}
+	if s.Description != "" {
+		m["description"] = s.Description
+	}
+	if len(s.Enum) > 0 {
+		m["enum"] = s.Enum
+	}
+	if len(s.AnyOf) > 0 {
+		m["anyOf"] = s.AnyOf
+	}
+	if s.Properties != nil {
+		m["properties"] = s.Properties
+	}
+	if len(s.Required) > 0 {
+		m["required"] = s.Required
+	}
+	if s.Closed {
+		m["additionalProperties"] = false
+	} else if s.AdditionalProperties != nil {
+		m["additionalProperties"] = s.AdditionalProperties
+	}
+	if s.Items != nil {
+		m["items"] = s.Items
+	}
+	for name, v := range map[string]*float64{"minimum": s.Minimum, "maximum": s.Maximum} {
+		if v != nil {
+			m[name] = *v
+		}
+	}
+	for name, v := range map[string]*int{
+		"minLength": s.MinLength, "maxLength": s.MaxLength, "minItems": s.MinItems, "maxItems": s.MaxItems,
+	} {
+		if v != nil {
+			m[name] = *v
+		}
+	}
+	return json.Marshal(m)
+}
+
+var (
+	timeType    = reflect.TypeFor[time.Time]()
+	uuidType    = reflect.TypeFor[uuid.UUID]()
+	rawJSONType = reflect.TypeFor[json.RawMessage]()
+)
+
+// generator строит схемы по типам Go: именованные структуры попадают
+// в components.schemas под именем "пакет.Тип" и подставляются через $ref.
+type generator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newGenerator() *generator {
+	return &generator{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+func (g *generator) schema(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case t == rawJSONType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := g.schema(t.Elem())
+		s.Nullable = true
+		return s
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int32, reflect.Uint, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + g.component(t)}
+	}
+
+	return &Schema{}
+}
+
+func (g *generator) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	path := strings.Split(t.PkgPath(), "/")
+	name := path[len(path)-1] + "." + t.Name()
+	// Одноимённые пакеты (domain/importer и handlers/importer) различаются родителем
+	if _, taken := g.schemas[name]; taken && len(path) > 1 {
+		name = path[len(path)-2] + "." + name
+	}
+
+	g.names[t] = name
+	g.schemas[name] = &Schema{}
+	*g.schemas[name] = *g.object(t)
+	return name
+}
+
+// object описывает поля структуры по тегам json; встроенные структуры без
+// имени в json (resp.Response) раскрываются. Все объекты закрыты.
+func (g *generator) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema), Closed: true}
+	g.fields(s, t)
+	return s
+}
+
+func (g *generator) fields(s *Schema, t reflect.Type) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.fields(s, ft)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		fs := g.schema(f.Type)
+		if applyValidate(fs, f.Tag.Get("validate")) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = fs
+	}
+}
+
+// applyValidate переносит правила validator в схему и сообщает, обязательно
+// ли поле. Правила после dive относятся к элементам массива.
+func applyValidate(s *Schema, tag string) (required bool) {
+	if tag == "" {
+		return false
+	}
+
+	omitempty := false
+	defer func() {
+		// omitempty у строки пропускает правила для "": пустая строка - отдельный вариант
+		if omitempty && s.Type == "string" && !s.Nullable &&
+			(s.Format != "" || len(s.Enum) > 0 || s.MinLength != nil) {
+			rest, empty := *s, 0
+			*s = Schema{AnyOf: []*Schema{{Type: "string", MaxLength: &empty}, &rest}}
+		}
+	}()
+
+	rules := strings.Split(tag, ",")
+	for i, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			if s.Items != nil {
+				applyValidate(s.Items, strings.Join(rules[i+1:], ","))
+			}
+			return required
+		case "omitempty":
+			omitempty = true
+		case "required":
+			required = true
+		case "oneof":
+			for _, v := range strings.Fields(param) {
+				if s.Type == "integer" {
+					n, _ := strconv.Atoi(v)
+					s.Enum = append(s.Enum, n)
+				} else {
+					s.Enum = append(s.Enum, v)
+				}
+			}
+		case "min", "max":
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				continue
+			}
+			setBound(s, name == "min", n)
+		case "email":
+			s.Format = "email"
+		case "uuid", "uuid4":
+			s.Format = "uuid"
+		case "url":
+			s.Format = "uri"
+		case "datetime":
+			if param == time.DateOnly {
+				s.Format = "date"
+			}
+		}
+	}
+	return required
+}
+
+func setBound(s *Schema, isMin bool, n int) {
+	switch s.Type {
+	case "integer", "number":
+		v := float64(n)
+		if isMin {
+			s.Minimum = &v
+		} else {
+			s.Maximum = &v
+		}
+	case "string":
+		if isMin {
+			s.MinLength = &n
+		} else {
+			s.MaxLength = &n
+		}
+	case "array":
+		if isMin {
+			s.MinItems = &n
+		} else {
+			s.MaxItems = &n
+		}
+	}
+}
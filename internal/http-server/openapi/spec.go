@@ -0,0 +1,237 @@
+// Package openapi описывает API в формате OpenAPI 3.1. Схемы тел строятся
+// по типам запросов и ответов обработчиков, маршруты перечислены в operations.go
+// и сверяются с роутером через Check.
+package openapi
+
+import (
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Схемы авторизации операций.
+const (
+	AuthActor = "actor"
+	AuthAdmin = "admin"
+)
+
+const (
+	MediaJSON   = "application/json"
+	MediaCSV    = "text/csv"
+	MediaNDJSON = "application/x-ndjson"
+)
+
+// Operation описывает маршрут. Body и Response - значения типов обработчика,
+// по ним строятся схемы; Upload и Produces - медиатипы тел, отличных от JSON.
+// OptionalBody - тело можно не передавать.
+type Operation struct {
+	Method       string
+	Path         string
+	Tag          string
+	Summary      string
+	Auth         string
+	Params       []Param
+	Body         any
+	OptionalBody bool
+	Upload       []string
+	Response     any
+	Produces     []string
+}
+
+// Param - параметр запроса. In по умолчанию query; параметры пути, не
+// перечисленные явно, добавляются строками.
+type Param struct {
+	Name        string
+	In          string
+	Description string
+	Required    bool
+	Schema      *Schema
+}
+
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Tags       []Tag                           `json:"tags,omitempty"`
+	Paths      map[string]map[string]*OpObject `json:"paths"`
+	Components Components                      `json:"components"`
+
+	// index: "METHOD /path" без расширения -> операция, для Lookup
+	index map[string]*OpObject
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type Tag struct {
+	Name string `json:"name"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	In          string `json:"in"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type OpObject struct {
+	OperationID string                `json:"operationId"`
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Parameters  []ParamObject         `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+
+	// upload - тело - загружаемый файл, а не JSON по схеме
+	upload bool
+}
+
+type ParamObject struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+const description = `Ошибки возвращаются со статусом 200 и телом {"status": "Error", "error": "..."}, ` +
+	`успешные ответы содержат "status": "OK". Запрос, не прошедший проверку по этому документу, ` +
+	`отклоняется со статусом 400 и списком ошибок полей в "fields". Пользователь передаётся ` +
+	`заголовком X-User-ID, администратор - X-Admin-Token.`
+
+var pathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+// Build собирает документ из операций.
+func Build(ops []Operation) *Document {
+	g := newGenerator()
+	doc := &Document{
+		OpenAPI: "3.1.0",
+		Info: Info{
+			Title:       "Project Management API",
+			Version:     "1.0.0",
+			Description: description,
+		},
+		Paths: make(map[string]map[string]*OpObject),
+		index: make(map[string]*OpObject),
+		Components: Components{
+			Schemas: g.schemas,
+			SecuritySchemes: map[string]SecurityScheme{
+				AuthActor: {Type: "apiKey", In: "header", Name: "X-User-ID", Description: "UUID пользователя"},
+				AuthAdmin: {Type: "apiKey", In: "header", Name: "X-Admin-Token"},
+			},
+		},
+	}
+
+	for _, op := range ops {
+		if doc.Paths[op.Path] == nil {
+			doc.Paths[op.Path] = make(map[string]*OpObject)
+		}
+		o := build(g, op)
+		doc.Paths[op.Path][strings.ToLower(op.Method)] = o
+		doc.index[op.Method+" "+normalize(op.Path)] = o
+		if !slices.Contains(doc.Tags, Tag{Name: op.Tag}) {
+			doc.Tags = append(doc.Tags, Tag{Name: op.Tag})
+		}
+	}
+
+	return doc
+}
+
+// Lookup находит операцию по методу и шаблону маршрута chi.
+func (d *Document) Lookup(method, pattern string) *OpObject {
+	return d.index[method+" "+normalize(pattern)]
+}
+
+func build(g *generator, op Operation) *OpObject {
+	o := &OpObject{
+		OperationID: operationID(op.Method, op.Path),
+		Tags:        []string{op.Tag},
+		Summary:     op.Summary,
+		Responses:   make(map[string]Response),
+	}
+	if op.Auth != "" {
+		o.Security = []map[string][]string{{op.Auth: {}}}
+	}
+
+	params := op.Params
+	for _, m := range pathParam.FindAllStringSubmatch(op.Path, -1) {
+		if !slices.ContainsFunc(params, func(p Param) bool { return p.In == "path" && p.Name == m[1] }) {
+			params = append(params, Param{Name: m[1], In: "path", Schema: &Schema{Type: "string"}})
+		}
+	}
+	for _, p := range params {
+		in := p.In
+		if in == "" {
+			in = "query"
+		}
+		o.Parameters = append(o.Parameters, ParamObject{
+			Name:        p.Name,
+			In:          in,
+			Description: p.Description,
+			Required:    p.Required || in == "path",
+			Schema:      p.Schema,
+		})
+	}
+
+	switch {
+	case op.Body != nil:
+		o.RequestBody = &RequestBody{
+			Required: !op.OptionalBody,
+			Content:  map[string]MediaType{MediaJSON: {Schema: g.schema(reflect.TypeOf(op.Body))}},
+		}
+	case len(op.Upload) > 0:
+		o.upload = true
+		o.RequestBody = &RequestBody{Required: true, Content: make(map[string]MediaType)}
+		for _, media := range op.Upload {
+			o.RequestBody.Content[media] = MediaType{Schema: &Schema{Type: "string"}}
+		}
+	}
+
+	ok := Response{Description: "OK", Content: make(map[string]MediaType)}
+	if op.Response != nil {
+		ok.Content[MediaJSON] = MediaType{Schema: g.schema(reflect.TypeOf(op.Response))}
+	}
+	for _, media := range op.Produces {
+		if _, set := ok.Content[media]; !set {
+			ok.Content[media] = MediaType{Schema: &Schema{Type: "string"}}
+		}
+	}
+	o.Responses["200"] = ok
+
+	return o
+}
+
+// operationID - метод и сегменты пути: GET /tasks/{id}/comments -> getTasksIdComments.
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, seg := range strings.FieldsFunc(path, func(r rune) bool {
+		return r == '/' || r == '{' || r == '}' || r == '-' || r == '.' || r == '_'
+	}) {
+		b.WriteString(strings.ToUpper(seg[:1]) + seg[1:])
+	}
+	return b.String()
+}
@@ -0,0 +1,269 @@
+package openapi
+
+import (
+	resp "ProjectManagementAPI/internal/lib/api/response"
+	"ProjectManagementAPI/internal/lib/i18n"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Места параметров и тела в resp.FieldError.In.
+const (
+	InBody  = resp.InBody
+	InQuery = resp.InQuery
+	InPath  = resp.InPath
+)
+
+// ValidateParams проверяет параметры пути и query по схемам операции.
+// Неописанные query-параметры не считаются ошибкой.
+func (d *Document) ValidateParams(op *OpObject, query url.Values, path map[string]string) []resp.FieldError {
+	var errs []resp.FieldError
+	for _, p := range op.Parameters {
+		var raw string
+		var set bool
+		switch p.In {
+		case InPath:
+			raw, set = path[p.Name]
+		case InQuery:
+			set = query.Has(p.Name)
+			raw = query.Get(p.Name)
+		}
+
+		if !set || raw == "" {
+			if p.Required {
+				errs = append(errs, resp.NewFieldError(p.In, p.Name, "required", nil, "rule.required"))
+			}
+			continue
+		}
+
+		v, ok := parseParam(p.Schema, raw)
+		if !ok {
+			errs = append(errs, resp.NewFieldError(p.In, p.Name, "type", echo(raw), "rule.type", i18n.Plain("type."+p.Schema.Type)))
+			continue
+		}
+		d.validate(p.Schema, v, p.In, p.Name, &errs)
+	}
+	return errs
+}
+
+// ValidateBody проверяет тело, разобранное json.Decoder с UseNumber.
+func (d *Document) ValidateBody(s *Schema, body any) []resp.FieldError {
+	var errs []resp.FieldError
+	d.validate(s, body, InBody, "", &errs)
+	return errs
+}
+
+// JSONBody - схема JSON-тела операции или nil, если тело не JSON.
+// Загрузки файлов (Upload) не проверяются, даже если среди их медиатипов есть JSON.
+func (op *OpObject) JSONBody() *Schema {
+	if op.RequestBody == nil || op.upload {
+		return nil
+	}
+	return op.RequestBody.Content[MediaJSON].Schema
+}
+
+func parseParam(s *Schema, raw string) (any, bool) {
+	switch s.Type {
+	case "integer":
+		if _, err := strconv.ParseInt(raw, 10, 64); err != nil {
+			return nil, false
+		}
+		return json.Number(raw), true
+	case "number":
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			return nil, false
+		}
+		return json.Number(raw), true
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		return b, err == nil
+	}
+	return raw, true
+}
+
+func (d *Document) validate(s *Schema, v any, in, field string, errs *[]resp.FieldError) {
+	if v == nil {
+		if !s.Nullable && (s.Type != "" || s.Ref != "") {
+			*errs = append(*errs, resp.NewFieldError(in, field, "type", nil, "rule.null"))
+		}
+		return
+	}
+
+	if s.Ref != "" {
+		d.validate(d.Components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")], v, in, field, errs)
+		return
+	}
+
+	if len(s.AnyOf) > 0 {
+		var last []resp.FieldError
+		for _, alt := range s.AnyOf {
+			last = nil
+			if d.validate(alt, v, in, field, &last); len(last) == 0 {
+				return
+			}
+		}
+		*errs = append(*errs, last...)
+		return
+	}
+
+	if !matchType(s.Type, v) {
+		*errs = append(*errs, resp.NewFieldError(in, field, "type", echo(v), "rule.type", i18n.Plain("type."+s.Type)))
+		return
+	}
+
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(e any) bool { return fmt.Sprint(e) == fmt.Sprint(v) }) {
+		values := make([]string, len(s.Enum))
+		for i, e := range s.Enum {
+			values[i] = fmt.Sprint(e)
+		}
+		*errs = append(*errs, resp.NewFieldError(in, field, "oneof", echo(v), "rule.oneof", i18n.Literal(strings.Join(values, ", "))))
+		return
+	}
+
+	switch v := v.(type) {
+	case string:
+		d.validateString(s, v, in, field, errs)
+	case json.Number:
+		n, _ := v.Float64()
+		if s.Minimum != nil && n < *s.Minimum {
+			*errs = append(*errs, resp.NewFieldError(in, field, "min", echo(v), "rule.min", i18n.Literal(fmt.Sprint(*s.Minimum))))
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			*errs = append(*errs, resp.NewFieldError(in, field, "max", echo(v), "rule.max", i18n.Literal(fmt.Sprint(*s.Maximum))))
+		}
+	case []any:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			*errs = append(*errs, resp.NewFieldError(in, field, "min", echo(v), "rule.min.items", i18n.Count("unit.items", *s.MinItems)))
+		}
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			*errs = append(*errs, resp.NewFieldError(in, field, "max", echo(v), "rule.max.items", i18n.Count("unit.items", *s.MaxItems)))
+		}
+		if s.Items != nil {
+			for i, item := range v {
+				d.validate(s.Items, item, in, field+"/"+strconv.Itoa(i), errs)
+			}
+		}
+	case map[string]any:
+		d.validateObject(s, v, in, field, errs)
+	}
+}
+
+func (d *Document) validateString(s *Schema, v, in, field string, errs *[]resp.FieldError) {
+	n := utf8.RuneCountInString(v)
+	if s.MinLength != nil && n < *s.MinLength {
+		*errs = append(*errs, resp.NewFieldError(in, field, "min", echo(v), "rule.min.length", i18n.Count("unit.characters", *s.MinLength)))
+	}
+	if s.MaxLength != nil && n > *s.MaxLength {
+		*errs = append(*errs, resp.NewFieldError(in, field, "max", echo(v), "rule.max.length", i18n.Count("unit.characters", *s.MaxLength)))
+	}
+	if s.Format != "" && !matchFormat(s.Format, v) {
+		*errs = append(*errs, resp.NewFieldError(in, field, s.Format, echo(v), "rule.format", i18n.Literal(s.Format)))
+	}
+}
+
+func (d *Document) validateObject(s *Schema, v map[string]any, in, field string, errs *[]resp.FieldError) {
+	for _, name := range s.Required {
+		if _, ok := v[name]; !ok {
+			*errs = append(*errs, resp.NewFieldError(in, field+"/"+pointerToken(name), "required", nil, "rule.required"))
+		}
+	}
+
+	keys := make([]string, 0, len(v))
+	for k := range v {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		child := field + "/" + pointerToken(k)
+		switch ps, ok := s.Properties[k]; {
+		case ok:
+			d.validate(ps, v[k], in, child, errs)
+		case s.AdditionalProperties != nil:
+			d.validate(s.AdditionalProperties, v[k], in, child, errs)
+		case s.Closed:
+			*errs = append(*errs, resp.NewFieldError(in, child, "unknown", nil, "rule.unknown"))
+		}
+	}
+}
+
+func matchType(typ string, v any) bool {
+	switch typ {
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "integer":
+		n, ok := v.(json.Number)
+		if !ok {
+			return false
+		}
+		_, err := n.Int64()
+		return err == nil
+	case "number":
+		_, ok := v.(json.Number)
+		return ok
+	case "array":
+		_, ok := v.([]any)
+		return ok
+	case "object":
+		_, ok := v.(map[string]any)
+		return ok
+	}
+	return true
+}
+
+func matchFormat(format, v string) bool {
+	switch format {
+	case "uuid":
+		_, err := uuid.Parse(v)
+		return err == nil && len(v) == 36
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, v)
+		return err == nil
+	case "date":
+		_, err := time.Parse(time.DateOnly, v)
+		return err == nil
+	case "email":
+		addr, err := mail.ParseAddress(v)
+		return err == nil && addr.Address == v
+	case "uri":
+		u, err := url.ParseRequestURI(v)
+		return err == nil && u.Scheme != "" && u.Host != ""
+	}
+	return true
+}
+
+// pointerToken экранирует имя по RFC 6901.
+func pointerToken(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+// echoLimit - сколько символов строки возвращается в FieldError.Value.
+const echoLimit = 64
+
+// echo - значение для FieldError.Value: объекты и массивы не возвращаются,
+// длинные строки обрезаются, чтобы ответ не повторял тело запроса.
+func echo(v any) any {
+	switch v := v.(type) {
+	case map[string]any, []any:
+		return nil
+	case string:
+		if r := []rune(v); len(r) > echoLimit {
+			return string(r[:echoLimit]) + "…"
+		}
+	}
+	return v
+}
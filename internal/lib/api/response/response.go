@@ -1,17 +1,43 @@
 package response
 
 import (
-	"fmt"
+	"ProjectManagementAPI/internal/lib/i18n"
+	"net/http"
+	"reflect"
+	"strconv"
 	"strings"
 
+	"github.com/go-chi/render"
+	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
 )
 
 type Response struct {
-	Status string `json:"status"`
-	Error  string `json:"error,omitempty"`
+	Status string       `json:"status"`
+	Error  string       `json:"error,omitempty"`
+	Fields []FieldError `json:"fields,omitempty"`
 }
 
+// FieldError - ошибка в одном поле запроса. Field - JSON pointer в теле
+// (/assignees/0) или имя параметра для query и path.
+type FieldError struct {
+	In      string `json:"in"`
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	// Value - значение, не прошедшее проверку
+	Value any `json:"value,omitempty"`
+
+	text i18n.Text
+}
+
+// Места полей в FieldError.In.
+const (
+	InBody  = "body"
+	InQuery = "query"
+	InPath  = "path"
+)
+
 const (
 	StatusOK    = "OK"
 	StatusError = "Error"
@@ -30,22 +56,147 @@ func Error(msg string) Response {
 	}
 }
 
+// NewFieldError строит ошибку поля по шаблону key из каталога i18n; первым
+// аргументом шаблона подставляется название поля. Message - по-английски.
+func NewFieldError(in, field, rule string, value any, key string, args ...i18n.Text) FieldError {
+	text := i18n.Template(key, append([]i18n.Text{label(in, field)}, args...)...)
+
+	return FieldError{
+		In:      in,
+		Field:   field,
+		Rule:    rule,
+		Message: text(i18n.English()),
+		Value:   value,
+		text:    text,
+	}
+}
+
+func label(in, field string) i18n.Text {
+	switch {
+	case in != InBody:
+		return i18n.Template("label.parameter", i18n.Literal(field))
+	case field == "":
+		return i18n.Plain("label.body")
+	default:
+		return i18n.Template("label.field", i18n.Literal(field))
+	}
+}
+
+// Invalid собирает ответ из ошибок полей; Error дублирует их одной строкой.
+func Invalid(fields []FieldError) Response {
+	msgs := make([]string, len(fields))
+	for i, f := range fields {
+		msgs[i] = f.Message
+	}
+
+	return Response{
+		Status: StatusError,
+		Error:  strings.Join(msgs, ", "),
+		Fields: fields,
+	}
+}
+
+// Localize переводит сообщения ответа. Сообщения без перевода, например
+// собранные через fmt.Sprintf, остаются английскими.
+func (r Response) Localize(t ut.Translator) Response {
+	if len(r.Fields) == 0 {
+		r.Error = i18n.Message(t, r.Error)
+		return r
+	}
+
+	fields := make([]FieldError, len(r.Fields))
+	for i, f := range r.Fields {
+		if f.text != nil {
+			f.Message = f.text(t)
+		}
+		fields[i] = f
+	}
+	return Invalid(fields)
+}
+
+// JSON отправляет ответ с ошибкой на языке из Accept-Language.
+func JSON(w http.ResponseWriter, r *http.Request, v Response) {
+	t := i18n.FromHeader(r.Header.Get("Accept-Language"))
+	w.Header().Set("Content-Language", t.Locale())
+	render.JSON(w, r, v.Localize(t))
+}
+
+// ValidationError переводит ошибки validator в ошибки полей тела. Имена полей
+// берутся из тегов json (validate.Struct), поэтому Field - JSON pointer.
 func ValidationError(errs validator.ValidationErrors) Response {
-	var errMsgs []string
+	fields := make([]FieldError, 0, len(errs))
 
 	for _, err := range errs {
-		switch err.ActualTag() {
+		pointer, tag, value := Pointer(err.Namespace()), err.Tag(), err.Value()
+
+		var fe FieldError
+		switch tag {
 		case "required":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is a required field", err.Field()))
-		case "email":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is not a valid email", err.Field()))
+			fe = NewFieldError(InBody, pointer, tag, nil, "rule.required")
+		case "email", "uuid", "uuid4", "url":
+			fe = NewFieldError(InBody, pointer, tag, value, "rule.format", i18n.Literal(tag))
+		case "oneof":
+			fe = NewFieldError(InBody, pointer, tag, value, "rule.oneof",
+				i18n.Literal(strings.Join(strings.Fields(err.Param()), ", ")))
+		case "datetime":
+			fe = NewFieldError(InBody, pointer, tag, value, "rule.layout", i18n.Literal(err.Param()))
+		case "min", "max":
+			key, bound := bound(err)
+			fe = NewFieldError(InBody, pointer, tag, value, key, bound)
 		default:
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is not valid", err.Field()))
+			fe = NewFieldError(InBody, pointer, tag, value, "rule.invalid")
 		}
+
+		fields = append(fields, fe)
 	}
 
-	return Response{
-		Status: StatusError,
-		Error:  strings.Join(errMsgs, ", "),
+	return Invalid(fields)
+}
+
+// bound выбирает шаблон min/max по типу поля: длина строки, число элементов или значение.
+func bound(err validator.FieldError) (string, i18n.Text) {
+	kind := err.Kind()
+	if kind == reflect.Pointer {
+		kind = err.Type().Elem().Kind()
 	}
+
+	n, convErr := strconv.Atoi(err.Param())
+
+	switch {
+	case convErr != nil:
+		return "rule." + err.Tag(), i18n.Literal(err.Param())
+	case kind == reflect.String:
+		return "rule." + err.Tag() + ".length", i18n.Count("unit.characters", n)
+	case kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map:
+		return "rule." + err.Tag() + ".items", i18n.Count("unit.items", n)
+	default:
+		return "rule." + err.Tag(), i18n.Literal(err.Param())
+	}
+}
+
+// Pointer переводит namespace validator (CreateRequest.assignees[0]) в JSON
+// pointer (/assignees/0); имя корневой структуры отбрасывается.
+func Pointer(namespace string) string {
+	_, path, found := strings.Cut(namespace, ".")
+	if !found {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, part := range strings.Split(path, ".") {
+		name, rest, _ := strings.Cut(part, "[")
+		b.WriteString("/" + escape(name))
+		for rest != "" {
+			var key string
+			key, rest, _ = strings.Cut(rest, "]")
+			b.WriteString("/" + escape(key))
+			rest = strings.TrimPrefix(rest, "[")
+		}
+	}
+	return b.String()
+}
+
+// escape экранирует сегмент pointer по RFC 6901.
+func escape(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
 }
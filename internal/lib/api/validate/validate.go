@@ -0,0 +1,29 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// v общий: validator кэширует разбор структур, создавать его на запрос незачем.
+var v = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	// Поля в ошибках называются как в JSON: resp.ValidationError строит по ним JSON pointer
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	return v
+}
+
+// Struct проверяет структуру по тегам validate.
+func Struct(s any) error {
+	return v.Struct(s)
+}
@@ -0,0 +1,15 @@
+package backoff
+
+import "time"
+
+// Exponential - экспоненциальная задержка перед попыткой attempt+1.
+func Exponential(attempt int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return delay
+}
@@ -0,0 +1,14 @@
+package email
+
+import "context"
+
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type Sender interface {
+	Send(ctx context.Context, m Message) error
+}
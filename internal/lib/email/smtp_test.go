@@ -0,0 +1,193 @@
+package email
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+// session - то, что получил фейковый SMTP-сервер.
+type session struct {
+	from, rcpt string
+	data       string
+}
+
+// fakeSMTP принимает одно письмо без авторизации и TLS и отдаёт его в канал.
+func fakeSMTP(t *testing.T) (host string, port int, got <-chan session) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	ch := make(chan session, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(s string) { _, _ = io.WriteString(conn, s+"\r\n") }
+
+		var s session
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.TrimRight(line, "\r\n")
+			switch upper := strings.ToUpper(cmd); {
+			case strings.HasPrefix(upper, "EHLO"), strings.HasPrefix(upper, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(upper, "MAIL FROM:"):
+				s.from = strings.Trim(cmd[len("MAIL FROM:"):], "<>")
+				reply("250 OK")
+			case strings.HasPrefix(upper, "RCPT TO:"):
+				s.rcpt = strings.Trim(cmd[len("RCPT TO:"):], "<>")
+				reply("250 OK")
+			case upper == "DATA":
+				reply("354 end with <CRLF>.<CRLF>")
+				var data strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				s.data = data.String()
+				reply("250 OK")
+			case upper == "QUIT":
+				reply("221 bye")
+				ch <- s
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	addr := ln.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, ch
+}
+
+func send(t *testing.T, m Message) session {
+	t.Helper()
+
+	host, port, got := fakeSMTP(t)
+	sender := NewSMTPSender(host, port, "", "", "pm@example.com")
+
+	if err := sender.Send(context.Background(), m); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	s := <-got
+	if s.from != "pm@example.com" || s.rcpt != m.To {
+		t.Errorf("envelope = %s -> %s", s.from, s.rcpt)
+	}
+	if bare := strings.ReplaceAll(s.data, "\r\n", ""); strings.ContainsAny(bare, "\r\n") {
+		t.Errorf("message has bare CR or LF:\n%q", s.data)
+	}
+
+	return s
+}
+
+func TestSMTPSenderPlainText(t *testing.T) {
+	s := send(t, Message{
+		To:      "john@example.com",
+		Subject: "Задача назначена",
+		Text:    "Первая строка\nвторая строка\r\nтретья\rчетвёртая",
+	})
+
+	msg, err := mail.ReadMessage(strings.NewReader(s.data))
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+
+	if to := msg.Header.Get("To"); to != "john@example.com" {
+		t.Errorf("To = %q", to)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != "Задача назначена" {
+		t.Errorf("Subject = %q, %v", subject, err)
+	}
+
+	body, _ := io.ReadAll(msg.Body)
+	if want := "Первая строка\r\nвторая строка\r\nтретья\r\nчетвёртая\r\n"; string(body) != want {
+		t.Errorf("body = %q, want %q", body, want)
+	}
+}
+
+func TestSMTPSenderMultipart(t *testing.T) {
+	s := send(t, Message{
+		To:      "john@example.com",
+		Subject: "Digest",
+		Text:    "line 1\nline 2",
+		HTML:    "<p>line 1</p>\n<p>line 2</p>",
+	})
+
+	msg, err := mail.ReadMessage(strings.NewReader(s.data))
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q, %v", msg.Header.Get("Content-Type"), err)
+	}
+
+	want := []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", "line 1\r\nline 2"},
+		{"text/html; charset=utf-8", "<p>line 1</p>\r\n<p>line 2</p>"},
+	}
+
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for i, w := range want {
+		part, err := mr.NextPart()
+		if err != nil {
+			t.Fatalf("part %d: %v", i, err)
+		}
+		if ct := part.Header.Get("Content-Type"); ct != w.contentType {
+			t.Errorf("part %d Content-Type = %q, want %q", i, ct, w.contentType)
+		}
+		body, _ := io.ReadAll(part)
+		if string(body) != w.body {
+			t.Errorf("part %d body = %q, want %q", i, body, w.body)
+		}
+	}
+	if _, err := mr.NextPart(); err != io.EOF {
+		t.Errorf("extra part: %v", err)
+	}
+}
+
+// Письмо собирается уже с CRLF, не полагаясь на нормализацию в net/smtp.
+func TestBuildUsesCRLF(t *testing.T) {
+	sender := NewSMTPSender("localhost", 25, "", "", "pm@example.com")
+
+	for _, m := range []Message{
+		{To: "john@example.com", Subject: "s", Text: "a\nb\rc"},
+		{To: "john@example.com", Subject: "s", Text: "a\nb", HTML: "<p>a</p>\n<p>b</p>"},
+	} {
+		b, err := sender.build(m)
+		if err != nil {
+			t.Fatalf("build: %v", err)
+		}
+		if bare := strings.ReplaceAll(string(b), "\r\n", ""); strings.ContainsAny(bare, "\r\n") {
+			t.Errorf("message has bare CR or LF:\n%q", b)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package i18n
+
+import "github.com/go-playground/locales"
+
+// enCatalog содержит только шаблоны: простые сообщения написаны по-английски.
+var enCatalog = catalog{
+	messages: map[string]string{
+		"label.body":      "body",
+		"label.field":     "field {0}",
+		"label.parameter": "parameter {0}",
+
+		"rule.required":   "{0} is required",
+		"rule.unknown":    "{0} is not allowed",
+		"rule.null":       "{0} must not be null",
+		"rule.type":       "{0} must be {1}",
+		"rule.oneof":      "{0} must be one of: {1}",
+		"rule.format":     "{0} must be a valid {1}",
+		"rule.layout":     "{0} must match layout {1}",
+		"rule.min":        "{0} must be at least {1}",
+		"rule.max":        "{0} must be at most {1}",
+		"rule.min.length": "{0} must be at least {1}",
+		"rule.max.length": "{0} must be at most {1}",
+		"rule.min.items":  "{0} must contain at least {1}",
+		"rule.max.items":  "{0} must contain at most {1}",
+		"rule.invalid":    "{0} is not valid",
+
+		"type.string":  "a string",
+		"type.integer": "an integer",
+		"type.number":  "a number",
+		"type.boolean": "a boolean",
+		"type.array":   "an array",
+		"type.object":  "an object",
+	},
+	cardinals: map[string]map[locales.PluralRule]string{
+		"unit.characters": {
+			locales.PluralRuleOne:   "{0} character",
+			locales.PluralRuleOther: "{0} characters",
+		},
+		"unit.items": {
+			locales.PluralRuleOne:   "{0} item",
+			locales.PluralRuleOther: "{0} items",
+		},
+	},
+}
@@ -0,0 +1,215 @@
+package i18n
+
+import "github.com/go-playground/locales"
+
+// ruCatalog: шаблоны ошибок полей и сообщения обработчиков и доменных ошибок.
+// Шаблоны построены через двоеточие, чтобы не согласовывать род "поле"/"параметр".
+var ruCatalog = catalog{
+	messages: map[string]string{
+		"label.body":      "тело запроса",
+		"label.field":     "поле {0}",
+		"label.parameter": "параметр {0}",
+
+		"rule.required":   "{0}: обязательное значение",
+		"rule.unknown":    "{0}: неизвестное поле",
+		"rule.null":       "{0}: значение не может быть null",
+		"rule.type":       "{0}: ожидается {1}",
+		"rule.oneof":      "{0}: допустимые значения - {1}",
+		"rule.format":     "{0}: некорректный {1}",
+		"rule.layout":     "{0}: ожидается формат {1}",
+		"rule.min":        "{0}: не меньше {1}",
+		"rule.max":        "{0}: не больше {1}",
+		"rule.min.length": "{0}: не короче {1}",
+		"rule.max.length": "{0}: не длиннее {1}",
+		"rule.min.items":  "{0}: не меньше {1}",
+		"rule.max.items":  "{0}: не больше {1}",
+		"rule.invalid":    "{0}: некорректное значение",
+
+		"type.string":  "строка",
+		"type.integer": "целое число",
+		"type.number":  "число",
+		"type.boolean": "логическое значение",
+		"type.array":   "массив",
+		"type.object":  "объект",
+
+		// Запрос
+		"invalid request":                           "некорректный запрос",
+		"failed to read request":                    "не удалось прочитать запрос",
+		"Content-Type must be application/json":     "Content-Type должен быть application/json",
+		"invalid pagination":                        "некорректная пагинация",
+		"invalid limit":                             "некорректный limit",
+		"invalid offset":                            "некорректный offset",
+		"invalid id":                                "некорректный id",
+		"invalid comment id":                        "некорректный id комментария",
+		"invalid delivery id":                       "некорректный id доставки",
+		"include must be assignees":                 "include - assignees",
+		"fields must list task fields":              "fields - поля задачи через запятую",
+		"invalid cursor":                            "некорректный курсор",
+		"first must be between 1 and 100":           "first - от 1 до 100",
+		"query is too complex":                      "запрос слишком сложный",
+		"invalid milestone id":                      "некорректный id вехи",
+		"invalid project id":                        "некорректный id проекта",
+		"invalid project":                           "некорректный проект",
+		"invalid project_id":                        "некорректный project_id",
+		"invalid milestone_id":                      "некорректный milestone_id",
+		"invalid task_id":                           "некорректный task_id",
+		"invalid user_id":                           "некорректный user_id",
+		"invalid entity_id":                         "некорректный entity_id",
+		"invalid actor_id":                          "некорректный actor_id",
+		"invalid assignee":                          "некорректный исполнитель",
+		"invalid dry_run":                           "некорректный dry_run",
+		"invalid unread":                            "некорректный unread",
+		"invalid status":                            "некорректный статус",
+		"invalid title":                             "некорректное название",
+		"invalid name":                              "некорректное имя",
+		"invalid due_at, expected RFC 3339":         "некорректный due_at, ожидается RFC 3339",
+		"invalid duration, expected e.g. 1h30m":     "некорректная длительность, ожидается, например, 1h30m",
+		"invalid from, expected YYYY-MM-DD":         "некорректный from, ожидается YYYY-MM-DD",
+		"invalid to, expected YYYY-MM-DD":           "некорректный to, ожидается YYYY-MM-DD",
+		"invalid X-User-ID header":                  "некорректный заголовок X-User-ID",
+		"X-User-ID header is required":              "требуется заголовок X-User-ID",
+		"assignee=me requires X-User-ID header":     "assignee=me требует заголовка X-User-ID",
+		"forbidden":                                 "доступ запрещён",
+		"streaming is not supported":                "потоковая передача не поддерживается",
+		"request body must contain the export file": "тело запроса должно содержать файл выгрузки",
+
+		// Не найдено
+		"user not found":                 "пользователь не найден",
+		"task not found":                 "задача не найдена",
+		"project not found":              "проект не найден",
+		"milestone not found":            "веха не найдена",
+		"comment not found":              "комментарий не найден",
+		"filter not found":               "фильтр не найден",
+		"worklog not found":              "запись времени не найдена",
+		"notification not found":         "уведомление не найдено",
+		"job not found":                  "задание не найдено",
+		"import not found":               "импорт не найден",
+		"webhook not found":              "подписка не найдена",
+		"webhook subscription not found": "подписка не найдена",
+		"webhook delivery not found":     "доставка не найдена",
+		"dead delivery not found":        "неудавшаяся доставка не найдена",
+		"calendar not found":             "календарь не найден",
+		"calendar token not found":       "токен календаря не найден",
+		"digest settings not found":      "настройки дайджеста не найдены",
+		"recurrence series not found":    "серия повторений не найдена",
+		"no running timer":               "нет запущенного таймера",
+
+		// Сбои
+		"failed to create user":               "не удалось создать пользователя",
+		"failed to create task":               "не удалось создать задачу",
+		"failed to create project":            "не удалось создать проект",
+		"failed to create milestone":          "не удалось создать веху",
+		"failed to create webhook":            "не удалось создать подписку",
+		"failed to update task":               "не удалось изменить задачу",
+		"failed to get":                       "не удалось получить данные",
+		"failed to delete":                    "не удалось удалить",
+		"failed to delete comment":            "не удалось удалить комментарий",
+		"failed to delete filter":             "не удалось удалить фильтр",
+		"failed to delete worklog":            "не удалось удалить запись времени",
+		"failed to list tasks":                "не удалось получить задачи",
+		"failed to list comments":             "не удалось получить комментарии",
+		"failed to list filters":              "не удалось получить фильтры",
+		"failed to list worklogs":             "не удалось получить записи времени",
+		"failed to list notifications":        "не удалось получить уведомления",
+		"failed to list digests":              "не удалось получить дайджесты",
+		"failed to list jobs":                 "не удалось получить задания",
+		"failed to list audit events":         "не удалось получить журнал аудита",
+		"failed to get history":               "не удалось получить историю",
+		"failed to get burndown":              "не удалось построить burndown",
+		"failed to get deliveries":            "не удалось получить доставки",
+		"failed to get filter":                "не удалось получить фильтр",
+		"failed to get import":                "не удалось получить импорт",
+		"failed to get preferences":           "не удалось получить настройки",
+		"failed to get digest settings":       "не удалось получить настройки дайджеста",
+		"failed to get calendar token":        "не удалось получить токен календаря",
+		"failed to save filter":               "не удалось сохранить фильтр",
+		"failed to save preferences":          "не удалось сохранить настройки",
+		"failed to save digest settings":      "не удалось сохранить настройки дайджеста",
+		"failed to update notification":       "не удалось обновить уведомление",
+		"failed to update notifications":      "не удалось обновить уведомления",
+		"failed to run filter":                "не удалось выполнить фильтр",
+		"failed to run bulk operation":        "не удалось выполнить массовую операцию",
+		"failed to search":                    "не удалось выполнить поиск",
+		"failed to aggregate worklogs":        "не удалось подсчитать время",
+		"failed to export tasks":              "не удалось выгрузить задачи",
+		"failed to export users":              "не удалось выгрузить пользователей",
+		"failed to import":                    "не удалось загрузить данные",
+		"failed to start import":              "не удалось запустить импорт",
+		"failed to read file":                 "не удалось прочитать файл",
+		"failed to process job":               "не удалось обработать задание",
+		"failed to redeliver":                 "не удалось повторить доставку",
+		"failed to build calendar":            "не удалось построить календарь",
+		"failed to rotate calendar token":     "не удалось выпустить токен календаря",
+		"failed to revoke calendar token":     "не удалось отозвать токен календаря",
+		"failed to build workload report":     "не удалось построить отчёт о нагрузке",
+		"failed to save capacity":             "не удалось сохранить ёмкость",
+		"failed to estimate query complexity": "не удалось оценить сложность запроса",
+
+		// Доменные ошибки
+		"assignee not found":                                        "исполнитель не найден",
+		"user is not assigned to the task":                          "пользователь не назначен на задачу",
+		"email already exists":                                      "email уже занят",
+		"task must have at least one assignee":                      "у задачи должен быть хотя бы один исполнитель",
+		"task can have at most 20 labels":                           "у задачи может быть не больше 20 меток",
+		"story points must not be negative":                         "story points не могут быть отрицательными",
+		"label must be 1-50 letters, digits, '-', '_' or '.'":       "метка - от 1 до 50 букв, цифр, '-', '_' или '.'",
+		"end date must not be before start date":                    "дата окончания не может быть раньше даты начала",
+		"invalid notification type":                                 "некорректный тип уведомления",
+		"invalid digest frequency":                                  "некорректная частота дайджеста",
+		"send hour must be between 0 and 23":                        "час отправки должен быть от 0 до 23",
+		"weekday must be between 0 and 6":                           "день недели должен быть от 0 до 6",
+		"invalid event type":                                        "некорректный тип события",
+		"invalid webhook url":                                       "некорректный адрес подписки",
+		"invalid job status":                                        "некорректный статус задания",
+		"only failed or cancelled jobs can be retried":              "повторить можно только упавшее или отменённое задание",
+		"only pending or running jobs can be cancelled":             "отменить можно только ожидающее или выполняющееся задание",
+		"comment body must not be empty":                            "текст комментария не может быть пустым",
+		"comment body is too long":                                  "текст комментария слишком длинный",
+		"another timer is already running":                          "уже запущен другой таймер",
+		"duration must be positive":                                 "длительность должна быть положительной",
+		"started_at must not be in the future":                      "started_at не может быть в будущем",
+		"from must be before to":                                    "from должен быть раньше to",
+		"group_by must be task, user or project":                    "group_by - task, user или project",
+		"search query must not be empty":                            "поисковый запрос не может быть пустым",
+		"search query is too long":                                  "поисковый запрос слишком длинный",
+		"invalid query":                                             "некорректный запрос фильтра",
+		"filter name must be 1-100 characters":                      "название фильтра - от 1 до 100 символов",
+		"filter with this name already exists":                      "фильтр с таким названием уже есть",
+		"invalid recurrence rule":                                   "некорректное правило повторения",
+		"recurrence rule yields no occurrences":                     "правило повторения не даёт ни одного повторения",
+		"task is not recurring":                                     "задача не повторяющаяся",
+		"scope must be this or future":                              "scope - this или future",
+		"status and due_at can only be changed for this occurrence": "status и due_at меняются только для этого повторения",
+		"bulk mode must be atomic or per_item":                      "mode - atomic или per_item",
+		"invalid bulk operation":                                    "некорректная массовая операция",
+		"too many tasks in one bulk request":                        "слишком много задач в одном запросе",
+		"either operations or filter with operation is required":    "нужны operations или filter с operation",
+		"operation with filter must not have task_id":               "операция с filter не может содержать task_id",
+		"format must be csv, json or ndjson":                        "format - csv, json или ndjson",
+		"invalid column mapping":                                    "некорректное сопоставление колонок",
+		"invalid export file":                                       "некорректный файл выгрузки",
+		"no rows to import":                                         "нет строк для загрузки",
+		"too many rows to import":                                   "слишком много строк для загрузки",
+		"invalid import options":                                    "некорректные параметры импорта",
+		"source must be jira_xml, jira_csv, trello or github":       "source - jira_xml, jira_csv, trello или github",
+		"type must be event or todo":                                "type - event или todo",
+		"weekly capacity must not be negative":                      "недельная ёмкость не может быть отрицательной",
+		"workload period is too long":                               "слишком длинный период отчёта о нагрузке",
+		"invalid timezone":                                          "некорректный часовой пояс",
+	},
+	cardinals: map[string]map[locales.PluralRule]string{
+		// После "не меньше", "не длиннее" - родительный падеж
+		"unit.characters": {
+			locales.PluralRuleOne:   "{0} символа",
+			locales.PluralRuleFew:   "{0} символов",
+			locales.PluralRuleMany:  "{0} символов",
+			locales.PluralRuleOther: "{0} символа",
+		},
+		"unit.items": {
+			locales.PluralRuleOne:   "{0} элемента",
+			locales.PluralRuleFew:   "{0} элементов",
+			locales.PluralRuleMany:  "{0} элементов",
+			locales.PluralRuleOther: "{0} элемента",
+		},
+	},
+}
@@ -0,0 +1,143 @@
+// Package i18n переводит сообщения API. Язык выбирается по Accept-Language,
+// по умолчанию и для неизвестных языков - английский. Ключ простого сообщения -
+// его английский текст, поэтому сообщение без перевода остаётся как есть.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ru"
+	ut "github.com/go-playground/universal-translator"
+)
+
+var uni = newUniversal()
+
+// catalog - переводы одного языка: шаблоны с {0}, {1} и счётные формы.
+type catalog struct {
+	messages  map[string]string
+	cardinals map[string]map[locales.PluralRule]string
+}
+
+func newUniversal() *ut.UniversalTranslator {
+	fallback := en.New()
+	uni := ut.New(fallback, fallback, ru.New())
+
+	for locale, c := range map[string]catalog{"en": enCatalog, "ru": ruCatalog} {
+		t, _ := uni.GetTranslator(locale)
+		for key, text := range c.messages {
+			must(t.Add(key, text, false))
+		}
+		for key, forms := range c.cardinals {
+			for rule, text := range forms {
+				must(t.AddCardinal(key, text, rule, false))
+			}
+		}
+	}
+
+	must(uni.VerifyTranslations())
+	return uni
+}
+
+// must: ошибка в каталоге - ошибка программы, как regexp.MustCompile.
+func must(err error) {
+	if err != nil {
+		panic(fmt.Sprintf("i18n: %v", err))
+	}
+}
+
+// English - переводчик по умолчанию.
+func English() ut.Translator {
+	return uni.GetFallback()
+}
+
+// FromHeader выбирает переводчик по заголовку Accept-Language с учётом q:
+// "ru-RU,ru;q=0.9,en;q=0.8" -> ru.
+func FromHeader(header string) ut.Translator {
+	type lang struct {
+		tag string
+		q   float64
+	}
+
+	var langs []lang
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		base, _, _ := strings.Cut(tag, "-")
+		langs = append(langs, lang{tag: strings.ToLower(base), q: q})
+	}
+	sort.SliceStable(langs, func(i, j int) bool { return langs[i].q > langs[j].q })
+
+	tags := make([]string, 0, len(langs))
+	for _, l := range langs {
+		if l.q > 0 {
+			tags = append(tags, l.tag)
+		}
+	}
+
+	t, _ := uni.FindTranslator(tags...)
+	return t
+}
+
+// Message переводит простое сообщение; без перевода возвращает его же.
+func Message(t ut.Translator, msg string) string {
+	if s, err := t.T(msg); err == nil {
+		return s
+	}
+	return msg
+}
+
+// Text - сообщение, которое собирается на языке ответа.
+type Text func(t ut.Translator) string
+
+// Plain - сообщение из каталога без параметров.
+func Plain(msg string) Text {
+	return func(t ut.Translator) string {
+		return Message(t, msg)
+	}
+}
+
+// Literal не переводится: имена полей, значения.
+func Literal(s string) Text {
+	return func(ut.Translator) string {
+		return s
+	}
+}
+
+// Template подставляет переведённые аргументы в шаблон key.
+func Template(key string, args ...Text) Text {
+	return func(t ut.Translator) string {
+		params := make([]string, len(args))
+		for i, a := range args {
+			params[i] = a(t)
+		}
+		s, err := t.T(key, params...)
+		if err != nil {
+			s, _ = English().T(key, params...)
+		}
+		return s
+	}
+}
+
+// Count - число со словом в нужной форме: "3 characters", "3 символов".
+func Count(key string, n int) Text {
+	return func(t ut.Translator) string {
+		s, err := t.C(key, float64(n), 0, strconv.Itoa(n))
+		if err != nil {
+			s, _ = English().C(key, float64(n), 0, strconv.Itoa(n))
+		}
+		return s
+	}
+}
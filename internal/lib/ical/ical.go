@@ -0,0 +1,99 @@
+// Package ical записывает объекты iCalendar (RFC 5545): экранирование
+// текста, даты и перенос строк длиннее 75 октетов.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// lineLimit - максимальная длина строки без CRLF (RFC 5545, 3.1).
+const lineLimit = 75
+
+var escaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// Escape экранирует значение типа TEXT.
+func Escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// DateTime форматирует момент времени в UTC: 20240131T150405Z.
+func DateTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// Date форматирует дату без времени: 20240131.
+func Date(t time.Time) string {
+	return t.Format("20060102")
+}
+
+// Encoder пишет строки содержимого. Первая ошибка записи сохраняется
+// и возвращается из Flush.
+type Encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: bufio.NewWriter(w)}
+}
+
+func (e *Encoder) Begin(component string) {
+	e.line("BEGIN:" + component)
+}
+
+func (e *Encoder) End(component string) {
+	e.line("END:" + component)
+}
+
+// Prop пишет свойство как есть: name может содержать параметры
+// (DTSTART;VALUE=DATE), value должно быть уже отформатировано.
+func (e *Encoder) Prop(name, value string) {
+	e.line(name + ":" + value)
+}
+
+// Text пишет свойство типа TEXT, экранируя значение.
+func (e *Encoder) Text(name, value string) {
+	e.Prop(name, Escape(value))
+}
+
+func (e *Encoder) Flush() error {
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+// line переносит строку по 75 октетов, не разрывая символы UTF-8:
+// продолжение начинается с пробела.
+func (e *Encoder) line(s string) {
+	limit := lineLimit
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		e.write(s[:cut])
+		e.write("\r\n ")
+		s = s[cut:]
+		limit = lineLimit - 1
+	}
+	e.write(s)
+	e.write("\r\n")
+}
+
+func (e *Encoder) write(s string) {
+	if e.err == nil {
+		_, e.err = e.w.WriteString(s)
+	}
+}
@@ -0,0 +1,142 @@
+package ical
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestEscape(t *testing.T) {
+	for _, tc := range []struct {
+		in, want string
+	}{
+		{"plain text", "plain text"},
+		{`a\b`, `a\\b`},
+		{"a;b,c", `a\;b\,c`},
+		{"line1\nline2", `line1\nline2`},
+		{"crlf\r\nend", `crlf\nend`},
+		{"cr\rend", `cr\nend`},
+		// двоеточие и кавычки в TEXT не экранируются
+		{`url: "http://x"`, `url: "http://x"`},
+		{`\n`, `\\n`},
+	} {
+		if got := Escape(tc.in); got != tc.want {
+			t.Errorf("Escape(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestDates(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	at := time.Date(2026, 1, 31, 2, 4, 5, 0, moscow)
+
+	if got := DateTime(at); got != "20260130T230405Z" {
+		t.Errorf("DateTime = %s", got)
+	}
+	if got := Date(at); got != "20260131" {
+		t.Errorf("Date = %s, want the date in the value's own zone", got)
+	}
+}
+
+func encode(t *testing.T, fn func(e *Encoder)) string {
+	t.Helper()
+
+	var sb strings.Builder
+	e := NewEncoder(&sb)
+	fn(e)
+	if err := e.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	return sb.String()
+}
+
+// unfold склеивает перенесённые строки обратно (RFC 5545, 3.1).
+func unfold(s string) string {
+	return strings.ReplaceAll(s, "\r\n ", "")
+}
+
+func TestFolding(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		value string
+	}{
+		{"short", "Short"},
+		{"exact limit", strings.Repeat("a", lineLimit-len("SUMMARY:"))},
+		{"one over", strings.Repeat("a", lineLimit-len("SUMMARY:")+1)},
+		{"long ascii", strings.Repeat("abcdefghij", 30)},
+		{"cyrillic", strings.Repeat("Задача со сроком ", 12)},
+		{"emoji", strings.Repeat("🎉", 50)},
+		{"escaped", strings.Repeat("a,b;c\n", 30)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out := encode(t, func(e *Encoder) { e.Text("SUMMARY", tc.value) })
+
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatalf("output %q does not end with CRLF", out)
+			}
+			lines := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+			for i, l := range lines {
+				if len(l) > lineLimit {
+					t.Errorf("line %d is %d octets: %q", i, len(l), l)
+				}
+				if !utf8.ValidString(l) {
+					t.Errorf("line %d splits a character: %q", i, l)
+				}
+				if i > 0 && !strings.HasPrefix(l, " ") {
+					t.Errorf("continuation line %d does not start with a space", i)
+				}
+				if strings.ContainsAny(l, "\r\n") {
+					t.Errorf("line %d has a bare line break", i)
+				}
+			}
+
+			if got, want := unfold(out), "SUMMARY:"+Escape(tc.value)+"\r\n"; got != want {
+				t.Errorf("unfolded = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestFoldingBoundary(t *testing.T) {
+	// 74 октета ASCII, затем двухбайтовый символ: он целиком уходит на следующую строку
+	value := strings.Repeat("a", lineLimit-len("X:")-1) + "ж"
+	out := encode(t, func(e *Encoder) { e.Text("X", value) })
+
+	want := "X:" + strings.Repeat("a", lineLimit-len("X:")-1) + "\r\n ж\r\n"
+	if out != want {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	out := encode(t, func(e *Encoder) {
+		e.Begin("VCALENDAR")
+		e.Prop("DTSTART;VALUE=DATE", "20260302")
+		e.Text("SUMMARY", "a;b")
+		e.End("VCALENDAR")
+	})
+
+	want := "BEGIN:VCALENDAR\r\nDTSTART;VALUE=DATE:20260302\r\nSUMMARY:a\\;b\r\nEND:VCALENDAR\r\n"
+	if out != want {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+}
+
+type failWriter struct{}
+
+func (failWriter) Write([]byte) (int, error) {
+	return 0, errors.New("closed")
+}
+
+func TestEncoderKeepsFirstError(t *testing.T) {
+	e := NewEncoder(failWriter{})
+	// больше размера буфера, чтобы запись дошла до writer
+	for range 100 {
+		e.Text("DESCRIPTION", strings.Repeat("x", 100))
+	}
+	if err := e.Flush(); err == nil || err.Error() != "closed" {
+		t.Errorf("Flush error = %v, want closed", err)
+	}
+}
@@ -0,0 +1,109 @@
+// Package query разбирает язык фильтров задач вида
+//
+//	status:in_progress assignee:me due<2026-11-01 -label:wontfix
+//
+// в дерево (AST). Условия через пробел объединяются по AND, поддерживаются
+// OR, NOT, префикс "-" для отрицания, скобки, списки значений через запятую
+// (status:todo,in_progress), строки в кавычках и свободный текст.
+// Смысл полей задаёт Schema, перевод в SQL выполняет репозиторий.
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Операторы сравнения. ":" и "=" равнозначны.
+const (
+	OpEq  = ":"
+	OpNe  = "!="
+	OpLt  = "<"
+	OpLe  = "<="
+	OpGt  = ">"
+	OpGe  = ">="
+	opEq2 = "="
+)
+
+var ErrInvalidQuery = errors.New("invalid query")
+
+// Error - синтаксическая или смысловая ошибка; Pos - номер символа с 1.
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("at position %d: %s", e.Pos, e.Msg)
+}
+
+func (e *Error) Unwrap() error {
+	return ErrInvalidQuery
+}
+
+func errorf(pos int, format string, args ...any) *Error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+type Node interface {
+	Pos() int
+	String() string
+}
+
+type And struct {
+	Left, Right Node
+}
+
+type Or struct {
+	Left, Right Node
+}
+
+type Not struct {
+	X  Node
+	At int
+}
+
+// Comparison - условие на поле: field:value, field<value и т.д.
+type Comparison struct {
+	Field  string
+	Op     string
+	Values []string
+	At     int
+	// ValueAt - позиции значений, для сообщений об ошибках
+	ValueAt []int
+}
+
+// Text - свободный текст без поля.
+type Text struct {
+	Value string
+	At    int
+}
+
+func (n *And) Pos() int        { return n.Left.Pos() }
+func (n *Or) Pos() int         { return n.Left.Pos() }
+func (n *Not) Pos() int        { return n.At }
+func (n *Comparison) Pos() int { return n.At }
+func (n *Text) Pos() int       { return n.At }
+
+func (n *And) String() string { return "(" + n.Left.String() + " " + n.Right.String() + ")" }
+func (n *Or) String() string  { return "(" + n.Left.String() + " OR " + n.Right.String() + ")" }
+func (n *Not) String() string { return "-" + n.X.String() }
+func (n *Text) String() string {
+	return quote(n.Value)
+}
+
+func (n *Comparison) String() string {
+	values := make([]string, len(n.Values))
+	for i, v := range n.Values {
+		values[i] = quote(v)
+	}
+	return n.Field + n.Op + strings.Join(values, ",")
+}
+
+func quote(s string) string {
+	if s == "" || strings.ContainsFunc(s, isSpecial) || isKeyword(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
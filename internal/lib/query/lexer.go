@@ -0,0 +1,126 @@
+package query
+
+import (
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString
+	tokOp
+	tokComma
+	tokMinus
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+	// glued - токен идёт вплотную к предыдущему, без пробела
+	glued bool
+}
+
+func isSpecial(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune(`()":=!<>,`, r)
+}
+
+func isKeyword(s string) bool {
+	return s == "AND" || s == "OR" || s == "NOT"
+}
+
+func lex(input string) ([]token, error) {
+	var (
+		tokens []token
+		rs     = []rune(input)
+		glued  bool
+	)
+
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		pos := i + 1
+
+		if unicode.IsSpace(r) {
+			i++
+			glued = false
+			continue
+		}
+
+		tok := token{pos: pos, glued: glued}
+		switch {
+		case r == '(':
+			tok.kind, tok.value = tokLParen, "("
+			i++
+		case r == ')':
+			tok.kind, tok.value = tokRParen, ")"
+			i++
+		case r == ',':
+			tok.kind, tok.value = tokComma, ","
+			i++
+		case r == ':' || r == '=':
+			tok.kind, tok.value = tokOp, string(r)
+			i++
+		case r == '!' || r == '<' || r == '>':
+			tok.kind, tok.value = tokOp, string(r)
+			i++
+			if i < len(rs) && rs[i] == '=' {
+				tok.value += "="
+				i++
+			}
+			if tok.value == "!" {
+				return nil, errorf(pos, `unexpected "!", use "-" or NOT for negation`)
+			}
+		case r == '-' && atTermStart(tokens, glued) && i+1 < len(rs) && !unicode.IsSpace(rs[i+1]):
+			// "-" в начале условия - отрицание; внутри значения (due>-7d) - часть слова
+			tok.kind, tok.value = tokMinus, "-"
+			i++
+		case r == '"':
+			var sb strings.Builder
+			i++
+			closed := false
+			for i < len(rs) {
+				if rs[i] == '\\' && i+1 < len(rs) {
+					sb.WriteRune(rs[i+1])
+					i += 2
+					continue
+				}
+				if rs[i] == '"' {
+					closed = true
+					i++
+					break
+				}
+				sb.WriteRune(rs[i])
+				i++
+			}
+			if !closed {
+				return nil, errorf(pos, "unterminated string")
+			}
+			tok.kind, tok.value = tokString, sb.String()
+		default:
+			start := i
+			for i < len(rs) && !isSpecial(rs[i]) {
+				i++
+			}
+			tok.kind, tok.value = tokWord, string(rs[start:i])
+		}
+
+		tokens = append(tokens, tok)
+		glued = true
+	}
+
+	return append(tokens, token{kind: tokEOF, pos: len(rs) + 1}), nil
+}
+
+// atTermStart сообщает, начинается ли с текущего символа новое условие.
+func atTermStart(tokens []token, glued bool) bool {
+	if len(tokens) == 0 || !glued {
+		return true
+	}
+	prev := tokens[len(tokens)-1].kind
+	return prev == tokLParen || prev == tokMinus
+}
@@ -0,0 +1,211 @@
+package query
+
+import "unicode/utf8"
+
+const (
+	MaxLength = 2000
+	maxDepth  = 32
+	maxTerms  = 64
+)
+
+// Parse разбирает запрос. Пустой запрос даёт nil без ошибки.
+//
+//	expr    = and { "OR" and }
+//	and     = unary { ["AND"] unary }
+//	unary   = ("-" | "NOT") unary | primary
+//	primary = "(" expr ")" | field op value {"," value} | text
+func Parse(input string) (Node, error) {
+	if utf8.RuneCountInString(input) > MaxLength {
+		return nil, errorf(MaxLength, "query is longer than %d characters", MaxLength)
+	}
+
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokEOF {
+		return nil, nil
+	}
+
+	n, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		if tok.kind == tokRParen {
+			return nil, errorf(tok.pos, `unexpected ")"`)
+		}
+		return nil, errorf(tok.pos, "unexpected %q", tok.value)
+	}
+
+	return n, nil
+}
+
+type parser struct {
+	tokens []token
+	i      int
+	depth  int
+	terms  int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.i]
+	if tok.kind != tokEOF {
+		p.i++
+	}
+	return tok
+}
+
+func (p *parser) keyword(kw string) bool {
+	tok := p.peek()
+	return tok.kind == tokWord && tok.value == kw && !p.isField()
+}
+
+// isField - за словом вплотную идёт оператор, т.е. это имя поля.
+func (p *parser) isField() bool {
+	next := p.tokens[p.i+1]
+	return next.kind == tokOp && next.glued
+}
+
+func (p *parser) expr() (Node, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxDepth {
+		return nil, errorf(p.peek().pos, "query is nested too deeply")
+	}
+
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("OR") {
+		p.next()
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = &Or{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) and() (Node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if p.keyword("AND") {
+			p.next()
+		} else if tok := p.peek(); tok.kind == tokEOF || tok.kind == tokRParen || p.keyword("OR") {
+			return left, nil
+		}
+
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = &And{Left: left, Right: right}
+	}
+}
+
+func (p *parser) unary() (Node, error) {
+	tok := p.peek()
+	if tok.kind == tokMinus || p.keyword("NOT") {
+		p.next()
+		p.depth++
+		defer func() { p.depth-- }()
+		if p.depth > maxDepth {
+			return nil, errorf(tok.pos, "query is nested too deeply")
+		}
+
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{X: x, At: tok.pos}, nil
+	}
+
+	return p.primary()
+}
+
+func (p *parser) primary() (Node, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokLParen:
+		n, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, errorf(tok.pos, `missing ")" for "(" opened here`)
+		}
+		return n, nil
+	case tokString:
+		return p.term(&Text{Value: tok.value, At: tok.pos})
+	case tokWord:
+		if op := p.peek(); op.kind == tokOp && op.glued {
+			p.next()
+			return p.comparison(tok, op)
+		}
+		if isKeyword(tok.value) {
+			return nil, errorf(tok.pos, "expected condition after %s", tok.value)
+		}
+		return p.term(&Text{Value: tok.value, At: tok.pos})
+	case tokEOF:
+		return nil, errorf(tok.pos, "unexpected end of query")
+	case tokOp:
+		return nil, errorf(tok.pos, "expected field name before %q", tok.value)
+	default:
+		return nil, errorf(tok.pos, "unexpected %q", tok.value)
+	}
+}
+
+func (p *parser) comparison(field, op token) (Node, error) {
+	c := &Comparison{Field: field.value, Op: op.value, At: field.pos}
+	if c.Op == opEq2 {
+		c.Op = OpEq
+	}
+
+	expectAt, after := op.pos+len([]rune(op.value)), field.value+op.value
+	for {
+		v := p.peek()
+		if (v.kind != tokWord && v.kind != tokString) || !v.glued {
+			return nil, errorf(expectAt, "expected value after %s", after)
+		}
+		p.next()
+		c.Values = append(c.Values, v.value)
+		c.ValueAt = append(c.ValueAt, v.pos)
+
+		comma := p.peek()
+		if comma.kind != tokComma || !comma.glued {
+			break
+		}
+		p.next()
+		expectAt, after = comma.pos+1, `"," (no spaces in a list)`
+	}
+
+	if len(c.Values) > 1 && c.Op != OpEq && c.Op != OpNe {
+		return nil, errorf(c.ValueAt[1], "a list of values is only allowed with : and !=")
+	}
+
+	return p.term(c)
+}
+
+func (p *parser) term(n Node) (Node, error) {
+	p.terms++
+	if p.terms > maxTerms {
+		return nil, errorf(n.Pos(), "query has more than %d conditions", maxTerms)
+	}
+	return n, nil
+}
@@ -0,0 +1,130 @@
+package query
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want string
+	}{
+		{"status:in_progress", "status:in_progress"},
+		{"status=done", "status:done"},
+		{"status:in_progress assignee:me", "(status:in_progress assignee:me)"},
+		{"a AND b", "(a b)"},
+		{"a b OR c", "((a b) OR c)"},
+		{"a OR b c", "(a OR (b c))"},
+		{"(a OR b) c", "((a OR b) c)"},
+		{"-label:wontfix", "-label:wontfix"},
+		{"NOT label:wontfix", "-label:wontfix"},
+		{"NOT -a", "--a"},
+		// одиночный "-" - обычный текст
+		{"- a", "(- a)"},
+		{"status:todo,in_progress", "status:todo,in_progress"},
+		{"status!=done", "status!=done"},
+		{"due<=2026-11-01 points>3", "(due<=2026-11-01 points>3)"},
+		// "-" внутри значения - часть слова, а не отрицание
+		{"due>-7d", "due>-7d"},
+		{"a-b", "a-b"},
+		{`"hello world"`, `"hello world"`},
+		{`title:"a \"b\""`, `title:"a \"b\""`},
+		{`"OR"`, `"OR"`},
+		// ключевые слова как поле остаются полем
+		{"OR:x", "OR:x"},
+		{"or", "or"},
+	} {
+		n, err := Parse(tc.in)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tc.in, err)
+			continue
+		}
+		if got := n.String(); got != tc.want {
+			t.Errorf("Parse(%q) = %s, want %s", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestParseEmpty(t *testing.T) {
+	for _, in := range []string{"", "   ", "\t\n"} {
+		n, err := Parse(in)
+		if n != nil || err != nil {
+			t.Errorf("Parse(%q) = %v, %v, want nil", in, n, err)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tc := range []struct {
+		in  string
+		pos int
+		msg string
+	}{
+		{"status:", 8, "expected value after status:"},
+		{"status: done", 8, "expected value after status:"},
+		{"status:todo, done", 13, `expected value after "," (no spaces in a list)`},
+		{"(a", 1, `missing ")"`},
+		{"a)", 2, `unexpected ")"`},
+		{"!a", 1, `unexpected "!"`},
+		{`title:"abc`, 7, "unterminated string"},
+		{"due<1,2", 7, "a list of values is only allowed"},
+		{":a", 1, "expected field name"},
+		{"OR", 1, "expected condition after OR"},
+		{"a OR", 5, "unexpected end of query"},
+		{"a NOT", 6, "unexpected end of query"},
+	} {
+		_, err := Parse(tc.in)
+
+		var qe *Error
+		if !errors.As(err, &qe) {
+			t.Errorf("Parse(%q) error = %v, want *Error", tc.in, err)
+			continue
+		}
+		if !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("Parse(%q) error does not wrap ErrInvalidQuery", tc.in)
+		}
+		if qe.Pos != tc.pos || !strings.Contains(qe.Msg, tc.msg) {
+			t.Errorf("Parse(%q) = %v, want position %d: %s", tc.in, err, tc.pos, tc.msg)
+		}
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	for name, in := range map[string]string{
+		"length": strings.Repeat("a", MaxLength+1),
+		"depth":  strings.Repeat("(", maxDepth+1) + "a" + strings.Repeat(")", maxDepth+1),
+		"not":    strings.Repeat("-", maxDepth+1) + "a",
+		"terms":  strings.TrimSpace(strings.Repeat("a ", maxTerms+1)),
+	} {
+		if _, err := Parse(in); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("%s: error = %v, want ErrInvalidQuery", name, err)
+		}
+	}
+
+	if _, err := Parse(strings.TrimSpace(strings.Repeat("a ", maxTerms))); err != nil {
+		t.Errorf("%d terms: %v", maxTerms, err)
+	}
+}
+
+// String даёт запрос, который разбирается в то же дерево.
+func TestStringRoundTrip(t *testing.T) {
+	for _, in := range []string{
+		`status:todo,in_progress -(label:wontfix OR label:duplicate)`,
+		`title:"a b" "free text" due>-7d`,
+		`"AND" x:"" y:"a,b"`,
+	} {
+		n, err := Parse(in)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", in, err)
+		}
+		again, err := Parse(n.String())
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", n.String(), err)
+		}
+		if again.String() != n.String() {
+			t.Errorf("round trip of %q: %s != %s", in, again, n)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package query
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Kind int
+
+const (
+	// KindEnum - одно из Field.Values
+	KindEnum Kind = iota
+	// KindRef - ссылка: UUID, имя или, если AllowMe, "me"
+	KindRef
+	// KindDate - дата, см. ParseDate
+	KindDate
+	KindInt
+	// KindText - поиск подстроки
+	KindText
+)
+
+// None - значение для проверки на отсутствие: due:none, project:none.
+const None = "none"
+
+const Me = "me"
+
+type Field struct {
+	Kind     Kind
+	Values   []string
+	AllowMe  bool
+	Nullable bool
+}
+
+// Schema описывает допустимые поля запроса.
+type Schema map[string]Field
+
+// Check проверяет, что поля, операторы и значения запроса допустимы.
+func (s Schema) Check(n Node) error {
+	switch n := n.(type) {
+	case nil:
+		return nil
+	case *And:
+		if err := s.Check(n.Left); err != nil {
+			return err
+		}
+		return s.Check(n.Right)
+	case *Or:
+		if err := s.Check(n.Left); err != nil {
+			return err
+		}
+		return s.Check(n.Right)
+	case *Not:
+		return s.Check(n.X)
+	case *Text:
+		return nil
+	case *Comparison:
+		return s.checkComparison(n)
+	}
+	return errorf(n.Pos(), "unsupported expression")
+}
+
+func (s Schema) checkComparison(c *Comparison) error {
+	f, ok := s[c.Field]
+	if !ok {
+		return errorf(c.At, "unknown field %q, expected one of: %s", c.Field, strings.Join(slices.Sorted(maps.Keys(s)), ", "))
+	}
+
+	ordered := c.Op != OpEq && c.Op != OpNe
+	if ordered && f.Kind != KindDate && f.Kind != KindInt {
+		return errorf(c.At, "field %s supports only : and !=", c.Field)
+	}
+
+	for i, v := range c.Values {
+		pos := c.ValueAt[i]
+		if v == None {
+			if !f.Nullable || ordered {
+				return errorf(pos, "field %s cannot be compared with none", c.Field)
+			}
+			continue
+		}
+
+		switch f.Kind {
+		case KindEnum:
+			if !slices.Contains(f.Values, v) {
+				return errorf(pos, "invalid %s %q, expected one of: %s", c.Field, v, strings.Join(f.Values, ", "))
+			}
+		case KindRef:
+			if v == Me && !f.AllowMe {
+				return errorf(pos, "%s:me is not supported", c.Field)
+			}
+		case KindDate:
+			if _, err := ParseDate(v, time.Now()); err != nil {
+				return errorf(pos, "invalid date %q, expected YYYY-MM-DD, today or an offset like -7d, +2w", v)
+			}
+		case KindInt:
+			if _, err := strconv.Atoi(v); err != nil {
+				return errorf(pos, "invalid number %q", v)
+			}
+		case KindText:
+			if v == "" {
+				return errorf(pos, "empty value for %s", c.Field)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ParseDate возвращает полночь (по зоне now) даты YYYY-MM-DD, "today" или
+// смещения от сегодняшнего дня: +3d, -2w, +1m.
+func ParseDate(v string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch {
+	case v == "today":
+		return today, nil
+	case strings.HasPrefix(v, "+") || strings.HasPrefix(v, "-"):
+		if len(v) < 3 {
+			return time.Time{}, ErrInvalidQuery
+		}
+		n, err := strconv.Atoi(v[:len(v)-1])
+		if err != nil {
+			return time.Time{}, ErrInvalidQuery
+		}
+		switch v[len(v)-1] {
+		case 'd':
+			return today.AddDate(0, 0, n), nil
+		case 'w':
+			return today.AddDate(0, 0, 7*n), nil
+		case 'm':
+			return today.AddDate(0, n, 0), nil
+		}
+		return time.Time{}, ErrInvalidQuery
+	}
+
+	return time.ParseInLocation(time.DateOnly, v, now.Location())
+}
@@ -0,0 +1,97 @@
+package query
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSchema = Schema{
+	"status":   {Kind: KindEnum, Values: []string{"todo", "done"}},
+	"assignee": {Kind: KindRef, AllowMe: true},
+	"project":  {Kind: KindRef, Nullable: true},
+	"due":      {Kind: KindDate, Nullable: true},
+	"points":   {Kind: KindInt},
+	"title":    {Kind: KindText},
+}
+
+func TestSchemaCheck(t *testing.T) {
+	for _, in := range []string{
+		"status:todo,done",
+		"assignee:me project:none",
+		"project:Backend,none",
+		"due:none due<today due>=-7d due!=2026-11-01",
+		"points>3 points:1,2",
+		`title:"a b" -(status:done OR free text)`,
+	} {
+		n, err := Parse(in)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", in, err)
+		}
+		if err := testSchema.Check(n); err != nil {
+			t.Errorf("Check(%q): %v", in, err)
+		}
+	}
+}
+
+func TestSchemaCheckErrors(t *testing.T) {
+	for _, tc := range []struct {
+		in  string
+		pos int
+		msg string
+	}{
+		{"owner:me", 1, `unknown field "owner", expected one of: assignee, due, points, project, status, title`},
+		{"status:todo,open", 13, `invalid status "open", expected one of: todo, done`},
+		{"status>todo", 1, "field status supports only : and !="},
+		{"assignee:none", 10, "cannot be compared with none"},
+		{"due<none", 5, "cannot be compared with none"},
+		{"due:tomorrow", 5, `invalid date "tomorrow"`},
+		{"points:many", 8, `invalid number "many"`},
+		{`title:""`, 7, "empty value for title"},
+		{"-(a OR project:me)", 16, "project:me is not supported"},
+	} {
+		n, err := Parse(tc.in)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tc.in, err)
+		}
+		err = testSchema.Check(n)
+
+		var qe *Error
+		if !errors.As(err, &qe) || qe.Pos != tc.pos || !strings.Contains(qe.Msg, tc.msg) {
+			t.Errorf("Check(%q) = %v, want position %d: %s", tc.in, err, tc.pos, tc.msg)
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	now := time.Date(2026, 1, 31, 15, 4, 5, 0, time.UTC)
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	for _, tc := range []struct {
+		in   string
+		want time.Time
+	}{
+		{"today", day(2026, 1, 31)},
+		{"2026-11-01", day(2026, 11, 1)},
+		{"+3d", day(2026, 2, 3)},
+		{"-7d", day(2026, 1, 24)},
+		{"+2w", day(2026, 2, 14)},
+		{"-1w", day(2026, 1, 24)},
+		// AddDate нормализует 31 февраля в 3 марта
+		{"+1m", day(2026, 3, 3)},
+	} {
+		got, err := ParseDate(tc.in, now)
+		if err != nil || !got.Equal(tc.want) {
+			t.Errorf("ParseDate(%q) = %v, %v, want %v", tc.in, got, err, tc.want)
+		}
+	}
+
+	for _, in := range []string{"", "+d", "3d", "+3y", "+xd", "2026-13-01", "tomorrow"} {
+		if _, err := ParseDate(in, now); err == nil {
+			t.Errorf("ParseDate(%q) succeeded", in)
+		}
+	}
+}
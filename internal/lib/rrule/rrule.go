@@ -0,0 +1,367 @@
+// Package rrule реализует подмножество RRULE из RFC 5545: FREQ=DAILY, WEEKLY,
+// MONTHLY, INTERVAL, BYDAY (для MONTHLY - с порядковым номером, например 1MO
+// или -1FR), BYMONTHDAY, UNTIL и COUNT.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	Daily   = "DAILY"
+	Weekly  = "WEEKLY"
+	Monthly = "MONTHLY"
+)
+
+// maxPeriods ограничивает перебор для правил, которые почти не дают дат.
+const maxPeriods = 10000
+
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// Day - день недели с необязательным порядковым номером в месяце:
+// N=1 - первый, N=-1 - последний, N=0 - каждый.
+type Day struct {
+	N       int
+	Weekday time.Weekday
+}
+
+type Rule struct {
+	Freq       string
+	Interval   int
+	ByDay      []Day
+	ByMonthDay []int
+	Until      *time.Time
+	Count      int
+}
+
+// Parse разбирает строку вида "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE".
+// Префикс "RRULE:" допускается.
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	r := &Rule{Interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			r.Freq = strings.ToUpper(value)
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+			if err == nil && r.Interval < 1 {
+				err = errors.New("must be positive")
+			}
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+			if err == nil && r.Count < 1 {
+				err = errors.New("must be positive")
+			}
+		case "UNTIL":
+			var until time.Time
+			until, err = parseUntil(value)
+			r.Until = &until
+		case "BYDAY":
+			r.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseByMonthDay(value)
+		default:
+			err = errors.New("unsupported")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidRule, key, err)
+		}
+	}
+
+	if err := r.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+	}
+
+	return r, nil
+}
+
+func (r *Rule) validate() error {
+	switch r.Freq {
+	case Daily, Weekly:
+		if len(r.ByMonthDay) > 0 {
+			return errors.New("BYMONTHDAY is only supported with FREQ=MONTHLY")
+		}
+		for _, d := range r.ByDay {
+			if d.N != 0 {
+				return errors.New("numbered BYDAY is only supported with FREQ=MONTHLY")
+			}
+		}
+	case Monthly:
+		if len(r.ByDay) > 0 && len(r.ByMonthDay) > 0 {
+			return errors.New("BYDAY and BYMONTHDAY cannot be combined")
+		}
+	case "":
+		return errors.New("FREQ is required")
+	default:
+		return fmt.Errorf("FREQ=%s is not supported", r.Freq)
+	}
+
+	if r.Until != nil && r.Count > 0 {
+		return errors.New("UNTIL and COUNT are mutually exclusive")
+	}
+
+	return nil
+}
+
+// String возвращает правило в каноническом виде.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			days[i] = d.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, d := range r.ByMonthDay {
+			days[i] = strconv.Itoa(d)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+func (d Day) String() string {
+	for name, wd := range weekdays {
+		if wd == d.Weekday {
+			if d.N != 0 {
+				return strconv.Itoa(d.N) + name
+			}
+			return name
+		}
+	}
+	return ""
+}
+
+// After возвращает первое повторение строго после t для серии,
+// начинающейся в dtstart. Часовой пояс и время суток берутся из dtstart.
+func (r *Rule) After(dtstart, t time.Time) (time.Time, bool) {
+	it := r.Iterator(dtstart)
+	for {
+		next, ok := it.Next()
+		if !ok {
+			return time.Time{}, false
+		}
+		if next.After(t) {
+			return next, true
+		}
+	}
+}
+
+// Iterator перебирает повторения по порядку, начиная с dtstart.
+type Iterator struct {
+	rule    *Rule
+	dtstart time.Time
+	period  int
+	buf     []time.Time
+	emitted int
+	done    bool
+}
+
+func (r *Rule) Iterator(dtstart time.Time) *Iterator {
+	return &Iterator{rule: r, dtstart: dtstart}
+}
+
+func (it *Iterator) Next() (time.Time, bool) {
+	for len(it.buf) == 0 {
+		if it.done || it.period >= maxPeriods {
+			return time.Time{}, false
+		}
+		it.buf = it.candidates(it.period)
+		it.period++
+	}
+
+	next := it.buf[0]
+	it.buf = it.buf[1:]
+
+	r := it.rule
+	if r.Until != nil && next.After(*r.Until) {
+		it.done, it.buf = true, nil
+		return time.Time{}, false
+	}
+	it.emitted++
+	if r.Count > 0 && it.emitted >= r.Count {
+		it.done, it.buf = true, nil
+	}
+
+	return next, true
+}
+
+// candidates возвращает отсортированные даты периода с номером p, не раньше dtstart.
+func (it *Iterator) candidates(p int) []time.Time {
+	r, start := it.rule, it.dtstart
+	step := p * r.Interval
+
+	var days []time.Time
+	switch r.Freq {
+	case Daily:
+		day := at(start, start.Year(), start.Month(), start.Day()+step)
+		if len(r.ByDay) == 0 || hasWeekday(r.ByDay, day.Weekday()) {
+			days = append(days, day)
+		}
+	case Weekly:
+		offset := (int(start.Weekday()) + 6) % 7 // неделя начинается с понедельника
+		monday := at(start, start.Year(), start.Month(), start.Day()-offset+7*step)
+		byDay := r.ByDay
+		if len(byDay) == 0 {
+			byDay = []Day{{Weekday: start.Weekday()}}
+		}
+		for _, d := range byDay {
+			days = append(days, at(start, monday.Year(), monday.Month(), monday.Day()+(int(d.Weekday)+6)%7))
+		}
+	case Monthly:
+		first := at(start, start.Year(), start.Month()+time.Month(step), 1)
+		days = monthDays(r, start, first)
+	}
+
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	res := days[:0]
+	for i, d := range days {
+		if d.Before(start) || (i > 0 && d.Equal(days[i-1])) {
+			continue
+		}
+		res = append(res, d)
+	}
+	return res
+}
+
+func monthDays(r *Rule, start, first time.Time) []time.Time {
+	year, month := first.Year(), first.Month()
+	last := at(start, year, month+1, 0).Day()
+
+	var days []time.Time
+	add := func(day int) {
+		if day >= 1 && day <= last {
+			days = append(days, at(start, year, month, day))
+		}
+	}
+
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, d := range r.ByMonthDay {
+			if d < 0 {
+				d = last + d + 1
+			}
+			add(d)
+		}
+	case len(r.ByDay) > 0:
+		for _, d := range r.ByDay {
+			firstDay := 1 + (int(d.Weekday)-int(first.Weekday())+7)%7
+			switch {
+			case d.N > 0:
+				add(firstDay + 7*(d.N-1))
+			case d.N < 0:
+				lastDay := firstDay + 7*((last-firstDay)/7)
+				add(lastDay + 7*(d.N+1))
+			default:
+				for day := firstDay; day <= last; day += 7 {
+					add(day)
+				}
+			}
+		}
+	default:
+		// Месяцы без дня dtstart (например, 31-го) пропускаются, как в RFC 5545
+		add(start.Day())
+	}
+
+	return days
+}
+
+func at(start time.Time, year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+}
+
+func hasWeekday(days []Day, wd time.Weekday) bool {
+	for _, d := range days {
+		if d.Weekday == wd {
+			return true
+		}
+	}
+	return false
+}
+
+func parseUntil(v string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if t, err := time.Parse(layout, v); err == nil {
+			if layout == "20060102" {
+				t = t.Add(24*time.Hour - time.Second)
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("expected YYYYMMDD or YYYYMMDDTHHMMSSZ")
+}
+
+func parseByDay(v string) ([]Day, error) {
+	var days []Day
+	for _, item := range strings.Split(strings.ToUpper(v), ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("bad day %q", item)
+		}
+		wd, ok := weekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("bad day %q", item)
+		}
+
+		d := Day{Weekday: wd}
+		if num := item[:len(item)-2]; num != "" {
+			n, err := strconv.Atoi(num)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, fmt.Errorf("bad day %q", item)
+			}
+			d.N = n
+		}
+		days = append(days, d)
+	}
+	return days, nil
+}
+
+func parseByMonthDay(v string) ([]int, error) {
+	var days []int
+	for _, item := range strings.Split(v, ",") {
+		d, err := strconv.Atoi(item)
+		if err != nil || d == 0 || d < -31 || d > 31 {
+			return nil, fmt.Errorf("bad month day %q", item)
+		}
+		days = append(days, d)
+	}
+	return days, nil
+}
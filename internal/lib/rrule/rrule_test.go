@@ -0,0 +1,204 @@
+package rrule
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want string
+	}{
+		{"FREQ=DAILY", "FREQ=DAILY"},
+		{"RRULE:freq=weekly;interval=2;byday=mo,we", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE"},
+		{"FREQ=MONTHLY;BYDAY=1MO,-1FR", "FREQ=MONTHLY;BYDAY=1MO,-1FR"},
+		{"FREQ=MONTHLY;BYMONTHDAY=1,-1", "FREQ=MONTHLY;BYMONTHDAY=1,-1"},
+		{"FREQ=DAILY;INTERVAL=1;COUNT=3", "FREQ=DAILY;COUNT=3"},
+		{"FREQ=DAILY;UNTIL=20260310", "FREQ=DAILY;UNTIL=20260310T235959Z"},
+		{"FREQ=DAILY;UNTIL=20260310T120000Z", "FREQ=DAILY;UNTIL=20260310T120000Z"},
+	} {
+		r, err := Parse(tc.in)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tc.in, err)
+			continue
+		}
+		if got := r.String(); got != tc.want {
+			t.Errorf("Parse(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"FREQ=YEARLY",
+		"INTERVAL=2",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=-1",
+		"FREQ=DAILY;COUNT=2;UNTIL=20260101",
+		"FREQ=DAILY;BYMONTHDAY=1",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=MONTHLY;BYDAY=MO;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYDAY=6MO",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=DAILY;UNTIL=tomorrow",
+		"FREQ=DAILY;BYHOUR=9",
+		"FREQ=DAILY;COUNT",
+	} {
+		if _, err := Parse(in); !errors.Is(err, ErrInvalidRule) {
+			t.Errorf("Parse(%q) error = %v, want ErrInvalidRule", in, err)
+		}
+	}
+}
+
+func TestIterator(t *testing.T) {
+	// 2 марта 2026 - понедельник
+	start := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 9, 30, 0, 0, time.UTC)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		want    []time.Time
+	}{
+		{"daily", "FREQ=DAILY", start,
+			[]time.Time{date(3, 2), date(3, 3), date(3, 4), date(3, 5)}},
+		{"daily interval", "FREQ=DAILY;INTERVAL=3", start,
+			[]time.Time{date(3, 2), date(3, 5), date(3, 8), date(3, 11)}},
+		{"daily byday", "FREQ=DAILY;BYDAY=SA,SU", start,
+			[]time.Time{date(3, 7), date(3, 8), date(3, 14), date(3, 15)}},
+		{"weekly", "FREQ=WEEKLY", start,
+			[]time.Time{date(3, 2), date(3, 9), date(3, 16), date(3, 23)}},
+		{"weekly byday from midweek", "FREQ=WEEKLY;BYDAY=MO,FR", date(3, 4),
+			[]time.Time{date(3, 6), date(3, 9), date(3, 13), date(3, 16)}},
+		{"weekly interval", "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH", start,
+			[]time.Time{date(3, 3), date(3, 5), date(3, 17), date(3, 19)}},
+		{"weekly sunday belongs to monday week", "FREQ=WEEKLY;BYDAY=MO,SU", date(3, 8),
+			[]time.Time{date(3, 8), date(3, 9), date(3, 15), date(3, 16)}},
+		{"monthly", "FREQ=MONTHLY", date(1, 15),
+			[]time.Time{date(1, 15), date(2, 15), date(3, 15), date(4, 15)}},
+		{"monthly 31st skips short months", "FREQ=MONTHLY", date(1, 31),
+			[]time.Time{date(1, 31), date(3, 31), date(5, 31), date(7, 31)}},
+		{"bymonthday 31", "FREQ=MONTHLY;BYMONTHDAY=31", date(1, 1),
+			[]time.Time{date(1, 31), date(3, 31), date(5, 31), date(7, 31)}},
+		{"bymonthday last", "FREQ=MONTHLY;BYMONTHDAY=-1", date(1, 1),
+			[]time.Time{date(1, 31), date(2, 28), date(3, 31), date(4, 30)}},
+		{"bymonthday several", "FREQ=MONTHLY;BYMONTHDAY=15,1", date(1, 10),
+			[]time.Time{date(1, 15), date(2, 1), date(2, 15), date(3, 1)}},
+		{"first monday", "FREQ=MONTHLY;BYDAY=1MO", date(1, 1),
+			[]time.Time{date(1, 5), date(2, 2), date(3, 2), date(4, 6)}},
+		{"last friday", "FREQ=MONTHLY;BYDAY=-1FR", date(1, 1),
+			[]time.Time{date(1, 30), date(2, 27), date(3, 27), date(4, 24)}},
+		{"fifth friday only where it exists", "FREQ=MONTHLY;BYDAY=5FR", date(1, 1),
+			[]time.Time{date(1, 30), date(5, 29), date(7, 31), date(10, 30)}},
+		{"monthly interval", "FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=10", date(1, 1),
+			[]time.Time{date(1, 10), date(3, 10), date(5, 10), date(7, 10)}},
+		{"count", "FREQ=DAILY;COUNT=2", start,
+			[]time.Time{date(3, 2), date(3, 3)}},
+		{"until inclusive", "FREQ=DAILY;UNTIL=20260304T093000Z", start,
+			[]time.Time{date(3, 2), date(3, 3), date(3, 4)}},
+		{"until date", "FREQ=WEEKLY;UNTIL=20260309", start,
+			[]time.Time{date(3, 2), date(3, 9)}},
+		{"count with byday", "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=3", start,
+			[]time.Time{date(3, 2), date(3, 4), date(3, 9)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := Parse(tc.rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			it := r.Iterator(tc.dtstart)
+			var got []time.Time
+			for len(got) < len(tc.want)+1 {
+				next, ok := it.Next()
+				if !ok {
+					break
+				}
+				got = append(got, next)
+			}
+
+			// для правил без конца лишнее повторение не проверяется
+			if r.Count == 0 && r.Until == nil && len(got) > len(tc.want) {
+				got = got[:len(tc.want)]
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tc.want[i]) {
+					t.Errorf("occurrence %d = %v, want %v", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+// Время суток держится по местному времени через переход на летнее.
+func TestIteratorKeepsWallClockAcrossDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := Parse("FREQ=DAILY")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	it := r.Iterator(time.Date(2026, 3, 28, 9, 0, 0, 0, berlin))
+	it.Next()
+	next, _ := it.Next()
+	if next.Hour() != 9 || next.Day() != 29 {
+		t.Errorf("next = %v, want 29 March 09:00", next)
+	}
+	if got := next.UTC().Hour(); got != 7 {
+		t.Errorf("UTC hour = %d, want 7 after the switch to CEST", got)
+	}
+}
+
+func TestAfter(t *testing.T) {
+	r, err := Parse("FREQ=WEEKLY;BYDAY=MO,TH")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		after time.Time
+		want  time.Time
+	}{
+		{start.Add(-time.Hour), start},
+		{start, time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)},
+		{time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)},
+	} {
+		got, ok := r.After(start, tc.after)
+		if !ok || !got.Equal(tc.want) {
+			t.Errorf("After(%v) = %v, %v, want %v", tc.after, got, ok, tc.want)
+		}
+	}
+
+	limited, _ := Parse("FREQ=DAILY;COUNT=2")
+	if got, ok := limited.After(start, start.AddDate(0, 0, 1)); ok {
+		t.Errorf("After past COUNT = %v, want none", got)
+	}
+}
+
+// Правило без подходящих дат не зацикливается.
+func TestIteratorStopsOnEmptyRule(t *testing.T) {
+	r, err := Parse("FREQ=MONTHLY;BYMONTHDAY=31;INTERVAL=12")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// каждый февраль: 31-го не бывает
+	it := r.Iterator(time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC))
+	if got, ok := it.Next(); ok {
+		t.Errorf("Next = %v, want none", got)
+	}
+}
@@ -0,0 +1,134 @@
+package trackers
+
+import (
+	"ProjectManagementAPI/internal/domain/importer"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type githubUser struct {
+	Login string `json:"login"`
+}
+
+type githubIssue struct {
+	URL       string       `json:"url"`
+	HTMLURL   string       `json:"html_url"`
+	Number    int          `json:"number"`
+	Title     string       `json:"title"`
+	Body      string       `json:"body"`
+	State     string       `json:"state"`
+	Assignees []githubUser `json:"assignees"`
+	Labels    []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Milestone *struct {
+		DueOn *time.Time `json:"due_on"`
+	} `json:"milestone"`
+	PullRequest json.RawMessage `json:"pull_request"`
+}
+
+type githubComment struct {
+	IssueURL  string     `json:"issue_url"`
+	User      githubUser `json:"user"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// parseGitHub читает задачи в формате REST API: массив из
+// "gh api repos/OWNER/REPO/issues?state=all --paginate" или объект
+// {"issues": [...], "comments": [...]}, где comments - ответ
+// repos/OWNER/REPO/issues/comments. Pull request'ы пропускаются.
+func parseGitHub(r io.Reader) ([]importer.Item, error) {
+	br := bufio.NewReader(r)
+	first, err := peekNonSpace(br)
+	if err != nil {
+		return nil, err
+	}
+
+	var export struct {
+		Issues   []githubIssue   `json:"issues"`
+		Comments []githubComment `json:"comments"`
+	}
+	if first == '[' {
+		err = json.NewDecoder(br).Decode(&export.Issues)
+	} else {
+		err = json.NewDecoder(br).Decode(&export)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make(map[string][]importer.Comment)
+	for _, c := range export.Comments {
+		comments[c.IssueURL] = append(comments[c.IssueURL], importer.Comment{
+			Author:    importer.Person{Login: c.User.Login},
+			Body:      c.Body,
+			CreatedAt: c.CreatedAt,
+		})
+	}
+
+	items := make([]importer.Item, 0, len(export.Issues))
+	for _, gi := range export.Issues {
+		it := importer.Item{
+			ExternalID:  githubID(gi),
+			Key:         "#" + strconv.Itoa(gi.Number),
+			Title:       strings.TrimSpace(gi.Title),
+			Description: strings.TrimSpace(gi.Body),
+			Status:      gi.State,
+			Closed:      gi.State == "closed",
+			Comments:    comments[gi.URL],
+		}
+		if len(gi.PullRequest) > 0 && string(gi.PullRequest) != "null" {
+			it.Skip = "pull request"
+		}
+		if gi.Milestone != nil {
+			it.Due = gi.Milestone.DueOn
+		}
+		for _, a := range gi.Assignees {
+			it.Assignees = append(it.Assignees, importer.Person{Login: a.Login})
+		}
+		for _, l := range gi.Labels {
+			it.Labels = append(it.Labels, l.Name)
+		}
+
+		items = append(items, it)
+	}
+
+	return items, nil
+}
+
+// githubID - "owner/repo#12": номера задач уникальны только в репозитории.
+// Путь html_url одинаков у github.com и GitHub Enterprise.
+func githubID(gi githubIssue) string {
+	id := "#" + strconv.Itoa(gi.Number)
+	u, err := url.Parse(gi.HTMLURL)
+	if err != nil {
+		return id
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if n := len(parts); n >= 4 && (parts[n-2] == "issues" || parts[n-2] == "pull") {
+		return parts[n-4] + "/" + parts[n-3] + id
+	}
+	return id
+}
+
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return 0, err
+		}
+		if !bytes.ContainsAny(b, " \t\r\n") {
+			return b[0], nil
+		}
+		if _, err := br.ReadByte(); err != nil {
+			return 0, err
+		}
+	}
+}
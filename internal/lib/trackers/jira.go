@@ -0,0 +1,279 @@
+package trackers
+
+import (
+	"ProjectManagementAPI/internal/domain/importer"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Форматы дат: в XML - RFC 1123, в CSV - как в интерфейсе Jira.
+var (
+	jiraXMLLayouts = []string{"Mon, 2 Jan 2006 15:04:05 -0700", "Mon, 2 Jan 2006 15:04:05 MST"}
+	jiraCSVLayouts = []string{"2/Jan/06 3:04 PM", "2/Jan/06", "2006-01-02 15:04", "2006-01-02"}
+)
+
+// jiraStoryPoints - названия поля оценки в классических и next-gen проектах.
+var jiraStoryPoints = map[string]bool{"story points": true, "story point estimate": true}
+
+type jiraRSS struct {
+	Items []jiraItem `xml:"channel>item"`
+}
+
+type jiraUser struct {
+	Username  string `xml:"username,attr"`
+	AccountID string `xml:"accountid,attr"`
+	Name      string `xml:",chardata"`
+}
+
+type jiraLinks struct {
+	Description string   `xml:"description,attr"`
+	Keys        []string `xml:"issuelink>issuekey"`
+}
+
+type jiraItem struct {
+	Key            string `xml:"key"`
+	Summary        string `xml:"summary"`
+	Description    string `xml:"description"`
+	Type           string `xml:"type"`
+	Status         string `xml:"status"`
+	StatusCategory struct {
+		Key string `xml:"key,attr"`
+	} `xml:"statusCategory"`
+	Resolution string   `xml:"resolution"`
+	Assignee   jiraUser `xml:"assignee"`
+	Labels     []string `xml:"labels>label"`
+	Due        string   `xml:"due"`
+	Comments   []struct {
+		Author  string `xml:"author,attr"`
+		Created string `xml:"created,attr"`
+		Body    string `xml:",chardata"`
+	} `xml:"comments>comment"`
+	LinkTypes []struct {
+		Outward jiraLinks `xml:"outwardlinks"`
+		Inward  jiraLinks `xml:"inwardlinks"`
+	} `xml:"issuelinks>issuelinktype"`
+	CustomFields []struct {
+		Name   string   `xml:"customfieldname"`
+		Values []string `xml:"customfieldvalues>customfieldvalue"`
+	} `xml:"customfields>customfield"`
+}
+
+// parseJiraXML читает выгрузку "Export XML" из поиска задач Jira.
+func parseJiraXML(r io.Reader) ([]importer.Item, error) {
+	var rss jiraRSS
+	if err := xml.NewDecoder(r).Decode(&rss); err != nil {
+		return nil, err
+	}
+
+	items := make([]importer.Item, 0, len(rss.Items))
+	for _, ji := range rss.Items {
+		it := importer.Item{
+			ExternalID:  strings.TrimSpace(ji.Key),
+			Key:         strings.TrimSpace(ji.Key),
+			Kind:        ji.Type,
+			Title:       strings.TrimSpace(ji.Summary),
+			Description: htmlToText(ji.Description),
+			Status:      ji.Status,
+			Closed:      ji.StatusCategory.Key == "done" || jiraResolved(ji.Resolution),
+			Labels:      ji.Labels,
+		}
+		if it.ExternalID == "" {
+			return nil, errors.New("item without key")
+		}
+
+		if p := jiraPerson(ji.Assignee); !p.IsZero() {
+			it.Assignees = append(it.Assignees, p)
+		}
+
+		due, ok := parseTime(ji.Due, jiraXMLLayouts...)
+		if !ok {
+			return nil, fmt.Errorf("%s: invalid due date %q", it.Key, ji.Due)
+		}
+		it.Due = due
+
+		for _, c := range ji.Comments {
+			created, _ := parseTime(c.Created, jiraXMLLayouts...)
+			comment := importer.Comment{Author: importer.Person{ID: c.Author}, Body: htmlToText(c.Body)}
+			if created != nil {
+				comment.CreatedAt = *created
+			}
+			it.Comments = append(it.Comments, comment)
+		}
+
+		for _, lt := range ji.LinkTypes {
+			for _, l := range []jiraLinks{lt.Outward, lt.Inward} {
+				for _, key := range l.Keys {
+					it.Links = append(it.Links, importer.Link{Type: l.Description, Target: strings.TrimSpace(key)})
+				}
+			}
+		}
+
+		for _, cf := range ji.CustomFields {
+			if jiraStoryPoints[strings.ToLower(cf.Name)] && len(cf.Values) > 0 {
+				it.StoryPoints = storyPoints(cf.Values[0])
+			}
+		}
+
+		items = append(items, it)
+	}
+
+	return items, nil
+}
+
+func jiraPerson(u jiraUser) importer.Person {
+	// Так Jira выгружает задачу без исполнителя
+	if u.Username == "-1" || strings.EqualFold(strings.TrimSpace(u.Name), "Unassigned") {
+		return importer.Person{}
+	}
+	id := u.AccountID
+	if id == "" {
+		id = u.Username
+	}
+	return importer.Person{ID: id, Name: strings.TrimSpace(u.Name)}
+}
+
+func jiraResolved(resolution string) bool {
+	resolution = strings.TrimSpace(resolution)
+	return resolution != "" && !strings.EqualFold(resolution, "Unresolved")
+}
+
+func storyPoints(s string) int {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || f < 0 {
+		return 0
+	}
+	return int(math.Round(f))
+}
+
+// parseJiraCSV читает выгрузку "Export CSV (all fields)". Многозначные поля
+// (Labels, Comment, связи) Jira пишет повторяющимися колонками.
+func parseJiraCSV(r io.Reader) ([]importer.Item, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	columns := make(map[string][]int)
+	var links []jiraCSVLink
+	for i, h := range header {
+		name := strings.ToLower(strings.TrimSpace(h))
+		columns[name] = append(columns[name], i)
+		if linkType, ok := jiraCSVLinkType(h); ok {
+			links = append(links, jiraCSVLink{column: i, linkType: linkType})
+		}
+	}
+
+	var items []importer.Item
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return items, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		get := func(name string) string {
+			for _, i := range columns[name] {
+				if i < len(row) && strings.TrimSpace(row[i]) != "" {
+					return strings.TrimSpace(row[i])
+				}
+			}
+			return ""
+		}
+		all := func(name string) []string {
+			var res []string
+			for _, i := range columns[name] {
+				if i < len(row) && strings.TrimSpace(row[i]) != "" {
+					res = append(res, strings.TrimSpace(row[i]))
+				}
+			}
+			return res
+		}
+
+		it := importer.Item{
+			ExternalID:  get("issue key"),
+			Key:         get("issue key"),
+			Kind:        get("issue type"),
+			Title:       get("summary"),
+			Description: get("description"),
+			Status:      get("status"),
+			Closed:      strings.EqualFold(get("status category"), "done") || jiraResolved(get("resolution")),
+			Labels:      all("labels"),
+		}
+		if it.ExternalID == "" {
+			return nil, fmt.Errorf("row %d: no Issue key", len(items)+1)
+		}
+
+		if name := get("assignee"); name != "" {
+			it.Assignees = append(it.Assignees, importer.Person{ID: get("assignee id"), Name: name})
+		}
+
+		due, ok := parseTime(get("due date"), jiraCSVLayouts...)
+		if !ok {
+			return nil, fmt.Errorf("%s: invalid due date %q", it.Key, get("due date"))
+		}
+		it.Due = due
+
+		for name := range jiraStoryPoints {
+			if v := get("custom field (" + name + ")"); v != "" {
+				it.StoryPoints = storyPoints(v)
+			}
+		}
+
+		// Комментарий: "02/Jan/23 10:00 AM;автор;текст"
+		for _, c := range all("comment") {
+			comment := importer.Comment{Body: c}
+			if parts := strings.SplitN(c, ";", 3); len(parts) == 3 {
+				if created, ok := parseTime(parts[0], jiraCSVLayouts...); ok && created != nil {
+					comment = importer.Comment{
+						Author:    importer.Person{ID: parts[1]},
+						Body:      parts[2],
+						CreatedAt: *created,
+					}
+				}
+			}
+			it.Comments = append(it.Comments, comment)
+		}
+
+		for _, l := range links {
+			if l.column < len(row) && strings.TrimSpace(row[l.column]) != "" {
+				it.Links = append(it.Links, importer.Link{Type: l.linkType, Target: strings.TrimSpace(row[l.column])})
+			}
+		}
+
+		items = append(items, it)
+	}
+}
+
+type jiraCSVLink struct {
+	column   int
+	linkType string
+}
+
+// jiraCSVLinkType разбирает заголовок "Outward issue link (Blocks)".
+func jiraCSVLinkType(column string) (string, bool) {
+	column = strings.TrimSpace(column)
+	for _, prefix := range []string{"Outward issue link (", "Inward issue link ("} {
+		rest, ok := strings.CutPrefix(column, prefix)
+		if !ok {
+			continue
+		}
+		linkType := strings.ToLower(strings.TrimSuffix(rest, ")"))
+		if prefix == "Inward issue link (" {
+			linkType += " (inward)"
+		}
+		return linkType, true
+	}
+	return "", false
+}
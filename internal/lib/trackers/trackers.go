@@ -0,0 +1,57 @@
+// Package trackers читает файлы экспорта Jira, Trello и GitHub Issues
+// и приводит элементы к importer.Item.
+package trackers
+
+import (
+	"ProjectManagementAPI/internal/domain/importer"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Parse разбирает файл формата source.
+func Parse(source string, r io.Reader) ([]importer.Item, error) {
+	switch source {
+	case importer.SourceJiraXML:
+		return parseJiraXML(r)
+	case importer.SourceJiraCSV:
+		return parseJiraCSV(r)
+	case importer.SourceTrello:
+		return parseTrello(r)
+	case importer.SourceGitHub:
+		return parseGitHub(r)
+	}
+	return nil, importer.ErrInvalidSource
+}
+
+var (
+	reBreak = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</li>|</h\d>|</div>`)
+	reTag   = regexp.MustCompile(`<[^>]*>`)
+	reBlank = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToText превращает HTML описания в текст: переносы строк сохраняются,
+// остальная разметка отбрасывается.
+func htmlToText(s string) string {
+	s = reBreak.ReplaceAllString(s, "\n")
+	s = reTag.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.TrimSpace(reBlank.ReplaceAllString(s, "\n\n"))
+}
+
+// parseTime пробует форматы по очереди; пустая строка - нет времени.
+func parseTime(s string, layouts ...string) (*time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, true
+	}
+	for _, l := range layouts {
+		if t, err := time.Parse(l, s); err == nil {
+			return &t, true
+		}
+	}
+	return nil, false
+}
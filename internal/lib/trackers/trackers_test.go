@@ -0,0 +1,263 @@
+package trackers
+
+import (
+	"ProjectManagementAPI/internal/domain/importer"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func parse(t *testing.T, source, file string) []importer.Item {
+	t.Helper()
+
+	items, err := Parse(source, strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("Parse(%s): %v", source, err)
+	}
+	return items
+}
+
+const jiraXML = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="0.92"><channel>
+<item>
+	<key id="10001">PROJ-1</key>
+	<summary> Login fails </summary>
+	<description>&lt;p&gt;Steps:&lt;br/&gt;1. open&lt;/p&gt;&lt;p&gt;2. &amp;amp; click&lt;/p&gt;</description>
+	<type id="1">Bug</type>
+	<status id="3">In Review</status>
+	<statusCategory id="4" key="indeterminate"/>
+	<resolution>Unresolved</resolution>
+	<assignee username="jdoe" accountid="5b10ac">John Doe</assignee>
+	<labels><label>backend</label><label>auth</label></labels>
+	<due>Mon, 2 Mar 2026 00:00:00 +0000</due>
+	<comments>
+		<comment id="1" author="5b10ac" created="Tue, 3 Mar 2026 10:15:00 +0000">&lt;p&gt;Reproduced&lt;/p&gt;</comment>
+	</comments>
+	<issuelinks>
+		<issuelinktype id="1">
+			<outwardlinks description="blocks"><issuelink><issuekey id="2">PROJ-2</issuekey></issuelink></outwardlinks>
+			<inwardlinks description="is blocked by"><issuelink><issuekey id="3">PROJ-3</issuekey></issuelink></inwardlinks>
+		</issuelinktype>
+	</issuelinks>
+	<customfields>
+		<customfield id="customfield_10016"><customfieldname>Story point estimate</customfieldname>
+			<customfieldvalues><customfieldvalue>2.5</customfieldvalue></customfieldvalues></customfield>
+	</customfields>
+</item>
+<item>
+	<key id="10002">PROJ-2</key>
+	<summary>Done task</summary>
+	<status id="5">Closed</status>
+	<statusCategory id="3" key="done"/>
+	<assignee username="-1">Unassigned</assignee>
+	<due></due>
+</item>
+</channel></rss>`
+
+func TestParseJiraXML(t *testing.T) {
+	items := parse(t, importer.SourceJiraXML, jiraXML)
+	if len(items) != 2 {
+		t.Fatalf("items = %d, want 2", len(items))
+	}
+
+	it := items[0]
+	if it.ExternalID != "PROJ-1" || it.Key != "PROJ-1" || it.Kind != "Bug" || it.Title != "Login fails" {
+		t.Errorf("item = %+v", it)
+	}
+	if want := "Steps:\n1. open\n2. & click"; it.Description != want {
+		t.Errorf("description = %q, want %q", it.Description, want)
+	}
+	if it.Status != "In Review" || it.Closed {
+		t.Errorf("status = %q, closed %v", it.Status, it.Closed)
+	}
+	if want := []importer.Person{{ID: "5b10ac", Name: "John Doe"}}; !slices.Equal(it.Assignees, want) {
+		t.Errorf("assignees = %+v, want %+v", it.Assignees, want)
+	}
+	if !slices.Equal(it.Labels, []string{"backend", "auth"}) {
+		t.Errorf("labels = %v", it.Labels)
+	}
+	if it.Due == nil || !it.Due.Equal(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("due = %v", it.Due)
+	}
+	if it.StoryPoints != 3 {
+		t.Errorf("story points = %d, want 2.5 rounded to 3", it.StoryPoints)
+	}
+	if len(it.Comments) != 1 || it.Comments[0].Body != "Reproduced" || it.Comments[0].Author.ID != "5b10ac" ||
+		!it.Comments[0].CreatedAt.Equal(time.Date(2026, 3, 3, 10, 15, 0, 0, time.UTC)) {
+		t.Errorf("comments = %+v", it.Comments)
+	}
+	wantLinks := []importer.Link{{Type: "blocks", Target: "PROJ-2"}, {Type: "is blocked by", Target: "PROJ-3"}}
+	if !slices.Equal(it.Links, wantLinks) {
+		t.Errorf("links = %+v, want %+v", it.Links, wantLinks)
+	}
+
+	done := items[1]
+	if !done.Closed || len(done.Assignees) != 0 || done.Due != nil {
+		t.Errorf("done item = %+v", done)
+	}
+}
+
+func TestParseJiraXMLInvalidDue(t *testing.T) {
+	file := `<rss><channel><item><key>PROJ-1</key><due>next week</due></item></channel></rss>`
+	if _, err := Parse(importer.SourceJiraXML, strings.NewReader(file)); err == nil || !strings.Contains(err.Error(), "PROJ-1") {
+		t.Errorf("error = %v, want invalid due date of PROJ-1", err)
+	}
+}
+
+func TestParseJiraCSV(t *testing.T) {
+	file := "\ufeffSummary,Issue key,Issue Type,Status,Status Category,Resolution,Assignee,Assignee Id,Labels,Labels," +
+		"Due date,Custom field (Story Points),Comment,Comment,Outward issue link (Blocks),Inward issue link (Cloners)\n" +
+		`Fix login,PROJ-1,Bug,Open,To Do,,John Doe,5b10ac,backend,,2/Mar/26 3:04 PM,5,` +
+		`"02/Mar/26 10:00 AM;5b10ac;First; with semicolon",plain note,PROJ-2,PROJ-0` + "\n" +
+		`Old task,PROJ-2,Task,Done,Done,Fixed,,,,,,,,,,` + "\n"
+
+	items := parse(t, importer.SourceJiraCSV, file)
+	if len(items) != 2 {
+		t.Fatalf("items = %d, want 2", len(items))
+	}
+
+	it := items[0]
+	if it.ExternalID != "PROJ-1" || it.Title != "Fix login" || it.Kind != "Bug" || it.Status != "Open" || it.Closed {
+		t.Errorf("item = %+v", it)
+	}
+	if want := []importer.Person{{ID: "5b10ac", Name: "John Doe"}}; !slices.Equal(it.Assignees, want) {
+		t.Errorf("assignees = %+v", it.Assignees)
+	}
+	if !slices.Equal(it.Labels, []string{"backend"}) {
+		t.Errorf("labels = %v", it.Labels)
+	}
+	if it.Due == nil || !it.Due.Equal(time.Date(2026, 3, 2, 15, 4, 0, 0, time.UTC)) {
+		t.Errorf("due = %v", it.Due)
+	}
+	if it.StoryPoints != 5 {
+		t.Errorf("story points = %d", it.StoryPoints)
+	}
+	if len(it.Comments) != 2 {
+		t.Fatalf("comments = %+v", it.Comments)
+	}
+	if c := it.Comments[0]; c.Author.ID != "5b10ac" || c.Body != "First; with semicolon" || c.CreatedAt.IsZero() {
+		t.Errorf("comment = %+v", c)
+	}
+	if c := it.Comments[1]; c.Body != "plain note" || !c.Author.IsZero() {
+		t.Errorf("comment without metadata = %+v", c)
+	}
+	wantLinks := []importer.Link{{Type: "blocks", Target: "PROJ-2"}, {Type: "cloners (inward)", Target: "PROJ-0"}}
+	if !slices.Equal(it.Links, wantLinks) {
+		t.Errorf("links = %+v, want %+v", it.Links, wantLinks)
+	}
+
+	if !items[1].Closed || len(items[1].Assignees) != 0 {
+		t.Errorf("resolved item = %+v", items[1])
+	}
+}
+
+func TestParseJiraCSVWithoutKey(t *testing.T) {
+	file := "Summary,Issue key\nNo key,\n"
+	if _, err := Parse(importer.SourceJiraCSV, strings.NewReader(file)); err == nil {
+		t.Error("row without Issue key accepted")
+	}
+}
+
+func TestParseTrello(t *testing.T) {
+	file := `{
+		"lists": [{"id": "l1", "name": "Doing"}, {"id": "l2", "name": "Old", "closed": true}],
+		"members": [{"id": "m1", "fullName": "Ann Lee", "username": "ann"}],
+		"cards": [
+			{"id": "c1", "shortLink": "AbC", "name": " Card ", "desc": "text", "idList": "l1",
+				"due": "2026-03-02T12:00:00.000Z", "idMembers": ["m1", "m9"],
+				"labels": [{"name": "Urgent", "color": "red"}, {"name": "", "color": "green"}, {"name": "", "color": ""}]},
+			{"id": "c2", "name": "Archived", "idList": "l1", "closed": true},
+			{"id": "c3", "name": "In old list", "idList": "l2"}
+		],
+		"actions": [
+			{"type": "commentCard", "date": "2026-03-03T10:00:00Z", "idMemberCreator": "m1",
+				"data": {"text": "second", "card": {"id": "c1"}}},
+			{"type": "updateCard", "data": {"card": {"id": "c1"}}},
+			{"type": "commentCard", "date": "2026-03-02T10:00:00Z", "idMemberCreator": "x1",
+				"memberCreator": {"fullName": "Guest", "username": "guest"},
+				"data": {"text": "first", "card": {"id": "c1"}}}
+		]
+	}`
+
+	items := parse(t, importer.SourceTrello, file)
+	if len(items) != 3 {
+		t.Fatalf("items = %d, want 3", len(items))
+	}
+
+	it := items[0]
+	if it.ExternalID != "c1" || it.Key != "AbC" || it.Title != "Card" || it.Status != "Doing" || it.Skip != "" {
+		t.Errorf("item = %+v", it)
+	}
+	wantAssignees := []importer.Person{{ID: "m1", Login: "ann", Name: "Ann Lee"}, {ID: "m9"}}
+	if !slices.Equal(it.Assignees, wantAssignees) {
+		t.Errorf("assignees = %+v, want %+v", it.Assignees, wantAssignees)
+	}
+	if !slices.Equal(it.Labels, []string{"Urgent", "green"}) {
+		t.Errorf("labels = %v", it.Labels)
+	}
+	if it.Due == nil || !it.Due.Equal(time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("due = %v", it.Due)
+	}
+	// действия в экспорте идут от новых к старым
+	if len(it.Comments) != 2 || it.Comments[0].Body != "first" || it.Comments[1].Body != "second" {
+		t.Fatalf("comments = %+v", it.Comments)
+	}
+	if a := it.Comments[0].Author; a != (importer.Person{ID: "x1", Login: "guest", Name: "Guest"}) {
+		t.Errorf("guest author = %+v", a)
+	}
+
+	if items[1].Skip != "archived card" || items[2].Skip != "card in archived list Old" {
+		t.Errorf("skip = %q, %q", items[1].Skip, items[2].Skip)
+	}
+}
+
+func TestParseGitHub(t *testing.T) {
+	issues := `[
+		{"url": "https://api.github.com/repos/acme/app/issues/12", "html_url": "https://github.com/acme/app/issues/12",
+			"number": 12, "title": "Crash", "body": " trace ", "state": "closed",
+			"assignees": [{"login": "octo"}], "labels": [{"name": "bug"}],
+			"milestone": {"due_on": "2026-03-02T08:00:00Z"}},
+		{"html_url": "https://ghe.example.com/acme/app/pull/13", "number": 13, "title": "Fix", "state": "open",
+			"pull_request": {"url": "x"}},
+		{"number": 14, "title": "No url", "state": "open", "milestone": null, "pull_request": null}
+	]`
+
+	items := parse(t, importer.SourceGitHub, "\n  "+issues)
+	if len(items) != 3 {
+		t.Fatalf("items = %d, want 3", len(items))
+	}
+
+	it := items[0]
+	if it.ExternalID != "acme/app#12" || it.Key != "#12" || it.Title != "Crash" || it.Description != "trace" || !it.Closed {
+		t.Errorf("item = %+v", it)
+	}
+	if !slices.Equal(it.Assignees, []importer.Person{{Login: "octo"}}) || !slices.Equal(it.Labels, []string{"bug"}) {
+		t.Errorf("assignees = %+v, labels = %v", it.Assignees, it.Labels)
+	}
+	if it.Due == nil || !it.Due.Equal(time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("due = %v", it.Due)
+	}
+
+	if items[1].ExternalID != "acme/app#13" || items[1].Skip != "pull request" {
+		t.Errorf("pull request = %+v", items[1])
+	}
+	if items[2].ExternalID != "#14" || items[2].Skip != "" || items[2].Due != nil {
+		t.Errorf("issue without url = %+v", items[2])
+	}
+
+	// объект с комментариями
+	withComments := `{"issues": ` + issues + `, "comments": [{"issue_url": "https://api.github.com/repos/acme/app/issues/12",
+		"user": {"login": "octo"}, "body": "done", "created_at": "2026-03-03T00:00:00Z"}]}`
+	items = parse(t, importer.SourceGitHub, withComments)
+	if c := items[0].Comments; len(c) != 1 || c[0].Body != "done" || c[0].Author.Login != "octo" {
+		t.Errorf("comments = %+v", c)
+	}
+}
+
+func TestParseUnknownSource(t *testing.T) {
+	if _, err := Parse("asana", strings.NewReader("{}")); !errors.Is(err, importer.ErrInvalidSource) {
+		t.Errorf("error = %v, want ErrInvalidSource", err)
+	}
+}
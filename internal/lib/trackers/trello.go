@@ -0,0 +1,136 @@
+package trackers
+
+import (
+	"ProjectManagementAPI/internal/domain/importer"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+)
+
+type trelloBoard struct {
+	Lists []struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Closed bool   `json:"closed"`
+	} `json:"lists"`
+	Cards []struct {
+		ID        string     `json:"id"`
+		Name      string     `json:"name"`
+		Desc      string     `json:"desc"`
+		IDList    string     `json:"idList"`
+		Closed    bool       `json:"closed"`
+		Due       *time.Time `json:"due"`
+		IDMembers []string   `json:"idMembers"`
+		Labels    []struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		} `json:"labels"`
+		ShortLink string `json:"shortLink"`
+	} `json:"cards"`
+	Members []struct {
+		ID       string `json:"id"`
+		FullName string `json:"fullName"`
+		Username string `json:"username"`
+	} `json:"members"`
+	Actions []struct {
+		Type            string    `json:"type"`
+		Date            time.Time `json:"date"`
+		IDMemberCreator string    `json:"idMemberCreator"`
+		Data            struct {
+			Text string `json:"text"`
+			Card struct {
+				ID string `json:"id"`
+			} `json:"card"`
+		} `json:"data"`
+		MemberCreator *struct {
+			FullName string `json:"fullName"`
+			Username string `json:"username"`
+		} `json:"memberCreator"`
+	} `json:"actions"`
+}
+
+// parseTrello читает JSON доски (Menu - Print and export - Export as JSON).
+// Статус карточки - название её колонки. Архивные карточки и карточки
+// архивных колонок пропускаются.
+func parseTrello(r io.Reader) ([]importer.Item, error) {
+	var board trelloBoard
+	if err := json.NewDecoder(r).Decode(&board); err != nil {
+		return nil, err
+	}
+
+	type list struct {
+		name   string
+		closed bool
+	}
+	lists := make(map[string]list, len(board.Lists))
+	for _, l := range board.Lists {
+		lists[l.ID] = list{name: l.Name, closed: l.Closed}
+	}
+
+	members := make(map[string]importer.Person, len(board.Members))
+	for _, m := range board.Members {
+		members[m.ID] = importer.Person{ID: m.ID, Login: m.Username, Name: m.FullName}
+	}
+
+	// Экспорт содержит последние действия доски, комментарии - среди них
+	comments := make(map[string][]importer.Comment)
+	for i := len(board.Actions) - 1; i >= 0; i-- {
+		a := board.Actions[i]
+		if a.Type != "commentCard" {
+			continue
+		}
+		author, ok := members[a.IDMemberCreator]
+		if !ok {
+			author = importer.Person{ID: a.IDMemberCreator}
+			if a.MemberCreator != nil {
+				author.Login, author.Name = a.MemberCreator.Username, a.MemberCreator.FullName
+			}
+		}
+		comments[a.Data.Card.ID] = append(comments[a.Data.Card.ID], importer.Comment{
+			Author:    author,
+			Body:      a.Data.Text,
+			CreatedAt: a.Date,
+		})
+	}
+
+	items := make([]importer.Item, 0, len(board.Cards))
+	for _, c := range board.Cards {
+		l := lists[c.IDList]
+		it := importer.Item{
+			ExternalID:  c.ID,
+			Key:         c.ShortLink,
+			Title:       strings.TrimSpace(c.Name),
+			Description: strings.TrimSpace(c.Desc),
+			Status:      l.name,
+			Due:         c.Due,
+			Comments:    comments[c.ID],
+		}
+		switch {
+		case c.Closed:
+			it.Skip = "archived card"
+		case l.closed:
+			it.Skip = "card in archived list " + l.name
+		}
+
+		for _, id := range c.IDMembers {
+			p, ok := members[id]
+			if !ok {
+				p = importer.Person{ID: id}
+			}
+			it.Assignees = append(it.Assignees, p)
+		}
+		for _, label := range c.Labels {
+			// У меток Trello может не быть названия, только цвет
+			if label.Name != "" {
+				it.Labels = append(it.Labels, label.Name)
+			} else if label.Color != "" {
+				it.Labels = append(it.Labels, label.Color)
+			}
+		}
+
+		items = append(items, it)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,113 @@
+package audit
+
+import (
+	audit2 "ProjectManagementAPI/internal/domain/audit"
+	"ProjectManagementAPI/internal/storage/postgre"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Insert(ctx context.Context, e *audit2.Event) error {
+	const query = `INSERT INTO audit_events(actor_id, entity_type, entity_id, action, before, after, diff, request_id)
+		VALUES($1,$2,$3,$4,$5,$6,$7,$8)
+		RETURNING id, created_at`
+
+	var actorID uuid.NullUUID
+	if e.ActorID != nil {
+		actorID = uuid.NullUUID{UUID: *e.ActorID, Valid: true}
+	}
+
+	return postgre.Conn(ctx, r.db).QueryRowContext(ctx, query,
+		actorID, e.EntityType, e.EntityID, e.Action,
+		nullJSON(e.Before), nullJSON(e.After), nullJSON(e.Diff), e.RequestID,
+	).Scan(&e.ID, &e.CreatedAt)
+}
+
+func (r *Repository) List(ctx context.Context, f audit2.Filter) ([]audit2.Event, error) {
+	var (
+		where []string
+		args  []any
+	)
+
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+
+	if f.EntityType != "" {
+		add("entity_type=$%d", f.EntityType)
+	}
+	if f.EntityID != nil {
+		add("entity_id=$%d", *f.EntityID)
+	}
+	if f.ActorID != nil {
+		add("actor_id=$%d", *f.ActorID)
+	}
+	if f.Action != "" {
+		add("action=$%d", f.Action)
+	}
+	if f.From != nil {
+		add("created_at>=$%d", *f.From)
+	}
+	if f.To != nil {
+		add("created_at<$%d", *f.To)
+	}
+
+	query := `SELECT id, actor_id, entity_type, entity_id, action, before, after, diff, request_id, created_at
+		FROM audit_events`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	args = append(args, f.Limit, f.Offset)
+	query += fmt.Sprintf(" ORDER BY id DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []audit2.Event
+	for rows.Next() {
+		var (
+			e                   audit2.Event
+			actorID             uuid.NullUUID
+			requestID           sql.NullString
+			before, after, diff []byte
+		)
+		if err := rows.Scan(
+			&e.ID, &actorID, &e.EntityType, &e.EntityID, &e.Action,
+			&before, &after, &diff, &requestID, &e.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		e.Before, e.After, e.Diff = before, after, diff
+		if actorID.Valid {
+			e.ActorID = &actorID.UUID
+		}
+		e.RequestID = requestID.String
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
+
+func nullJSON(b []byte) any {
+	if len(b) == 0 {
+		return nil
+	}
+	return string(b)
+}
@@ -0,0 +1,173 @@
+package calendar
+
+import (
+	calendar2 "ProjectManagementAPI/internal/domain/calendar"
+	user2 "ProjectManagementAPI/internal/domain/user"
+	"ProjectManagementAPI/internal/storage/postgre"
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewCalendarRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// SaveToken выпускает токен пользователя, заменяя прежний.
+func (r *Repository) SaveToken(ctx context.Context, userID uuid.UUID, hash string) (*calendar2.Token, error) {
+	t := &calendar2.Token{UserID: userID, CreatedAt: time.Now()}
+
+	const query = `INSERT INTO calendar_tokens(user_id, token_hash, created_at) VALUES($1,$2,$3)
+		ON CONFLICT (user_id) DO UPDATE SET token_hash=EXCLUDED.token_hash, created_at=EXCLUDED.created_at`
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, userID, hash, t.CreatedAt)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return t, nil
+}
+
+func (r *Repository) Token(ctx context.Context, userID uuid.UUID) (*calendar2.Token, error) {
+	const query = `SELECT user_id, created_at FROM calendar_tokens WHERE user_id=$1`
+
+	t := &calendar2.Token{}
+	err := postgre.Conn(ctx, r.db).QueryRowContext(ctx, query, userID).Scan(&t.UserID, &t.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, calendar2.ErrTokenNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (r *Repository) DeleteToken(ctx context.Context, userID uuid.UUID) error {
+	const query = `DELETE FROM calendar_tokens WHERE user_id=$1`
+
+	res, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, userID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return calendar2.ErrTokenNotFound
+	}
+
+	return nil
+}
+
+// UserByToken возвращает владельца токена по его хэшу.
+func (r *Repository) UserByToken(ctx context.Context, hash string) (uuid.UUID, string, error) {
+	const query = `SELECT u.id, u.name FROM calendar_tokens c JOIN users u ON u.id = c.user_id WHERE c.token_hash=$1`
+
+	var (
+		id   uuid.UUID
+		name string
+	)
+	err := postgre.Conn(ctx, r.db).QueryRowContext(ctx, query, hash).Scan(&id, &name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.Nil, "", calendar2.ErrTokenNotFound
+	}
+	return id, name, err
+}
+
+// Items возвращает задачи пользователя со сроком по возрастанию срока.
+func (r *Repository) Items(ctx context.Context, userID uuid.UUID, f calendar2.Filter) ([]calendar2.Item, error) {
+	query := `SELECT t.id, t.title, t.description, t.status, t.due_at, COALESCE(p.name, ''), t.created_at, t.updated_at
+		FROM tasks t
+		JOIN user_tasks ut ON ut.task_id = t.id AND ut.user_id = $1
+		LEFT JOIN projects p ON p.id = t.project_id
+		WHERE t.due_at IS NOT NULL`
+	args := []any{userID}
+	if len(f.ProjectIDs) > 0 {
+		args = append(args, f.ProjectIDs)
+		query += ` AND t.project_id = ANY($` + strconv.Itoa(len(args)) + `)`
+	}
+	if len(f.Statuses) > 0 {
+		args = append(args, f.Statuses)
+		query += ` AND t.status = ANY($` + strconv.Itoa(len(args)) + `)`
+	}
+	query += ` ORDER BY t.due_at, t.id`
+
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var (
+		items []calendar2.Item
+		ids   []uuid.UUID
+	)
+	for rows.Next() {
+		var it calendar2.Item
+		if err := rows.Scan(&it.TaskID, &it.Title, &it.Description, &it.Status, &it.DueAt, &it.Project,
+			&it.CreatedAt, &it.UpdatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, it)
+		ids = append(ids, it.TaskID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadLabels(ctx, ids, items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (r *Repository) loadLabels(ctx context.Context, ids []uuid.UUID, items []calendar2.Item) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	const query = `SELECT task_id, label FROM task_labels WHERE task_id = ANY($1) ORDER BY task_id, label`
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	labels := make(map[uuid.UUID][]string)
+	for rows.Next() {
+		var (
+			taskID uuid.UUID
+			label  string
+		)
+		if err := rows.Scan(&taskID, &label); err != nil {
+			return err
+		}
+		labels[taskID] = append(labels[taskID], label)
+	}
+	for i := range items {
+		items[i].Labels = labels[items[i].TaskID]
+	}
+
+	return rows.Err()
+}
+
+func mapError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	if pgErr.Code == "23503" && pgErr.ConstraintName == "calendar_tokens_user_id_fkey" {
+		return user2.ErrUserNotFound
+	}
+
+	return err
+}
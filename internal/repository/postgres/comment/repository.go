@@ -0,0 +1,152 @@
+package comment
+
+import (
+	comment2 "ProjectManagementAPI/internal/domain/comment"
+	task2 "ProjectManagementAPI/internal/domain/task"
+	user2 "ProjectManagementAPI/internal/domain/user"
+	"ProjectManagementAPI/internal/storage/postgre"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewCommentRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+const commentColumns = `c.id, c.task_id, c.author_id, c.body, COALESCE(u.name, ''), c.created_at, c.updated_at`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanComment(row scanner) (*comment2.Comment, error) {
+	var (
+		c        comment2.Comment
+		authorID uuid.NullUUID
+	)
+	err := row.Scan(&c.ID, &c.TaskID, &authorID, &c.Body, &c.AuthorName, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if authorID.Valid {
+		c.AuthorID = &authorID.UUID
+	}
+	return &c, nil
+}
+
+func (r *Repository) Create(ctx context.Context, c *comment2.Comment) error {
+	c.ID = uuid.New()
+	// Импорт из других трекеров сохраняет исходное время комментария
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = time.Now()
+	}
+	c.UpdatedAt = c.CreatedAt
+
+	const query = `INSERT INTO comments(id, task_id, author_id, body, created_at, updated_at)
+		VALUES($1,$2,$3,$4,$5,$6)`
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query,
+		c.ID, c.TaskID, nullUUID(c.AuthorID), c.Body, c.CreatedAt, c.UpdatedAt,
+	)
+	return mapError(err)
+}
+
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*comment2.Comment, error) {
+	const query = `SELECT ` + commentColumns + `
+		FROM comments c LEFT JOIN users u ON u.id = c.author_id
+		WHERE c.id=$1`
+
+	c, err := scanComment(postgre.Conn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, comment2.ErrCommentNotFound
+	}
+	return c, err
+}
+
+// ListByTask возвращает комментарии задачи в порядке написания.
+func (r *Repository) ListByTask(ctx context.Context, taskID uuid.UUID, limit, offset int) ([]comment2.Comment, error) {
+	const query = `SELECT ` + commentColumns + `
+		FROM comments c LEFT JOIN users u ON u.id = c.author_id
+		WHERE c.task_id=$1
+		ORDER BY c.created_at, c.id
+		LIMIT $2 OFFSET $3`
+
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, taskID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []comment2.Comment
+	for rows.Next() {
+		c, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *c)
+	}
+
+	return list, rows.Err()
+}
+
+// UpdateBody меняет текст комментария автора authorID к задаче taskID.
+func (r *Repository) UpdateBody(ctx context.Context, authorID, taskID, id uuid.UUID, body string) error {
+	const query = `UPDATE comments SET body=$4, updated_at=$5 WHERE id=$1 AND task_id=$2 AND author_id=$3`
+	res, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, id, taskID, authorID, body, time.Now())
+	if err != nil {
+		return err
+	}
+	return checkAffected(res)
+}
+
+// DeleteByID удаляет комментарий автора authorID к задаче taskID.
+func (r *Repository) DeleteByID(ctx context.Context, authorID, taskID, id uuid.UUID) error {
+	const query = `DELETE FROM comments WHERE id=$1 AND task_id=$2 AND author_id=$3`
+	res, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, id, taskID, authorID)
+	if err != nil {
+		return err
+	}
+	return checkAffected(res)
+}
+
+func checkAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return comment2.ErrCommentNotFound
+	}
+	return nil
+}
+
+func nullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func mapError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch {
+	case pgErr.Code == "23503" && pgErr.ConstraintName == "comments_task_id_fkey":
+		return task2.ErrTaskNotFound
+	case pgErr.Code == "23503" && pgErr.ConstraintName == "comments_author_id_fkey":
+		return user2.ErrUserNotFound
+	}
+
+	return err
+}
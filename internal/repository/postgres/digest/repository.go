@@ -0,0 +1,186 @@
+package digest
+
+import (
+	digest2 "ProjectManagementAPI/internal/domain/digest"
+	task2 "ProjectManagementAPI/internal/domain/task"
+	"ProjectManagementAPI/internal/storage/postgre"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewDigestRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Settings(ctx context.Context, userID uuid.UUID) (*digest2.Settings, error) {
+	const query = `SELECT user_id, frequency, timezone, send_hour, weekday FROM digest_settings WHERE user_id=$1`
+
+	s := &digest2.Settings{}
+	err := postgre.Conn(ctx, r.db).QueryRowContext(ctx, query, userID).
+		Scan(&s.UserID, &s.Frequency, &s.Timezone, &s.SendHour, &s.Weekday)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, digest2.ErrSettingsNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (r *Repository) SaveSettings(ctx context.Context, s *digest2.Settings) error {
+	const query = `INSERT INTO digest_settings(user_id, frequency, timezone, send_hour, weekday, updated_at)
+		VALUES($1,$2,$3,$4,$5,NOW())
+		ON CONFLICT (user_id) DO UPDATE SET frequency=EXCLUDED.frequency, timezone=EXCLUDED.timezone,
+			send_hour=EXCLUDED.send_hour, weekday=EXCLUDED.weekday, updated_at=EXCLUDED.updated_at`
+
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, s.UserID, s.Frequency, s.Timezone, s.SendHour, int(s.Weekday))
+	return err
+}
+
+// Recipients возвращает пользователей с включённым дайджестом.
+func (r *Repository) Recipients(ctx context.Context) ([]digest2.Recipient, error) {
+	const query = `SELECT s.user_id, s.frequency, s.timezone, s.send_hour, s.weekday, u.email, u.name
+		FROM digest_settings s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.frequency <> 'off'
+		ORDER BY s.user_id`
+
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []digest2.Recipient
+	for rows.Next() {
+		var rc digest2.Recipient
+		if err := rows.Scan(
+			&rc.UserID, &rc.Frequency, &rc.Timezone, &rc.SendHour, &rc.Weekday, &rc.Email, &rc.Name,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, rc)
+	}
+
+	return list, rows.Err()
+}
+
+// Items возвращает задачи пользователя, изменённые после since, а также
+// незавершённые задачи со сроком до dueBefore.
+func (r *Repository) Items(ctx context.Context, userID uuid.UUID, since, dueBefore time.Time) ([]digest2.Item, error) {
+	const query = `SELECT t.id, t.title, t.status, t.due_at, t.updated_at
+		FROM tasks t
+		JOIN user_tasks ut ON ut.task_id = t.id
+		WHERE ut.user_id = $1
+		  AND (t.updated_at >= $2 OR (t.status <> $4 AND t.due_at < $3))
+		ORDER BY t.due_at NULLS LAST, t.updated_at DESC`
+
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, userID, since, dueBefore, task2.StatusDone)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []digest2.Item
+	for rows.Next() {
+		var (
+			it    digest2.Item
+			dueAt sql.NullTime
+		)
+		if err := rows.Scan(&it.TaskID, &it.Title, &it.Status, &dueAt, &it.UpdatedAt); err != nil {
+			return nil, err
+		}
+		if dueAt.Valid {
+			it.DueAt = &dueAt.Time
+		}
+		items = append(items, it)
+	}
+
+	return items, rows.Err()
+}
+
+// LastSentAt возвращает время последнего отправленного дайджеста.
+func (r *Repository) LastSentAt(ctx context.Context, userID uuid.UUID, frequency string) (*time.Time, error) {
+	const query = `SELECT MAX(sent_at) FROM digest_runs WHERE user_id=$1 AND frequency=$2 AND status IN ('sent', 'empty')`
+
+	var at sql.NullTime
+	if err := postgre.Conn(ctx, r.db).QueryRowContext(ctx, query, userID, frequency).Scan(&at); err != nil {
+		return nil, err
+	}
+	if !at.Valid {
+		return nil, nil
+	}
+
+	return &at.Time, nil
+}
+
+// ClaimRun резервирует отправку за период. Повторно забрать можно только
+// неудачную попытку, пока не исчерпан maxAttempts.
+func (r *Repository) ClaimRun(ctx context.Context, userID uuid.UUID, frequency, periodKey string, maxAttempts int) (int64, bool, error) {
+	const query = `INSERT INTO digest_runs(user_id, frequency, period_key, status)
+		VALUES($1,$2,$3,'sending')
+		ON CONFLICT (user_id, frequency, period_key) DO UPDATE
+			SET status='sending', attempts=digest_runs.attempts+1, error=NULL
+			WHERE digest_runs.status='failed' AND digest_runs.attempts < $4
+		RETURNING id`
+
+	var id int64
+	err := postgre.Conn(ctx, r.db).QueryRowContext(ctx, query, userID, frequency, periodKey, maxAttempts).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, err
+	}
+
+	return id, true, nil
+}
+
+func (r *Repository) FinishRun(ctx context.Context, id int64, status string, items int, lastError string) error {
+	const query = `UPDATE digest_runs
+		SET status=$2, items=$3, error=NULLIF($4, ''), sent_at=CASE WHEN $2 IN ('sent', 'empty') THEN NOW() END
+		WHERE id=$1`
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, id, status, items, lastError)
+	return err
+}
+
+func (r *Repository) Runs(ctx context.Context, userID uuid.UUID, limit, offset int) ([]digest2.Run, error) {
+	const query = `SELECT id, user_id, frequency, period_key, status, items, attempts, COALESCE(error, ''), created_at, sent_at
+		FROM digest_runs
+		WHERE user_id=$1
+		ORDER BY id DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []digest2.Run
+	for rows.Next() {
+		var (
+			run    digest2.Run
+			sentAt sql.NullTime
+		)
+		if err := rows.Scan(
+			&run.ID, &run.UserID, &run.Frequency, &run.PeriodKey, &run.Status, &run.Items, &run.Attempts, &run.Error,
+			&run.CreatedAt, &sentAt,
+		); err != nil {
+			return nil, err
+		}
+		if sentAt.Valid {
+			run.SentAt = &sentAt.Time
+		}
+		runs = append(runs, run)
+	}
+
+	return runs, rows.Err()
+}
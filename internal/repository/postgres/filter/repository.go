@@ -0,0 +1,134 @@
+package filter
+
+import (
+	filter2 "ProjectManagementAPI/internal/domain/filter"
+	user2 "ProjectManagementAPI/internal/domain/user"
+	"ProjectManagementAPI/internal/storage/postgre"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewFilterRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+const filterColumns = `id, owner_id, name, query, shared, created_at, updated_at`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanFilter(row scanner) (*filter2.Filter, error) {
+	var f filter2.Filter
+	err := row.Scan(&f.ID, &f.OwnerID, &f.Name, &f.Query, &f.Shared, &f.CreatedAt, &f.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *Repository) Create(ctx context.Context, f *filter2.Filter) error {
+	f.ID = uuid.New()
+	f.CreatedAt = time.Now()
+	f.UpdatedAt = f.CreatedAt
+
+	const query = `INSERT INTO saved_filters(` + filterColumns + `) VALUES($1,$2,$3,$4,$5,$6,$7)`
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query,
+		f.ID, f.OwnerID, f.Name, f.Query, f.Shared, f.CreatedAt, f.UpdatedAt,
+	)
+	return mapError(err)
+}
+
+// GetVisible возвращает фильтр, если он принадлежит userID или общий.
+func (r *Repository) GetVisible(ctx context.Context, userID, id uuid.UUID) (*filter2.Filter, error) {
+	const query = `SELECT ` + filterColumns + ` FROM saved_filters WHERE id=$1 AND (owner_id=$2 OR shared)`
+
+	f, err := scanFilter(postgre.Conn(ctx, r.db).QueryRowContext(ctx, query, id, userID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, filter2.ErrFilterNotFound
+	}
+	return f, err
+}
+
+// ListVisible возвращает свои фильтры пользователя и общие фильтры остальных.
+func (r *Repository) ListVisible(ctx context.Context, userID uuid.UUID) ([]filter2.Filter, error) {
+	const query = `SELECT ` + filterColumns + ` FROM saved_filters
+		WHERE owner_id=$1 OR shared
+		ORDER BY owner_id <> $1, name, id`
+
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []filter2.Filter
+	for rows.Next() {
+		f, err := scanFilter(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *f)
+	}
+
+	return list, rows.Err()
+}
+
+// Update меняет фильтр владельца f.OwnerID.
+func (r *Repository) Update(ctx context.Context, f *filter2.Filter) error {
+	f.UpdatedAt = time.Now()
+
+	const query = `UPDATE saved_filters SET name=$3, query=$4, shared=$5, updated_at=$6
+		WHERE id=$1 AND owner_id=$2
+		RETURNING created_at`
+	err := postgre.Conn(ctx, r.db).QueryRowContext(ctx, query,
+		f.ID, f.OwnerID, f.Name, f.Query, f.Shared, f.UpdatedAt,
+	).Scan(&f.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return filter2.ErrFilterNotFound
+	}
+	return mapError(err)
+}
+
+func (r *Repository) DeleteByID(ctx context.Context, ownerID, id uuid.UUID) error {
+	const query = `DELETE FROM saved_filters WHERE id=$1 AND owner_id=$2`
+	res, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, id, ownerID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return filter2.ErrFilterNotFound
+	}
+
+	return nil
+}
+
+func mapError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch {
+	case pgErr.Code == "23505" && pgErr.ConstraintName == "saved_filters_owner_name_key":
+		return filter2.ErrDuplicateName
+	case pgErr.Code == "23503" && pgErr.ConstraintName == "saved_filters_owner_id_fkey":
+		return user2.ErrUserNotFound
+	}
+
+	return err
+}
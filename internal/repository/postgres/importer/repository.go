@@ -0,0 +1,209 @@
+package importer
+
+import (
+	importer2 "ProjectManagementAPI/internal/domain/importer"
+	user2 "ProjectManagementAPI/internal/domain/user"
+	"ProjectManagementAPI/internal/storage/postgre"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewImporterRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+const importColumns = `id, source, status, options, report, COALESCE(error, ''), created_at, finished_at`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanImport(row scanner) (*importer2.Import, error) {
+	var (
+		imp             importer2.Import
+		options, report []byte
+		finishedAt      sql.NullTime
+	)
+	err := row.Scan(&imp.ID, &imp.Source, &imp.Status, &options, &report, &imp.Error, &imp.CreatedAt, &finishedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(options, &imp.Options); err != nil {
+		return nil, err
+	}
+	if report != nil {
+		imp.Report = &importer2.Report{}
+		if err := json.Unmarshal(report, imp.Report); err != nil {
+			return nil, err
+		}
+	}
+	if finishedAt.Valid {
+		imp.FinishedAt = &finishedAt.Time
+	}
+	return &imp, nil
+}
+
+// Create сохраняет импорт вместе с файлом; файл удаляется по завершении.
+func (r *Repository) Create(ctx context.Context, imp *importer2.Import, file []byte) error {
+	imp.ID = uuid.New()
+	imp.Status = importer2.StatusPending
+	imp.CreatedAt = time.Now()
+
+	options, err := json.Marshal(imp.Options)
+	if err != nil {
+		return err
+	}
+
+	const query = `INSERT INTO imports(id, source, status, options, file, created_at) VALUES($1,$2,$3,$4,$5,$6)`
+	_, err = postgre.Conn(ctx, r.db).ExecContext(ctx, query,
+		imp.ID, imp.Source, imp.Status, options, file, imp.CreatedAt,
+	)
+	return err
+}
+
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*importer2.Import, error) {
+	const query = `SELECT ` + importColumns + ` FROM imports WHERE id=$1`
+
+	imp, err := scanImport(postgre.Conn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, importer2.ErrImportNotFound
+	}
+	return imp, err
+}
+
+// Start переводит незавершённый импорт в running и возвращает его файл.
+// Успешный импорт не запускается повторно: ok=false.
+func (r *Repository) Start(ctx context.Context, id uuid.UUID) (imp *importer2.Import, file []byte, ok bool, err error) {
+	const query = `UPDATE imports SET status=$2, error=NULL
+		WHERE id=$1 AND status <> $3 AND file IS NOT NULL
+		RETURNING ` + importColumns + `, file`
+
+	row := postgre.Conn(ctx, r.db).QueryRowContext(ctx, query, id, importer2.StatusRunning, importer2.StatusSucceeded)
+	imp, err = scanImport(withFile{row: row, file: &file})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, false, nil
+	}
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return imp, file, true, nil
+}
+
+// withFile дочитывает колонку file после importColumns.
+type withFile struct {
+	row  scanner
+	file *[]byte
+}
+
+func (w withFile) Scan(dest ...any) error {
+	return w.row.Scan(append(dest, w.file)...)
+}
+
+// Finish записывает итог. Файл удаляется только после успеха, чтобы
+// упавший импорт можно было перезапустить.
+func (r *Repository) Finish(ctx context.Context, id uuid.UUID, status string, report *importer2.Report, errText string) error {
+	var raw []byte
+	if report != nil {
+		var err error
+		if raw, err = json.Marshal(report); err != nil {
+			return err
+		}
+	}
+
+	const query = `UPDATE imports SET status=$2, report=$3, error=NULLIF($4, ''), finished_at=NOW(),
+			file = CASE WHEN $2 = 'succeeded' THEN NULL ELSE file END
+		WHERE id=$1`
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, id, status, raw, errText)
+	return err
+}
+
+// Imported возвращает задачи, уже перенесённые из system, по external_id.
+func (r *Repository) Imported(ctx context.Context, system string, externalIDs []string) (map[string]uuid.UUID, error) {
+	res := make(map[string]uuid.UUID)
+	if len(externalIDs) == 0 {
+		return res, nil
+	}
+
+	const query = `SELECT external_id, task_id FROM import_refs WHERE system=$1 AND external_id = ANY($2)`
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, system, externalIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			externalID string
+			taskID     uuid.UUID
+		)
+		if err := rows.Scan(&externalID, &taskID); err != nil {
+			return nil, err
+		}
+		res[externalID] = taskID
+	}
+
+	return res, rows.Err()
+}
+
+func (r *Repository) SaveRef(ctx context.Context, system, externalID string, taskID uuid.UUID, importID *uuid.UUID) error {
+	const query = `INSERT INTO import_refs(system, external_id, task_id, import_id) VALUES($1,$2,$3,$4)`
+	var id uuid.NullUUID
+	if importID != nil {
+		id = uuid.NullUUID{UUID: *importID, Valid: true}
+	}
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, system, externalID, taskID, id)
+	return err
+}
+
+// Users возвращает всех пользователей: внешних пользователей сопоставляют
+// по email и имени, и одного запроса на весь импорт достаточно.
+func (r *Repository) Users(ctx context.Context) ([]user2.User, error) {
+	const query = `SELECT id, email, name FROM users`
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []user2.User
+	for rows.Next() {
+		var u user2.User
+		if err := rows.Scan(&u.ID, &u.Email, &u.Name); err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+
+	return users, rows.Err()
+}
+
+// Projects находит проекты по UUID или названию без учёта регистра.
+func (r *Repository) Projects(ctx context.Context, ref string) ([]uuid.UUID, error) {
+	const query = `SELECT id FROM projects WHERE id::text = $1 OR lower(name) = $1`
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, strings.ToLower(ref))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
@@ -0,0 +1,290 @@
+package job
+
+import (
+	job2 "ProjectManagementAPI/internal/domain/job"
+	"ProjectManagementAPI/internal/storage/postgre"
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewJobRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+const jobColumns = `id, kind, payload, status, COALESCE(unique_key, ''), run_at, attempts, max_attempts,
+	COALESCE(last_error, ''), COALESCE(locked_by, ''), locked_until, created_at, updated_at, finished_at`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanJob(row scanner) (*job2.Job, error) {
+	var (
+		j                       job2.Job
+		payload                 []byte
+		lockedUntil, finishedAt sql.NullTime
+	)
+	err := row.Scan(
+		&j.ID, &j.Kind, &payload, &j.Status, &j.UniqueKey, &j.RunAt, &j.Attempts, &j.MaxAttempts,
+		&j.LastError, &j.LockedBy, &lockedUntil, &j.CreatedAt, &j.UpdatedAt, &finishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	j.Payload = payload
+	if lockedUntil.Valid {
+		j.LockedUntil = &lockedUntil.Time
+	}
+	if finishedAt.Valid {
+		j.FinishedAt = &finishedAt.Time
+	}
+	return &j, nil
+}
+
+// Enqueue ставит задание в очередь. Если незавершённое задание с тем же
+// unique_key уже есть, возвращает false и ничего не меняет.
+func (r *Repository) Enqueue(ctx context.Context, j *job2.Job) (bool, error) {
+	const query = `INSERT INTO jobs(kind, payload, unique_key, run_at, max_attempts)
+		VALUES($1, $2, NULLIF($3, ''), $4, $5)
+		ON CONFLICT (unique_key) WHERE unique_key IS NOT NULL AND status IN ('pending', 'running') DO NOTHING
+		RETURNING ` + jobColumns
+
+	inserted, err := scanJob(postgre.Conn(ctx, r.db).QueryRowContext(ctx, query,
+		j.Kind, []byte(j.Payload), j.UniqueKey, j.RunAt, j.MaxAttempts,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	*j = *inserted
+	return true, nil
+}
+
+// Claim забирает до limit готовых заданий и продлевает их аренду на lease.
+// Задания, чья аренда истекла (упавший воркер), забираются повторно.
+func (r *Repository) Claim(ctx context.Context, workerID string, limit int, lease time.Duration) ([]job2.Job, error) {
+	const query = `UPDATE jobs
+		SET status='running', attempts=attempts+1, locked_by=$2,
+			locked_until=NOW() + make_interval(secs => $3), updated_at=NOW()
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE (status = 'pending' AND run_at <= NOW())
+			   OR (status = 'running' AND locked_until < NOW() AND attempts < max_attempts)
+			ORDER BY run_at, id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns
+
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, limit, workerID, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []job2.Job
+	for rows.Next() {
+		j, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *j)
+	}
+
+	return jobs, rows.Err()
+}
+
+// FailExpired помечает упавшими задания с истёкшей арендой и без попыток.
+func (r *Repository) FailExpired(ctx context.Context) (int64, error) {
+	const query = `UPDATE jobs
+		SET status='failed', last_error='lease expired', locked_by=NULL, locked_until=NULL,
+			finished_at=NOW(), updated_at=NOW()
+		WHERE status='running' AND locked_until < NOW() AND attempts >= max_attempts`
+
+	res, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// Complete завершает задание, если оно всё ещё принадлежит воркеру:
+// отменённое во время выполнения задание остаётся отменённым.
+func (r *Repository) Complete(ctx context.Context, id int64, workerID string) error {
+	const query = `UPDATE jobs
+		SET status='succeeded', last_error=NULL, locked_by=NULL, locked_until=NULL, finished_at=NOW(), updated_at=NOW()
+		WHERE id=$1 AND status='running' AND locked_by=$2`
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, id, workerID)
+	return err
+}
+
+// Fail возвращает задание в очередь на retryAt или, если retryAt nil,
+// окончательно помечает его упавшим.
+func (r *Repository) Fail(ctx context.Context, id int64, workerID, lastError string, retryAt *time.Time) error {
+	const query = `UPDATE jobs
+		SET status = CASE WHEN $4::timestamptz IS NULL THEN 'failed' ELSE 'pending' END,
+			run_at = COALESCE($4, run_at),
+			finished_at = CASE WHEN $4::timestamptz IS NULL THEN NOW() END,
+			last_error=$3, locked_by=NULL, locked_until=NULL, updated_at=NOW()
+		WHERE id=$1 AND status='running' AND locked_by=$2`
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, id, workerID, lastError, retryAt)
+	return err
+}
+
+func (r *Repository) GetByID(ctx context.Context, id int64) (*job2.Job, error) {
+	const query = `SELECT ` + jobColumns + ` FROM jobs WHERE id=$1`
+
+	j, err := scanJob(postgre.Conn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, job2.ErrJobNotFound
+	}
+	return j, err
+}
+
+func (r *Repository) List(ctx context.Context, f job2.Filter) ([]job2.Job, error) {
+	var (
+		conds []string
+		args  []any
+	)
+	if f.Status != "" {
+		args = append(args, f.Status)
+		conds = append(conds, "status = $"+strconv.Itoa(len(args)))
+	}
+	if f.Kind != "" {
+		args = append(args, f.Kind)
+		conds = append(conds, "kind = $"+strconv.Itoa(len(args)))
+	}
+
+	query := `SELECT ` + jobColumns + ` FROM jobs`
+	if len(conds) > 0 {
+		query += ` WHERE ` + strings.Join(conds, " AND ")
+	}
+	args = append(args, f.Limit, f.Offset)
+	query += ` ORDER BY id DESC LIMIT $` + strconv.Itoa(len(args)-1) + ` OFFSET $` + strconv.Itoa(len(args))
+
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []job2.Job
+	for rows.Next() {
+		j, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *j)
+	}
+
+	return jobs, rows.Err()
+}
+
+// Retry заново ставит упавшее или отменённое задание в очередь.
+func (r *Repository) Retry(ctx context.Context, id int64) (bool, error) {
+	const query = `UPDATE jobs
+		SET status='pending', run_at=NOW(), attempts=0, last_error=NULL, finished_at=NULL, updated_at=NOW()
+		WHERE id=$1 AND status IN ('failed', 'cancelled')`
+
+	res, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return false, job2.ErrDuplicateJob
+		}
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (r *Repository) Cancel(ctx context.Context, id int64) (bool, error) {
+	const query = `UPDATE jobs
+		SET status='cancelled', locked_by=NULL, locked_until=NULL, finished_at=NOW(), updated_at=NOW()
+		WHERE id=$1 AND status IN ('pending', 'running')`
+
+	res, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// SaveSchedule регистрирует расписание. next_run_at сохраняется, если
+// spec не изменился, чтобы рестарт не сдвигал ближайший запуск.
+func (r *Repository) SaveSchedule(ctx context.Context, s *job2.Schedule) error {
+	const query = `INSERT INTO job_schedules(name, spec, kind, payload, next_run_at)
+		VALUES($1,$2,$3,$4,$5)
+		ON CONFLICT (name) DO UPDATE SET kind=EXCLUDED.kind, payload=EXCLUDED.payload, spec=EXCLUDED.spec,
+			next_run_at = CASE WHEN job_schedules.spec = EXCLUDED.spec THEN job_schedules.next_run_at
+				ELSE EXCLUDED.next_run_at END`
+
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, s.Name, s.Spec, s.Kind, []byte(s.Payload), s.NextRunAt)
+	return err
+}
+
+// DueSchedules блокирует расписания, время запуска которых наступило.
+// Вызывается в транзакции, чтобы реплики не запускали их одновременно.
+func (r *Repository) DueSchedules(ctx context.Context) ([]job2.Schedule, error) {
+	const query = `SELECT name, spec, kind, payload, next_run_at, last_run_at
+		FROM job_schedules
+		WHERE next_run_at <= NOW()
+		FOR UPDATE SKIP LOCKED`
+
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []job2.Schedule
+	for rows.Next() {
+		var (
+			s       job2.Schedule
+			payload []byte
+			lastRun sql.NullTime
+		)
+		if err := rows.Scan(&s.Name, &s.Spec, &s.Kind, &payload, &s.NextRunAt, &lastRun); err != nil {
+			return nil, err
+		}
+		s.Payload = payload
+		if lastRun.Valid {
+			s.LastRunAt = &lastRun.Time
+		}
+		list = append(list, s)
+	}
+
+	return list, rows.Err()
+}
+
+func (r *Repository) AdvanceSchedule(ctx context.Context, name string, ranAt, next time.Time) error {
+	const query = `UPDATE job_schedules SET last_run_at=$2, next_run_at=$3 WHERE name=$1`
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, name, ranAt, next)
+	return err
+}
+
+// Purge удаляет завершённые задания, закончившиеся раньше before.
+func (r *Repository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	const query = `DELETE FROM jobs WHERE status IN ('succeeded', 'cancelled', 'failed') AND finished_at < $1`
+
+	res, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
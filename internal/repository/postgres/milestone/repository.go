@@ -0,0 +1,106 @@
+package milestone
+
+import (
+	milestone2 "ProjectManagementAPI/internal/domain/milestone"
+	task2 "ProjectManagementAPI/internal/domain/task"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewMilestoneRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(ctx context.Context, m *milestone2.Milestone) error {
+	m.CreatedAt = time.Now()
+
+	const query = `INSERT INTO milestones(id, title, start_date, end_date, created_at) VALUES($1,$2,$3,$4,$5)`
+	_, err := r.db.ExecContext(ctx, query, m.ID, m.Title, m.StartDate, m.EndDate, m.CreatedAt)
+	return err
+}
+
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*milestone2.Milestone, error) {
+	const query = `SELECT id, title, start_date, end_date, created_at FROM milestones WHERE id=$1`
+
+	m := &milestone2.Milestone{}
+	err := r.db.QueryRowContext(ctx, query, id).
+		Scan(&m.ID, &m.Title, &m.StartDate, &m.EndDate, &m.CreatedAt)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, milestone2.ErrMilestoneNotFound
+	}
+
+	return m, err
+}
+
+func (r *Repository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	const query = `DELETE FROM milestones WHERE id=$1`
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return milestone2.ErrMilestoneNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) TaskEstimates(ctx context.Context, id uuid.UUID) ([]milestone2.TaskEstimate, error) {
+	const query = `SELECT id, story_points, status, created_at FROM tasks WHERE milestone_id=$1`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var estimates []milestone2.TaskEstimate
+	for rows.Next() {
+		var e milestone2.TaskEstimate
+		if err := rows.Scan(&e.TaskID, &e.StoryPoints, &e.Status, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		estimates = append(estimates, e)
+	}
+
+	return estimates, rows.Err()
+}
+
+func (r *Repository) StatusHistory(ctx context.Context, id uuid.UUID) ([]task2.StatusChange, error) {
+	const query = `SELECT h.task_id, h.status, h.changed_at
+		FROM task_status_history h
+		JOIN tasks t ON t.id = h.task_id
+		WHERE t.milestone_id=$1
+		ORDER BY h.changed_at, h.id`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []task2.StatusChange
+	for rows.Next() {
+		var c task2.StatusChange
+		if err := rows.Scan(&c.TaskID, &c.Status, &c.ChangedAt); err != nil {
+			return nil, err
+		}
+		history = append(history, c)
+	}
+
+	return history, rows.Err()
+}
@@ -0,0 +1,229 @@
+package notification
+
+import (
+	notification2 "ProjectManagementAPI/internal/domain/notification"
+	"ProjectManagementAPI/internal/storage/postgre"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewNotificationRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Insert сохраняет уведомление, если его ещё нет с тем же dedupe_key.
+// Уведомление пропускается, если пользователь или задача уже удалены:
+// события обрабатываются позже изменения, и ошибка внешнего ключа
+// повторялась бы бесконечно. Строки блокируются до конца транзакции,
+// чтобы их не удалили между проверкой и вставкой.
+func (r *Repository) Insert(ctx context.Context, n *notification2.Notification) (bool, error) {
+	n.CreatedAt = time.Now()
+
+	const query = `INSERT INTO notifications(id, user_id, type, title, body, task_id, dedupe_key, in_app, email,
+			email_status, email_next_attempt_at, created_at)
+		SELECT $1,$2,$3,$4,$5,$6,$7,$8,$9, CASE WHEN $9 THEN 'pending' END, CASE WHEN $9 THEN NOW() END, $10
+		WHERE EXISTS (SELECT 1 FROM users WHERE id=$2 FOR KEY SHARE)
+		  AND ($6::uuid IS NULL OR EXISTS (SELECT 1 FROM tasks WHERE id=$6 FOR KEY SHARE))
+		ON CONFLICT (user_id, dedupe_key) DO NOTHING`
+
+	var taskID uuid.NullUUID
+	if n.TaskID != nil {
+		taskID = uuid.NullUUID{UUID: *n.TaskID, Valid: true}
+	}
+
+	res, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query,
+		n.ID, n.UserID, n.Type, n.Title, n.Body, taskID, n.DedupeKey, n.InApp, n.Email, n.CreatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	return affected > 0, err
+}
+
+func (r *Repository) List(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]notification2.Notification, error) {
+	const query = `SELECT id, user_id, type, title, body, task_id, dedupe_key, in_app, email, read_at, created_at
+		FROM notifications
+		WHERE user_id=$1 AND in_app AND (NOT $2 OR read_at IS NULL)
+		ORDER BY created_at DESC, id
+		LIMIT $3 OFFSET $4`
+
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, userID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []notification2.Notification
+	for rows.Next() {
+		var (
+			n      notification2.Notification
+			taskID uuid.NullUUID
+			readAt sql.NullTime
+		)
+		if err := rows.Scan(
+			&n.ID, &n.UserID, &n.Type, &n.Title, &n.Body, &taskID, &n.DedupeKey, &n.InApp, &n.Email, &readAt, &n.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if taskID.Valid {
+			n.TaskID = &taskID.UUID
+		}
+		if readAt.Valid {
+			n.ReadAt = &readAt.Time
+		}
+		list = append(list, n)
+	}
+
+	return list, rows.Err()
+}
+
+func (r *Repository) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	const query = `SELECT COUNT(*) FROM notifications WHERE user_id=$1 AND in_app AND read_at IS NULL`
+
+	var n int
+	err := postgre.Conn(ctx, r.db).QueryRowContext(ctx, query, userID).Scan(&n)
+	return n, err
+}
+
+func (r *Repository) SetRead(ctx context.Context, userID, id uuid.UUID, read bool) error {
+	const query = `UPDATE notifications
+		SET read_at = CASE WHEN $3 THEN COALESCE(read_at, NOW()) END
+		WHERE id=$1 AND user_id=$2 AND in_app`
+	res, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, id, userID, read)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return notification2.ErrNotificationNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	const query = `UPDATE notifications SET read_at=NOW() WHERE user_id=$1 AND in_app AND read_at IS NULL`
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, userID)
+	return err
+}
+
+func (r *Repository) Preferences(ctx context.Context, userID uuid.UUID) ([]notification2.Preference, error) {
+	const query = `SELECT type, in_app, email FROM notification_preferences WHERE user_id=$1`
+
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prefs []notification2.Preference
+	for rows.Next() {
+		var p notification2.Preference
+		if err := rows.Scan(&p.Type, &p.InApp, &p.Email); err != nil {
+			return nil, err
+		}
+		prefs = append(prefs, p)
+	}
+
+	return prefs, rows.Err()
+}
+
+func (r *Repository) SavePreferences(ctx context.Context, userID uuid.UUID, prefs []notification2.Preference) error {
+	const query = `INSERT INTO notification_preferences(user_id, type, in_app, email)
+		VALUES($1,$2,$3,$4)
+		ON CONFLICT (user_id, type) DO UPDATE SET in_app=EXCLUDED.in_app, email=EXCLUDED.email`
+
+	return postgre.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		for _, p := range prefs {
+			if _, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, userID, p.Type, p.InApp, p.Email); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ClaimPendingEmails забирает письма к отправке и откладывает их на lease.
+func (r *Repository) ClaimPendingEmails(ctx context.Context, limit int, lease time.Duration) ([]notification2.PendingEmail, error) {
+	const query = `UPDATE notifications n
+		SET email_next_attempt_at = NOW() + make_interval(secs => $2)
+		FROM users u
+		WHERE u.id = n.user_id
+		  AND n.id IN (
+			SELECT id FROM notifications
+			WHERE email_status = 'pending' AND email_next_attempt_at <= NOW()
+			ORDER BY email_next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		  )
+		RETURNING n.id, n.user_id, n.type, n.title, n.body, n.task_id, n.email_attempts, u.email, u.name`
+
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var emails []notification2.PendingEmail
+	for rows.Next() {
+		var (
+			e      notification2.PendingEmail
+			taskID uuid.NullUUID
+		)
+		if err := rows.Scan(
+			&e.ID, &e.UserID, &e.Type, &e.Title, &e.Body, &taskID, &e.Attempts, &e.To, &e.UserName,
+		); err != nil {
+			return nil, err
+		}
+		if taskID.Valid {
+			e.TaskID = &taskID.UUID
+		}
+		emails = append(emails, e)
+	}
+
+	return emails, rows.Err()
+}
+
+func (r *Repository) MarkEmailed(ctx context.Context, id uuid.UUID) error {
+	const query = `UPDATE notifications
+		SET email_status='sent', email_attempts=email_attempts+1, email_error=NULL, emailed_at=NOW()
+		WHERE id=$1`
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, id)
+	return err
+}
+
+func (r *Repository) MarkEmailFailed(ctx context.Context, id uuid.UUID, lastError string, retryIn time.Duration, dead bool) error {
+	const query = `UPDATE notifications
+		SET email_status = CASE WHEN $4 THEN 'failed' ELSE 'pending' END,
+			email_attempts = email_attempts + 1,
+			email_error = $2,
+			email_next_attempt_at = NOW() + make_interval(secs => $3)
+		WHERE id=$1`
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, id, lastError, retryIn.Seconds(), dead)
+	return err
+}
+
+// PurgeRead удаляет прочитанные уведомления, созданные раньше before.
+func (r *Repository) PurgeRead(ctx context.Context, before time.Time) (int64, error) {
+	const query = `DELETE FROM notifications
+		WHERE created_at < $1 AND (read_at IS NOT NULL OR NOT in_app) AND email_status IS DISTINCT FROM 'pending'`
+
+	res, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
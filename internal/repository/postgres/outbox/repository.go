@@ -0,0 +1,140 @@
+package outbox
+
+import (
+	event2 "ProjectManagementAPI/internal/domain/event"
+	outbox2 "ProjectManagementAPI/internal/domain/outbox"
+	"ProjectManagementAPI/internal/storage/postgre"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// relayLockKey - ключ advisory lock, под которым работает relay.
+// Один активный relay сохраняет порядок событий внутри агрегата.
+const relayLockKey = 7_240_001
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewOutboxRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Insert(ctx context.Context, e event2.Event) error {
+	const query = `INSERT INTO outbox(event_id, event_type, aggregate_type, aggregate_id, payload, occurred_at)
+		VALUES($1,$2,$3,$4,$5,$6)`
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query,
+		e.ID, e.Type, e.AggregateType, e.AggregateID, string(e.Payload), e.OccurredAt,
+	)
+	return err
+}
+
+// TryLock берёт advisory lock до конца текущей транзакции.
+func (r *Repository) TryLock(ctx context.Context) (bool, error) {
+	var ok bool
+	err := postgre.Conn(ctx, r.db).QueryRowContext(ctx, `SELECT pg_try_advisory_xact_lock($1)`, relayLockKey).Scan(&ok)
+	return ok, err
+}
+
+// FetchPending отдаёт готовые к отправке события по порядку id. Событие
+// агрегата, у которого более раннее событие ждёт повтора, не отдаётся,
+// чтобы не нарушить порядок внутри агрегата.
+func (r *Repository) FetchPending(ctx context.Context, limit int) ([]outbox2.Record, error) {
+	const query = `SELECT o.id, o.event_id, o.event_type, o.aggregate_type, o.aggregate_id, o.payload, o.occurred_at, o.attempts
+		FROM outbox o
+		WHERE o.status = 'pending'
+		  AND NOT EXISTS (
+			SELECT 1 FROM outbox p
+			WHERE p.status = 'pending' AND p.aggregate_id = o.aggregate_id
+			  AND p.id <= o.id AND p.next_attempt_at > NOW()
+		  )
+		ORDER BY o.id
+		LIMIT $1`
+
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []outbox2.Record
+	for rows.Next() {
+		var (
+			rec     outbox2.Record
+			payload []byte
+		)
+		if err := rows.Scan(
+			&rec.ID, &rec.Event.ID, &rec.Event.Type, &rec.Event.AggregateType, &rec.Event.AggregateID,
+			&payload, &rec.Event.OccurredAt, &rec.Attempts,
+		); err != nil {
+			return nil, err
+		}
+		rec.Event.Payload = payload
+		records = append(records, rec)
+	}
+
+	return records, rows.Err()
+}
+
+func (r *Repository) MarkPublished(ctx context.Context, id int64) error {
+	const query = `UPDATE outbox SET status='published', published_at=NOW(), attempts=attempts+1, last_error=NULL
+		WHERE id=$1`
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, id)
+	return err
+}
+
+// MarkFailed фиксирует неудачную попытку и откладывает событие на retryIn.
+// dead переводит событие в dead-letter.
+func (r *Repository) MarkFailed(ctx context.Context, id int64, lastError string, retryIn time.Duration, dead bool) error {
+	const query = `UPDATE outbox
+		SET status = CASE WHEN $4 THEN 'dead' ELSE 'pending' END,
+			attempts = attempts + 1,
+			last_error = $2,
+			next_attempt_at = NOW() + make_interval(secs => $3)
+		WHERE id=$1`
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, id, lastError, retryIn.Seconds(), dead)
+	return err
+}
+
+// Purge удаляет опубликованные события, отправленные раньше before.
+// События в dead-letter остаются для разбора.
+func (r *Repository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	const query = `DELETE FROM outbox WHERE status='published' AND published_at < $1`
+
+	res, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// Notify отправляет ID события в канал LISTEN/NOTIFY. В транзакции
+// уведомление уходит только после фиксации.
+func (r *Repository) Notify(ctx context.Context, channel string, e event2.Event) error {
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, `SELECT pg_notify($1, $2)`, channel, e.ID.String())
+	return err
+}
+
+func (r *Repository) GetByEventID(ctx context.Context, id uuid.UUID) (*event2.Event, error) {
+	const query = `SELECT event_id, event_type, aggregate_type, aggregate_id, payload, occurred_at
+		FROM outbox WHERE event_id=$1`
+
+	var (
+		e       event2.Event
+		payload []byte
+	)
+	err := postgre.Conn(ctx, r.db).QueryRowContext(ctx, query, id).
+		Scan(&e.ID, &e.Type, &e.AggregateType, &e.AggregateID, &payload, &e.OccurredAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, outbox2.ErrEventNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	e.Payload = payload
+
+	return &e, nil
+}
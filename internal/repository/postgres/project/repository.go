@@ -0,0 +1,60 @@
+package project
+
+import (
+	project2 "ProjectManagementAPI/internal/domain/project"
+	"ProjectManagementAPI/internal/storage/postgre"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewProjectRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(ctx context.Context, p *project2.Project) error {
+	p.CreatedAt = time.Now()
+
+	const query = `INSERT INTO projects(id, name, created_at) VALUES($1,$2,$3)`
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, p.ID, p.Name, p.CreatedAt)
+	return err
+}
+
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*project2.Project, error) {
+	const query = `SELECT id, name, created_at FROM projects WHERE id=$1`
+
+	p := &project2.Project{}
+	err := postgre.Conn(ctx, r.db).QueryRowContext(ctx, query, id).
+		Scan(&p.ID, &p.Name, &p.CreatedAt)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, project2.ErrProjectNotFound
+	}
+
+	return p, err
+}
+
+func (r *Repository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	const query = `DELETE FROM projects WHERE id=$1`
+	res, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return project2.ErrProjectNotFound
+	}
+
+	return nil
+}
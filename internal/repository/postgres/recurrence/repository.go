@@ -0,0 +1,150 @@
+package recurrence
+
+import (
+	recurrence2 "ProjectManagementAPI/internal/domain/recurrence"
+	"ProjectManagementAPI/internal/storage/postgre"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Repository struct {
+	db    *sql.DB
+	types *pgtype.Map
+}
+
+func NewRecurrenceRepository(db *sql.DB) *Repository {
+	return &Repository{db: db, types: pgtype.NewMap()}
+}
+
+const seriesColumns = `id, rrule, dtstart, timezone, title, description, story_points, milestone_id, project_id,
+	assignees, last_occurrence_at, next_occurrence_at, ends_before, finished, created_at, updated_at`
+
+func (r *Repository) Create(ctx context.Context, s *recurrence2.Series) error {
+	s.CreatedAt = time.Now()
+	s.UpdatedAt = s.CreatedAt
+
+	const query = `INSERT INTO task_series(` + seriesColumns + `)
+		VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16)`
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query,
+		s.ID, s.RRule, s.DTStart, s.Timezone, s.Title, s.Description, s.StoryPoints, nullUUID(s.MilestoneID),
+		nullUUID(s.ProjectID), s.Assignees, s.LastOccurrenceAt, s.NextOccurrenceAt,
+		s.EndsBefore, s.Finished, s.CreatedAt, s.UpdatedAt,
+	)
+	return err
+}
+
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*recurrence2.Series, error) {
+	const query = `SELECT ` + seriesColumns + ` FROM task_series WHERE id=$1`
+	return r.get(ctx, query, id)
+}
+
+// GetForUpdate блокирует серию до конца транзакции, чтобы повторение
+// не было создано дважды.
+func (r *Repository) GetForUpdate(ctx context.Context, id uuid.UUID) (*recurrence2.Series, error) {
+	const query = `SELECT ` + seriesColumns + ` FROM task_series WHERE id=$1 FOR UPDATE`
+	return r.get(ctx, query, id)
+}
+
+func (r *Repository) get(ctx context.Context, query string, id uuid.UUID) (*recurrence2.Series, error) {
+	var (
+		series                       recurrence2.Series
+		milestoneID, projectID       uuid.NullUUID
+		assignees                    []string
+		nextOccurrenceAt, endsBefore sql.NullTime
+	)
+	err := postgre.Conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
+		&series.ID, &series.RRule, &series.DTStart, &series.Timezone, &series.Title, &series.Description,
+		&series.StoryPoints, &milestoneID, &projectID, r.types.SQLScanner(&assignees), &series.LastOccurrenceAt,
+		&nextOccurrenceAt, &endsBefore, &series.Finished, &series.CreatedAt, &series.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, recurrence2.ErrSeriesNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	if milestoneID.Valid {
+		series.MilestoneID = &milestoneID.UUID
+	}
+	if projectID.Valid {
+		series.ProjectID = &projectID.UUID
+	}
+	if nextOccurrenceAt.Valid {
+		series.NextOccurrenceAt = &nextOccurrenceAt.Time
+	}
+	if endsBefore.Valid {
+		series.EndsBefore = &endsBefore.Time
+	}
+	series.Assignees = make([]uuid.UUID, len(assignees))
+	for i, a := range assignees {
+		if series.Assignees[i], err = uuid.Parse(a); err != nil {
+			return nil, err
+		}
+	}
+
+	return &series, nil
+}
+
+func (r *Repository) Update(ctx context.Context, s *recurrence2.Series) error {
+	s.UpdatedAt = time.Now()
+
+	const query = `UPDATE task_series SET rrule=$2, title=$3, description=$4, story_points=$5, milestone_id=$6,
+			project_id=$7, assignees=$8, last_occurrence_at=$9, next_occurrence_at=$10, ends_before=$11, finished=$12,
+			updated_at=$13
+		WHERE id=$1`
+	res, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query,
+		s.ID, s.RRule, s.Title, s.Description, s.StoryPoints, nullUUID(s.MilestoneID), nullUUID(s.ProjectID),
+		s.Assignees, s.LastOccurrenceAt, s.NextOccurrenceAt, s.EndsBefore, s.Finished,
+		s.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return recurrence2.ErrSeriesNotFound
+	}
+
+	return nil
+}
+
+// Due возвращает серии, у которых наступило время следующего повторения.
+func (r *Repository) Due(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error) {
+	const query = `SELECT id FROM task_series
+		WHERE NOT finished AND next_occurrence_at <= $1
+		ORDER BY next_occurrence_at
+		LIMIT $2`
+
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func nullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil || *id == uuid.Nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
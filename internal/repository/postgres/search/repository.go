@@ -0,0 +1,244 @@
+package search
+
+import (
+	search2 "ProjectManagementAPI/internal/domain/search"
+	"ProjectManagementAPI/internal/storage/postgre"
+	"context"
+	"database/sql"
+	"errors"
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Маркеры совпадений в ts_headline; заменяются на <mark> после экранирования HTML.
+const (
+	startSel = "\x02"
+	stopSel  = "\x03"
+)
+
+const (
+	titleHeadline   = "HighlightAll=true, StartSel=" + startSel + ", StopSel=" + stopSel
+	snippetHeadline = "MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=\" … \", " +
+		"StartSel=" + startSel + ", StopSel=" + stopSel
+	fuzzySnippetLength = 200
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewSearchRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// SetLanguage сохраняет конфигурацию текстового поиска и, если она
+// изменилась, пересчитывает поисковые векторы задач и комментариев.
+func (r *Repository) SetLanguage(ctx context.Context, language string) error {
+	return postgre.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		tx := postgre.Conn(ctx, r.db)
+
+		var current string
+		err := tx.QueryRowContext(ctx, `SELECT language::text, $1::regconfig::text FROM search_settings FOR UPDATE`,
+			language).Scan(&current, &language)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "42704" {
+				return search2.ErrInvalidLanguage
+			}
+			return err
+		}
+		if current == language {
+			return nil
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE search_settings SET language=$1::regconfig`, language); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE tasks SET search_vector = task_search_vector(title, description)`); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `UPDATE comments SET search_vector = to_tsvector(search_language(), body)`)
+		return err
+	})
+}
+
+// Search ищет задачи по названию, описанию и комментариям. Совпадение
+// в комментарии весит вдвое меньше совпадения в самой задаче.
+func (r *Repository) Search(ctx context.Context, q search2.Query) ([]search2.Result, int, error) {
+	args := []any{q.Text}
+	conds := append([]string{"(t.search_vector @@ q.query OR ch.task_id IS NOT NULL)"}, filter(q, &args)...)
+
+	const matched = `WITH q AS (SELECT websearch_to_tsquery(search_language(), $1) AS query),
+		comment_hits AS (
+			SELECT DISTINCT ON (c.task_id) c.task_id, c.body, ts_rank(c.search_vector, q.query) AS rank
+			FROM comments c, q
+			WHERE c.search_vector @@ q.query
+			ORDER BY c.task_id, rank DESC
+		)`
+	from := ` FROM tasks t CROSS JOIN q LEFT JOIN comment_hits ch ON ch.task_id = t.id
+		WHERE ` + strings.Join(conds, " AND ")
+
+	conn := postgre.Conn(ctx, r.db)
+
+	var total int
+	if err := conn.QueryRowContext(ctx, matched+` SELECT COUNT(*)`+from, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return nil, 0, nil
+	}
+
+	args = append(args, q.Limit, q.Offset, titleHeadline, snippetHeadline)
+	n := len(args)
+	query := matched + `,
+		page AS (
+			SELECT t.id, t.title, t.description, t.status, t.project_id, t.due_at, t.updated_at, ch.body AS comment_body,
+				COALESCE(ts_rank(t.search_vector, q.query), 0) + COALESCE(ch.rank, 0) * 0.5 AS rank` + from + `
+			ORDER BY rank DESC, t.updated_at DESC, t.id
+			LIMIT $` + strconv.Itoa(n-3) + ` OFFSET $` + strconv.Itoa(n-2) + `
+		)
+		SELECT p.id, p.title, p.status, p.project_id, p.due_at, p.rank,
+			ts_headline(search_language(), p.title, q.query, $` + strconv.Itoa(n-1) + `),
+			CASE
+				WHEN to_tsvector(search_language(), p.description) @@ q.query
+					THEN ts_headline(search_language(), p.description, q.query, $` + strconv.Itoa(n) + `)
+				WHEN p.comment_body IS NOT NULL
+					THEN ts_headline(search_language(), p.comment_body, q.query, $` + strconv.Itoa(n) + `)
+				ELSE ''
+			END,
+			to_tsvector(search_language(), p.title) @@ q.query,
+			to_tsvector(search_language(), p.description) @@ q.query,
+			p.comment_body IS NOT NULL
+		FROM page p CROSS JOIN q
+		ORDER BY p.rank DESC, p.updated_at DESC, p.id`
+
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var results []search2.Result
+	for rows.Next() {
+		var (
+			res                     search2.Result
+			projectID               uuid.NullUUID
+			dueAt                   sql.NullTime
+			inTitle, inDesc, inComm bool
+		)
+		err := rows.Scan(&res.TaskID, &res.Title, &res.Status, &projectID, &dueAt, &res.Rank,
+			&res.TitleHighlight, &res.Snippet, &inTitle, &inDesc, &inComm)
+		if err != nil {
+			return nil, 0, err
+		}
+		setOptional(&res, projectID, dueAt)
+		res.TitleHighlight = highlight(res.TitleHighlight)
+		res.Snippet = highlight(res.Snippet)
+		if inTitle {
+			res.MatchedIn = append(res.MatchedIn, search2.MatchTitle)
+		}
+		if inDesc {
+			res.MatchedIn = append(res.MatchedIn, search2.MatchDescription)
+		}
+		if inComm {
+			res.MatchedIn = append(res.MatchedIn, search2.MatchComments)
+		}
+		results = append(results, res)
+	}
+
+	return results, total, rows.Err()
+}
+
+// Fuzzy подбирает задачи по триграммной похожести названия
+// (оператор <% из pg_trgm), когда полнотекстовый поиск пуст - например, из-за опечатки.
+func (r *Repository) Fuzzy(ctx context.Context, q search2.Query) ([]search2.Result, int, error) {
+	args := []any{q.Text}
+	conds := append([]string{"$1 <% t.title"}, filter(q, &args)...)
+	from := ` FROM tasks t WHERE ` + strings.Join(conds, " AND ")
+
+	conn := postgre.Conn(ctx, r.db)
+
+	var total int
+	if err := conn.QueryRowContext(ctx, `SELECT COUNT(*)`+from, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return nil, 0, nil
+	}
+
+	args = append(args, q.Limit, q.Offset)
+	query := `SELECT t.id, t.title, t.status, t.project_id, t.due_at, word_similarity($1, t.title) AS rank,
+			LEFT(t.description, ` + strconv.Itoa(fuzzySnippetLength) + `)` + from + `
+		ORDER BY rank DESC, t.updated_at DESC, t.id
+		LIMIT $` + strconv.Itoa(len(args)-1) + ` OFFSET $` + strconv.Itoa(len(args))
+
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var results []search2.Result
+	for rows.Next() {
+		var (
+			res       search2.Result
+			projectID uuid.NullUUID
+			dueAt     sql.NullTime
+		)
+		err := rows.Scan(&res.TaskID, &res.Title, &res.Status, &projectID, &dueAt, &res.Rank, &res.Snippet)
+		if err != nil {
+			return nil, 0, err
+		}
+		setOptional(&res, projectID, dueAt)
+		res.TitleHighlight = html.EscapeString(res.Title)
+		res.Snippet = html.EscapeString(res.Snippet)
+		res.MatchedIn = []string{search2.MatchTitle}
+		results = append(results, res)
+	}
+
+	return results, total, rows.Err()
+}
+
+func filter(q search2.Query, args *[]any) []string {
+	var conds []string
+	add := func(cond string, v any) {
+		*args = append(*args, v)
+		conds = append(conds, strings.ReplaceAll(cond, "$?", "$"+strconv.Itoa(len(*args))))
+	}
+
+	if q.Status != "" {
+		add("t.status = $?", q.Status)
+	}
+	if q.ProjectID != nil {
+		add("t.project_id = $?", *q.ProjectID)
+	}
+	if q.MilestoneID != nil {
+		add("t.milestone_id = $?", *q.MilestoneID)
+	}
+	if q.AssigneeID != nil {
+		add("EXISTS (SELECT 1 FROM user_tasks ut WHERE ut.task_id = t.id AND ut.user_id = $?)", *q.AssigneeID)
+	}
+
+	return conds
+}
+
+func setOptional(res *search2.Result, projectID uuid.NullUUID, dueAt sql.NullTime) {
+	if projectID.Valid {
+		res.ProjectID = &projectID.UUID
+	}
+	if dueAt.Valid {
+		res.DueAt = &dueAt.Time
+	}
+}
+
+// highlight экранирует HTML и заменяет маркеры ts_headline на <mark>.
+func highlight(s string) string {
+	s = html.EscapeString(s)
+	s = strings.ReplaceAll(s, startSel, "<mark>")
+	return strings.ReplaceAll(s, stopSel, "</mark>")
+}
@@ -0,0 +1,261 @@
+package task
+
+import (
+	task2 "ProjectManagementAPI/internal/domain/task"
+	"ProjectManagementAPI/internal/lib/query"
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Find возвращает задачи, подходящие под запрос на языке фильтров. Запрос
+// должен быть проверен task.QuerySchema; me подставляется вместо assignee:me.
+func (r *Repository) Find(ctx context.Context, n query.Node, me *uuid.UUID, limit, offset int) ([]*task2.Task, error) {
+	c := &compiler{me: me, now: time.Now()}
+
+	where := "TRUE"
+	if n != nil {
+		var err error
+		if where, err = c.compile(n); err != nil {
+			return nil, err
+		}
+	}
+
+	q := `SELECT ` + taskColumns + ` FROM tasks t WHERE ` + where + `
+		ORDER BY t.created_at DESC, t.id
+		LIMIT ` + c.arg(limit) + ` OFFSET ` + c.arg(offset)
+
+	return r.query(ctx, q, c.args...)
+}
+
+// FindAfter - постраничный Find по ключу (created_at, id) для выгрузки
+// всех задач: after - последняя задача предыдущей порции или nil.
+func (r *Repository) FindAfter(ctx context.Context, n query.Node, me *uuid.UUID, after *task2.Task, limit int) ([]*task2.Task, error) {
+	c := &compiler{me: me, now: time.Now()}
+
+	where := "TRUE"
+	if n != nil {
+		var err error
+		if where, err = c.compile(n); err != nil {
+			return nil, err
+		}
+	}
+	if after != nil {
+		where = "(" + where + ") AND (t.created_at, t.id) > (" + c.arg(after.CreatedAt) + ", " + c.arg(after.ID) + ")"
+	}
+
+	q := `SELECT ` + taskColumns + ` FROM tasks t WHERE ` + where + `
+		ORDER BY t.created_at, t.id
+		LIMIT ` + c.arg(limit)
+
+	return r.query(ctx, q, c.args...)
+}
+
+// compiler переводит AST в условие WHERE. Значения передаются только
+// параметрами, имена колонок берутся из фиксированного списка.
+type compiler struct {
+	args []any
+	me   *uuid.UUID
+	now  time.Time
+}
+
+func (c *compiler) arg(v any) string {
+	c.args = append(c.args, v)
+	return "$" + strconv.Itoa(len(c.args))
+}
+
+func (c *compiler) compile(n query.Node) (string, error) {
+	switch n := n.(type) {
+	case *query.And:
+		return c.binary(n.Left, n.Right, " AND ")
+	case *query.Or:
+		return c.binary(n.Left, n.Right, " OR ")
+	case *query.Not:
+		x, err := c.compile(n.X)
+		if err != nil {
+			return "", err
+		}
+		return "NOT " + x, nil
+	case *query.Text:
+		p := c.arg(likePattern(n.Value))
+		return "(t.title ILIKE " + p + " OR t.description ILIKE " + p + ")", nil
+	case *query.Comparison:
+		cond, err := c.comparison(n)
+		if err != nil {
+			return "", err
+		}
+		if n.Op == query.OpNe {
+			return "NOT " + cond, nil
+		}
+		return cond, nil
+	}
+	return "", &query.Error{Pos: n.Pos(), Msg: "unsupported expression"}
+}
+
+func (c *compiler) binary(left, right query.Node, op string) (string, error) {
+	l, err := c.compile(left)
+	if err != nil {
+		return "", err
+	}
+	r, err := c.compile(right)
+	if err != nil {
+		return "", err
+	}
+	return "(" + l + op + r + ")", nil
+}
+
+// comparison строит условие для ":" ("!=" - его отрицание) или сравнения
+// дат и чисел. Результат никогда не NULL, чтобы NOT работал ожидаемо.
+func (c *compiler) comparison(n *query.Comparison) (string, error) {
+	var (
+		values  []string
+		hasNone bool
+	)
+	for _, v := range n.Values {
+		if v == query.None {
+			hasNone = true
+		} else {
+			values = append(values, v)
+		}
+	}
+
+	var parts []string
+	switch n.Field {
+	case task2.FieldStatus:
+		parts = append(parts, "t.status = ANY("+c.arg(values)+")")
+
+	case task2.FieldAssignee:
+		ids, emails, err := c.userRefs(n, values)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, `EXISTS (SELECT 1 FROM user_tasks ut JOIN users u ON u.id = ut.user_id
+			WHERE ut.task_id = t.id AND (ut.user_id = ANY(`+c.arg(ids)+`) OR lower(u.email) = ANY(`+c.arg(emails)+`)))`)
+
+	case task2.FieldLabel:
+		if hasNone {
+			parts = append(parts, "NOT EXISTS (SELECT 1 FROM task_labels tl WHERE tl.task_id = t.id)")
+		}
+		if len(values) > 0 {
+			for i, v := range values {
+				values[i] = strings.ToLower(v)
+			}
+			parts = append(parts, "EXISTS (SELECT 1 FROM task_labels tl WHERE tl.task_id = t.id AND tl.label = ANY("+
+				c.arg(values)+"))")
+		}
+
+	case task2.FieldProject, task2.FieldMilestone:
+		column, table, nameColumn := "t.project_id", "projects", "name"
+		if n.Field == task2.FieldMilestone {
+			column, table, nameColumn = "t.milestone_id", "milestones", "title"
+		}
+		if hasNone {
+			parts = append(parts, column+" IS NULL")
+		}
+		if len(values) > 0 {
+			ids, names := splitRefs(values)
+			parts = append(parts, "COALESCE("+column+" = ANY("+c.arg(ids)+") OR "+column+" IN (SELECT id FROM "+
+				table+" WHERE lower("+nameColumn+") = ANY("+c.arg(names)+")), FALSE)")
+		}
+
+	case task2.FieldDue, task2.FieldCreated, task2.FieldUpdated:
+		column := map[string]string{
+			task2.FieldDue: "t.due_at", task2.FieldCreated: "t.created_at", task2.FieldUpdated: "t.updated_at",
+		}[n.Field]
+		if hasNone {
+			parts = append(parts, column+" IS NULL")
+		}
+		for _, v := range values {
+			day, err := query.ParseDate(v, c.now)
+			if err != nil {
+				return "", &query.Error{Pos: n.At, Msg: "invalid date " + strconv.Quote(v)}
+			}
+			parts = append(parts, "COALESCE("+c.dateCondition(column, n.Op, day)+", FALSE)")
+		}
+
+	case task2.FieldPoints:
+		nums := make([]int, len(values))
+		for i, v := range values {
+			num, err := strconv.Atoi(v)
+			if err != nil {
+				return "", &query.Error{Pos: n.At, Msg: "invalid number " + strconv.Quote(v)}
+			}
+			nums[i] = num
+		}
+		if n.Op == query.OpEq || n.Op == query.OpNe {
+			parts = append(parts, "t.story_points = ANY("+c.arg(nums)+")")
+		} else {
+			parts = append(parts, "t.story_points "+n.Op+" "+c.arg(nums[0]))
+		}
+
+	case task2.FieldTitle, task2.FieldDescription:
+		column := "t." + n.Field
+		for _, v := range values {
+			parts = append(parts, column+" ILIKE "+c.arg(likePattern(v)))
+		}
+
+	default:
+		return "", &query.Error{Pos: n.At, Msg: "unknown field " + strconv.Quote(n.Field)}
+	}
+
+	return "(" + strings.Join(parts, " OR ") + ")", nil
+}
+
+// userRefs разделяет значения assignee на UUID (включая me) и email.
+func (c *compiler) userRefs(n *query.Comparison, values []string) ([]uuid.UUID, []string, error) {
+	ids := []uuid.UUID{}
+	emails := []string{}
+	for i, v := range values {
+		if v == query.Me {
+			if c.me == nil {
+				return nil, nil, &query.Error{Pos: n.ValueAt[i], Msg: "assignee:me requires X-User-ID header"}
+			}
+			ids = append(ids, *c.me)
+			continue
+		}
+		if id, err := uuid.Parse(v); err == nil {
+			ids = append(ids, id)
+		} else {
+			emails = append(emails, strings.ToLower(v))
+		}
+	}
+	return ids, emails, nil
+}
+
+func splitRefs(values []string) ([]uuid.UUID, []string) {
+	ids := []uuid.UUID{}
+	names := []string{}
+	for _, v := range values {
+		if id, err := uuid.Parse(v); err == nil {
+			ids = append(ids, id)
+		} else {
+			names = append(names, strings.ToLower(v))
+		}
+	}
+	return ids, names
+}
+
+// dateCondition сравнивает колонку с днём: due:2026-11-01 - весь день,
+// due<=2026-11-01 - до конца дня, due>2026-11-01 - начиная со следующего.
+func (c *compiler) dateCondition(column, op string, day time.Time) string {
+	nextDay := day.AddDate(0, 0, 1)
+	switch op {
+	case query.OpLt:
+		return column + " < " + c.arg(day)
+	case query.OpLe:
+		return column + " < " + c.arg(nextDay)
+	case query.OpGt:
+		return column + " >= " + c.arg(nextDay)
+	case query.OpGe:
+		return column + " >= " + c.arg(day)
+	}
+	return "(" + column + " >= " + c.arg(day) + " AND " + column + " < " + c.arg(nextDay) + ")"
+}
+
+func likePattern(v string) string {
+	v = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(v)
+	return "%" + v + "%"
+}
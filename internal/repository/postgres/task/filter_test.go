@@ -0,0 +1,109 @@
+package task
+
+import (
+	"ProjectManagementAPI/internal/lib/query"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func compile(t *testing.T, in string, me *uuid.UUID) (string, []any) {
+	t.Helper()
+
+	n, err := query.Parse(in)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", in, err)
+	}
+	c := &compiler{me: me, now: time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)}
+	where, err := c.compile(n)
+	if err != nil {
+		t.Fatalf("compile(%q): %v", in, err)
+	}
+	return where, c.args
+}
+
+func TestCompile(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	for _, tc := range []struct {
+		in    string
+		where string
+		args  []any
+	}{
+		{"status:todo,done", "(t.status = ANY($1))", []any{[]string{"todo", "done"}}},
+		{"status!=done", "NOT (t.status = ANY($1))", []any{[]string{"done"}}},
+		{"-status:done", "NOT (t.status = ANY($1))", []any{[]string{"done"}}},
+		{"status:todo OR points>3", "((t.status = ANY($1)) OR (t.story_points > $2))", []any{[]string{"todo"}, 3}},
+		{"points:1,2", "(t.story_points = ANY($1))", []any{[]int{1, 2}}},
+		{"due:none", "(t.due_at IS NULL)", nil},
+		{"due<2026-03-20", "(COALESCE(t.due_at < $1, FALSE))", []any{day(20)}},
+		{"due<=today", "(COALESCE(t.due_at < $1, FALSE))", []any{day(11)}},
+		{"created>-7d", "(COALESCE(t.created_at >= $1, FALSE))", []any{day(4)}},
+		{"updated>=+1w", "(COALESCE(t.updated_at >= $1, FALSE))", []any{day(17)}},
+		{"due:2026-03-20", "(COALESCE((t.due_at >= $1 AND t.due_at < $2), FALSE))", []any{day(20), day(21)}},
+		{"due:none,today", "(t.due_at IS NULL OR COALESCE((t.due_at >= $1 AND t.due_at < $2), FALSE))",
+			[]any{day(10), day(11)}},
+		{"label:none", "(NOT EXISTS (SELECT 1 FROM task_labels tl WHERE tl.task_id = t.id))", nil},
+		{"label:Bug", "(EXISTS (SELECT 1 FROM task_labels tl WHERE tl.task_id = t.id AND tl.label = ANY($1)))",
+			[]any{[]string{"bug"}}},
+		{"project:none", "(t.project_id IS NULL)", nil},
+		{"milestone:Sprint", "(COALESCE(t.milestone_id = ANY($1) OR t.milestone_id IN (SELECT id FROM milestones " +
+			"WHERE lower(title) = ANY($2)), FALSE))", []any{[]uuid.UUID{}, []string{"sprint"}}},
+		{"title:report", "(t.title ILIKE $1)", []any{"%report%"}},
+		{"50%_off", "(t.title ILIKE $1 OR t.description ILIKE $1)", []any{`%50\%\_off%`}},
+	} {
+		where, args := compile(t, tc.in, nil)
+		if where != tc.where {
+			t.Errorf("compile(%q) = %s, want %s", tc.in, where, tc.where)
+		}
+		if len(args) != len(tc.args) || (len(args) > 0 && !reflect.DeepEqual(args, tc.args)) {
+			t.Errorf("compile(%q) args = %#v, want %#v", tc.in, args, tc.args)
+		}
+	}
+}
+
+func TestCompileRefs(t *testing.T) {
+	me, other := uuid.New(), uuid.New()
+
+	where, args := compile(t, "assignee:me,"+other.String()+",Ann@Example.com", &me)
+	if !strings.Contains(where, "ut.user_id = ANY($1) OR lower(u.email) = ANY($2)") {
+		t.Errorf("where = %s", where)
+	}
+	want := []any{[]uuid.UUID{me, other}, []string{"ann@example.com"}}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %v, want %v", args, want)
+	}
+
+	project := uuid.New()
+	_, args = compile(t, "project:"+project.String()+",Backend", nil)
+	want = []any{[]uuid.UUID{project}, []string{"backend"}}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("project args = %v, want %v", args, want)
+	}
+}
+
+func TestCompileMeWithoutUser(t *testing.T) {
+	n, err := query.Parse("status:todo assignee:me")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = (&compiler{now: time.Now()}).compile(n)
+	var qe *query.Error
+	if !errors.As(err, &qe) || qe.Pos != 22 {
+		t.Errorf("error = %v, want position 22", err)
+	}
+}
+
+// Значения никогда не попадают в текст запроса.
+func TestCompileKeepsValuesInArgs(t *testing.T) {
+	in := `title:"x' OR 1=1 --" label:"a'b" project:"p'); DROP TABLE tasks; --"`
+	where, _ := compile(t, in, nil)
+	if strings.ContainsAny(where, "'") || strings.Contains(where, "DROP") {
+		t.Errorf("value leaked into SQL: %s", where)
+	}
+}
@@ -1,13 +1,17 @@
 package task
 
 import (
+	milestone2 "ProjectManagementAPI/internal/domain/milestone"
+	project2 "ProjectManagementAPI/internal/domain/project"
 	task2 "ProjectManagementAPI/internal/domain/task"
+	"ProjectManagementAPI/internal/storage/postgre"
 	"context"
 	"database/sql"
 	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type Repository struct {
@@ -21,55 +25,340 @@ func NewTaskRepository(db *sql.DB) *Repository {
 func (r *Repository) Create(ctx context.Context, t *task2.Task) error {
 	t.ID = uuid.New()
 	t.CreatedAt = time.Now()
+	t.UpdatedAt = t.CreatedAt
 
-	const query = `INSERT INTO tasks(id, title, description, status, created_at) VALUES($1,$2,$3,$4,$5)`
-	_, err := r.db.ExecContext(ctx, query, t.ID, t.Title, t.Description, t.Status, t.CreatedAt)
+	return postgre.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		return r.create(ctx, t)
+	})
+}
+
+func (r *Repository) create(ctx context.Context, t *task2.Task) error {
+	tx := postgre.Conn(ctx, r.db)
+
+	const query = `INSERT INTO tasks(id, title, description, status, story_points, milestone_id, project_id, due_at,
+			series_id, occurrence_at, created_at, updated_at)
+		VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)`
+	_, err := tx.ExecContext(ctx, query,
+		t.ID, t.Title, t.Description, t.Status, t.StoryPoints, nullUUID(t.MilestoneID), nullUUID(t.ProjectID),
+		t.DueAt, nullUUID(t.SeriesID), t.OccurrenceAt, t.CreatedAt, t.UpdatedAt,
+	)
+	if err != nil {
+		return mapError(err)
+	}
 
 	for _, userID := range t.Assignees {
 		const linkQuery = `INSERT INTO user_tasks(user_id, task_id) VALUES($1, $2)`
-		if _, err := r.db.ExecContext(ctx, linkQuery, userID, t.ID); err != nil {
-			return err
+		if _, err := tx.ExecContext(ctx, linkQuery, userID, t.ID); err != nil {
+			return mapError(err)
 		}
 	}
 
-	return err
+	if err := insertLabels(ctx, tx, t.ID, t.Labels); err != nil {
+		return err
+	}
+
+	return insertStatusChange(ctx, tx, t.ID, t.Status, t.CreatedAt)
 }
 
-func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*task2.Task, error) {
-	const taskQuery = `SELECT id, title, description, status, created_at FROM tasks WHERE id=$1`
+const taskColumns = `id, title, description, status, story_points, milestone_id, project_id, due_at, series_id,
+	occurrence_at, created_at, updated_at`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
 
+func scanTask(row scanner) (*task2.Task, error) {
 	t := &task2.Task{}
-	err := r.db.QueryRowContext(ctx, taskQuery, id).Scan(
-		&t.ID, &t.Title, &t.Description, &t.Status, &t.CreatedAt,
+	var (
+		milestoneID, projectID, seriesID uuid.NullUUID
+		dueAt, occurrenceAt              sql.NullTime
 	)
+	err := row.Scan(
+		&t.ID, &t.Title, &t.Description, &t.Status, &t.StoryPoints, &milestoneID, &projectID, &dueAt, &seriesID,
+		&occurrenceAt, &t.CreatedAt, &t.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if milestoneID.Valid {
+		t.MilestoneID = &milestoneID.UUID
+	}
+	if projectID.Valid {
+		t.ProjectID = &projectID.UUID
+	}
+	if dueAt.Valid {
+		t.DueAt = &dueAt.Time
+	}
+	if seriesID.Valid {
+		t.SeriesID = &seriesID.UUID
+	}
+	if occurrenceAt.Valid {
+		t.OccurrenceAt = &occurrenceAt.Time
+	}
+	return t, nil
+}
+
+func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*task2.Task, error) {
+	return r.get(ctx, `SELECT `+taskColumns+` FROM tasks WHERE id=$1`, id)
+}
+
+// GetForUpdate читает задачу и блокирует её строку до конца транзакции:
+// параллельные изменения исполнителей и меток не затирают друг друга.
+func (r *Repository) GetForUpdate(ctx context.Context, id uuid.UUID) (*task2.Task, error) {
+	return r.get(ctx, `SELECT `+taskColumns+` FROM tasks WHERE id=$1 FOR UPDATE`, id)
+}
+
+func (r *Repository) get(ctx context.Context, taskQuery string, id uuid.UUID) (*task2.Task, error) {
+	t, err := scanTask(postgre.Conn(ctx, r.db).QueryRowContext(ctx, taskQuery, id))
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, task2.ErrTaskNotFound
 	} else if err != nil {
 		return nil, err
 	}
 
-	// Подгружаем исполнителей
-	const assigneeQuery = `SELECT user_id FROM user_tasks WHERE task_id=$1`
-	rows, err := r.db.QueryContext(ctx, assigneeQuery, id)
+	if err := r.loadRelations(ctx, []*task2.Task{t}); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// DueBetween возвращает незавершённые задачи со сроком в [from, to).
+func (r *Repository) DueBetween(ctx context.Context, from, to time.Time) ([]*task2.Task, error) {
+	const query = `SELECT ` + taskColumns + ` FROM tasks
+		WHERE due_at >= $1 AND due_at < $2 AND status <> $3
+		ORDER BY due_at`
+
+	return r.query(ctx, query, from, to, task2.StatusDone)
+}
+
+// SeriesInstances возвращает незавершённые повторения серии начиная с from.
+func (r *Repository) SeriesInstances(ctx context.Context, seriesID uuid.UUID, from time.Time) ([]*task2.Task, error) {
+	const query = `SELECT ` + taskColumns + ` FROM tasks
+		WHERE series_id = $1 AND occurrence_at >= $2 AND status <> $3
+		ORDER BY occurrence_at`
+
+	return r.query(ctx, query, seriesID, from, task2.StatusDone)
+}
+
+// SetSeries привязывает задачу к серии повторений.
+func (r *Repository) SetSeries(ctx context.Context, taskID, seriesID uuid.UUID, occurrenceAt time.Time) error {
+	const query = `UPDATE tasks SET series_id=$2, occurrence_at=$3, updated_at=NOW() WHERE id=$1`
+	res, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, taskID, seriesID, occurrenceAt)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return task2.ErrTaskNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) query(ctx context.Context, query string, args ...any) ([]*task2.Task, error) {
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	var assignees []uuid.UUID
+	var tasks []*task2.Task
 	for rows.Next() {
-		var userID uuid.UUID
-		if err := rows.Scan(&userID); err != nil {
+		t, err := scanTask(rows)
+		if err != nil {
 			return nil, err
 		}
-		assignees = append(assignees, userID)
+		tasks = append(tasks, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
-	t.Assignees = assignees
 
-	return t, nil
+	if err := r.loadRelations(ctx, tasks); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// loadRelations подгружает исполнителей и метки: по одному запросу на все задачи.
+func (r *Repository) loadRelations(ctx context.Context, tasks []*task2.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(tasks))
+	byID := make(map[uuid.UUID]*task2.Task, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+		byID[t.ID] = t
+	}
+
+	if err := r.loadAssignees(ctx, ids, byID); err != nil {
+		return err
+	}
+	return r.loadLabels(ctx, ids, byID)
+}
+
+func (r *Repository) loadAssignees(ctx context.Context, ids []uuid.UUID, byID map[uuid.UUID]*task2.Task) error {
+	const assigneeQuery = `SELECT task_id, user_id FROM user_tasks WHERE task_id = ANY($1) ORDER BY task_id, user_id`
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, assigneeQuery, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID, userID uuid.UUID
+		if err := rows.Scan(&taskID, &userID); err != nil {
+			return err
+		}
+		if t, ok := byID[taskID]; ok {
+			t.Assignees = append(t.Assignees, userID)
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *Repository) loadLabels(ctx context.Context, ids []uuid.UUID, byID map[uuid.UUID]*task2.Task) error {
+	const labelQuery = `SELECT task_id, label FROM task_labels WHERE task_id = ANY($1) ORDER BY task_id, label`
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, labelQuery, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			taskID uuid.UUID
+			label  string
+		)
+		if err := rows.Scan(&taskID, &label); err != nil {
+			return err
+		}
+		if t, ok := byID[taskID]; ok {
+			t.Labels = append(t.Labels, label)
+		}
+	}
+
+	return rows.Err()
+}
+
+// Update сохраняет изменяемые поля задачи и пишет смену статуса в историю.
+func (r *Repository) Update(ctx context.Context, t *task2.Task) error {
+	return postgre.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		return r.update(ctx, t)
+	})
+}
+
+func (r *Repository) update(ctx context.Context, t *task2.Task) error {
+	tx := postgre.Conn(ctx, r.db)
+
+	var prevStatus string
+	err := tx.QueryRowContext(ctx, `SELECT status FROM tasks WHERE id=$1 FOR UPDATE`, t.ID).Scan(&prevStatus)
+	if errors.Is(err, sql.ErrNoRows) {
+		return task2.ErrTaskNotFound
+	} else if err != nil {
+		return err
+	}
+
+	t.UpdatedAt = time.Now()
+
+	const query = `UPDATE tasks SET title=$2, description=$3, status=$4, story_points=$5, milestone_id=$6, project_id=$7,
+		due_at=$8, updated_at=$9
+		WHERE id=$1`
+	_, err = tx.ExecContext(ctx, query,
+		t.ID, t.Title, t.Description, t.Status, t.StoryPoints, nullUUID(t.MilestoneID), nullUUID(t.ProjectID),
+		t.DueAt, t.UpdatedAt,
+	)
+	if err != nil {
+		return mapError(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_tasks WHERE task_id=$1`, t.ID); err != nil {
+		return err
+	}
+	for _, userID := range t.Assignees {
+		const linkQuery = `INSERT INTO user_tasks(user_id, task_id) VALUES($1, $2)`
+		if _, err := tx.ExecContext(ctx, linkQuery, userID, t.ID); err != nil {
+			return mapError(err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM task_labels WHERE task_id=$1`, t.ID); err != nil {
+		return err
+	}
+	if err := insertLabels(ctx, tx, t.ID, t.Labels); err != nil {
+		return err
+	}
+
+	if prevStatus != t.Status {
+		return insertStatusChange(ctx, tx, t.ID, t.Status, t.UpdatedAt)
+	}
+
+	return nil
 }
 
 func (r *Repository) DeleteByID(ctx context.Context, id uuid.UUID) error {
 	const query = `DELETE FROM tasks WHERE id=$1`
-	_, err := r.db.ExecContext(ctx, query, id)
+	res, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return task2.ErrTaskNotFound
+	}
+
+	return nil
+}
+
+func insertLabels(ctx context.Context, tx postgre.DBTX, taskID uuid.UUID, labels []string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	const query = `INSERT INTO task_labels(task_id, label) SELECT $1, unnest($2::text[]) ON CONFLICT DO NOTHING`
+	_, err := tx.ExecContext(ctx, query, taskID, labels)
+	return err
+}
+
+func insertStatusChange(ctx context.Context, tx postgre.DBTX, taskID uuid.UUID, status string, at time.Time) error {
+	const query = `INSERT INTO task_status_history(task_id, status, changed_at) VALUES($1, $2, $3)`
+	_, err := tx.ExecContext(ctx, query, taskID, status, at)
+	return err
+}
+
+func nullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil || *id == uuid.Nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func mapError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23503" {
+		return err
+	}
+
+	switch pgErr.ConstraintName {
+	case "tasks_milestone_id_fkey":
+		return milestone2.ErrMilestoneNotFound
+	case "tasks_project_id_fkey":
+		return project2.ErrProjectNotFound
+	case "user_tasks_user_id_fkey":
+		return task2.ErrAssigneeNotFound
+	}
+
 	return err
 }
@@ -0,0 +1,117 @@
+package transfer
+
+import (
+	transfer2 "ProjectManagementAPI/internal/domain/transfer"
+	user2 "ProjectManagementAPI/internal/domain/user"
+	"ProjectManagementAPI/internal/storage/postgre"
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Repository ищет связанные сущности для импорта и экспорта.
+type Repository struct {
+	db *sql.DB
+}
+
+func NewTransferRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Users находит пользователей по UUID или email (без учёта регистра).
+func (r *Repository) Users(ctx context.Context, refs []string) ([]transfer2.Ref, error) {
+	const query = `SELECT id, lower(email) FROM users WHERE id::text = ANY($1) OR lower(email) = ANY($1)`
+	return r.refs(ctx, query, refs)
+}
+
+// Projects находит проекты по UUID или названию.
+func (r *Repository) Projects(ctx context.Context, refs []string) ([]transfer2.Ref, error) {
+	const query = `SELECT id, lower(name) FROM projects WHERE id::text = ANY($1) OR lower(name) = ANY($1)`
+	return r.refs(ctx, query, refs)
+}
+
+// Milestones находит вехи по UUID или названию.
+func (r *Repository) Milestones(ctx context.Context, refs []string) ([]transfer2.Ref, error) {
+	const query = `SELECT id, lower(title) FROM milestones WHERE id::text = ANY($1) OR lower(title) = ANY($1)`
+	return r.refs(ctx, query, refs)
+}
+
+func (r *Repository) refs(ctx context.Context, query string, refs []string) ([]transfer2.Ref, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	lower := make([]string, len(refs))
+	for i, ref := range refs {
+		lower[i] = strings.ToLower(ref)
+	}
+
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, lower)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []transfer2.Ref
+	for rows.Next() {
+		var ref transfer2.Ref
+		if err := rows.Scan(&ref.ID, &ref.Key); err != nil {
+			return nil, err
+		}
+		res = append(res, ref)
+	}
+
+	return res, rows.Err()
+}
+
+// Emails возвращает email пользователей по их id.
+func (r *Repository) Emails(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]string, error) {
+	res := make(map[uuid.UUID]string, len(ids))
+	if len(ids) == 0 {
+		return res, nil
+	}
+
+	const query = `SELECT id, email FROM users WHERE id = ANY($1)`
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id    uuid.UUID
+			email string
+		)
+		if err := rows.Scan(&id, &email); err != nil {
+			return nil, err
+		}
+		res[id] = email
+	}
+
+	return res, rows.Err()
+}
+
+// UsersAfter отдаёт пользователей порциями по возрастанию id: следующая
+// порция начинается после последнего id предыдущей.
+func (r *Repository) UsersAfter(ctx context.Context, after uuid.UUID, limit int) ([]*user2.User, error) {
+	const query = `SELECT id, email, name FROM users WHERE id > $1 ORDER BY id LIMIT $2`
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*user2.User
+	for rows.Next() {
+		u := &user2.User{}
+		if err := rows.Scan(&u.ID, &u.Email, &u.Name); err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+
+	return users, rows.Err()
+}
@@ -2,9 +2,11 @@ package user
 
 import (
 	user2 "ProjectManagementAPI/internal/domain/user"
+	"ProjectManagementAPI/internal/storage/postgre"
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -21,7 +23,7 @@ func NewUserRepository(db *sql.DB) *Repository {
 func (r *Repository) Create(ctx context.Context, u *user2.User) error {
 	const query = `INSERT INTO users(id, email, name) VALUES ($1, $2, $3)`
 
-	_, err := r.db.ExecContext(ctx, query, u.ID, u.Email, u.Name)
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, u.ID, u.Email, u.Name)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
@@ -37,7 +39,7 @@ func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*user2.User, er
 	const query = `SELECT id, email, name FROM users WHERE id=$1`
 
 	u := &user2.User{}
-	err := r.db.QueryRowContext(ctx, query, id).
+	err := postgre.Conn(ctx, r.db).QueryRowContext(ctx, query, id).
 		Scan(&u.ID, &u.Email, &u.Name)
 
 	if errors.Is(err, sql.ErrNoRows) {
@@ -49,6 +51,53 @@ func (r *Repository) GetByID(ctx context.Context, id uuid.UUID) (*user2.User, er
 
 func (r *Repository) DeleteByID(ctx context.Context, id uuid.UUID) error {
 	const query = `DELETE FROM users WHERE id=$1`
-	_, err := r.db.ExecContext(ctx, query, id)
-	return err
+	res, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return user2.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*user2.User, error) {
+	const query = `SELECT id, email, name FROM users WHERE id = ANY($1)`
+	return r.query(ctx, query, ids)
+}
+
+func (r *Repository) GetByEmails(ctx context.Context, emails []string) ([]*user2.User, error) {
+	const query = `SELECT id, email, name FROM users WHERE lower(email) = ANY($1)`
+
+	lower := make([]string, len(emails))
+	for i, e := range emails {
+		lower[i] = strings.ToLower(e)
+	}
+
+	return r.query(ctx, query, lower)
+}
+
+func (r *Repository) query(ctx context.Context, query string, args ...any) ([]*user2.User, error) {
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*user2.User
+	for rows.Next() {
+		u := &user2.User{}
+		if err := rows.Scan(&u.ID, &u.Email, &u.Name); err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+
+	return users, rows.Err()
 }
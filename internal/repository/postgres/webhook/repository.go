@@ -0,0 +1,216 @@
+package webhook
+
+import (
+	event2 "ProjectManagementAPI/internal/domain/event"
+	webhook2 "ProjectManagementAPI/internal/domain/webhook"
+	"ProjectManagementAPI/internal/storage/postgre"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Repository struct {
+	db    *sql.DB
+	types *pgtype.Map
+}
+
+func NewWebhookRepository(db *sql.DB) *Repository {
+	return &Repository{db: db, types: pgtype.NewMap()}
+}
+
+func (r *Repository) CreateSubscription(ctx context.Context, s *webhook2.Subscription) error {
+	s.CreatedAt = time.Now()
+
+	const query = `INSERT INTO webhook_subscriptions(id, url, secret, event_types, active, created_at)
+		VALUES($1,$2,$3,$4,$5,$6)`
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, s.ID, s.URL, s.Secret, s.EventTypes, s.Active, s.CreatedAt)
+	return err
+}
+
+func (r *Repository) GetSubscription(ctx context.Context, id uuid.UUID) (*webhook2.Subscription, error) {
+	const query = `SELECT id, url, secret, event_types, active, created_at FROM webhook_subscriptions WHERE id=$1`
+
+	s := &webhook2.Subscription{}
+	err := postgre.Conn(ctx, r.db).QueryRowContext(ctx, query, id).
+		Scan(&s.ID, &s.URL, &s.Secret, r.types.SQLScanner(&s.EventTypes), &s.Active, &s.CreatedAt)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, webhook2.ErrSubscriptionNotFound
+	}
+
+	return s, err
+}
+
+func (r *Repository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	const query = `DELETE FROM webhook_subscriptions WHERE id=$1`
+	res, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return webhook2.ErrSubscriptionNotFound
+	}
+
+	return nil
+}
+
+// Enqueue ставит событие в очередь доставки всем активным подпискам на его тип.
+func (r *Repository) Enqueue(ctx context.Context, e event2.Event) error {
+	const query = `INSERT INTO webhook_deliveries(subscription_id, event_id, event_type, payload)
+		SELECT id, $1, $2, $3 FROM webhook_subscriptions
+		WHERE active AND $2 = ANY(event_types)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING`
+
+	body, err := e.Envelope()
+	if err != nil {
+		return err
+	}
+
+	_, err = postgre.Conn(ctx, r.db).ExecContext(ctx, query, e.ID, e.Type, string(body))
+	return err
+}
+
+func (r *Repository) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit, offset int) ([]webhook2.Delivery, error) {
+	const query = `SELECT id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+			last_error, response_code, created_at, delivered_at
+		FROM webhook_deliveries
+		WHERE subscription_id=$1
+		ORDER BY id DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, subscriptionID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []webhook2.Delivery
+	for rows.Next() {
+		var (
+			d            webhook2.Delivery
+			payload      []byte
+			lastError    sql.NullString
+			responseCode sql.NullInt64
+			deliveredAt  sql.NullTime
+		)
+		if err := rows.Scan(
+			&d.ID, &d.SubscriptionID, &d.EventID, &d.EventType, &payload, &d.Status, &d.Attempts, &d.NextAttemptAt,
+			&lastError, &responseCode, &d.CreatedAt, &deliveredAt,
+		); err != nil {
+			return nil, err
+		}
+		d.Payload = payload
+		d.LastError = lastError.String
+		d.ResponseCode = int(responseCode.Int64)
+		if deliveredAt.Valid {
+			d.DeliveredAt = &deliveredAt.Time
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// ClaimDue забирает готовые к отправке доставки и откладывает их на lease,
+// чтобы другой экземпляр сервиса не отправил их параллельно.
+func (r *Repository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]webhook2.PendingDelivery, error) {
+	const query = `UPDATE webhook_deliveries d
+		SET next_attempt_at = NOW() + make_interval(secs => $2)
+		FROM webhook_subscriptions s
+		WHERE s.id = d.subscription_id
+		  AND d.id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		  )
+		RETURNING d.id, d.subscription_id, d.event_id, d.event_type, d.payload, d.attempts, s.url, s.secret`
+
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []webhook2.PendingDelivery
+	for rows.Next() {
+		var (
+			d       webhook2.PendingDelivery
+			payload []byte
+		)
+		if err := rows.Scan(
+			&d.ID, &d.SubscriptionID, &d.EventID, &d.EventType, &payload, &d.Attempts, &d.URL, &d.Secret,
+		); err != nil {
+			return nil, err
+		}
+		d.Payload = payload
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func (r *Repository) MarkSucceeded(ctx context.Context, id int64, responseCode int) error {
+	const query = `UPDATE webhook_deliveries
+		SET status='succeeded', attempts=attempts+1, response_code=$2, last_error=NULL, delivered_at=NOW()
+		WHERE id=$1`
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, id, responseCode)
+	return err
+}
+
+// MarkFailed фиксирует неудачную попытку. dead переводит доставку в dead-letter.
+func (r *Repository) MarkFailed(ctx context.Context, id int64, responseCode int, lastError string, retryIn time.Duration, dead bool) error {
+	const query = `UPDATE webhook_deliveries
+		SET status = CASE WHEN $5 THEN 'dead' ELSE 'pending' END,
+			attempts = attempts + 1,
+			response_code = NULLIF($2, 0),
+			last_error = $3,
+			next_attempt_at = NOW() + make_interval(secs => $4)
+		WHERE id=$1`
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, id, responseCode, lastError, retryIn.Seconds(), dead)
+	return err
+}
+
+// Purge удаляет успешные доставки, выполненные раньше before, и доставки
+// из dead-letter, созданные раньше before.
+func (r *Repository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	const query = `DELETE FROM webhook_deliveries
+		WHERE (status='succeeded' AND delivered_at < $1) OR (status='dead' AND created_at < $1)`
+
+	res, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// Redeliver возвращает доставку из dead-letter в очередь.
+func (r *Repository) Redeliver(ctx context.Context, subscriptionID uuid.UUID, id int64) error {
+	const query = `UPDATE webhook_deliveries
+		SET status='pending', attempts=0, next_attempt_at=NOW()
+		WHERE id=$1 AND subscription_id=$2 AND status='dead'`
+	res, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, id, subscriptionID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return webhook2.ErrDeliveryNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package workload
+
+import (
+	task2 "ProjectManagementAPI/internal/domain/task"
+	user2 "ProjectManagementAPI/internal/domain/user"
+	workload2 "ProjectManagementAPI/internal/domain/workload"
+	"ProjectManagementAPI/internal/storage/postgre"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewWorkloadRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Users возвращает всех пользователей: в отчёте видны и свободные.
+func (r *Repository) Users(ctx context.Context) ([]workload2.User, error) {
+	const query = `SELECT id, name, email, weekly_capacity FROM users ORDER BY name, id`
+
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []workload2.User
+	for rows.Next() {
+		var (
+			u        workload2.User
+			capacity sql.NullInt64
+		)
+		if err := rows.Scan(&u.ID, &u.Name, &u.Email, &capacity); err != nil {
+			return nil, err
+		}
+		if capacity.Valid {
+			c := int(capacity.Int64)
+			u.Capacity = &c
+		}
+		users = append(users, u)
+	}
+
+	return users, rows.Err()
+}
+
+// Assignments возвращает назначения задач со сроком в [from, to) и
+// незавершённых задач без срока.
+func (r *Repository) Assignments(ctx context.Context, from, to time.Time) ([]workload2.Assignment, error) {
+	const query = `SELECT ut.user_id, t.id, t.status, t.story_points, t.due_at,
+			(SELECT COUNT(*) FROM user_tasks a WHERE a.task_id = t.id)
+		FROM user_tasks ut
+		JOIN tasks t ON t.id = ut.task_id
+		WHERE (t.due_at >= $1 AND t.due_at < $2) OR (t.due_at IS NULL AND t.status <> $3)`
+
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, from, to, task2.StatusDone)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []workload2.Assignment
+	for rows.Next() {
+		var (
+			a     workload2.Assignment
+			dueAt sql.NullTime
+		)
+		if err := rows.Scan(&a.UserID, &a.TaskID, &a.Status, &a.StoryPoints, &dueAt, &a.Assignees); err != nil {
+			return nil, err
+		}
+		if dueAt.Valid {
+			a.DueAt = &dueAt.Time
+		}
+		res = append(res, a)
+	}
+
+	return res, rows.Err()
+}
+
+// SetCapacity задаёт недельную ёмкость пользователя, nil сбрасывает её.
+func (r *Repository) SetCapacity(ctx context.Context, userID uuid.UUID, capacity *int) error {
+	const query = `UPDATE users SET weekly_capacity=$2 WHERE id=$1`
+	res, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, userID, capacity)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return user2.ErrUserNotFound
+	}
+
+	return nil
+}
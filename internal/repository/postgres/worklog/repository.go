@@ -0,0 +1,236 @@
+package worklog
+
+import (
+	task2 "ProjectManagementAPI/internal/domain/task"
+	user2 "ProjectManagementAPI/internal/domain/user"
+	worklog2 "ProjectManagementAPI/internal/domain/worklog"
+	"ProjectManagementAPI/internal/storage/postgre"
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+func NewWorklogRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+const worklogColumns = `id, user_id, task_id, started_at, duration_seconds, note, source, created_at, updated_at`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWorklog(row scanner) (*worklog2.Worklog, error) {
+	var (
+		w       worklog2.Worklog
+		seconds sql.NullInt64
+	)
+	err := row.Scan(&w.ID, &w.UserID, &w.TaskID, &w.StartedAt, &seconds, &w.Note, &w.Source, &w.CreatedAt, &w.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if seconds.Valid {
+		d := time.Duration(seconds.Int64) * time.Second
+		w.Duration = &d
+	}
+	return &w, nil
+}
+
+// Create сохраняет запись. Запись без Duration - запущенный таймер; если
+// у пользователя уже есть таймер, возвращается ErrTimerRunning.
+func (r *Repository) Create(ctx context.Context, w *worklog2.Worklog) error {
+	w.ID = uuid.New()
+	w.CreatedAt = time.Now()
+	w.UpdatedAt = w.CreatedAt
+
+	var seconds sql.NullInt64
+	if w.Duration != nil {
+		seconds = sql.NullInt64{Int64: int64(w.Duration.Seconds()), Valid: true}
+	}
+
+	const query = `INSERT INTO worklogs(` + worklogColumns + `) VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+	_, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query,
+		w.ID, w.UserID, w.TaskID, w.StartedAt, seconds, w.Note, w.Source, w.CreatedAt, w.UpdatedAt,
+	)
+	return mapError(err)
+}
+
+// StopTimer останавливает запущенный таймер пользователя.
+func (r *Repository) StopTimer(ctx context.Context, userID uuid.UUID, note *string) (*worklog2.Worklog, error) {
+	const query = `UPDATE worklogs
+		SET duration_seconds = GREATEST(EXTRACT(EPOCH FROM NOW() - started_at)::int, 0),
+			note = COALESCE($2, note), updated_at = NOW()
+		WHERE user_id=$1 AND duration_seconds IS NULL
+		RETURNING ` + worklogColumns
+
+	w, err := scanWorklog(postgre.Conn(ctx, r.db).QueryRowContext(ctx, query, userID, note))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, worklog2.ErrNoRunningTimer
+	}
+	return w, err
+}
+
+func (r *Repository) RunningTimer(ctx context.Context, userID uuid.UUID) (*worklog2.Worklog, error) {
+	const query = `SELECT ` + worklogColumns + ` FROM worklogs WHERE user_id=$1 AND duration_seconds IS NULL`
+
+	w, err := scanWorklog(postgre.Conn(ctx, r.db).QueryRowContext(ctx, query, userID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, worklog2.ErrNoRunningTimer
+	}
+	return w, err
+}
+
+func (r *Repository) List(ctx context.Context, f worklog2.Filter) ([]worklog2.Worklog, error) {
+	conds, args := filter(f)
+
+	query := `SELECT ` + worklogColumns + ` FROM worklogs`
+	if len(conds) > 0 {
+		query += ` WHERE ` + strings.Join(conds, " AND ")
+	}
+	args = append(args, f.Limit, f.Offset)
+	query += ` ORDER BY started_at DESC, id LIMIT $` + strconv.Itoa(len(args)-1) + ` OFFSET $` + strconv.Itoa(len(args))
+
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []worklog2.Worklog
+	for rows.Next() {
+		w, err := scanWorklog(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *w)
+	}
+
+	return list, rows.Err()
+}
+
+// DeleteByID удаляет запись пользователя.
+func (r *Repository) DeleteByID(ctx context.Context, userID, id uuid.UUID) error {
+	const query = `DELETE FROM worklogs WHERE id=$1 AND user_id=$2`
+	res, err := postgre.Conn(ctx, r.db).ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return worklog2.ErrWorklogNotFound
+	}
+
+	return nil
+}
+
+// Totals суммирует завершённые записи, начатые в [From, To), по задачам,
+// пользователям или проектам.
+func (r *Repository) Totals(ctx context.Context, groupBy string, f worklog2.Filter) ([]worklog2.Total, error) {
+	var key, name, join string
+	switch groupBy {
+	case worklog2.GroupByTask:
+		key, name, join = "t.id", "t.title", "JOIN tasks t ON t.id = w.task_id"
+	case worklog2.GroupByUser:
+		key, name, join = "u.id", "u.name", "JOIN users u ON u.id = w.user_id"
+	case worklog2.GroupByProject:
+		key, name, join = "p.id", "COALESCE(p.name, '')",
+			"JOIN tasks t ON t.id = w.task_id LEFT JOIN projects p ON p.id = t.project_id"
+	default:
+		return nil, worklog2.ErrInvalidGroupBy
+	}
+
+	conds, args := filter(f)
+	for i, c := range conds {
+		conds[i] = "w." + c
+	}
+	conds = append(conds, "w.duration_seconds IS NOT NULL")
+
+	query := `SELECT ` + key + `, ` + name + `, SUM(w.duration_seconds), COUNT(*)
+		FROM worklogs w ` + join + `
+		WHERE ` + strings.Join(conds, " AND ") + `
+		GROUP BY ` + key + `, ` + name + `
+		ORDER BY SUM(w.duration_seconds) DESC`
+
+	rows, err := postgre.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var totals []worklog2.Total
+	for rows.Next() {
+		var (
+			t       worklog2.Total
+			id      uuid.NullUUID
+			seconds int64
+		)
+		if err := rows.Scan(&id, &t.Name, &seconds, &t.Entries); err != nil {
+			return nil, err
+		}
+		if id.Valid {
+			t.ID = &id.UUID
+		}
+		t.Duration = time.Duration(seconds) * time.Second
+		totals = append(totals, t)
+	}
+
+	return totals, rows.Err()
+}
+
+func filter(f worklog2.Filter) ([]string, []any) {
+	var (
+		conds []string
+		args  []any
+	)
+	add := func(cond string, v any) {
+		args = append(args, v)
+		conds = append(conds, cond+" $"+strconv.Itoa(len(args)))
+	}
+
+	if f.UserID != nil {
+		add("user_id =", *f.UserID)
+	}
+	if f.TaskID != nil {
+		add("task_id =", *f.TaskID)
+	}
+	if f.From != nil {
+		add("started_at >=", *f.From)
+	}
+	if f.To != nil {
+		add("started_at <", *f.To)
+	}
+
+	return conds, args
+}
+
+func mapError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch {
+	case pgErr.Code == "23505" && pgErr.ConstraintName == "idx_worklogs_running_timer":
+		return worklog2.ErrTimerRunning
+	case pgErr.Code == "23503" && pgErr.ConstraintName == "worklogs_task_id_fkey":
+		return task2.ErrTaskNotFound
+	case pgErr.Code == "23503" && pgErr.ConstraintName == "worklogs_user_id_fkey":
+		return user2.ErrUserNotFound
+	}
+
+	return err
+}
@@ -0,0 +1,41 @@
+package worklog
+
+import (
+	task2 "ProjectManagementAPI/internal/domain/task"
+	user2 "ProjectManagementAPI/internal/domain/user"
+	worklog2 "ProjectManagementAPI/internal/domain/worklog"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestMapError(t *testing.T) {
+	other := errors.New("connection reset")
+
+	for _, tc := range []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"second running timer", &pgconn.PgError{Code: "23505", ConstraintName: "idx_worklogs_running_timer"},
+			worklog2.ErrTimerRunning},
+		{"wrapped", fmt.Errorf("insert: %w", &pgconn.PgError{Code: "23505", ConstraintName: "idx_worklogs_running_timer"}),
+			worklog2.ErrTimerRunning},
+		{"missing task", &pgconn.PgError{Code: "23503", ConstraintName: "worklogs_task_id_fkey"}, task2.ErrTaskNotFound},
+		{"missing user", &pgconn.PgError{Code: "23503", ConstraintName: "worklogs_user_id_fkey"}, user2.ErrUserNotFound},
+		{"nil", nil, nil},
+		{"not postgres", other, other},
+	} {
+		if got := mapError(tc.err); !errors.Is(got, tc.want) || (tc.want == nil && got != nil) {
+			t.Errorf("%s: mapError = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	// другие нарушения уникальности не выдаются за запущенный таймер
+	pk := &pgconn.PgError{Code: "23505", ConstraintName: "worklogs_pkey"}
+	if got := mapError(pk); errors.Is(got, worklog2.ErrTimerRunning) || got != pk {
+		t.Errorf("mapError(pkey) = %v, want the original error", got)
+	}
+}
@@ -0,0 +1,54 @@
+package postgre
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Listen подписывается на LISTEN channel отдельным соединением и вызывает
+// fn для каждого уведомления. При обрыве соединение восстанавливается,
+// onError получает причину. Возвращается после отмены ctx.
+func Listen(ctx context.Context, dsn, channel string, fn func(ctx context.Context, payload string), onError func(err error)) {
+	const op = "storage.postgresql.Listen"
+
+	backoff := time.Second
+	for ctx.Err() == nil {
+		err := listen(ctx, dsn, channel, fn)
+		if ctx.Err() != nil {
+			return
+		}
+		onError(fmt.Errorf("%s: %w", op, err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+func listen(ctx context.Context, dsn, channel string, fn func(ctx context.Context, payload string)) error {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return err
+	}
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		fn(ctx, n.Payload)
+	}
+}
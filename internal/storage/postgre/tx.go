@@ -0,0 +1,95 @@
+package postgre
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// DBTX - общий интерфейс *sql.DB и *sql.Tx для репозиториев.
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type txKey struct{}
+
+// Conn возвращает транзакцию из контекста, если она открыта, иначе db.
+func Conn(ctx context.Context, db *sql.DB) DBTX {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// RunInTx выполняет fn в транзакции. Если в ctx уже есть транзакция,
+// fn выполняется в ней, а фиксирует её внешний вызов.
+func RunInTx(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) error {
+	const op = "storage.postgresql.RunInTx"
+
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RunInSavepoint выполняет fn под SAVEPOINT транзакции из ctx. Ошибка fn
+// откатывает только её изменения, и транзакция остаётся пригодной.
+// Без транзакции в ctx fn выполняется в отдельной транзакции.
+func RunInSavepoint(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) error {
+	const op = "storage.postgresql.RunInSavepoint"
+
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	if !ok {
+		return RunInTx(ctx, db, fn)
+	}
+
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT sp"); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := fn(ctx); err != nil {
+		if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT sp"); rbErr != nil {
+			return fmt.Errorf("%s: %w", op, rbErr)
+		}
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT sp"); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+type Transactor struct {
+	db *sql.DB
+}
+
+func NewTransactor(db *sql.DB) *Transactor {
+	return &Transactor{db: db}
+}
+
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return RunInTx(ctx, t.db, fn)
+}
+
+func (t *Transactor) WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	return RunInSavepoint(ctx, t.db, fn)
+}
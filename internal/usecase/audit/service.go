@@ -0,0 +1,127 @@
+package audit
+
+import (
+	"ProjectManagementAPI/internal/domain/audit"
+	"ProjectManagementAPI/internal/lib/actor"
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 500
+)
+
+type RepositoryInterface interface {
+	Insert(ctx context.Context, e *audit.Event) error
+	List(ctx context.Context, f audit.Filter) ([]audit.Event, error)
+}
+
+type Service struct {
+	repo RepositoryInterface
+}
+
+func NewAuditService(repo RepositoryInterface) *Service {
+	return &Service{repo: repo}
+}
+
+// Record пишет событие аудита. Вызывается внутри транзакции изменения,
+// поэтому событие сохраняется только вместе с самим изменением.
+// Автор и request_id берутся из контекста запроса.
+func (s *Service) Record(ctx context.Context, entityType string, entityID uuid.UUID, action string, before, after any) error {
+	e := &audit.Event{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		RequestID:  middleware.GetReqID(ctx),
+	}
+
+	if id, ok := actor.FromContext(ctx); ok {
+		e.ActorID = &id
+	}
+
+	var err error
+	if e.Before, err = marshal(before); err != nil {
+		return err
+	}
+	if e.After, err = marshal(after); err != nil {
+		return err
+	}
+	if e.Diff, err = diff(e.Before, e.After); err != nil {
+		return err
+	}
+
+	return s.repo.Insert(ctx, e)
+}
+
+func (s *Service) History(ctx context.Context, entityType string, entityID uuid.UUID, limit, offset int) ([]audit.Event, error) {
+	return s.List(ctx, audit.Filter{
+		EntityType: entityType,
+		EntityID:   &entityID,
+		Limit:      limit,
+		Offset:     offset,
+	})
+}
+
+func (s *Service) List(ctx context.Context, f audit.Filter) ([]audit.Event, error) {
+	if f.Limit <= 0 {
+		f.Limit = defaultLimit
+	}
+	if f.Limit > maxLimit {
+		f.Limit = maxLimit
+	}
+	if f.Offset < 0 {
+		f.Offset = 0
+	}
+
+	return s.repo.List(ctx, f)
+}
+
+func marshal(v any) (json.RawMessage, error) {
+	if v == nil || reflect.ValueOf(v).IsZero() {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
+
+type change struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// diff сравнивает снимки верхнего уровня и возвращает изменённые поля.
+func diff(before, after json.RawMessage) (json.RawMessage, error) {
+	var b, a map[string]any
+	if len(before) > 0 {
+		if err := json.Unmarshal(before, &b); err != nil {
+			return nil, err
+		}
+	}
+	if len(after) > 0 {
+		if err := json.Unmarshal(after, &a); err != nil {
+			return nil, err
+		}
+	}
+
+	changes := make(map[string]change)
+	for k, v := range b {
+		if !reflect.DeepEqual(v, a[k]) {
+			changes[k] = change{From: v, To: a[k]}
+		}
+	}
+	for k, v := range a {
+		if _, ok := b[k]; !ok {
+			changes[k] = change{From: nil, To: v}
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(changes)
+}
@@ -0,0 +1,101 @@
+package calendar
+
+import (
+	"ProjectManagementAPI/internal/domain/calendar"
+	"ProjectManagementAPI/internal/domain/task"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/google/uuid"
+)
+
+type RepositoryInterface interface {
+	SaveToken(ctx context.Context, userID uuid.UUID, hash string) (*calendar.Token, error)
+	Token(ctx context.Context, userID uuid.UUID) (*calendar.Token, error)
+	DeleteToken(ctx context.Context, userID uuid.UUID) error
+	UserByToken(ctx context.Context, hash string) (uuid.UUID, string, error)
+	Items(ctx context.Context, userID uuid.UUID, f calendar.Filter) ([]calendar.Item, error)
+}
+
+type Service struct {
+	repo RepositoryInterface
+}
+
+func NewCalendarService(repo RepositoryInterface) *Service {
+	return &Service{repo: repo}
+}
+
+// Rotate выпускает новый токен; ссылка со старым перестаёт работать.
+func (s *Service) Rotate(ctx context.Context, userID uuid.UUID) (string, *calendar.Token, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", nil, err
+	}
+	token := hex.EncodeToString(buf)
+
+	t, err := s.repo.SaveToken(ctx, userID, hash(token))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token, t, nil
+}
+
+func (s *Service) Token(ctx context.Context, userID uuid.UUID) (*calendar.Token, error) {
+	return s.repo.Token(ctx, userID)
+}
+
+func (s *Service) Revoke(ctx context.Context, userID uuid.UUID) error {
+	return s.repo.DeleteToken(ctx, userID)
+}
+
+// Feed собирает календарь владельца токена. kind по умолчанию - event,
+// timezone - часовой пояс сервера: в нём сохраняются сроки, заданные датой.
+func (s *Service) Feed(ctx context.Context, token, kind, timezone string, f calendar.Filter) (*calendar.Feed, error) {
+	if kind == "" {
+		kind = calendar.KindEvent
+	}
+	if kind != calendar.KindEvent && kind != calendar.KindTodo {
+		return nil, calendar.ErrInvalidKind
+	}
+
+	loc := time.Local
+	if timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(timezone); err != nil {
+			return nil, calendar.ErrInvalidTimezone
+		}
+	}
+
+	for _, st := range f.Statuses {
+		if !task.ValidStatus(st) {
+			return nil, calendar.ErrInvalidStatus
+		}
+	}
+
+	userID, name, err := s.repo.UserByToken(ctx, hash(token))
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.Items(ctx, userID, f)
+	if err != nil {
+		return nil, err
+	}
+
+	return &calendar.Feed{
+		UserName: name,
+		Kind:     kind,
+		Location: loc,
+		Items:    items,
+	}, nil
+}
+
+func hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
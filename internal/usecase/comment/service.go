@@ -0,0 +1,89 @@
+package comment
+
+import (
+	"ProjectManagementAPI/internal/domain/comment"
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxBodyLength = 10000
+	defaultLimit  = 100
+	maxLimit      = 1000
+)
+
+type RepositoryInterface interface {
+	Create(ctx context.Context, c *comment.Comment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*comment.Comment, error)
+	ListByTask(ctx context.Context, taskID uuid.UUID, limit, offset int) ([]comment.Comment, error)
+	UpdateBody(ctx context.Context, authorID, taskID, id uuid.UUID, body string) error
+	DeleteByID(ctx context.Context, authorID, taskID, id uuid.UUID) error
+}
+
+type Service struct {
+	repo RepositoryInterface
+}
+
+func NewCommentService(repo RepositoryInterface) *Service {
+	return &Service{repo: repo}
+}
+
+func (s *Service) Create(ctx context.Context, authorID, taskID uuid.UUID, body string) (*comment.Comment, error) {
+	body, err := normalizeBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &comment.Comment{
+		TaskID:   taskID,
+		AuthorID: &authorID,
+		Body:     body,
+	}
+	if err := s.repo.Create(ctx, c); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByID(ctx, c.ID)
+}
+
+func (s *Service) List(ctx context.Context, taskID uuid.UUID, limit, offset int) ([]comment.Comment, error) {
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+	return s.repo.ListByTask(ctx, taskID, limit, offset)
+}
+
+// Update меняет текст комментария; править можно только свои комментарии.
+func (s *Service) Update(ctx context.Context, authorID, taskID, id uuid.UUID, body string) (*comment.Comment, error) {
+	body, err := normalizeBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateBody(ctx, authorID, taskID, id, body); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *Service) Delete(ctx context.Context, authorID, taskID, id uuid.UUID) error {
+	return s.repo.DeleteByID(ctx, authorID, taskID, id)
+}
+
+func normalizeBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", comment.ErrEmptyBody
+	}
+	if utf8.RuneCountInString(body) > maxBodyLength {
+		return "", comment.ErrBodyTooLong
+	}
+	return body, nil
+}
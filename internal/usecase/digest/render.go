@@ -0,0 +1,89 @@
+package digest
+
+import (
+	"ProjectManagementAPI/internal/domain/digest"
+	"ProjectManagementAPI/internal/lib/email"
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+var (
+	textTemplate = texttemplate.Must(texttemplate.New("digest.txt").Funcs(texttemplate.FuncMap(funcs(time.UTC))).
+			ParseFS(templatesFS, "templates/digest.txt"))
+	htmlTemplate = htmltemplate.Must(htmltemplate.New("digest.html").Funcs(htmltemplate.FuncMap(funcs(time.UTC))).
+			ParseFS(templatesFS, "templates/digest.html"))
+)
+
+// funcs форматирует даты в часовом поясе получателя.
+func funcs(loc *time.Location) map[string]any {
+	return map[string]any{
+		"fmtDate": func(t time.Time) string {
+			return t.In(loc).Format("2006-01-02")
+		},
+		"fmtTime": func(v any) string {
+			switch t := v.(type) {
+			case time.Time:
+				return t.In(loc).Format("2006-01-02 15:04")
+			case *time.Time:
+				if t != nil {
+					return t.In(loc).Format("2006-01-02 15:04")
+				}
+			}
+			return ""
+		},
+	}
+}
+
+// Render собирает письмо с текстовой и HTML-версией.
+func Render(d digest.Digest) (email.Message, error) {
+	var text, html bytes.Buffer
+
+	tt, err := textTemplate.Clone()
+	if err != nil {
+		return email.Message{}, err
+	}
+	if err := tt.Funcs(funcs(d.Location)).Execute(&text, d); err != nil {
+		return email.Message{}, err
+	}
+
+	ht, err := htmlTemplate.Clone()
+	if err != nil {
+		return email.Message{}, err
+	}
+	if err := ht.Funcs(funcs(d.Location)).Execute(&html, d); err != nil {
+		return email.Message{}, err
+	}
+
+	frequency := d.Recipient.Frequency
+	return email.Message{
+		To:      d.Recipient.Email,
+		Subject: "Your " + frequency + " digest: " + strings.Join(summary(d), ", "),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+func summary(d digest.Digest) []string {
+	var parts []string
+	for _, p := range []struct {
+		n    int
+		name string
+	}{
+		{len(d.Overdue), "overdue"},
+		{len(d.DueSoon), "due soon"},
+		{len(d.Changed), "changed"},
+	} {
+		if p.n > 0 {
+			parts = append(parts, strconv.Itoa(p.n)+" "+p.name)
+		}
+	}
+	return parts
+}
@@ -0,0 +1,129 @@
+package digest
+
+import (
+	"ProjectManagementAPI/internal/domain/digest"
+	"ProjectManagementAPI/internal/domain/task"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+type RepositoryInterface interface {
+	Settings(ctx context.Context, userID uuid.UUID) (*digest.Settings, error)
+	SaveSettings(ctx context.Context, s *digest.Settings) error
+	Recipients(ctx context.Context) ([]digest.Recipient, error)
+	Items(ctx context.Context, userID uuid.UUID, since, dueBefore time.Time) ([]digest.Item, error)
+	LastSentAt(ctx context.Context, userID uuid.UUID, frequency string) (*time.Time, error)
+	ClaimRun(ctx context.Context, userID uuid.UUID, frequency, periodKey string, maxAttempts int) (int64, bool, error)
+	FinishRun(ctx context.Context, id int64, status string, items int, lastError string) error
+	Runs(ctx context.Context, userID uuid.UUID, limit, offset int) ([]digest.Run, error)
+}
+
+type Service struct {
+	repo RepositoryInterface
+}
+
+func NewDigestService(repo RepositoryInterface) *Service {
+	return &Service{repo: repo}
+}
+
+func (s *Service) Settings(ctx context.Context, userID uuid.UUID) (*digest.Settings, error) {
+	settings, err := s.repo.Settings(ctx, userID)
+	if errors.Is(err, digest.ErrSettingsNotFound) {
+		def := digest.DefaultSettings(userID)
+		return &def, nil
+	}
+	return settings, err
+}
+
+func (s *Service) SaveSettings(ctx context.Context, settings *digest.Settings) error {
+	if !digest.ValidFrequency(settings.Frequency) {
+		return digest.ErrInvalidFrequency
+	}
+	if _, err := time.LoadLocation(settings.Timezone); err != nil || settings.Timezone == "" {
+		return digest.ErrInvalidTimezone
+	}
+	if settings.SendHour < 0 || settings.SendHour > 23 {
+		return digest.ErrInvalidSendHour
+	}
+	if settings.Weekday < time.Sunday || settings.Weekday > time.Saturday {
+		return digest.ErrInvalidWeekday
+	}
+
+	return s.repo.SaveSettings(ctx, settings)
+}
+
+func (s *Service) Runs(ctx context.Context, userID uuid.UUID, limit, offset int) ([]digest.Run, error) {
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+	return s.repo.Runs(ctx, userID, limit, offset)
+}
+
+// PeriodKey возвращает ключ периода, за который пора отправить дайджест,
+// или false, если время отправки в часовом поясе пользователя ещё не наступило.
+// Для daily ключ - локальная дата, для weekly - ISO-неделя.
+func PeriodKey(s digest.Settings, loc *time.Location, now time.Time) (string, bool) {
+	local := now.In(loc)
+	if local.Hour() < s.SendHour {
+		return "", false
+	}
+
+	switch s.Frequency {
+	case digest.FrequencyDaily:
+		return local.Format("2006-01-02"), true
+	case digest.FrequencyWeekly:
+		if local.Weekday() != s.Weekday {
+			return "", false
+		}
+		year, week := local.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week), true
+	}
+
+	return "", false
+}
+
+// Period возвращает длительность периода дайджеста.
+func Period(frequency string) time.Duration {
+	if frequency == digest.FrequencyWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// Compile собирает дайджест из задач пользователя. Каждая задача попадает
+// в одну группу: просроченные, затем со сроком до dueSoon, затем изменённые.
+func Compile(rc digest.Recipient, loc *time.Location, items []digest.Item, since, now time.Time, dueSoon time.Duration) digest.Digest {
+	d := digest.Digest{
+		Recipient: rc,
+		Location:  loc,
+		From:      since,
+		To:        now,
+	}
+
+	for _, it := range items {
+		open := it.Status != task.StatusDone
+		switch {
+		case open && it.DueAt != nil && it.DueAt.Before(now):
+			d.Overdue = append(d.Overdue, it)
+		case open && it.DueAt != nil && it.DueAt.Before(now.Add(dueSoon)):
+			d.DueSoon = append(d.DueSoon, it)
+		case !it.UpdatedAt.Before(since):
+			d.Changed = append(d.Changed, it)
+		}
+	}
+
+	return d
+}
@@ -0,0 +1,107 @@
+package digest
+
+import (
+	"ProjectManagementAPI/internal/domain/digest"
+	"ProjectManagementAPI/internal/lib/email"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"context"
+	"log/slog"
+	"time"
+)
+
+type WorkerConfig struct {
+	DueSoon     time.Duration
+	MaxAttempts int
+}
+
+// Worker проверяет расписания пользователей и отправляет дайджесты.
+// SendDue запускается периодическим заданием планировщика.
+// Отправка за период резервируется в digest_runs до отправки письма,
+// поэтому при рестарте или нескольких репликах письмо не уходит дважды.
+type Worker struct {
+	log    *slog.Logger
+	repo   RepositoryInterface
+	sender email.Sender
+	cfg    WorkerConfig
+}
+
+func NewWorker(log *slog.Logger, repo RepositoryInterface, sender email.Sender, cfg WorkerConfig) *Worker {
+	return &Worker{
+		log:    log.With(slog.String("component", "digest/worker")),
+		repo:   repo,
+		sender: sender,
+		cfg:    cfg,
+	}
+}
+
+func (w *Worker) SendDue(ctx context.Context, now time.Time) error {
+	recipients, err := w.repo.Recipients(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, rc := range recipients {
+		if err := w.send(ctx, rc, now); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			w.log.Error("digest failed", sl.Err(err), slog.String("user_id", rc.UserID.String()))
+		}
+	}
+
+	return nil
+}
+
+func (w *Worker) send(ctx context.Context, rc digest.Recipient, now time.Time) error {
+	loc, err := time.LoadLocation(rc.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	key, ok := PeriodKey(rc.Settings, loc, now)
+	if !ok {
+		return nil
+	}
+
+	runID, ok, err := w.repo.ClaimRun(ctx, rc.UserID, rc.Frequency, key, w.cfg.MaxAttempts)
+	if err != nil || !ok {
+		return err
+	}
+
+	since := now.Add(-Period(rc.Frequency))
+	last, err := w.repo.LastSentAt(ctx, rc.UserID, rc.Frequency)
+	if err != nil {
+		return w.fail(ctx, runID, err)
+	}
+	if last != nil && last.After(since) {
+		since = *last
+	}
+
+	items, err := w.repo.Items(ctx, rc.UserID, since, now.Add(w.cfg.DueSoon))
+	if err != nil {
+		return w.fail(ctx, runID, err)
+	}
+
+	d := Compile(rc, loc, items, since, now, w.cfg.DueSoon)
+	if d.Len() == 0 {
+		return w.repo.FinishRun(ctx, runID, digest.RunEmpty, 0, "")
+	}
+
+	msg, err := Render(d)
+	if err != nil {
+		return w.fail(ctx, runID, err)
+	}
+
+	if err := w.sender.Send(ctx, msg); err != nil {
+		return w.fail(ctx, runID, err)
+	}
+
+	return w.repo.FinishRun(ctx, runID, digest.RunSent, d.Len(), "")
+}
+
+func (w *Worker) fail(ctx context.Context, runID int64, err error) error {
+	if ferr := w.repo.FinishRun(ctx, runID, digest.RunFailed, 0, err.Error()); ferr != nil {
+		return ferr
+	}
+	return err
+}
@@ -0,0 +1,78 @@
+package filter
+
+import (
+	"ProjectManagementAPI/internal/domain/filter"
+	"ProjectManagementAPI/internal/domain/task"
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const maxNameLength = 100
+
+type RepositoryInterface interface {
+	Create(ctx context.Context, f *filter.Filter) error
+	GetVisible(ctx context.Context, userID, id uuid.UUID) (*filter.Filter, error)
+	ListVisible(ctx context.Context, userID uuid.UUID) ([]filter.Filter, error)
+	Update(ctx context.Context, f *filter.Filter) error
+	DeleteByID(ctx context.Context, ownerID, id uuid.UUID) error
+}
+
+// Tasks - поиск задач по языку фильтров.
+type Tasks interface {
+	CheckQuery(q string) error
+	Find(ctx context.Context, q string, limit, offset int) ([]*task.Task, error)
+}
+
+type Service struct {
+	repo  RepositoryInterface
+	tasks Tasks
+}
+
+func NewFilterService(repo RepositoryInterface, tasks Tasks) *Service {
+	return &Service{
+		repo:  repo,
+		tasks: tasks,
+	}
+}
+
+// Save создаёт фильтр (f.ID == uuid.Nil) или меняет существующий фильтр
+// владельца. Запрос проверяется до сохранения.
+func (s *Service) Save(ctx context.Context, f *filter.Filter) error {
+	f.Name = strings.TrimSpace(f.Name)
+	if f.Name == "" || utf8.RuneCountInString(f.Name) > maxNameLength {
+		return filter.ErrInvalidName
+	}
+	f.Query = strings.TrimSpace(f.Query)
+	if err := s.tasks.CheckQuery(f.Query); err != nil {
+		return err
+	}
+
+	if f.ID == uuid.Nil {
+		return s.repo.Create(ctx, f)
+	}
+	return s.repo.Update(ctx, f)
+}
+
+func (s *Service) Get(ctx context.Context, userID, id uuid.UUID) (*filter.Filter, error) {
+	return s.repo.GetVisible(ctx, userID, id)
+}
+
+func (s *Service) List(ctx context.Context, userID uuid.UUID) ([]filter.Filter, error) {
+	return s.repo.ListVisible(ctx, userID)
+}
+
+func (s *Service) Delete(ctx context.Context, ownerID, id uuid.UUID) error {
+	return s.repo.DeleteByID(ctx, ownerID, id)
+}
+
+// Run выполняет сохранённый фильтр от имени пользователя из контекста.
+func (s *Service) Run(ctx context.Context, userID, id uuid.UUID, limit, offset int) ([]*task.Task, error) {
+	f, err := s.repo.GetVisible(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.tasks.Find(ctx, f.Query, limit, offset)
+}
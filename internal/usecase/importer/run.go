@@ -0,0 +1,330 @@
+package importer
+
+import (
+	"ProjectManagementAPI/internal/domain/comment"
+	"ProjectManagementAPI/internal/domain/importer"
+	"ProjectManagementAPI/internal/domain/task"
+	"ProjectManagementAPI/internal/domain/user"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// defaultStatuses - типичные названия статусов Jira, колонок Trello и
+// состояний GitHub. Остальное задаётся через status_map.
+var defaultStatuses = map[string]string{
+	"to do":                    task.StatusTodo,
+	"todo":                     task.StatusTodo,
+	"open":                     task.StatusTodo,
+	"new":                      task.StatusTodo,
+	"backlog":                  task.StatusTodo,
+	"reopened":                 task.StatusTodo,
+	"selected for development": task.StatusTodo,
+	"in progress":              task.StatusInProgress,
+	"doing":                    task.StatusInProgress,
+	"in review":                task.StatusInProgress,
+	"review":                   task.StatusInProgress,
+	"testing":                  task.StatusInProgress,
+	"done":                     task.StatusDone,
+	"closed":                   task.StatusDone,
+	"resolved":                 task.StatusDone,
+	"complete":                 task.StatusDone,
+	"completed":                task.StatusDone,
+}
+
+// run - состояние одного импорта.
+type run struct {
+	s               *Service
+	system          string
+	opts            importer.Options
+	importID        *uuid.UUID
+	users           *userIndex
+	defaultAssignee *uuid.UUID
+	project         *uuid.UUID
+	// imported - external_id -> задача, включая созданные в этом импорте
+	imported map[string]uuid.UUID
+	warned   map[string]bool
+	report   *importer.Report
+}
+
+func (r *run) execute(ctx context.Context, items []importer.Item) error {
+	// Связи на элементы дальше по файлу дописываются, когда те созданы
+	type pending struct {
+		taskID uuid.UUID
+		item   importer.Item
+	}
+	var unresolved []pending
+
+	for _, it := range items {
+		if reason := r.skipReason(it); reason != "" {
+			r.skip(it, reason)
+			continue
+		}
+
+		t, reason := r.toTask(it)
+		if reason != "" {
+			r.skip(it, reason)
+			continue
+		}
+
+		var complete bool
+		t.Description, complete = r.withLinks(it)
+
+		id, err := r.s.tasks.Create(ctx, t)
+		if err != nil {
+			return fmt.Errorf("%s: %w", it.Key, err)
+		}
+		if err := r.s.repo.SaveRef(ctx, r.system, it.ExternalID, id, r.importID); err != nil {
+			return err
+		}
+		r.imported[it.ExternalID] = id
+		r.report.Created = append(r.report.Created, importer.Created{
+			ExternalID: it.ExternalID, Key: it.Key, TaskID: id.String(),
+		})
+		r.report.Links += len(it.Links)
+
+		if err := r.addComments(ctx, id, it.Comments); err != nil {
+			return fmt.Errorf("%s: %w", it.Key, err)
+		}
+
+		if !complete {
+			unresolved = append(unresolved, pending{taskID: id, item: it})
+		}
+	}
+
+	for _, p := range unresolved {
+		desc, _ := r.withLinks(p.item)
+		if _, err := r.s.tasks.Update(ctx, p.taskID, task.Patch{Description: &desc}); err != nil {
+			return fmt.Errorf("%s: %w", p.item.Key, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *run) skipReason(it importer.Item) string {
+	switch {
+	case it.Skip != "":
+		return it.Skip
+	case r.imported[it.ExternalID] != uuid.Nil:
+		return "already imported as task " + r.imported[it.ExternalID].String()
+	case strings.TrimSpace(it.Title) == "":
+		return "no title"
+	}
+	return ""
+}
+
+func (r *run) skip(it importer.Item, reason string) {
+	r.report.Skipped = append(r.report.Skipped, importer.Skipped{
+		ExternalID: it.ExternalID, Key: it.Key, Title: it.Title, Reason: reason,
+	})
+}
+
+func (r *run) warn(msg string) {
+	if !r.warned[msg] {
+		r.warned[msg] = true
+		r.report.Warnings = append(r.report.Warnings, msg)
+	}
+}
+
+func (r *run) toTask(it importer.Item) (*task.Task, string) {
+	t := &task.Task{
+		Title:       strings.TrimSpace(it.Title),
+		Status:      r.status(it),
+		StoryPoints: it.StoryPoints,
+		DueAt:       it.Due,
+		ProjectID:   r.project,
+		Labels:      r.labels(it),
+	}
+
+	for _, p := range it.Assignees {
+		if id, ok := r.user(p); ok && !slices.Contains(t.Assignees, id) {
+			t.Assignees = append(t.Assignees, id)
+		}
+	}
+	if len(t.Assignees) == 0 {
+		if r.defaultAssignee == nil {
+			return nil, "no assignee could be mapped, set default_assignee"
+		}
+		t.Assignees = []uuid.UUID{*r.defaultAssignee}
+	}
+
+	return t, ""
+}
+
+func (r *run) status(it importer.Item) string {
+	raw := strings.ToLower(strings.TrimSpace(it.Status))
+	if s, ok := r.opts.StatusMap[raw]; ok {
+		return s
+	}
+	if it.Closed {
+		return task.StatusDone
+	}
+	if s, ok := defaultStatuses[raw]; ok {
+		return s
+	}
+	if raw != "" {
+		r.warn(fmt.Sprintf("status %q imported as todo, map it with status_map", it.Status))
+	}
+	return task.StatusTodo
+}
+
+// labels переносит метки и тип задачи (bug, story...) как метку. Названия
+// приводятся к допустимым: пробелы становятся "-", прочие символы удаляются.
+func (r *run) labels(it importer.Item) []string {
+	raw := it.Labels
+	if it.Kind != "" {
+		raw = append([]string{it.Kind}, raw...)
+	}
+
+	labels := make([]string, 0, len(raw))
+	for _, l := range raw {
+		clean := cleanLabel(l)
+		if !task.ValidLabel(clean) {
+			r.warn(fmt.Sprintf("label %q dropped: no letters or digits", l))
+			continue
+		}
+		labels = append(labels, clean)
+	}
+
+	slices.Sort(labels)
+	labels = slices.Compact(labels)
+	if len(labels) > task.MaxLabels {
+		r.warn(fmt.Sprintf("%s: only %d labels imported", it.Key, task.MaxLabels))
+		labels = labels[:task.MaxLabels]
+	}
+	return labels
+}
+
+func cleanLabel(l string) string {
+	var b strings.Builder
+	dash := false
+	for _, c := range strings.ToLower(strings.TrimSpace(l)) {
+		switch {
+		case unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '.':
+			b.WriteRune(c)
+			dash = false
+		case (c == '-' || unicode.IsSpace(c) || c == '/') && !dash && b.Len() > 0:
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+
+	res := strings.TrimRight(b.String(), "-")
+	for utf8.RuneCountInString(res) > task.MaxLabelLength {
+		_, size := utf8.DecodeLastRuneInString(res)
+		res = res[:len(res)-size]
+	}
+	return res
+}
+
+// user ищет пользователя: сначала user_map по любому из идентификаторов,
+// затем email, затем имя или логин, если совпадение единственное.
+func (r *run) user(p importer.Person) (uuid.UUID, bool) {
+	for _, k := range p.Keys() {
+		if ref, ok := r.opts.UserMap[strings.ToLower(k)]; ok {
+			// Ссылки user_map проверены в prepare
+			return r.users.resolve(ref)
+		}
+	}
+	if id, ok := r.users.byEmail[strings.ToLower(p.Email)]; ok {
+		return id, true
+	}
+	for _, name := range []string{p.Name, p.Login} {
+		if ids := r.users.byName[strings.ToLower(name)]; name != "" && len(ids) == 1 {
+			return ids[0], true
+		}
+	}
+
+	r.warn(fmt.Sprintf("user %q is not mapped, add it to user_map", p.String()))
+	return uuid.Nil, false
+}
+
+// withLinks дописывает связи в описание: в модели задач их нет. complete -
+// все цели связей уже перенесены и указаны с id задачи.
+func (r *run) withLinks(it importer.Item) (string, bool) {
+	desc := strings.TrimSpace(it.Description)
+	if len(it.Links) == 0 {
+		return desc, true
+	}
+
+	complete := true
+	lines := make([]string, len(it.Links))
+	for i, l := range it.Links {
+		lines[i] = "- " + l.Type + " " + l.Target
+		if id, ok := r.imported[l.Target]; ok {
+			lines[i] += " (task " + id.String() + ")"
+		} else {
+			complete = false
+		}
+	}
+
+	if desc != "" {
+		desc += "\n\n"
+	}
+	return desc + "Links:\n" + strings.Join(lines, "\n"), complete
+}
+
+// addComments переносит комментарии с исходным временем. Если автора
+// сопоставить не удалось, его имя пишется в начале текста.
+func (r *run) addComments(ctx context.Context, taskID uuid.UUID, comments []importer.Comment) error {
+	for _, c := range comments {
+		body := strings.TrimSpace(c.Body)
+		if body == "" {
+			continue
+		}
+
+		cm := &comment.Comment{TaskID: taskID, Body: body}
+		if !c.CreatedAt.IsZero() {
+			cm.CreatedAt = c.CreatedAt
+		}
+		if !c.Author.IsZero() {
+			if id, ok := r.user(c.Author); ok {
+				cm.AuthorID = &id
+			} else {
+				cm.Body = c.Author.String() + ":\n" + body
+			}
+		}
+
+		if err := r.s.comments.Create(ctx, cm); err != nil {
+			return err
+		}
+		r.report.Comments++
+	}
+	return nil
+}
+
+type userIndex struct {
+	ids     map[uuid.UUID]bool
+	byEmail map[string]uuid.UUID
+	byName  map[string][]uuid.UUID
+}
+
+func newUserIndex(users []user.User) *userIndex {
+	ix := &userIndex{
+		ids:     make(map[uuid.UUID]bool, len(users)),
+		byEmail: make(map[string]uuid.UUID, len(users)),
+		byName:  make(map[string][]uuid.UUID, len(users)),
+	}
+	for _, u := range users {
+		ix.ids[u.ID] = true
+		ix.byEmail[strings.ToLower(u.Email)] = u.ID
+		name := strings.ToLower(strings.TrimSpace(u.Name))
+		ix.byName[name] = append(ix.byName[name], u.ID)
+	}
+	return ix
+}
+
+// resolve разбирает ссылку из опций: UUID или email.
+func (ix *userIndex) resolve(ref string) (uuid.UUID, bool) {
+	if id, err := uuid.Parse(ref); err == nil {
+		return id, ix.ids[id]
+	}
+	id, ok := ix.byEmail[strings.ToLower(strings.TrimSpace(ref))]
+	return id, ok
+}
@@ -0,0 +1,171 @@
+package importer
+
+import (
+	"ProjectManagementAPI/internal/domain/importer"
+	"ProjectManagementAPI/internal/domain/task"
+	"ProjectManagementAPI/internal/domain/user"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ann   = user.User{ID: uuid.New(), Email: "ann@example.com", Name: "Ann Lee"}
+	bob   = user.User{ID: uuid.New(), Email: "bob@example.com", Name: "Bob"}
+	bob2  = user.User{ID: uuid.New(), Email: "bob2@example.com", Name: "bob"}
+	carol = user.User{ID: uuid.New(), Email: "carol@example.com", Name: "Carol"}
+)
+
+func newRun(opts importer.Options) *run {
+	return &run{
+		opts:     opts.Lower(),
+		users:    newUserIndex([]user.User{ann, bob, bob2, carol}),
+		imported: make(map[string]uuid.UUID),
+		warned:   make(map[string]bool),
+		report:   &importer.Report{},
+	}
+}
+
+func TestStatusMapping(t *testing.T) {
+	r := newRun(importer.Options{StatusMap: map[string]string{"QA": task.StatusInProgress, "Won't Do": task.StatusDone}})
+
+	for _, tc := range []struct {
+		item importer.Item
+		want string
+	}{
+		{importer.Item{Status: "qa"}, task.StatusInProgress},
+		{importer.Item{Status: " Won't do "}, task.StatusDone},
+		// status_map важнее признака Closed
+		{importer.Item{Status: "QA", Closed: true}, task.StatusInProgress},
+		{importer.Item{Status: "Shipped", Closed: true}, task.StatusDone},
+		{importer.Item{Status: "In Progress"}, task.StatusInProgress},
+		{importer.Item{Status: "Selected for Development"}, task.StatusTodo},
+		{importer.Item{Status: "Resolved"}, task.StatusDone},
+		{importer.Item{Status: ""}, task.StatusTodo},
+		{importer.Item{Status: "Blocked"}, task.StatusTodo},
+		{importer.Item{Status: "blocked"}, task.StatusTodo},
+	} {
+		if got := r.status(tc.item); got != tc.want {
+			t.Errorf("status(%q, closed %v) = %s, want %s", tc.item.Status, tc.item.Closed, got, tc.want)
+		}
+	}
+
+	// о неизвестном статусе предупреждают по разу на написание
+	if len(r.report.Warnings) != 2 || !strings.Contains(r.report.Warnings[0], `"Blocked"`) {
+		t.Errorf("warnings = %q", r.report.Warnings)
+	}
+}
+
+func TestLabelMapping(t *testing.T) {
+	r := newRun(importer.Options{})
+
+	got := r.labels(importer.Item{Key: "PROJ-1", Kind: "Story", Labels: []string{
+		"Needs Review", "front/end", "v1.2", "C++", "  --a  b--  ", "!!!", "story", "Ünïcode",
+	}})
+	want := []string{"a-b", "c", "front-end", "needs-review", "story", "v1.2", "ünïcode"}
+	if !slices.Equal(got, want) {
+		t.Errorf("labels = %v, want %v", got, want)
+	}
+	if len(r.report.Warnings) != 1 || !strings.Contains(r.report.Warnings[0], `"!!!"`) {
+		t.Errorf("warnings = %q", r.report.Warnings)
+	}
+
+	if got := cleanLabel(strings.Repeat("я", task.MaxLabelLength+5)); len([]rune(got)) != task.MaxLabelLength {
+		t.Errorf("long label has %d runes, want %d", len([]rune(got)), task.MaxLabelLength)
+	}
+
+	many := make([]string, task.MaxLabels+3)
+	for i := range many {
+		many[i] = "l" + strings.Repeat("x", i)
+	}
+	if got := r.labels(importer.Item{Key: "PROJ-2", Labels: many}); len(got) != task.MaxLabels {
+		t.Errorf("labels = %d, want %d", len(got), task.MaxLabels)
+	}
+}
+
+func TestUserMapping(t *testing.T) {
+	r := newRun(importer.Options{UserMap: map[string]string{
+		"5B10AC": carol.Email,
+		"octo":   ann.ID.String(),
+	}})
+
+	for _, tc := range []struct {
+		name   string
+		person importer.Person
+		want   uuid.UUID
+	}{
+		{"user_map by id, case-insensitive", importer.Person{ID: "5b10ac", Name: "Ann Lee"}, carol.ID},
+		{"user_map to UUID", importer.Person{Login: "Octo"}, ann.ID},
+		{"email", importer.Person{Email: "BOB@example.com", Name: "Carol"}, bob.ID},
+		{"unique name", importer.Person{Name: "ann lee"}, ann.ID},
+		{"login as name", importer.Person{Login: "carol"}, carol.ID},
+		{"ambiguous name", importer.Person{Name: "Bob"}, uuid.Nil},
+		{"unknown", importer.Person{ID: "x1", Login: "ghost"}, uuid.Nil},
+	} {
+		id, ok := r.user(tc.person)
+		if ok != (tc.want != uuid.Nil) || id != tc.want {
+			t.Errorf("%s: user = %v, %v, want %v", tc.name, id, ok, tc.want)
+		}
+	}
+
+	if len(r.report.Warnings) != 2 {
+		t.Errorf("warnings = %q, want one per unmapped user", r.report.Warnings)
+	}
+}
+
+func TestToTask(t *testing.T) {
+	project := uuid.New()
+	r := newRun(importer.Options{})
+	r.project = &project
+
+	it := importer.Item{
+		Key: "PROJ-1", Kind: "Bug", Title: "  Crash  ", Status: "Done", StoryPoints: 3,
+		Assignees: []importer.Person{{Name: "Ann Lee"}, {Email: "ann@example.com"}, {Login: "ghost"}},
+	}
+	got, reason := r.toTask(it)
+	if reason != "" {
+		t.Fatalf("toTask skipped: %s", reason)
+	}
+	if got.Title != "Crash" || got.Status != task.StatusDone || got.StoryPoints != 3 || got.ProjectID != &project {
+		t.Errorf("task = %+v", got)
+	}
+	if !slices.Equal(got.Assignees, []uuid.UUID{ann.ID}) {
+		t.Errorf("assignees = %v, want Ann once", got.Assignees)
+	}
+	if !slices.Equal(got.Labels, []string{"bug"}) {
+		t.Errorf("labels = %v", got.Labels)
+	}
+
+	// без сопоставленных исполнителей нужен default_assignee
+	orphan := importer.Item{Key: "PROJ-2", Title: "Orphan", Assignees: []importer.Person{{Login: "ghost"}}}
+	if _, reason := r.toTask(orphan); reason == "" {
+		t.Error("task without assignees was not skipped")
+	}
+	r.defaultAssignee = &bob.ID
+	if got, _ := r.toTask(orphan); got == nil || !slices.Equal(got.Assignees, []uuid.UUID{bob.ID}) {
+		t.Errorf("default assignee not used: %+v", got)
+	}
+}
+
+func TestWithLinks(t *testing.T) {
+	r := newRun(importer.Options{})
+	target := uuid.New()
+	r.imported["PROJ-2"] = target
+
+	it := importer.Item{Description: " Body ", Links: []importer.Link{{Type: "blocks", Target: "PROJ-2"}}}
+	desc, complete := r.withLinks(it)
+	if want := "Body\n\nLinks:\n- blocks PROJ-2 (task " + target.String() + ")"; desc != want || !complete {
+		t.Errorf("withLinks = %q, %v, want %q", desc, complete, want)
+	}
+
+	it.Links = append(it.Links, importer.Link{Type: "relates to", Target: "PROJ-9"})
+	if desc, complete := r.withLinks(it); complete || !strings.HasSuffix(desc, "- relates to PROJ-9") {
+		t.Errorf("withLinks = %q, %v, want incomplete", desc, complete)
+	}
+
+	if desc, complete := r.withLinks(importer.Item{Description: "plain"}); desc != "plain" || !complete {
+		t.Errorf("withLinks without links = %q, %v", desc, complete)
+	}
+}
@@ -0,0 +1,255 @@
+package importer
+
+import (
+	"ProjectManagementAPI/internal/domain/comment"
+	"ProjectManagementAPI/internal/domain/importer"
+	"ProjectManagementAPI/internal/domain/job"
+	"ProjectManagementAPI/internal/domain/task"
+	"ProjectManagementAPI/internal/domain/user"
+	"ProjectManagementAPI/internal/lib/trackers"
+	jobService "ProjectManagementAPI/internal/usecase/job"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// JobKind - задание, выполняющее импорт, запущенный через API.
+const JobKind = "imports.run"
+
+// jobMaxAttempts: ошибки файла не повторяются, повтор нужен только при сбоях базы.
+const jobMaxAttempts = 3
+
+type RepositoryInterface interface {
+	Create(ctx context.Context, imp *importer.Import, file []byte) error
+	GetByID(ctx context.Context, id uuid.UUID) (*importer.Import, error)
+	Start(ctx context.Context, id uuid.UUID) (*importer.Import, []byte, bool, error)
+	Finish(ctx context.Context, id uuid.UUID, status string, report *importer.Report, errText string) error
+	Imported(ctx context.Context, system string, externalIDs []string) (map[string]uuid.UUID, error)
+	SaveRef(ctx context.Context, system, externalID string, taskID uuid.UUID, importID *uuid.UUID) error
+	Users(ctx context.Context) ([]user.User, error)
+	Projects(ctx context.Context, ref string) ([]uuid.UUID, error)
+}
+
+type Tasks interface {
+	Create(ctx context.Context, t *task.Task) (uuid.UUID, error)
+	Update(ctx context.Context, id uuid.UUID, p task.Patch) (*task.Task, error)
+}
+
+type Comments interface {
+	Create(ctx context.Context, c *comment.Comment) error
+}
+
+type Jobs interface {
+	Enqueue(ctx context.Context, kind string, payload any, opts jobService.Options) (*job.Job, error)
+}
+
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Service struct {
+	repo     RepositoryInterface
+	tasks    Tasks
+	comments Comments
+	jobs     Jobs
+	tx       Transactor
+}
+
+func NewImporterService(repo RepositoryInterface, tasks Tasks, comments Comments, jobs Jobs, tx Transactor) *Service {
+	return &Service{
+		repo:     repo,
+		tasks:    tasks,
+		comments: comments,
+		jobs:     jobs,
+		tx:       tx,
+	}
+}
+
+// errRollback откатывает транзакцию пробного импорта.
+var errRollback = errors.New("dry run")
+
+type runPayload struct {
+	ImportID uuid.UUID `json:"import_id"`
+}
+
+// Start сохраняет файл и ставит задание imports.run. Файл разбирается уже
+// в задании: ошибки формата попадают в статус импорта.
+func (s *Service) Start(ctx context.Context, source string, file []byte, opts importer.Options) (*importer.Import, *job.Job, error) {
+	if err := checkOptions(source, opts); err != nil {
+		return nil, nil, err
+	}
+
+	imp := &importer.Import{Source: source, Options: opts}
+	var j *job.Job
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, imp, file); err != nil {
+			return err
+		}
+		var err error
+		j, err = s.jobs.Enqueue(ctx, JobKind, runPayload{ImportID: imp.ID}, jobService.Options{
+			UniqueKey:   "import:" + imp.ID.String(),
+			MaxAttempts: jobMaxAttempts,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return imp, j, nil
+}
+
+func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (*importer.Import, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// HandleJob - обработчик задания imports.run.
+func (s *Service) HandleJob(ctx context.Context, j job.Job) error {
+	var p runPayload
+	if err := json.Unmarshal(j.Payload, &p); err != nil {
+		return err
+	}
+	return s.Run(ctx, p.ImportID)
+}
+
+// Run выполняет сохранённый импорт. Завершённый успешно импорт повторно не
+// выполняется; при сбое базы ошибка возвращается, и задание повторяется.
+func (s *Service) Run(ctx context.Context, id uuid.UUID) error {
+	imp, file, ok, err := s.repo.Start(ctx, id)
+	if err != nil || !ok {
+		return err
+	}
+
+	report, err := s.Execute(ctx, imp.Source, bytes.NewReader(file), imp.Options, &imp.ID)
+	if err != nil {
+		if err := s.repo.Finish(context.WithoutCancel(ctx), id, importer.StatusFailed, nil, err.Error()); err != nil {
+			return err
+		}
+		if errors.Is(err, importer.ErrInvalidFile) || errors.Is(err, importer.ErrInvalidOptions) {
+			return nil
+		}
+		return err
+	}
+
+	return s.repo.Finish(ctx, id, importer.StatusSucceeded, report, "")
+}
+
+// Execute разбирает файл и переносит элементы одной транзакцией: при ошибке
+// базы не сохраняется ничего. Элементы, которые перенести нельзя, попадают
+// в report.Skipped. importID - запуск через API, nil для CLI.
+func (s *Service) Execute(ctx context.Context, source string, r io.Reader, opts importer.Options, importID *uuid.UUID) (*importer.Report, error) {
+	if err := checkOptions(source, opts); err != nil {
+		return nil, err
+	}
+	opts = opts.Lower()
+
+	items, err := trackers.Parse(source, r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", importer.ErrInvalidFile, err)
+	}
+
+	run, err := s.prepare(ctx, source, items, opts, importID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := run.execute(ctx, items); err != nil {
+			return err
+		}
+		if opts.DryRun {
+			return errRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errRollback) {
+		return nil, err
+	}
+
+	if opts.DryRun {
+		for i := range run.report.Created {
+			run.report.Created[i].TaskID = ""
+		}
+	}
+
+	return run.report, nil
+}
+
+func checkOptions(source string, opts importer.Options) error {
+	if !importer.ValidSource(source) {
+		return importer.ErrInvalidSource
+	}
+	for from, to := range opts.StatusMap {
+		if !task.ValidStatus(to) {
+			return fmt.Errorf("%w: status_map: %q -> %q, expected todo, in_progress or done", importer.ErrInvalidOptions, from, to)
+		}
+	}
+	return nil
+}
+
+// prepare загружает всё, что нужно для сопоставления: пользователей,
+// проект, уже перенесённые элементы. Ссылки из опций проверяются сразу.
+func (s *Service) prepare(ctx context.Context, source string, items []importer.Item, opts importer.Options, importID *uuid.UUID) (*run, error) {
+	users, err := s.repo.Users(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &run{
+		s:        s,
+		system:   importer.System(source),
+		opts:     opts,
+		importID: importID,
+		users:    newUserIndex(users),
+		warned:   make(map[string]bool),
+		report: &importer.Report{
+			Source:   source,
+			DryRun:   opts.DryRun,
+			Items:    len(items),
+			Created:  []importer.Created{},
+			Skipped:  []importer.Skipped{},
+			Warnings: []string{},
+		},
+	}
+
+	for from, ref := range opts.UserMap {
+		if _, ok := r.users.resolve(ref); !ok {
+			return nil, fmt.Errorf("%w: user_map: %q -> user %q not found", importer.ErrInvalidOptions, from, ref)
+		}
+	}
+	if opts.DefaultAssignee != "" {
+		id, ok := r.users.resolve(opts.DefaultAssignee)
+		if !ok {
+			return nil, fmt.Errorf("%w: default_assignee: user %q not found", importer.ErrInvalidOptions, opts.DefaultAssignee)
+		}
+		r.defaultAssignee = &id
+	}
+	if opts.Project != "" {
+		ids, err := s.repo.Projects(ctx, opts.Project)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) != 1 {
+			return nil, fmt.Errorf("%w: project %q: %d projects match", importer.ErrInvalidOptions, opts.Project, len(ids))
+		}
+		r.project = &ids[0]
+	}
+
+	var externalIDs []string
+	for _, it := range items {
+		externalIDs = append(externalIDs, it.ExternalID)
+		for _, l := range it.Links {
+			externalIDs = append(externalIDs, l.Target)
+		}
+	}
+	if r.imported, err = s.repo.Imported(ctx, r.system, externalIDs); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
@@ -0,0 +1,224 @@
+package job
+
+import (
+	"ProjectManagementAPI/internal/domain/job"
+	"ProjectManagementAPI/internal/lib/backoff"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+)
+
+// Handler выполняет задание. Ошибка приводит к повтору с экспоненциальной
+// задержкой, пока не исчерпан max_attempts.
+type Handler func(ctx context.Context, j job.Job) error
+
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type SchedulerConfig struct {
+	Workers      int
+	PollInterval time.Duration
+	// Lease - сколько задание может выполняться, прежде чем его заберёт другой воркер
+	Lease       time.Duration
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+// Scheduler выполняет задания из таблицы jobs пулом воркеров и ставит
+// в очередь периодические задания из job_schedules.
+type Scheduler struct {
+	log       *slog.Logger
+	repo      RepositoryInterface
+	tx        Transactor
+	cfg       SchedulerConfig
+	id        string
+	handlers  map[string]Handler
+	schedules []job.Schedule
+}
+
+func NewScheduler(log *slog.Logger, repo RepositoryInterface, tx Transactor, cfg SchedulerConfig) *Scheduler {
+	host, _ := os.Hostname()
+
+	return &Scheduler{
+		log:      log.With(slog.String("component", "job/scheduler")),
+		repo:     repo,
+		tx:       tx,
+		cfg:      cfg,
+		id:       host + "-" + uuid.NewString()[:8],
+		handlers: make(map[string]Handler),
+	}
+}
+
+// Register задаёт обработчик для вида заданий. Вызывается до Run.
+func (s *Scheduler) Register(kind string, h Handler) {
+	s.handlers[kind] = h
+}
+
+// Schedule добавляет периодическое задание. spec - cron из 5 полей
+// или дескриптор вида @hourly, @every 5m. Вызывается до Run.
+func (s *Scheduler) Schedule(name, spec, kind string, payload any) error {
+	if _, err := cron.ParseStandard(spec); err != nil {
+		return fmt.Errorf("%w %q: %v", job.ErrInvalidSchedule, spec, err)
+	}
+	if _, ok := s.handlers[kind]; !ok {
+		return fmt.Errorf("%w: %s", job.ErrUnknownKind, kind)
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	s.schedules = append(s.schedules, job.Schedule{Name: name, Spec: spec, Kind: kind, Payload: raw})
+	return nil
+}
+
+func (s *Scheduler) Run(ctx context.Context) {
+	now := time.Now()
+	for i := range s.schedules {
+		sch := &s.schedules[i]
+		sch.NextRunAt = next(sch.Spec, now)
+		if err := s.repo.SaveSchedule(ctx, sch); err != nil {
+			s.log.Error("failed to save schedule", sl.Err(err), slog.String("schedule", sch.Name))
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < s.cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.work(ctx)
+		}()
+	}
+
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.EnqueueDue(ctx); err != nil && ctx.Err() == nil {
+			s.log.Error("failed to enqueue scheduled jobs", sl.Err(err))
+		}
+		if n, err := s.repo.FailExpired(ctx); err != nil && ctx.Err() == nil {
+			s.log.Error("failed to expire jobs", sl.Err(err))
+		} else if n > 0 {
+			s.log.Warn("jobs failed after lease expiry", slog.Int64("count", n))
+		}
+
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// EnqueueDue ставит в очередь задания по наступившим расписаниям.
+// Ключ уникальности - имя расписания, поэтому если предыдущий запуск
+// ещё не завершён, новый пропускается.
+func (s *Scheduler) EnqueueDue(ctx context.Context) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		due, err := s.repo.DueSchedules(ctx)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, sch := range due {
+			j := &job.Job{
+				Kind:        sch.Kind,
+				Payload:     sch.Payload,
+				UniqueKey:   "schedule:" + sch.Name,
+				RunAt:       now,
+				MaxAttempts: 1,
+			}
+			if _, err := s.repo.Enqueue(ctx, j); err != nil {
+				return err
+			}
+			if err := s.repo.AdvanceSchedule(ctx, sch.Name, now, next(sch.Spec, now)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *Scheduler) work(ctx context.Context) {
+	for {
+		jobs, err := s.repo.Claim(ctx, s.id, 1, s.cfg.Lease)
+		if err != nil && ctx.Err() == nil {
+			s.log.Error("failed to claim job", sl.Err(err))
+		}
+
+		if len(jobs) == 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(s.cfg.PollInterval):
+			}
+			continue
+		}
+
+		s.execute(ctx, jobs[0])
+	}
+}
+
+func (s *Scheduler) execute(ctx context.Context, j job.Job) {
+	log := s.log.With(slog.Int64("job_id", j.ID), slog.String("kind", j.Kind), slog.Int("attempt", j.Attempts))
+
+	err := s.handle(ctx, j)
+	if err == nil {
+		if err := s.repo.Complete(context.WithoutCancel(ctx), j.ID, s.id); err != nil {
+			log.Error("failed to complete job", sl.Err(err))
+		}
+		return
+	}
+
+	var retryAt *time.Time
+	if j.Attempts < j.MaxAttempts {
+		at := time.Now().Add(backoff.Exponential(j.Attempts, s.cfg.BaseBackoff, s.cfg.MaxBackoff))
+		retryAt = &at
+	}
+	log.Warn("job failed", sl.Err(err), slog.Bool("will_retry", retryAt != nil))
+
+	if err := s.repo.Fail(context.WithoutCancel(ctx), j.ID, s.id, err.Error(), retryAt); err != nil {
+		log.Error("failed to record job failure", sl.Err(err))
+	}
+}
+
+func (s *Scheduler) handle(ctx context.Context, j job.Job) (err error) {
+	h, ok := s.handlers[j.Kind]
+	if !ok {
+		return fmt.Errorf("%w: %s", job.ErrUnknownKind, j.Kind)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Lease)
+	defer cancel()
+
+	return h(ctx, j)
+}
+
+func next(spec string, from time.Time) time.Time {
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return from.Add(time.Hour)
+	}
+	return schedule.Next(from)
+}
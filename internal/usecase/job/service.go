@@ -0,0 +1,139 @@
+package job
+
+import (
+	"ProjectManagementAPI/internal/domain/job"
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 500
+)
+
+type RepositoryInterface interface {
+	Enqueue(ctx context.Context, j *job.Job) (bool, error)
+	Claim(ctx context.Context, workerID string, limit int, lease time.Duration) ([]job.Job, error)
+	FailExpired(ctx context.Context) (int64, error)
+	Complete(ctx context.Context, id int64, workerID string) error
+	Fail(ctx context.Context, id int64, workerID, lastError string, retryAt *time.Time) error
+	GetByID(ctx context.Context, id int64) (*job.Job, error)
+	List(ctx context.Context, f job.Filter) ([]job.Job, error)
+	Retry(ctx context.Context, id int64) (bool, error)
+	Cancel(ctx context.Context, id int64) (bool, error)
+	SaveSchedule(ctx context.Context, s *job.Schedule) error
+	DueSchedules(ctx context.Context) ([]job.Schedule, error)
+	AdvanceSchedule(ctx context.Context, name string, ranAt, next time.Time) error
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Options - параметры постановки задания в очередь.
+type Options struct {
+	UniqueKey   string
+	RunAt       time.Time
+	MaxAttempts int
+}
+
+type Service struct {
+	repo               RepositoryInterface
+	defaultMaxAttempts int
+}
+
+func NewJobService(repo RepositoryInterface, defaultMaxAttempts int) *Service {
+	return &Service{
+		repo:               repo,
+		defaultMaxAttempts: defaultMaxAttempts,
+	}
+}
+
+// Enqueue ставит задание в очередь. При совпадении UniqueKey с незавершённым
+// заданием возвращает job.ErrDuplicateJob.
+func (s *Service) Enqueue(ctx context.Context, kind string, payload any, opts Options) (*job.Job, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	j := &job.Job{
+		Kind:        kind,
+		Payload:     raw,
+		UniqueKey:   opts.UniqueKey,
+		RunAt:       opts.RunAt,
+		MaxAttempts: opts.MaxAttempts,
+	}
+	if j.RunAt.IsZero() {
+		j.RunAt = time.Now()
+	}
+	if j.MaxAttempts <= 0 {
+		j.MaxAttempts = s.defaultMaxAttempts
+	}
+
+	ok, err := s.repo.Enqueue(ctx, j)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, job.ErrDuplicateJob
+	}
+
+	return j, nil
+}
+
+func (s *Service) GetByID(ctx context.Context, id int64) (*job.Job, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *Service) List(ctx context.Context, f job.Filter) ([]job.Job, error) {
+	if f.Status != "" && !job.ValidStatus(f.Status) {
+		return nil, job.ErrInvalidStatus
+	}
+	if f.Limit <= 0 {
+		f.Limit = defaultLimit
+	}
+	if f.Limit > maxLimit {
+		f.Limit = maxLimit
+	}
+	return s.repo.List(ctx, f)
+}
+
+func (s *Service) Retry(ctx context.Context, id int64) (*job.Job, error) {
+	ok, err := s.repo.Retry(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	j, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, job.ErrNotRetryable
+	}
+
+	return j, nil
+}
+
+// Cancel отменяет задание. Уже выполняющийся обработчик не прерывается,
+// но его результат не будет записан.
+func (s *Service) Cancel(ctx context.Context, id int64) (*job.Job, error) {
+	ok, err := s.repo.Cancel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	j, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, job.ErrNotCancellable
+	}
+
+	return j, nil
+}
+
+// Purge удаляет завершённые задания старше retention.
+func (s *Service) Purge(ctx context.Context, retention time.Duration) (int64, error) {
+	return s.repo.Purge(ctx, time.Now().Add(-retention))
+}
@@ -0,0 +1,148 @@
+package milestone
+
+import (
+	"ProjectManagementAPI/internal/domain/milestone"
+	"ProjectManagementAPI/internal/domain/task"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RepositoryInterface interface {
+	Create(ctx context.Context, m *milestone.Milestone) error
+	GetByID(ctx context.Context, id uuid.UUID) (*milestone.Milestone, error)
+	DeleteByID(ctx context.Context, id uuid.UUID) error
+	TaskEstimates(ctx context.Context, id uuid.UUID) ([]milestone.TaskEstimate, error)
+	StatusHistory(ctx context.Context, id uuid.UUID) ([]task.StatusChange, error)
+}
+
+type Service struct {
+	repo RepositoryInterface
+}
+
+func NewMilestoneService(repo RepositoryInterface) *Service {
+	return &Service{repo: repo}
+}
+
+func (s *Service) Create(ctx context.Context, title string, start, end time.Time) (uuid.UUID, error) {
+	if title == "" {
+		return uuid.Nil, milestone.ErrInvalidTitle
+	}
+	if end.Before(start) {
+		return uuid.Nil, milestone.ErrInvalidDateRange
+	}
+
+	m := &milestone.Milestone{
+		ID:        uuid.New(),
+		Title:     title,
+		StartDate: start,
+		EndDate:   end,
+	}
+
+	if err := s.repo.Create(ctx, m); err != nil {
+		return uuid.Nil, err
+	}
+
+	return m.ID, nil
+}
+
+func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (*milestone.Milestone, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.repo.DeleteByID(ctx, id)
+}
+
+func (s *Service) Burndown(ctx context.Context, id uuid.UUID) (*milestone.Burndown, error) {
+	m, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	estimates, err := s.repo.TaskEstimates(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.repo.StatusHistory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return burndown(m, estimates, history, time.Now().UTC()), nil
+}
+
+// burndown считает остаток story points на конец каждого дня спринта.
+// Задача учитывается с момента создания и пока её статус не done; задача
+// без истории считается имеющей текущий статус с момента создания.
+// history должна быть отсортирована по времени изменения.
+func burndown(m *milestone.Milestone, estimates []milestone.TaskEstimate, history []task.StatusChange, now time.Time) *milestone.Burndown {
+	start := truncateDay(m.StartDate)
+	end := truncateDay(m.EndDate)
+	days := int(end.Sub(start).Hours()/24) + 1
+
+	result := &milestone.Burndown{MilestoneID: m.ID}
+	for _, e := range estimates {
+		result.TotalPoints += e.StoryPoints
+	}
+
+	byTask := make(map[uuid.UUID][]task.StatusChange, len(estimates))
+	for _, c := range history {
+		byTask[c.TaskID] = append(byTask[c.TaskID], c)
+	}
+
+	last := truncateDay(now)
+	if last.After(end) {
+		last = end
+	}
+
+	for i := 0; i < days; i++ {
+		day := start.AddDate(0, 0, i)
+		if day.After(last) {
+			break
+		}
+		cutoff := day.AddDate(0, 0, 1)
+
+		remaining := 0
+		for _, e := range estimates {
+			changes, ok := byTask[e.TaskID]
+			if !ok {
+				changes = []task.StatusChange{{TaskID: e.TaskID, Status: e.Status, ChangedAt: e.CreatedAt}}
+			}
+			status, ok := statusAt(changes, cutoff)
+			if ok && status != task.StatusDone {
+				remaining += e.StoryPoints
+			}
+		}
+
+		ideal := float64(result.TotalPoints)
+		if days > 1 {
+			ideal = float64(result.TotalPoints) * (1 - float64(i)/float64(days-1))
+		}
+
+		result.Points = append(result.Points, milestone.BurndownPoint{
+			Date:      day,
+			Remaining: remaining,
+			Ideal:     ideal,
+		})
+	}
+
+	return result
+}
+
+func statusAt(changes []task.StatusChange, before time.Time) (string, bool) {
+	status, ok := "", false
+	for _, c := range changes {
+		if !c.ChangedAt.Before(before) {
+			break
+		}
+		status, ok = c.Status, true
+	}
+	return status, ok
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,116 @@
+package milestone
+
+import (
+	"ProjectManagementAPI/internal/domain/milestone"
+	"ProjectManagementAPI/internal/domain/task"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// sprint - спринт со 2 по 6 марта 2026.
+func sprint() *milestone.Milestone {
+	return &milestone.Milestone{
+		ID:        uuid.New(),
+		StartDate: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func at(day, hour int) time.Time {
+	return time.Date(2026, 3, day, hour, 0, 0, 0, time.UTC)
+}
+
+func remaining(b *milestone.Burndown) []int {
+	res := make([]int, len(b.Points))
+	for i, p := range b.Points {
+		res[i] = p.Remaining
+	}
+	return res
+}
+
+func TestBurndown(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	estimates := []milestone.TaskEstimate{
+		{TaskID: a, StoryPoints: 5},
+		{TaskID: b, StoryPoints: 3},
+		// добавлена в середине спринта
+		{TaskID: c, StoryPoints: 2},
+	}
+	history := []task.StatusChange{
+		{TaskID: a, Status: task.StatusTodo, ChangedAt: at(1, 10)},
+		{TaskID: b, Status: task.StatusTodo, ChangedAt: at(1, 10)},
+		{TaskID: a, Status: task.StatusInProgress, ChangedAt: at(2, 12)},
+		{TaskID: a, Status: task.StatusDone, ChangedAt: at(3, 18)},
+		{TaskID: c, Status: task.StatusTodo, ChangedAt: at(4, 9)},
+		{TaskID: b, Status: task.StatusDone, ChangedAt: at(5, 23)},
+		// переоткрыта
+		{TaskID: b, Status: task.StatusInProgress, ChangedAt: at(6, 8)},
+	}
+
+	got := burndown(sprint(), estimates, history, at(20, 0))
+
+	if got.TotalPoints != 10 {
+		t.Errorf("total = %d, want 10", got.TotalPoints)
+	}
+	if want := []int{8, 3, 5, 2, 5}; !slices.Equal(remaining(got), want) {
+		t.Errorf("remaining = %v, want %v", remaining(got), want)
+	}
+
+	ideal := []float64{10, 7.5, 5, 2.5, 0}
+	for i, p := range got.Points {
+		if p.Ideal != ideal[i] {
+			t.Errorf("day %d ideal = %v, want %v", i, p.Ideal, ideal[i])
+		}
+		if want := at(2+i, 0); !p.Date.Equal(want) {
+			t.Errorf("day %d date = %v, want %v", i, p.Date, want)
+		}
+	}
+}
+
+// Дни после сегодняшнего не выводятся.
+func TestBurndownStopsToday(t *testing.T) {
+	id := uuid.New()
+	estimates := []milestone.TaskEstimate{{TaskID: id, StoryPoints: 1}}
+	history := []task.StatusChange{{TaskID: id, Status: task.StatusTodo, ChangedAt: at(1, 0)}}
+
+	got := burndown(sprint(), estimates, history, at(3, 15))
+	if want := []int{1, 1}; !slices.Equal(remaining(got), want) {
+		t.Errorf("remaining = %v, want %v", remaining(got), want)
+	}
+
+	if got := burndown(sprint(), estimates, history, at(1, 15)); len(got.Points) != 0 {
+		t.Errorf("points before the sprint = %d, want 0", len(got.Points))
+	}
+}
+
+// Задача без строк в task_status_history учитывается по текущему статусу
+// с момента создания.
+func TestBurndownWithoutHistory(t *testing.T) {
+	open, done := uuid.New(), uuid.New()
+	estimates := []milestone.TaskEstimate{
+		{TaskID: open, StoryPoints: 3, Status: task.StatusInProgress, CreatedAt: at(3, 12)},
+		{TaskID: done, StoryPoints: 2, Status: task.StatusDone, CreatedAt: at(1, 12)},
+	}
+
+	got := burndown(sprint(), estimates, nil, at(20, 0))
+	if want := []int{0, 3, 3, 3, 3}; !slices.Equal(remaining(got), want) {
+		t.Errorf("remaining = %v, want %v", remaining(got), want)
+	}
+	if got.TotalPoints != 5 {
+		t.Errorf("total = %d, want 5", got.TotalPoints)
+	}
+}
+
+func TestBurndownSingleDay(t *testing.T) {
+	m := sprint()
+	m.EndDate = m.StartDate
+
+	got := burndown(m, []milestone.TaskEstimate{{TaskID: uuid.New(), StoryPoints: 4, Status: task.StatusTodo,
+		CreatedAt: at(1, 0)}}, nil, at(20, 0))
+	if len(got.Points) != 1 || got.Points[0].Ideal != 4 || got.Points[0].Remaining != 4 {
+		t.Errorf("points = %+v", got.Points)
+	}
+}
@@ -0,0 +1,272 @@
+package notification
+
+import (
+	"ProjectManagementAPI/internal/domain/event"
+	"ProjectManagementAPI/internal/domain/notification"
+	"ProjectManagementAPI/internal/domain/task"
+	"ProjectManagementAPI/internal/domain/user"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 200
+)
+
+// mentionRe находит упоминания вида @user@example.com в описании задачи.
+var mentionRe = regexp.MustCompile(`(?:^|\s)@([A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,})`)
+
+type RepositoryInterface interface {
+	Insert(ctx context.Context, n *notification.Notification) (bool, error)
+	List(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]notification.Notification, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (int, error)
+	SetRead(ctx context.Context, userID, id uuid.UUID, read bool) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID) error
+	Preferences(ctx context.Context, userID uuid.UUID) ([]notification.Preference, error)
+	SavePreferences(ctx context.Context, userID uuid.UUID, prefs []notification.Preference) error
+	PurgeRead(ctx context.Context, before time.Time) (int64, error)
+}
+
+type UserReader interface {
+	GetByEmails(ctx context.Context, emails []string) ([]*user.User, error)
+}
+
+type TaskReader interface {
+	DueBetween(ctx context.Context, from, to time.Time) ([]*task.Task, error)
+}
+
+type Service struct {
+	repo  RepositoryInterface
+	users UserReader
+	tasks TaskReader
+}
+
+func NewNotificationService(repo RepositoryInterface, users UserReader, tasks TaskReader) *Service {
+	return &Service{
+		repo:  repo,
+		users: users,
+		tasks: tasks,
+	}
+}
+
+// Handle создаёт уведомления по событиям задач из шины.
+func (s *Service) Handle(ctx context.Context, e event.Event) error {
+	if e.AggregateType != event.AggregateTask {
+		return nil
+	}
+
+	var t event.TaskPayload
+	if err := json.Unmarshal(e.Payload, &t); err != nil {
+		return err
+	}
+
+	assignees, err := parseIDs(t.Assignees)
+	if err != nil {
+		return err
+	}
+
+	switch e.Type {
+	case event.TaskCreated:
+		title := fmt.Sprintf("You were assigned to %q", t.Title)
+		if err := s.notify(ctx, assignees, notification.TypeAssigned, title, t.Description, e.AggregateID,
+			"assigned:"+e.AggregateID.String()); err != nil {
+			return err
+		}
+		return s.notifyMentions(ctx, e.AggregateID, t)
+	case event.TaskUpdated:
+		added, err := parseIDs(t.AddedAssignees)
+		if err != nil {
+			return err
+		}
+		// ключ по событию: снятый и снова назначенный исполнитель получит уведомление ещё раз
+		title := fmt.Sprintf("You were assigned to %q", t.Title)
+		if err := s.notify(ctx, added, notification.TypeAssigned, title, t.Description, e.AggregateID,
+			"assigned:"+e.ID.String()); err != nil {
+			return err
+		}
+		return s.notifyMentions(ctx, e.AggregateID, t)
+	case event.TaskStatusChanged:
+		title := fmt.Sprintf("Task %q moved to %s", t.Title, t.Status)
+		body := fmt.Sprintf("Status changed from %s to %s", t.PreviousStatus, t.Status)
+		return s.notify(ctx, assignees, notification.TypeStatusChanged, title, body, e.AggregateID,
+			"status_changed:"+e.ID.String())
+	}
+
+	return nil
+}
+
+func parseIDs(ids []string) ([]uuid.UUID, error) {
+	res := make([]uuid.UUID, 0, len(ids))
+	for _, s := range ids {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, id)
+	}
+	return res, nil
+}
+
+// notifyMentions уведомляет упомянутых пользователей один раз на задачу.
+func (s *Service) notifyMentions(ctx context.Context, taskID uuid.UUID, t event.TaskPayload) error {
+	matches := mentionRe.FindAllStringSubmatch(t.Description, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	emails := make([]string, len(matches))
+	for i, m := range matches {
+		emails[i] = m[1]
+	}
+
+	users, err := s.users.GetByEmails(ctx, emails)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]uuid.UUID, len(users))
+	for i, u := range users {
+		ids[i] = u.ID
+	}
+
+	title := fmt.Sprintf("You were mentioned in %q", t.Title)
+	return s.notify(ctx, ids, notification.TypeMentioned, title, t.Description, taskID,
+		"mentioned:"+taskID.String())
+}
+
+// NotifyDueSoon уведомляет исполнителей задач со сроком в ближайшие window.
+// Повторно по той же задаче и сроку уведомление не создаётся.
+func (s *Service) NotifyDueSoon(ctx context.Context, window time.Duration) error {
+	now := time.Now()
+
+	tasks, err := s.tasks.DueBetween(ctx, now, now.Add(window))
+	if err != nil {
+		return err
+	}
+
+	for _, t := range tasks {
+		title := fmt.Sprintf("Task %q is due soon", t.Title)
+		body := "Due at " + t.DueAt.Format(time.RFC3339)
+		key := fmt.Sprintf("due_soon:%s:%d", t.ID, t.DueAt.Unix())
+		if err := s.notify(ctx, t.Assignees, notification.TypeDueSoon, title, body, t.ID, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) notify(ctx context.Context, userIDs []uuid.UUID, typ, title, body string, taskID uuid.UUID, dedupeKey string) error {
+	for _, userID := range userIDs {
+		pref, err := s.preference(ctx, userID, typ)
+		if err != nil {
+			return err
+		}
+		if !pref.InApp && !pref.Email {
+			continue
+		}
+
+		n := &notification.Notification{
+			ID:        uuid.New(),
+			UserID:    userID,
+			Type:      typ,
+			Title:     title,
+			Body:      body,
+			TaskID:    &taskID,
+			DedupeKey: dedupeKey,
+			InApp:     pref.InApp,
+			Email:     pref.Email,
+		}
+		if _, err := s.repo.Insert(ctx, n); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) preference(ctx context.Context, userID uuid.UUID, typ string) (notification.Preference, error) {
+	prefs, err := s.Preferences(ctx, userID)
+	if err != nil {
+		return notification.Preference{}, err
+	}
+	for _, p := range prefs {
+		if p.Type == typ {
+			return p, nil
+		}
+	}
+	return notification.DefaultPreference(typ), nil
+}
+
+func (s *Service) List(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]notification.Notification, int, error) {
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	list, err := s.repo.List(ctx, userID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	unread, err := s.repo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list, unread, nil
+}
+
+func (s *Service) SetRead(ctx context.Context, userID, id uuid.UUID, read bool) error {
+	return s.repo.SetRead(ctx, userID, id, read)
+}
+
+func (s *Service) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	return s.repo.MarkAllRead(ctx, userID)
+}
+
+// Preferences возвращает настройки по всем типам с учётом значений по умолчанию.
+func (s *Service) Preferences(ctx context.Context, userID uuid.UUID) ([]notification.Preference, error) {
+	saved, err := s.repo.Preferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	byType := make(map[string]notification.Preference, len(saved))
+	for _, p := range saved {
+		byType[p.Type] = p
+	}
+
+	prefs := make([]notification.Preference, len(notification.Types))
+	for i, t := range notification.Types {
+		if p, ok := byType[t]; ok {
+			prefs[i] = p
+		} else {
+			prefs[i] = notification.DefaultPreference(t)
+		}
+	}
+
+	return prefs, nil
+}
+
+func (s *Service) SavePreferences(ctx context.Context, userID uuid.UUID, prefs []notification.Preference) error {
+	for _, p := range prefs {
+		if !notification.ValidType(p.Type) {
+			return notification.ErrInvalidType
+		}
+	}
+	return s.repo.SavePreferences(ctx, userID, prefs)
+}
+
+// Purge удаляет прочитанные уведомления старше retention.
+func (s *Service) Purge(ctx context.Context, retention time.Duration) (int64, error) {
+	return s.repo.PurgeRead(ctx, time.Now().Add(-retention))
+}
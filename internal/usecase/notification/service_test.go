@@ -0,0 +1,94 @@
+package notification
+
+import (
+	"ProjectManagementAPI/internal/domain/event"
+	"ProjectManagementAPI/internal/domain/notification"
+	"ProjectManagementAPI/internal/domain/task"
+	"ProjectManagementAPI/internal/domain/user"
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// memRepo запоминает созданные уведомления; остальные методы не нужны Handle.
+type memRepo struct {
+	RepositoryInterface
+	inserted []*notification.Notification
+}
+
+func (r *memRepo) Insert(_ context.Context, n *notification.Notification) (bool, error) {
+	r.inserted = append(r.inserted, n)
+	return true, nil
+}
+
+func (r *memRepo) Preferences(context.Context, uuid.UUID) ([]notification.Preference, error) {
+	return nil, nil
+}
+
+type noUsers struct{}
+
+func (noUsers) GetByEmails(context.Context, []string) ([]*user.User, error) {
+	return nil, nil
+}
+
+func (r *memRepo) assigned() []uuid.UUID {
+	var res []uuid.UUID
+	for _, n := range r.inserted {
+		if n.Type == notification.TypeAssigned {
+			res = append(res, n.UserID)
+		}
+	}
+	return res
+}
+
+func handle(t *testing.T, typ string, tk *task.Task, added ...uuid.UUID) *memRepo {
+	t.Helper()
+
+	payload := event.NewTaskPayload(tk)
+	for _, a := range added {
+		payload.AddedAssignees = append(payload.AddedAssignees, a.String())
+	}
+	e, err := event.New(typ, event.AggregateTask, tk.ID, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &memRepo{}
+	if err := NewNotificationService(repo, noUsers{}, nil).Handle(context.Background(), e); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	return repo
+}
+
+func TestHandleNotifiesAssigneesOnCreate(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	tk := &task.Task{ID: uuid.New(), Title: "Task", Assignees: []uuid.UUID{a, b}, CreatedAt: time.Now()}
+
+	if got := handle(t, event.TaskCreated, tk).assigned(); !slices.Equal(got, []uuid.UUID{a, b}) {
+		t.Errorf("assigned = %v, want %v", got, []uuid.UUID{a, b})
+	}
+}
+
+func TestHandleNotifiesAddedAssigneesOnUpdate(t *testing.T) {
+	old, added := uuid.New(), uuid.New()
+	tk := &task.Task{ID: uuid.New(), Title: "Task", Assignees: []uuid.UUID{old, added}}
+
+	repo := handle(t, event.TaskUpdated, tk, added)
+	if got := repo.assigned(); !slices.Equal(got, []uuid.UUID{added}) {
+		t.Errorf("assigned = %v, want only %v", got, added)
+	}
+	if key := repo.inserted[0].DedupeKey; key == "assigned:"+tk.ID.String() {
+		t.Errorf("dedupe key %q is per task: a re-assigned user would not be notified", key)
+	}
+}
+
+func TestHandleUpdateWithoutNewAssignees(t *testing.T) {
+	tk := &task.Task{ID: uuid.New(), Title: "Task", Assignees: []uuid.UUID{uuid.New()}}
+
+	if got := handle(t, event.TaskUpdated, tk).assigned(); len(got) != 0 {
+		t.Errorf("assigned = %v, want none", got)
+	}
+}
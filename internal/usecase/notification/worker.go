@@ -0,0 +1,77 @@
+package notification
+
+import (
+	"ProjectManagementAPI/internal/domain/notification"
+	"ProjectManagementAPI/internal/lib/email"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EmailQueue interface {
+	ClaimPendingEmails(ctx context.Context, limit int, lease time.Duration) ([]notification.PendingEmail, error)
+	MarkEmailed(ctx context.Context, id uuid.UUID) error
+	MarkEmailFailed(ctx context.Context, id uuid.UUID, lastError string, retryIn time.Duration, dead bool) error
+}
+
+type WorkerConfig struct {
+	BatchSize     int
+	MaxAttempts   int
+	RetryInterval time.Duration
+}
+
+// Worker отправляет письма уведомлений.
+type Worker struct {
+	log    *slog.Logger
+	queue  EmailQueue
+	sender email.Sender
+	cfg    WorkerConfig
+}
+
+func NewWorker(log *slog.Logger, queue EmailQueue, sender email.Sender, cfg WorkerConfig) *Worker {
+	return &Worker{
+		log:    log.With(slog.String("component", "notification/worker")),
+		queue:  queue,
+		sender: sender,
+		cfg:    cfg,
+	}
+}
+
+// SendPending отправляет до BatchSize писем, вызывается периодическим
+// заданием notifications.email.
+func (w *Worker) SendPending(ctx context.Context) error {
+	emails, err := w.queue.ClaimPendingEmails(ctx, w.cfg.BatchSize, 5*time.Minute)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range emails {
+		err := w.sender.Send(ctx, email.Message{
+			To:      e.To,
+			Subject: e.Title,
+			Text:    "Hello, " + e.UserName + "!\n\n" + e.Body + "\n",
+		})
+		if err == nil {
+			if err := w.queue.MarkEmailed(ctx, e.ID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		attempts := e.Attempts + 1
+		dead := attempts >= w.cfg.MaxAttempts
+		w.log.Warn("email failed",
+			sl.Err(err),
+			slog.String("notification_id", e.ID.String()),
+			slog.Int("attempts", attempts),
+		)
+		if err := w.queue.MarkEmailFailed(ctx, e.ID, err.Error(), w.cfg.RetryInterval*time.Duration(attempts), dead); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
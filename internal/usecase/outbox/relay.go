@@ -0,0 +1,144 @@
+package outbox
+
+import (
+	"ProjectManagementAPI/internal/domain/event"
+	"ProjectManagementAPI/internal/domain/outbox"
+	"ProjectManagementAPI/internal/lib/backoff"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventPublisher доставляет событие потребителям. Ошибка означает,
+// что событие будет отправлено повторно, поэтому потребители должны
+// быть идемпотентны по event.ID.
+type EventPublisher interface {
+	Publish(ctx context.Context, e event.Event) error
+}
+
+type Queue interface {
+	TryLock(ctx context.Context) (bool, error)
+	FetchPending(ctx context.Context, limit int) ([]outbox.Record, error)
+	MarkPublished(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, lastError string, retryIn time.Duration, dead bool) error
+}
+
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type RelayConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// MaxAttempts - попыток до перевода события в dead
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+type Relay struct {
+	log       *slog.Logger
+	queue     Queue
+	tx        Transactor
+	publisher EventPublisher
+	cfg       RelayConfig
+}
+
+func NewRelay(log *slog.Logger, queue Queue, tx Transactor, publisher EventPublisher, cfg RelayConfig) *Relay {
+	return &Relay{
+		log:       log.With(slog.String("component", "outbox/relay")),
+		queue:     queue,
+		tx:        tx,
+		publisher: publisher,
+		cfg:       cfg,
+	}
+}
+
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := r.RelayBatch(ctx)
+			if err != nil && ctx.Err() == nil {
+				r.log.Error("relay failed", sl.Err(err))
+			}
+			// Полная пачка - вероятно, есть ещё события, не ждём тикера
+			if err != nil || n < r.cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayBatch публикует одну пачку событий в порядке записи в outbox.
+// Каждое событие публикуется под своим SAVEPOINT: ошибка подписчика
+// откатывает только его изменения и не ломает транзакцию пачки.
+// После первой ошибки по агрегату его остальные события в пачке
+// пропускаются, чтобы не нарушить порядок.
+func (r *Relay) RelayBatch(ctx context.Context) (int, error) {
+	published := 0
+
+	err := r.tx.WithinTx(ctx, func(ctx context.Context) error {
+		locked, err := r.queue.TryLock(ctx)
+		if err != nil || !locked {
+			return err
+		}
+
+		records, err := r.queue.FetchPending(ctx, r.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		blocked := make(map[uuid.UUID]bool)
+		for _, rec := range records {
+			if blocked[rec.Event.AggregateID] {
+				continue
+			}
+
+			err := r.tx.WithinSavepoint(ctx, func(ctx context.Context) error {
+				return r.publisher.Publish(ctx, rec.Event)
+			})
+			if err != nil {
+				attempts := rec.Attempts + 1
+				dead := attempts >= r.cfg.MaxAttempts
+				// мёртвое событие не задерживает следующие события агрегата
+				blocked[rec.Event.AggregateID] = !dead
+
+				r.log.Warn("publish failed",
+					sl.Err(err),
+					slog.Int64("outbox_id", rec.ID),
+					slog.String("event_type", rec.Event.Type),
+					slog.Int("attempts", attempts),
+					slog.Bool("dead", dead),
+				)
+
+				retryIn := backoff.Exponential(attempts, r.cfg.BaseBackoff, r.cfg.MaxBackoff)
+				if err := r.queue.MarkFailed(ctx, rec.ID, err.Error(), retryIn, dead); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := r.queue.MarkPublished(ctx, rec.ID); err != nil {
+				return err
+			}
+			published++
+		}
+
+		return nil
+	})
+
+	return published, err
+}
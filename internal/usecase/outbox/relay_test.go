@@ -0,0 +1,248 @@
+package outbox
+
+import (
+	"ProjectManagementAPI/internal/broker/inprocess"
+	"ProjectManagementAPI/internal/domain/event"
+	"ProjectManagementAPI/internal/domain/outbox"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// memQueue - outbox в памяти с теми же правилами выборки, что и в postgres:
+// событие не отдаётся, пока более раннее событие агрегата ждёт повтора.
+type memQueue struct {
+	now     time.Time
+	records []*memRecord
+}
+
+type memRecord struct {
+	outbox.Record
+	status    string
+	nextAt    time.Time
+	lastError string
+}
+
+func (q *memQueue) add(aggregateID uuid.UUID, typ string) {
+	q.records = append(q.records, &memRecord{
+		Record: outbox.Record{
+			ID: int64(len(q.records) + 1),
+			Event: event.Event{
+				ID:          uuid.New(),
+				Type:        typ,
+				AggregateID: aggregateID,
+			},
+		},
+		status: outbox.StatusPending,
+		nextAt: q.now,
+	})
+}
+
+func (q *memQueue) get(id int64) *memRecord {
+	return q.records[id-1]
+}
+
+func (q *memQueue) TryLock(context.Context) (bool, error) {
+	return true, nil
+}
+
+func (q *memQueue) FetchPending(_ context.Context, limit int) ([]outbox.Record, error) {
+	waiting := make(map[uuid.UUID]bool)
+	var res []outbox.Record
+	for _, rec := range q.records {
+		if rec.status != outbox.StatusPending {
+			continue
+		}
+		if rec.nextAt.After(q.now) {
+			waiting[rec.Event.AggregateID] = true
+		}
+		if waiting[rec.Event.AggregateID] || len(res) == limit {
+			continue
+		}
+		res = append(res, rec.Record)
+	}
+	return res, nil
+}
+
+func (q *memQueue) MarkPublished(_ context.Context, id int64) error {
+	rec := q.get(id)
+	rec.status = outbox.StatusPublished
+	rec.Attempts++
+	return nil
+}
+
+func (q *memQueue) MarkFailed(_ context.Context, id int64, lastError string, retryIn time.Duration, dead bool) error {
+	rec := q.get(id)
+	rec.status = outbox.StatusPending
+	if dead {
+		rec.status = outbox.StatusDead
+	}
+	rec.Attempts++
+	rec.lastError = lastError
+	rec.nextAt = q.now.Add(retryIn)
+	return nil
+}
+
+// memTx считает SAVEPOINT, откаченные из-за ошибки публикации.
+type memTx struct {
+	rolledBack int
+}
+
+func (t *memTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (t *memTx) WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		t.rolledBack++
+		return err
+	}
+	return nil
+}
+
+// recorder - подписчик шины, запоминающий порядок событий и падающий
+// на событиях из fail, пока их счётчик не обнулится (-1 - всегда).
+type recorder struct {
+	got  []string
+	fail map[string]int
+}
+
+func (rc *recorder) handle(_ context.Context, e event.Event) error {
+	if n := rc.fail[e.Type]; n != 0 {
+		rc.fail[e.Type] = n - 1
+		return errors.New("subscriber failed")
+	}
+	rc.got = append(rc.got, e.Type)
+	return nil
+}
+
+func newRelay(t *testing.T, queue Queue, tx Transactor, rc *recorder, maxAttempts int) *Relay {
+	t.Helper()
+
+	bus := inprocess.New()
+	bus.Subscribe("recorder", rc.handle)
+
+	return NewRelay(slog.New(slog.NewTextHandler(io.Discard, nil)), queue, tx, bus, RelayConfig{
+		BatchSize:   100,
+		MaxAttempts: maxAttempts,
+		BaseBackoff: time.Second,
+		MaxBackoff:  time.Minute,
+	})
+}
+
+func relay(t *testing.T, r *Relay) int {
+	t.Helper()
+
+	n, err := r.RelayBatch(context.Background())
+	if err != nil {
+		t.Fatalf("RelayBatch: %v", err)
+	}
+	return n
+}
+
+func TestRelayPublishesInOrder(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	queue := &memQueue{now: time.Now()}
+	queue.add(a, "a1")
+	queue.add(b, "b1")
+	queue.add(a, "a2")
+	queue.add(b, "b2")
+	queue.add(a, "a3")
+
+	rc := &recorder{}
+	if n := relay(t, newRelay(t, queue, &memTx{}, rc, 3)); n != 5 {
+		t.Fatalf("published = %d, want 5", n)
+	}
+
+	want := []string{"a1", "b1", "a2", "b2", "a3"}
+	if !slices.Equal(rc.got, want) {
+		t.Errorf("order = %v, want %v", rc.got, want)
+	}
+	for _, rec := range queue.records {
+		if rec.status != outbox.StatusPublished {
+			t.Errorf("%s: status = %s", rec.Event.Type, rec.status)
+		}
+	}
+}
+
+func TestRelayFailureBlocksOnlyItsAggregate(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	queue := &memQueue{now: time.Now()}
+	queue.add(a, "a1")
+	queue.add(b, "b1")
+	queue.add(a, "a2")
+	queue.add(b, "b2")
+
+	rc := &recorder{fail: map[string]int{"a1": 1}}
+	tx := &memTx{}
+	r := newRelay(t, queue, tx, rc, 3)
+
+	if n := relay(t, r); n != 2 {
+		t.Fatalf("published = %d, want 2", n)
+	}
+	if want := []string{"b1", "b2"}; !slices.Equal(rc.got, want) {
+		t.Fatalf("first batch = %v, want %v", rc.got, want)
+	}
+	if tx.rolledBack != 1 {
+		t.Errorf("rolled back savepoints = %d, want 1", tx.rolledBack)
+	}
+
+	a1 := queue.get(1)
+	if a1.status != outbox.StatusPending || a1.Attempts != 1 || a1.lastError == "" {
+		t.Fatalf("a1 = %+v", a1)
+	}
+	if got := a1.nextAt.Sub(queue.now); got != time.Second {
+		t.Errorf("a1 retry in %v, want 1s", got)
+	}
+
+	// пока a1 ждёт повтора, a2 не отправляется
+	if n := relay(t, r); n != 0 {
+		t.Fatalf("published before retry = %d, want 0", n)
+	}
+
+	queue.now = queue.now.Add(time.Second)
+	if n := relay(t, r); n != 2 {
+		t.Fatalf("published after retry = %d, want 2", n)
+	}
+	if want := []string{"b1", "b2", "a1", "a2"}; !slices.Equal(rc.got, want) {
+		t.Errorf("order = %v, want %v", rc.got, want)
+	}
+}
+
+func TestRelayDeadLettersAfterMaxAttempts(t *testing.T) {
+	a := uuid.New()
+	queue := &memQueue{now: time.Now()}
+	queue.add(a, "a1")
+	queue.add(a, "a2")
+
+	rc := &recorder{fail: map[string]int{"a1": -1}}
+	r := newRelay(t, queue, &memTx{}, rc, 3)
+
+	var retries []time.Duration
+	for range 3 {
+		relay(t, r)
+		a1 := queue.get(1)
+		retries = append(retries, a1.nextAt.Sub(queue.now))
+		queue.now = a1.nextAt
+	}
+
+	if want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}; !slices.Equal(retries, want) {
+		t.Errorf("retries = %v, want %v", retries, want)
+	}
+
+	a1 := queue.get(1)
+	if a1.status != outbox.StatusDead || a1.Attempts != 3 {
+		t.Fatalf("a1 status = %s, attempts = %d", a1.status, a1.Attempts)
+	}
+
+	// мёртвое событие не задерживает агрегат: a2 уходит в той же пачке
+	if want := []string{"a2"}; !slices.Equal(rc.got, want) {
+		t.Errorf("published = %v, want %v", rc.got, want)
+	}
+}
@@ -0,0 +1,31 @@
+package outbox
+
+import (
+	"ProjectManagementAPI/internal/domain/event"
+	"context"
+	"time"
+)
+
+type RepositoryInterface interface {
+	Insert(ctx context.Context, e event.Event) error
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+type Service struct {
+	repo RepositoryInterface
+}
+
+func NewOutboxService(repo RepositoryInterface) *Service {
+	return &Service{repo: repo}
+}
+
+// Emit сохраняет событие в outbox. Вызывается в транзакции изменения,
+// поэтому событие появляется ровно тогда, когда зафиксировано изменение.
+func (s *Service) Emit(ctx context.Context, e event.Event) error {
+	return s.repo.Insert(ctx, e)
+}
+
+// Purge удаляет опубликованные события старше retention.
+func (s *Service) Purge(ctx context.Context, retention time.Duration) (int64, error) {
+	return s.repo.Purge(ctx, time.Now().Add(-retention))
+}
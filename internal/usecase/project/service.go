@@ -0,0 +1,47 @@
+package project
+
+import (
+	"ProjectManagementAPI/internal/domain/project"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type RepositoryInterface interface {
+	Create(ctx context.Context, p *project.Project) error
+	GetByID(ctx context.Context, id uuid.UUID) (*project.Project, error)
+	DeleteByID(ctx context.Context, id uuid.UUID) error
+}
+
+type Service struct {
+	repo RepositoryInterface
+}
+
+func NewProjectService(repo RepositoryInterface) *Service {
+	return &Service{repo: repo}
+}
+
+func (s *Service) Create(ctx context.Context, name string) (uuid.UUID, error) {
+	if name == "" {
+		return uuid.Nil, project.ErrInvalidName
+	}
+
+	p := &project.Project{
+		ID:   uuid.New(),
+		Name: name,
+	}
+
+	if err := s.repo.Create(ctx, p); err != nil {
+		return uuid.Nil, err
+	}
+
+	return p.ID, nil
+}
+
+func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (*project.Project, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.repo.DeleteByID(ctx, id)
+}
@@ -0,0 +1,171 @@
+package realtime
+
+import (
+	"ProjectManagementAPI/internal/domain/event"
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+const subscriberBuffer = 64
+
+type Message struct {
+	ID        string
+	Type      string
+	TaskID    uuid.UUID
+	ProjectID string
+	Assignees []string
+	Data      json.RawMessage
+}
+
+type Filter struct {
+	ProjectID  string
+	AssigneeID string
+	TaskID     *uuid.UUID
+}
+
+func (f Filter) Match(m Message) bool {
+	if f.TaskID != nil && *f.TaskID != m.TaskID {
+		return false
+	}
+	if f.ProjectID != "" && f.ProjectID != m.ProjectID {
+		return false
+	}
+	if f.AssigneeID != "" {
+		for _, a := range m.Assignees {
+			if a == f.AssigneeID {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+type Subscription struct {
+	C      chan Message
+	filter Filter
+	closed bool
+}
+
+// Hub рассылает события задач подключённым клиентам и хранит последние
+// события для возобновления по Last-Event-ID.
+type Hub struct {
+	mu      sync.Mutex
+	subs    map[*Subscription]struct{}
+	history []Message
+	size    int
+}
+
+func NewHub(historySize int) *Hub {
+	return &Hub{
+		subs: make(map[*Subscription]struct{}),
+		size: historySize,
+	}
+}
+
+// Publish принимает событие из шины. Повторы одного события отбрасываются.
+func (h *Hub) Publish(_ context.Context, e event.Event) error {
+	if e.AggregateType != event.AggregateTask {
+		return nil
+	}
+
+	var payload event.TaskPayload
+	if err := json.Unmarshal(e.Payload, &payload); err != nil {
+		return err
+	}
+
+	data, err := e.Envelope()
+	if err != nil {
+		return err
+	}
+
+	h.Broadcast(Message{
+		ID:        e.ID.String(),
+		Type:      e.Type,
+		TaskID:    e.AggregateID,
+		ProjectID: payload.ProjectID,
+		Assignees: payload.Assignees,
+		Data:      data,
+	})
+
+	return nil
+}
+
+func (h *Hub) Broadcast(m Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, old := range h.history {
+		if old.ID == m.ID {
+			return
+		}
+	}
+
+	h.history = append(h.history, m)
+	if len(h.history) > h.size {
+		h.history = h.history[len(h.history)-h.size:]
+	}
+
+	for s := range h.subs {
+		if !s.filter.Match(m) {
+			continue
+		}
+		select {
+		case s.C <- m:
+		default:
+			// Медленный клиент отключается и переподключится с Last-Event-ID
+			h.remove(s)
+		}
+	}
+}
+
+// Subscribe регистрирует клиента. Если lastEventID найден в истории,
+// возвращаются пропущенные события; ok=false означает, что история
+// не покрывает разрыв и клиенту стоит перечитать состояние.
+func (h *Hub) Subscribe(f Filter, lastEventID string) (s *Subscription, missed []Message, ok bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s = &Subscription{
+		C:      make(chan Message, subscriberBuffer),
+		filter: f,
+	}
+	h.subs[s] = struct{}{}
+
+	if lastEventID == "" {
+		return s, nil, true
+	}
+
+	for i, m := range h.history {
+		if m.ID != lastEventID {
+			continue
+		}
+		for _, m := range h.history[i+1:] {
+			if f.Match(m) {
+				missed = append(missed, m)
+			}
+		}
+		return s, missed, true
+	}
+
+	return s, nil, false
+}
+
+func (h *Hub) Unsubscribe(s *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.remove(s)
+}
+
+func (h *Hub) remove(s *Subscription) {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	delete(h.subs, s)
+	close(s.C)
+}
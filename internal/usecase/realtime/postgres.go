@@ -0,0 +1,63 @@
+package realtime
+
+import (
+	"ProjectManagementAPI/internal/domain/event"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+)
+
+type EventStore interface {
+	Notify(ctx context.Context, channel string, e event.Event) error
+	GetByEventID(ctx context.Context, id uuid.UUID) (*event.Event, error)
+}
+
+// PGBridge синхронизирует хабы нескольких реплик через LISTEN/NOTIFY:
+// relay отправляет в канал ID события, каждая реплика читает событие
+// из outbox и рассылает своим клиентам.
+type PGBridge struct {
+	log     *slog.Logger
+	store   EventStore
+	hub     *Hub
+	channel string
+}
+
+func NewPGBridge(log *slog.Logger, store EventStore, hub *Hub, channel string) *PGBridge {
+	return &PGBridge{
+		log:     log.With(slog.String("component", "realtime/pg_bridge")),
+		store:   store,
+		hub:     hub,
+		channel: channel,
+	}
+}
+
+func (b *PGBridge) Publish(ctx context.Context, e event.Event) error {
+	if e.AggregateType != event.AggregateTask {
+		return nil
+	}
+	return b.store.Notify(ctx, b.channel, e)
+}
+
+func (b *PGBridge) HandleNotification(ctx context.Context, payload string) {
+	id, err := uuid.Parse(payload)
+	if err != nil {
+		b.log.Warn("invalid notification payload", slog.String("payload", payload))
+		return
+	}
+
+	e, err := b.store.GetByEventID(ctx, id)
+	if err != nil {
+		b.log.Error("failed to load event", sl.Err(err), slog.String("event_id", payload))
+		return
+	}
+
+	if err := b.hub.Publish(ctx, *e); err != nil {
+		b.log.Error("failed to broadcast event", sl.Err(err), slog.String("event_id", payload))
+	}
+}
+
+func (b *PGBridge) Channel() string {
+	return b.channel
+}
@@ -0,0 +1,457 @@
+package recurrence
+
+import (
+	"ProjectManagementAPI/internal/domain/event"
+	"ProjectManagementAPI/internal/domain/recurrence"
+	"ProjectManagementAPI/internal/domain/task"
+	"ProjectManagementAPI/internal/domain/user"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"ProjectManagementAPI/internal/lib/rrule"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maxCatchUp ограничивает число повторений, создаваемых за один проход,
+	// если сервис долго не работал.
+	maxCatchUp = 100
+	dueBatch   = 100
+	preview    = 5
+)
+
+type RepositoryInterface interface {
+	Create(ctx context.Context, s *recurrence.Series) error
+	GetByID(ctx context.Context, id uuid.UUID) (*recurrence.Series, error)
+	GetForUpdate(ctx context.Context, id uuid.UUID) (*recurrence.Series, error)
+	Update(ctx context.Context, s *recurrence.Series) error
+	Due(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error)
+}
+
+// TaskService - сервис задач: повторения создаются и меняются через него,
+// чтобы попасть в аудит и события.
+type TaskService interface {
+	Create(ctx context.Context, t *task.Task) (uuid.UUID, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*task.Task, error)
+	Update(ctx context.Context, id uuid.UUID, p task.Patch) (*task.Task, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type TaskRepository interface {
+	SeriesInstances(ctx context.Context, seriesID uuid.UUID, from time.Time) ([]*task.Task, error)
+	SetSeries(ctx context.Context, taskID, seriesID uuid.UUID, occurrenceAt time.Time) error
+}
+
+// Users - пользователи из шаблона серии. В task_series.assignees нет
+// внешнего ключа, удалённые пользователи пропускаются при создании повторений.
+type Users interface {
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*user.User, error)
+}
+
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Service struct {
+	log   *slog.Logger
+	repo  RepositoryInterface
+	tasks TaskService
+	inst  TaskRepository
+	users Users
+	tx    Transactor
+}
+
+func NewRecurrenceService(log *slog.Logger, repo RepositoryInterface, tasks TaskService, inst TaskRepository, users Users, tx Transactor) *Service {
+	return &Service{
+		log:   log.With(slog.String("component", "recurrence/service")),
+		repo:  repo,
+		tasks: tasks,
+		inst:  inst,
+		users: users,
+		tx:    tx,
+	}
+}
+
+// Set делает задачу повторяющейся с правилом rule. Задача становится первым
+// повторением серии. Если задача уже входит в серию, серия разделяется:
+// старая заканчивается перед этой задачей, а её будущие незавершённые
+// повторения удаляются.
+func (s *Service) Set(ctx context.Context, taskID uuid.UUID, rule, timezone string) (*recurrence.Series, error) {
+	r, err := rrule.Parse(rule)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", recurrence.ErrInvalidRule,
+			strings.TrimPrefix(err.Error(), rrule.ErrInvalidRule.Error()+": "))
+	}
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, recurrence.ErrInvalidTimezone
+	}
+
+	var series *recurrence.Series
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		t, err := s.tasks.GetByID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+
+		anchor := occurrence(t)
+		if t.SeriesID != nil {
+			if err := s.end(ctx, *t.SeriesID, anchor, t.ID); err != nil {
+				return err
+			}
+		}
+
+		series = &recurrence.Series{
+			ID:               uuid.New(),
+			RRule:            r.String(),
+			DTStart:          anchor.In(loc),
+			Timezone:         timezone,
+			Title:            t.Title,
+			Description:      t.Description,
+			StoryPoints:      t.StoryPoints,
+			MilestoneID:      t.MilestoneID,
+			ProjectID:        t.ProjectID,
+			Assignees:        t.Assignees,
+			LastOccurrenceAt: anchor,
+		}
+		advance(series, r, loc)
+
+		if err := s.repo.Create(ctx, series); err != nil {
+			return err
+		}
+		return s.inst.SetSeries(ctx, t.ID, series.ID, anchor)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// Get возвращает серию задачи и ближайшие даты повторений.
+func (s *Service) Get(ctx context.Context, taskID uuid.UUID) (*recurrence.Series, []time.Time, error) {
+	t, err := s.tasks.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if t.SeriesID == nil {
+		return nil, nil, recurrence.ErrNotRecurring
+	}
+
+	series, err := s.repo.GetByID(ctx, *t.SeriesID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, loc, err := parse(series)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var upcoming []time.Time
+	for next := series.NextOccurrenceAt; next != nil && len(upcoming) < preview; {
+		if series.EndsBefore != nil && !next.Before(*series.EndsBefore) {
+			break
+		}
+		upcoming = append(upcoming, *next)
+		after, ok := r.After(series.DTStart.In(loc), *next)
+		if !ok {
+			break
+		}
+		next = &after
+	}
+
+	return series, upcoming, nil
+}
+
+// Stop прекращает повторения после этой задачи: будущие незавершённые
+// повторения удаляются, сама задача остаётся.
+func (s *Service) Stop(ctx context.Context, taskID uuid.UUID) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		t, err := s.recurring(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		return s.end(ctx, *t.SeriesID, occurrence(t), t.ID)
+	})
+}
+
+// UpdateFuture применяет изменения к этой задаче, шаблону серии и всем
+// следующим незавершённым повторениям.
+func (s *Service) UpdateFuture(ctx context.Context, taskID uuid.UUID, p task.Patch) (*task.Task, error) {
+	if p.Status != nil || p.DueAt != nil {
+		return nil, recurrence.ErrFutureFieldScope
+	}
+
+	var updated *task.Task
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		t, err := s.recurring(ctx, taskID)
+		if err != nil {
+			return err
+		}
+
+		series, err := s.repo.GetForUpdate(ctx, *t.SeriesID)
+		if err != nil {
+			return err
+		}
+
+		if updated, err = s.tasks.Update(ctx, t.ID, p); err != nil {
+			return err
+		}
+
+		instances, err := s.inst.SeriesInstances(ctx, series.ID, occurrence(t))
+		if err != nil {
+			return err
+		}
+		for _, inst := range instances {
+			if inst.ID == t.ID {
+				continue
+			}
+			if _, err := s.tasks.Update(ctx, inst.ID, p); err != nil {
+				return err
+			}
+		}
+
+		series.Title = updated.Title
+		series.Description = updated.Description
+		series.StoryPoints = updated.StoryPoints
+		series.MilestoneID = updated.MilestoneID
+		series.ProjectID = updated.ProjectID
+		series.Assignees = updated.Assignees
+		return s.repo.Update(ctx, series)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteFuture удаляет эту задачу и все следующие незавершённые повторения
+// и прекращает серию.
+func (s *Service) DeleteFuture(ctx context.Context, taskID uuid.UUID) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		t, err := s.recurring(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if err := s.end(ctx, *t.SeriesID, occurrence(t), t.ID); err != nil {
+			return err
+		}
+		return s.tasks.Delete(ctx, t.ID)
+	})
+}
+
+// GenerateDue создаёт повторения, время которых наступило. Серия с ошибкой
+// пропускается, чтобы не задерживать следующие за ней.
+func (s *Service) GenerateDue(ctx context.Context) error {
+	now := time.Now()
+
+	ids, err := s.repo.Due(ctx, now, dueBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := s.generate(ctx, id, now, nil); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			s.log.Error("generate failed", sl.Err(err), slog.String("series_id", id.String()))
+		}
+	}
+
+	return nil
+}
+
+// Handle создаёт следующее повторение сразу после завершения последнего,
+// не дожидаясь его времени.
+func (s *Service) Handle(ctx context.Context, e event.Event) error {
+	if e.Type != event.TaskStatusChanged {
+		return nil
+	}
+
+	var p event.TaskPayload
+	if err := json.Unmarshal(e.Payload, &p); err != nil {
+		return err
+	}
+	if p.Status != task.StatusDone || p.SeriesID == "" {
+		return nil
+	}
+
+	t, err := s.tasks.GetByID(ctx, e.AggregateID)
+	if errors.Is(err, task.ErrTaskNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if t.SeriesID == nil || t.Status != task.StatusDone {
+		return nil
+	}
+
+	completed := occurrence(t)
+	return s.generate(ctx, *t.SeriesID, time.Time{}, &completed)
+}
+
+// generate создаёт повторения серии с датой не позже now. Если задан
+// completed и это последнее созданное повторение, следующее создаётся
+// независимо от даты.
+func (s *Service) generate(ctx context.Context, seriesID uuid.UUID, now time.Time, completed *time.Time) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		series, err := s.repo.GetForUpdate(ctx, seriesID)
+		if err != nil {
+			return err
+		}
+
+		r, loc, err := parse(series)
+		if err != nil {
+			return err
+		}
+
+		if completed != nil {
+			if series.Finished || series.NextOccurrenceAt == nil || completed.Before(series.LastOccurrenceAt) {
+				return nil
+			}
+			now = *series.NextOccurrenceAt
+		}
+
+		if err := s.dropDeletedAssignees(ctx, series); err != nil {
+			return err
+		}
+
+		for i := 0; i < maxCatchUp && !series.Finished && series.NextOccurrenceAt != nil &&
+			!series.NextOccurrenceAt.After(now); i++ {
+			at := *series.NextOccurrenceAt
+			t := &task.Task{
+				Title:        series.Title,
+				Description:  series.Description,
+				Status:       task.StatusTodo,
+				StoryPoints:  series.StoryPoints,
+				MilestoneID:  series.MilestoneID,
+				ProjectID:    series.ProjectID,
+				DueAt:        &at,
+				SeriesID:     &series.ID,
+				OccurrenceAt: &at,
+				Assignees:    series.Assignees,
+			}
+			if _, err := s.tasks.Create(ctx, t); err != nil {
+				return err
+			}
+
+			series.LastOccurrenceAt = at
+			advance(series, r, loc)
+		}
+
+		return s.repo.Update(ctx, series)
+	})
+}
+
+// dropDeletedAssignees убирает из шаблона удалённых пользователей,
+// иначе создание повторения упадёт с ErrAssigneeNotFound.
+func (s *Service) dropDeletedAssignees(ctx context.Context, series *recurrence.Series) error {
+	if len(series.Assignees) == 0 {
+		return nil
+	}
+
+	found, err := s.users.GetByIDs(ctx, series.Assignees)
+	if err != nil {
+		return err
+	}
+
+	exists := make(map[uuid.UUID]bool, len(found))
+	for _, u := range found {
+		exists[u.ID] = true
+	}
+
+	kept := make([]uuid.UUID, 0, len(series.Assignees))
+	for _, id := range series.Assignees {
+		if exists[id] {
+			kept = append(kept, id)
+		}
+	}
+	series.Assignees = kept
+	return nil
+}
+
+// end прекращает серию перед моментом before и удаляет её незавершённые
+// повторения начиная с before, кроме задачи keep.
+func (s *Service) end(ctx context.Context, seriesID uuid.UUID, before time.Time, keep uuid.UUID) error {
+	series, err := s.repo.GetForUpdate(ctx, seriesID)
+	if err != nil {
+		return err
+	}
+
+	instances, err := s.inst.SeriesInstances(ctx, seriesID, before)
+	if err != nil {
+		return err
+	}
+	for _, inst := range instances {
+		if inst.ID == keep {
+			continue
+		}
+		if err := s.tasks.Delete(ctx, inst.ID); err != nil {
+			return err
+		}
+	}
+
+	series.EndsBefore = &before
+	series.Finished = true
+	series.NextOccurrenceAt = nil
+	return s.repo.Update(ctx, series)
+}
+
+func (s *Service) recurring(ctx context.Context, taskID uuid.UUID) (*task.Task, error) {
+	t, err := s.tasks.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if t.SeriesID == nil {
+		return nil, recurrence.ErrNotRecurring
+	}
+	return t, nil
+}
+
+// advance вычисляет следующее повторение после LastOccurrenceAt
+// и завершает серию, если повторений больше нет.
+func advance(series *recurrence.Series, r *rrule.Rule, loc *time.Location) {
+	next, ok := r.After(series.DTStart.In(loc), series.LastOccurrenceAt)
+	if !ok || (series.EndsBefore != nil && !next.Before(*series.EndsBefore)) {
+		series.NextOccurrenceAt = nil
+		series.Finished = true
+		return
+	}
+	series.NextOccurrenceAt = &next
+}
+
+func parse(series *recurrence.Series) (*rrule.Rule, *time.Location, error) {
+	r, err := rrule.Parse(series.RRule)
+	if err != nil {
+		return nil, nil, err
+	}
+	loc, err := time.LoadLocation(series.Timezone)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, loc, nil
+}
+
+// occurrence - дата повторения задачи: для задачи вне серии это срок
+// или, если его нет, текущая минута.
+func occurrence(t *task.Task) time.Time {
+	switch {
+	case t.OccurrenceAt != nil:
+		return *t.OccurrenceAt
+	case t.DueAt != nil:
+		return *t.DueAt
+	}
+	return time.Now().Truncate(time.Minute)
+}
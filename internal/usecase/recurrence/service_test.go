@@ -0,0 +1,282 @@
+package recurrence
+
+import (
+	"ProjectManagementAPI/internal/domain/recurrence"
+	"ProjectManagementAPI/internal/domain/task"
+	"ProjectManagementAPI/internal/domain/user"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// store - серии, задачи и пользователи в памяти. Реализует все зависимости
+// сервиса; Get отдаёт копии, как строки из БД.
+type store struct {
+	series map[uuid.UUID]recurrence.Series
+	tasks  map[uuid.UUID]task.Task
+	users  map[uuid.UUID]bool
+	// failTitle - Create падает на задачах с этим названием
+	failTitle string
+}
+
+func newStore() *store {
+	return &store{
+		series: map[uuid.UUID]recurrence.Series{},
+		tasks:  map[uuid.UUID]task.Task{},
+		users:  map[uuid.UUID]bool{},
+	}
+}
+
+func (s *store) Create(_ context.Context, sr *recurrence.Series) error {
+	s.series[sr.ID] = *sr
+	return nil
+}
+
+func (s *store) GetByID(_ context.Context, id uuid.UUID) (*recurrence.Series, error) {
+	sr, ok := s.series[id]
+	if !ok {
+		return nil, recurrence.ErrSeriesNotFound
+	}
+	return &sr, nil
+}
+
+func (s *store) GetForUpdate(ctx context.Context, id uuid.UUID) (*recurrence.Series, error) {
+	return s.GetByID(ctx, id)
+}
+
+func (s *store) Update(_ context.Context, sr *recurrence.Series) error {
+	s.series[sr.ID] = *sr
+	return nil
+}
+
+func (s *store) Due(_ context.Context, now time.Time, limit int) ([]uuid.UUID, error) {
+	var due []recurrence.Series
+	for _, sr := range s.series {
+		if !sr.Finished && sr.NextOccurrenceAt != nil && !sr.NextOccurrenceAt.After(now) {
+			due = append(due, sr)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].NextOccurrenceAt.Before(*due[j].NextOccurrenceAt) })
+
+	var ids []uuid.UUID
+	for _, sr := range due[:min(len(due), limit)] {
+		ids = append(ids, sr.ID)
+	}
+	return ids, nil
+}
+
+// tasks реализует TaskService поверх той же памяти.
+type tasks struct{ *store }
+
+func (t tasks) Create(_ context.Context, tk *task.Task) (uuid.UUID, error) {
+	if tk.Title == t.failTitle {
+		return uuid.Nil, errors.New("create failed")
+	}
+	for _, a := range tk.Assignees {
+		if !t.users[a] {
+			return uuid.Nil, task.ErrAssigneeNotFound
+		}
+	}
+	tk.ID = uuid.New()
+	t.store.tasks[tk.ID] = *tk
+	return tk.ID, nil
+}
+
+func (t tasks) GetByID(_ context.Context, id uuid.UUID) (*task.Task, error) {
+	tk, ok := t.store.tasks[id]
+	if !ok {
+		return nil, task.ErrTaskNotFound
+	}
+	return &tk, nil
+}
+
+func (t tasks) Update(_ context.Context, id uuid.UUID, p task.Patch) (*task.Task, error) {
+	tk, ok := t.store.tasks[id]
+	if !ok {
+		return nil, task.ErrTaskNotFound
+	}
+	if p.Title != nil {
+		tk.Title = *p.Title
+	}
+	if p.Status != nil {
+		tk.Status = *p.Status
+	}
+	t.store.tasks[id] = tk
+	return &tk, nil
+}
+
+func (t tasks) Delete(_ context.Context, id uuid.UUID) error {
+	delete(t.store.tasks, id)
+	return nil
+}
+
+func (s *store) SeriesInstances(_ context.Context, seriesID uuid.UUID, from time.Time) ([]*task.Task, error) {
+	var res []*task.Task
+	for _, tk := range s.tasks {
+		if tk.SeriesID != nil && *tk.SeriesID == seriesID && !tk.OccurrenceAt.Before(from) && tk.Status != task.StatusDone {
+			res = append(res, &tk)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].OccurrenceAt.Before(*res[j].OccurrenceAt) })
+	return res, nil
+}
+
+func (s *store) SetSeries(_ context.Context, taskID, seriesID uuid.UUID, occurrenceAt time.Time) error {
+	tk := s.tasks[taskID]
+	tk.SeriesID, tk.OccurrenceAt = &seriesID, &occurrenceAt
+	s.tasks[taskID] = tk
+	return nil
+}
+
+func (s *store) GetByIDs(_ context.Context, ids []uuid.UUID) ([]*user.User, error) {
+	var res []*user.User
+	for _, id := range ids {
+		if s.users[id] {
+			res = append(res, &user.User{ID: id})
+		}
+	}
+	return res, nil
+}
+
+func (s *store) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (s *store) newService() *Service {
+	return NewRecurrenceService(slog.New(slog.NewTextHandler(io.Discard, nil)), s, tasks{s}, s, s, s)
+}
+
+// addSeries добавляет ежедневную серию, следующее повторение которой наступило daysAgo дней назад.
+func (s *store) addSeries(title string, daysAgo int, assignees ...uuid.UUID) uuid.UUID {
+	last := time.Now().UTC().Truncate(time.Hour).AddDate(0, 0, -daysAgo-1)
+	next := last.AddDate(0, 0, 1)
+	sr := recurrence.Series{
+		ID:               uuid.New(),
+		RRule:            "FREQ=DAILY",
+		DTStart:          last,
+		Timezone:         "UTC",
+		Title:            title,
+		Assignees:        assignees,
+		LastOccurrenceAt: last,
+		NextOccurrenceAt: &next,
+	}
+	s.series[sr.ID] = sr
+	return sr.ID
+}
+
+func (s *store) instances(seriesID uuid.UUID) []task.Task {
+	var res []task.Task
+	for _, tk := range s.tasks {
+		if tk.SeriesID != nil && *tk.SeriesID == seriesID {
+			res = append(res, tk)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].OccurrenceAt.Before(*res[j].OccurrenceAt) })
+	return res
+}
+
+func TestGenerateDueSkipsFailingSeries(t *testing.T) {
+	s := newStore()
+	s.failTitle = "broken"
+	// сломанная серия стоит первой в очереди Due
+	broken := s.addSeries("broken", 3)
+	ok := s.addSeries("ok", 1)
+
+	if err := s.newService().GenerateDue(context.Background()); err != nil {
+		t.Fatalf("GenerateDue: %v", err)
+	}
+
+	if n := len(s.instances(broken)); n != 0 {
+		t.Errorf("broken series instances = %d, want 0", n)
+	}
+	if n := len(s.instances(ok)); n != 2 {
+		t.Errorf("ok series instances = %d, want 2", n)
+	}
+	if next := s.series[ok].NextOccurrenceAt; next == nil || !next.After(time.Now()) {
+		t.Errorf("ok series next = %v, want in the future", next)
+	}
+}
+
+func TestGenerateDueDropsDeletedAssignees(t *testing.T) {
+	s := newStore()
+	alive, deleted := uuid.New(), uuid.New()
+	s.users[alive] = true
+	id := s.addSeries("standup", 0, alive, deleted)
+
+	if err := s.newService().GenerateDue(context.Background()); err != nil {
+		t.Fatalf("GenerateDue: %v", err)
+	}
+
+	got := s.instances(id)
+	if len(got) != 1 {
+		t.Fatalf("instances = %d, want 1", len(got))
+	}
+	if want := []uuid.UUID{alive}; !slices.Equal(got[0].Assignees, want) {
+		t.Errorf("assignees = %v, want %v", got[0].Assignees, want)
+	}
+	if want := []uuid.UUID{alive}; !slices.Equal(s.series[id].Assignees, want) {
+		t.Errorf("template assignees = %v, want %v", s.series[id].Assignees, want)
+	}
+}
+
+// Set для задачи из серии делит её: старая серия заканчивается перед
+// задачей, её будущие повторения удаляются, задача начинает новую серию.
+func TestSetSplitsSeries(t *testing.T) {
+	s := newStore()
+	svc := s.newService()
+	ctx := context.Background()
+
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	first, err := tasks{s}.Create(ctx, &task.Task{Title: "report", Status: task.StatusTodo, DueAt: &start})
+	if err != nil {
+		t.Fatal(err)
+	}
+	old, err := svc.Set(ctx, first, "FREQ=DAILY", "UTC")
+	if err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	// повторения на 3, 4 и 5 марта
+	for range 3 {
+		if err := svc.generate(ctx, old.ID, *s.series[old.ID].NextOccurrenceAt, nil); err != nil {
+			t.Fatalf("generate: %v", err)
+		}
+	}
+	inst := s.instances(old.ID)
+	if len(inst) != 4 {
+		t.Fatalf("instances = %d, want 4", len(inst))
+	}
+
+	split := inst[1]
+	updated, err := svc.Set(ctx, split.ID, "FREQ=WEEKLY", "UTC")
+	if err != nil {
+		t.Fatalf("Set future: %v", err)
+	}
+
+	oldSeries := s.series[old.ID]
+	if !oldSeries.Finished || oldSeries.EndsBefore == nil || !oldSeries.EndsBefore.Equal(*split.OccurrenceAt) {
+		t.Errorf("old series = finished %v, ends before %v", oldSeries.Finished, oldSeries.EndsBefore)
+	}
+
+	if got := s.instances(old.ID); len(got) != 1 || got[0].ID != first {
+		t.Errorf("old series keeps %d tasks, want only the first", len(got))
+	}
+	if _, ok := s.tasks[inst[2].ID]; ok {
+		t.Error("future occurrence of the old series was not deleted")
+	}
+
+	moved := s.tasks[split.ID]
+	if moved.SeriesID == nil || *moved.SeriesID != updated.ID {
+		t.Fatalf("split task series = %v, want %v", moved.SeriesID, updated.ID)
+	}
+	if want := split.OccurrenceAt.AddDate(0, 0, 7); !s.series[updated.ID].NextOccurrenceAt.Equal(want) {
+		t.Errorf("new series next = %v, want %v", s.series[updated.ID].NextOccurrenceAt, want)
+	}
+}
@@ -0,0 +1,70 @@
+package search
+
+import (
+	"ProjectManagementAPI/internal/domain/search"
+	"ProjectManagementAPI/internal/domain/task"
+	"context"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	defaultLimit   = 20
+	maxLimit       = 100
+	maxQueryLength = 256
+)
+
+type RepositoryInterface interface {
+	SetLanguage(ctx context.Context, language string) error
+	Search(ctx context.Context, q search.Query) ([]search.Result, int, error)
+	Fuzzy(ctx context.Context, q search.Query) ([]search.Result, int, error)
+}
+
+type Service struct {
+	repo RepositoryInterface
+}
+
+func NewSearchService(repo RepositoryInterface) *Service {
+	return &Service{repo: repo}
+}
+
+// SetLanguage вызывается при старте: язык из конфига применяется к индексам.
+func (s *Service) SetLanguage(ctx context.Context, language string) error {
+	return s.repo.SetLanguage(ctx, language)
+}
+
+// Search выполняет полнотекстовый поиск, а если он ничего не нашёл -
+// нечёткий поиск по названиям задач.
+func (s *Service) Search(ctx context.Context, q search.Query) (*search.Page, error) {
+	q.Text = strings.TrimSpace(q.Text)
+	if q.Text == "" {
+		return nil, search.ErrEmptyQuery
+	}
+	if utf8.RuneCountInString(q.Text) > maxQueryLength {
+		return nil, search.ErrQueryTooLong
+	}
+	if q.Status != "" && !task.ValidStatus(q.Status) {
+		return nil, task.ErrInvalidStatus
+	}
+	if q.Limit <= 0 {
+		q.Limit = defaultLimit
+	}
+	if q.Limit > maxLimit {
+		q.Limit = maxLimit
+	}
+
+	results, total, err := s.repo.Search(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	if total > 0 {
+		return &search.Page{Results: results, Total: total}, nil
+	}
+
+	results, total, err = s.repo.Fuzzy(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return &search.Page{Results: results, Total: total, Fuzzy: total > 0}, nil
+}
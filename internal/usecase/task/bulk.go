@@ -0,0 +1,197 @@
+package task
+
+import (
+	"ProjectManagementAPI/internal/domain/task"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BulkConfig struct {
+	// MaxItems - предел операций или задач, найденных фильтром
+	MaxItems int
+	Timeout  time.Duration
+}
+
+// BulkService выполняет пачку операций над задачами через Service, поэтому
+// аудит и события пишутся так же, как при одиночных запросах.
+type BulkService struct {
+	tasks *Service
+	tx    Transactor
+	cfg   BulkConfig
+}
+
+func NewBulkService(tasks *Service, tx Transactor, cfg BulkConfig) *BulkService {
+	return &BulkService{
+		tasks: tasks,
+		tx:    tx,
+		cfg:   cfg,
+	}
+}
+
+// errAbort прерывает транзакцию режима atomic после неудачной операции.
+var errAbort = errors.New("bulk aborted")
+
+func (s *BulkService) Run(ctx context.Context, req task.BulkRequest) (*task.BulkReport, error) {
+	if req.Mode == "" {
+		req.Mode = task.BulkAtomic
+	}
+	if req.Mode != task.BulkAtomic && req.Mode != task.BulkPerItem {
+		return nil, task.ErrInvalidBulkMode
+	}
+
+	byFilter := req.Operation != nil
+	if byFilter == (len(req.Operations) > 0) {
+		return nil, task.ErrBulkEmpty
+	}
+	if byFilter && strings.TrimSpace(req.Filter) == "" {
+		return nil, task.ErrBulkEmptyFilter
+	}
+	if len(req.Operations) > s.cfg.MaxItems {
+		return nil, fmt.Errorf("%w: at most %d operations", task.ErrBulkTooLarge, s.cfg.MaxItems)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	report := &task.BulkReport{Mode: req.Mode}
+
+	if req.Mode == task.BulkPerItem {
+		ops, err := s.operations(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		report.Results = make([]task.BulkResult, len(ops))
+		for i, op := range ops {
+			report.Results[i] = task.BulkResult{Index: i, TaskID: op.TaskID, Status: task.BulkResultOK}
+			err := validate(op)
+			if err == nil {
+				err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+					return s.exec(ctx, op)
+				})
+			}
+			if err != nil {
+				report.Results[i].Status, report.Results[i].Err = task.BulkResultFailed, err
+			}
+		}
+		return summarize(report), nil
+	}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		ops, err := s.operations(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		report.Results = make([]task.BulkResult, len(ops))
+		for i, op := range ops {
+			report.Results[i] = task.BulkResult{Index: i, TaskID: op.TaskID, Status: task.BulkResultSkipped}
+		}
+
+		// Сначала проверяем всё, чтобы не начинать заведомо неудачную транзакцию
+		for i, op := range ops {
+			if err := validate(op); err != nil {
+				report.Results[i].Status, report.Results[i].Err = task.BulkResultFailed, err
+				return errAbort
+			}
+		}
+
+		for i, op := range ops {
+			if err := s.exec(ctx, op); err != nil {
+				report.Results[i].Status, report.Results[i].Err = task.BulkResultFailed, err
+				for j := range i {
+					report.Results[j].Status = task.BulkResultRolledBack
+				}
+				return errAbort
+			}
+			report.Results[i].Status = task.BulkResultOK
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errAbort) {
+		return nil, err
+	}
+
+	return summarize(report), nil
+}
+
+// operations разворачивает запрос с фильтром в операции над каждой задачей.
+func (s *BulkService) operations(ctx context.Context, req task.BulkRequest) ([]task.BulkOp, error) {
+	if req.Operation == nil {
+		return req.Operations, nil
+	}
+
+	tasks, err := s.tasks.Find(ctx, req.Filter, s.cfg.MaxItems+1, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) > s.cfg.MaxItems {
+		return nil, fmt.Errorf("%w: filter matches more than %d tasks", task.ErrBulkTooLarge, s.cfg.MaxItems)
+	}
+
+	ops := make([]task.BulkOp, len(tasks))
+	for i, t := range tasks {
+		ops[i] = *req.Operation
+		ops[i].TaskID = t.ID
+	}
+	return ops, nil
+}
+
+func validate(op task.BulkOp) error {
+	if op.TaskID == uuid.Nil {
+		return fmt.Errorf("%w: task_id is required", task.ErrInvalidBulkOp)
+	}
+
+	switch op.Op {
+	case task.BulkSetStatus:
+		if !task.ValidStatus(op.Status) {
+			return task.ErrInvalidStatus
+		}
+	case task.BulkReassign:
+		if len(op.Assignees) == 0 {
+			return task.ErrNoAssignees
+		}
+	case task.BulkAddLabel:
+		if !task.ValidLabel(strings.ToLower(strings.TrimSpace(op.Label))) {
+			return task.ErrInvalidLabel
+		}
+	case task.BulkDelete:
+	default:
+		return fmt.Errorf("%w: unknown op %q", task.ErrInvalidBulkOp, op.Op)
+	}
+
+	return nil
+}
+
+func (s *BulkService) exec(ctx context.Context, op task.BulkOp) error {
+	switch op.Op {
+	case task.BulkSetStatus:
+		_, err := s.tasks.Update(ctx, op.TaskID, task.Patch{Status: &op.Status})
+		return err
+	case task.BulkReassign:
+		_, err := s.tasks.Update(ctx, op.TaskID, task.Patch{Assignees: &op.Assignees})
+		return err
+	case task.BulkAddLabel:
+		_, err := s.tasks.AddLabel(ctx, op.TaskID, op.Label)
+		return err
+	case task.BulkDelete:
+		return s.tasks.Delete(ctx, op.TaskID)
+	}
+	return task.ErrInvalidBulkOp
+}
+
+func summarize(report *task.BulkReport) *task.BulkReport {
+	for _, res := range report.Results {
+		if res.Status == task.BulkResultFailed {
+			report.Failed++
+		} else if res.Status == task.BulkResultOK {
+			report.Succeeded++
+		}
+	}
+	report.Applied = report.Failed == 0 || (report.Mode == task.BulkPerItem && report.Succeeded > 0)
+	return report
+}
@@ -0,0 +1,28 @@
+package task
+
+import (
+	"ProjectManagementAPI/internal/domain/task"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// Пустой фильтр выбрал бы все задачи: запрос отклоняется до поиска
+// (memRepo.Find не реализован и упал бы).
+func TestBulkRejectsEmptyFilter(t *testing.T) {
+	svc, _, _ := newService()
+	bulk := NewBulkService(svc, passTx{}, BulkConfig{MaxItems: 100, Timeout: time.Second})
+
+	for _, op := range []string{task.BulkDelete, task.BulkSetStatus, task.BulkReassign, task.BulkAddLabel} {
+		for _, filter := range []string{"", "   "} {
+			_, err := bulk.Run(context.Background(), task.BulkRequest{
+				Filter:    filter,
+				Operation: &task.BulkOp{Op: op, Status: task.StatusDone, Label: "x"},
+			})
+			if !errors.Is(err, task.ErrBulkEmptyFilter) {
+				t.Errorf("%s with filter %q: err = %v, want ErrBulkEmptyFilter", op, filter, err)
+			}
+		}
+	}
+}
@@ -1,9 +1,15 @@
 package task
 
 import (
+	"ProjectManagementAPI/internal/domain/audit"
+	"ProjectManagementAPI/internal/domain/event"
 	"ProjectManagementAPI/internal/domain/task"
+	"ProjectManagementAPI/internal/lib/actor"
+	"ProjectManagementAPI/internal/lib/query"
 	task2 "ProjectManagementAPI/internal/repository/postgres/task"
+	"bytes"
 	"context"
+	"slices"
 
 	"github.com/google/uuid"
 )
@@ -11,34 +17,80 @@ import (
 type RepositoryInterface interface {
 	Create(ctx context.Context, u *task.Task) error
 	GetByID(ctx context.Context, id uuid.UUID) (*task.Task, error)
+	GetForUpdate(ctx context.Context, id uuid.UUID) (*task.Task, error)
+	Update(ctx context.Context, t *task.Task) error
 	DeleteByID(ctx context.Context, id uuid.UUID) error
+	Find(ctx context.Context, n query.Node, me *uuid.UUID, limit, offset int) ([]*task.Task, error)
+	FindAfter(ctx context.Context, n query.Node, me *uuid.UUID, after *task.Task, limit int) ([]*task.Task, error)
+}
+
+const (
+	defaultLimit = 50
+	maxLimit     = 500
+	// eachBatch - размер порции в Each
+	eachBatch = 500
+)
+
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, entityType string, entityID uuid.UUID, action string, before, after any) error
+}
+
+type EventEmitter interface {
+	Emit(ctx context.Context, e event.Event) error
 }
 
 type Service struct {
-	repo RepositoryInterface
+	repo   RepositoryInterface
+	tx     Transactor
+	audit  AuditRecorder
+	events EventEmitter
 }
 
-func NewTaskService(repo *task2.Repository) *Service {
-	return &Service{repo: repo}
+func NewTaskService(repo *task2.Repository, tx Transactor, audit AuditRecorder, events EventEmitter) *Service {
+	return &Service{
+		repo:   repo,
+		tx:     tx,
+		audit:  audit,
+		events: events,
+	}
 }
 
-func (s *Service) Create(ctx context.Context, title, description, status string, assignees []uuid.UUID) (uuid.UUID, error) {
-	if title == "" {
+func (s *Service) Create(ctx context.Context, t *task.Task) (uuid.UUID, error) {
+	if t.Title == "" {
 		return uuid.Nil, task.ErrInvalidTitle
 	}
-	if len(assignees) == 0 {
+	if len(t.Assignees) == 0 {
 		return uuid.Nil, task.ErrNoAssignees
 	}
-
-	t := &task.Task{
-		ID:          uuid.New(),
-		Title:       title,
-		Description: description,
-		Status:      status,
-		Assignees:   assignees,
+	if t.Status == "" {
+		t.Status = task.StatusTodo
+	}
+	if !task.ValidStatus(t.Status) {
+		return uuid.Nil, task.ErrInvalidStatus
+	}
+	if t.StoryPoints < 0 {
+		return uuid.Nil, task.ErrInvalidStoryPoints
+	}
+	labels, err := task.NormalizeLabels(t.Labels)
+	if err != nil {
+		return uuid.Nil, err
 	}
+	t.Labels = labels
 
-	if err := s.repo.Create(ctx, t); err != nil {
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, t); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, audit.EntityTask, t.ID, audit.ActionCreate, nil, t); err != nil {
+			return err
+		}
+		return s.emit(ctx, event.TaskCreated, t)
+	})
+	if err != nil {
 		return uuid.Nil, err
 	}
 
@@ -49,6 +101,252 @@ func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (*task.Task, error)
 	return s.repo.GetByID(ctx, id)
 }
 
+// Find ищет задачи по запросу на языке фильтров (см. task.QuerySchema).
+// Ошибки в запросе возвращаются как *query.Error с позицией.
+func (s *Service) Find(ctx context.Context, q string, limit, offset int) ([]*task.Task, error) {
+	n, err := parseQuery(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	var me *uuid.UUID
+	if id, ok := actor.FromContext(ctx); ok {
+		me = &id
+	}
+
+	return s.repo.Find(ctx, n, me, limit, offset)
+}
+
+// Each передаёт в fn все задачи по запросу порциями, по возрастанию
+// created_at. Размер выборки не ограничен, в отличие от Find.
+func (s *Service) Each(ctx context.Context, q string, fn func(tasks []*task.Task) error) error {
+	n, err := parseQuery(q)
+	if err != nil {
+		return err
+	}
+
+	var me *uuid.UUID
+	if id, ok := actor.FromContext(ctx); ok {
+		me = &id
+	}
+
+	var after *task.Task
+	for {
+		tasks, err := s.repo.FindAfter(ctx, n, me, after, eachBatch)
+		if err != nil {
+			return err
+		}
+		if len(tasks) > 0 {
+			if err := fn(tasks); err != nil {
+				return err
+			}
+		}
+		if len(tasks) < eachBatch {
+			return nil
+		}
+		after = tasks[len(tasks)-1]
+	}
+}
+
+// CheckQuery проверяет запрос, не выполняя его.
+func (s *Service) CheckQuery(q string) error {
+	_, err := parseQuery(q)
+	return err
+}
+
+// parseQuery разбирает запрос и проверяет поля и значения.
+func parseQuery(q string) (query.Node, error) {
+	n, err := query.Parse(q)
+	if err != nil {
+		return nil, err
+	}
+	if err := task.QuerySchema.Check(n); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (s *Service) Update(ctx context.Context, id uuid.UUID, p task.Patch) (*task.Task, error) {
+	return s.change(ctx, id, func(t *task.Task) error {
+		return applyPatch(t, p)
+	})
+}
+
+// AddAssignees добавляет исполнителей, уже назначенные пропускаются.
+func (s *Service) AddAssignees(ctx context.Context, id uuid.UUID, userIDs []uuid.UUID) (*task.Task, error) {
+	return s.change(ctx, id, func(t *task.Task) error {
+		assignees := append(slices.Clone(t.Assignees), userIDs...)
+		return applyPatch(t, task.Patch{Assignees: &assignees})
+	})
+}
+
+// AddLabel добавляет метку к уже имеющимся.
+func (s *Service) AddLabel(ctx context.Context, id uuid.UUID, label string) (*task.Task, error) {
+	return s.change(ctx, id, func(t *task.Task) error {
+		labels := append(slices.Clone(t.Labels), label)
+		return applyPatch(t, task.Patch{Labels: &labels})
+	})
+}
+
+// RemoveAssignee снимает исполнителя; последнего снять нельзя.
+func (s *Service) RemoveAssignee(ctx context.Context, id, userID uuid.UUID) (*task.Task, error) {
+	return s.change(ctx, id, func(t *task.Task) error {
+		if !slices.Contains(t.Assignees, userID) {
+			return task.ErrNotAssigned
+		}
+		assignees := slices.DeleteFunc(slices.Clone(t.Assignees), func(a uuid.UUID) bool { return a == userID })
+		return applyPatch(t, task.Patch{Assignees: &assignees})
+	})
+}
+
+// change меняет задачу в транзакции и пишет аудит и события. Задача читается
+// под блокировкой: Update переписывает исполнителей и метки целиком.
+func (s *Service) change(ctx context.Context, id uuid.UUID, fn func(t *task.Task) error) (*task.Task, error) {
+	var t *task.Task
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := s.repo.GetForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		after := *before
+		t = &after
+		if err := fn(t); err != nil {
+			return err
+		}
+
+		if err := s.repo.Update(ctx, t); err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, audit.EntityTask, t.ID, audit.ActionUpdate, before, t); err != nil {
+			return err
+		}
+
+		payload := event.NewTaskPayload(t)
+		for _, a := range t.Assignees {
+			if !slices.Contains(before.Assignees, a) {
+				payload.AddedAssignees = append(payload.AddedAssignees, a.String())
+			}
+		}
+		updated, err := event.New(event.TaskUpdated, event.AggregateTask, t.ID, payload)
+		if err != nil {
+			return err
+		}
+		if err := s.events.Emit(ctx, updated); err != nil {
+			return err
+		}
+		if before.Status != t.Status {
+			payload := event.NewTaskPayload(t)
+			payload.PreviousStatus = before.Status
+			e, err := event.New(event.TaskStatusChanged, event.AggregateTask, t.ID, payload)
+			if err != nil {
+				return err
+			}
+			return s.events.Emit(ctx, e)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func applyPatch(t *task.Task, p task.Patch) error {
+	if p.Title != nil {
+		if *p.Title == "" {
+			return task.ErrInvalidTitle
+		}
+		t.Title = *p.Title
+	}
+	if p.Description != nil {
+		t.Description = *p.Description
+	}
+	if p.Status != nil {
+		if !task.ValidStatus(*p.Status) {
+			return task.ErrInvalidStatus
+		}
+		t.Status = *p.Status
+	}
+	if p.StoryPoints != nil {
+		if *p.StoryPoints < 0 {
+			return task.ErrInvalidStoryPoints
+		}
+		t.StoryPoints = *p.StoryPoints
+	}
+	if p.MilestoneID != nil {
+		if *p.MilestoneID == uuid.Nil {
+			t.MilestoneID = nil
+		} else {
+			t.MilestoneID = p.MilestoneID
+		}
+	}
+	if p.ProjectID != nil {
+		if *p.ProjectID == uuid.Nil {
+			t.ProjectID = nil
+		} else {
+			t.ProjectID = p.ProjectID
+		}
+	}
+	if p.DueAt != nil {
+		if p.DueAt.IsZero() {
+			t.DueAt = nil
+		} else {
+			t.DueAt = p.DueAt
+		}
+	}
+	if p.Labels != nil {
+		labels, err := task.NormalizeLabels(*p.Labels)
+		if err != nil {
+			return err
+		}
+		t.Labels = labels
+	}
+	if p.Assignees != nil {
+		assignees := slices.Clone(*p.Assignees)
+		slices.SortFunc(assignees, func(a, b uuid.UUID) int { return bytes.Compare(a[:], b[:]) })
+		assignees = slices.Compact(assignees)
+		if len(assignees) == 0 {
+			return task.ErrNoAssignees
+		}
+		t.Assignees = assignees
+	}
+
+	return nil
+}
+
 func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
-	return s.repo.DeleteByID(ctx, id)
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.DeleteByID(ctx, id); err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, audit.EntityTask, id, audit.ActionDelete, before, nil); err != nil {
+			return err
+		}
+		return s.emit(ctx, event.TaskDeleted, before)
+	})
+}
+
+func (s *Service) emit(ctx context.Context, eventType string, t *task.Task) error {
+	e, err := event.NewTaskEvent(eventType, t)
+	if err != nil {
+		return err
+	}
+	return s.events.Emit(ctx, e)
 }
@@ -0,0 +1,127 @@
+package task
+
+import (
+	"ProjectManagementAPI/internal/domain/event"
+	"ProjectManagementAPI/internal/domain/task"
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// memRepo - задачи в памяти; GetForUpdate считает блокировки.
+type memRepo struct {
+	RepositoryInterface
+	tasks  map[uuid.UUID]task.Task
+	locked int
+}
+
+func (r *memRepo) GetByID(_ context.Context, id uuid.UUID) (*task.Task, error) {
+	t, ok := r.tasks[id]
+	if !ok {
+		return nil, task.ErrTaskNotFound
+	}
+	return &t, nil
+}
+
+func (r *memRepo) GetForUpdate(ctx context.Context, id uuid.UUID) (*task.Task, error) {
+	r.locked++
+	return r.GetByID(ctx, id)
+}
+
+func (r *memRepo) Update(_ context.Context, t *task.Task) error {
+	r.tasks[t.ID] = *t
+	return nil
+}
+
+type passTx struct{}
+
+func (passTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type noAudit struct{}
+
+func (noAudit) Record(context.Context, string, uuid.UUID, string, any, any) error {
+	return nil
+}
+
+type events []event.Event
+
+func (e *events) Emit(_ context.Context, ev event.Event) error {
+	*e = append(*e, ev)
+	return nil
+}
+
+func newService(tasks ...task.Task) (*Service, *memRepo, *events) {
+	repo := &memRepo{tasks: map[uuid.UUID]task.Task{}}
+	for _, t := range tasks {
+		repo.tasks[t.ID] = t
+	}
+	emitted := &events{}
+	return &Service{repo: repo, tx: passTx{}, audit: noAudit{}, events: emitted}, repo, emitted
+}
+
+func (e *events) updated(t *testing.T) event.TaskPayload {
+	t.Helper()
+
+	for _, ev := range *e {
+		if ev.Type == event.TaskUpdated {
+			var p event.TaskPayload
+			if err := json.Unmarshal(ev.Payload, &p); err != nil {
+				t.Fatal(err)
+			}
+			return p
+		}
+	}
+	t.Fatal("no task.updated event")
+	return event.TaskPayload{}
+}
+
+func TestAddAssigneesReportsAddedOnly(t *testing.T) {
+	old, added := uuid.New(), uuid.New()
+	tk := task.Task{ID: uuid.New(), Title: "Task", Status: task.StatusTodo, Assignees: []uuid.UUID{old}}
+	svc, repo, emitted := newService(tk)
+
+	if _, err := svc.AddAssignees(context.Background(), tk.ID, []uuid.UUID{old, added}); err != nil {
+		t.Fatalf("AddAssignees: %v", err)
+	}
+
+	if got := emitted.updated(t).AddedAssignees; !slices.Equal(got, []string{added.String()}) {
+		t.Errorf("added_assignees = %v, want [%s]", got, added)
+	}
+	if repo.locked != 1 {
+		t.Errorf("task read without lock")
+	}
+}
+
+func TestReassignReportsNewAssignees(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	tk := task.Task{ID: uuid.New(), Title: "Task", Status: task.StatusTodo, Assignees: []uuid.UUID{a, b}}
+	svc, _, emitted := newService(tk)
+
+	assignees := []uuid.UUID{b, c}
+	if _, err := svc.Update(context.Background(), tk.ID, task.Patch{Assignees: &assignees}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	if got := emitted.updated(t).AddedAssignees; !slices.Equal(got, []string{c.String()}) {
+		t.Errorf("added_assignees = %v, want [%s]", got, c)
+	}
+}
+
+func TestUpdateWithoutAssigneeChange(t *testing.T) {
+	tk := task.Task{ID: uuid.New(), Title: "Task", Status: task.StatusTodo, Assignees: []uuid.UUID{uuid.New()}}
+	svc, _, emitted := newService(tk)
+
+	title := "Renamed"
+	if _, err := svc.Update(context.Background(), tk.ID, task.Patch{Title: &title}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	if got := emitted.updated(t).AddedAssignees; len(got) != 0 {
+		t.Errorf("added_assignees = %v, want none", got)
+	}
+}
@@ -0,0 +1,483 @@
+package transfer
+
+import (
+	"ProjectManagementAPI/internal/domain/milestone"
+	"ProjectManagementAPI/internal/domain/project"
+	"ProjectManagementAPI/internal/domain/task"
+	"ProjectManagementAPI/internal/domain/transfer"
+	"ProjectManagementAPI/internal/domain/user"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// exportBatch - сколько пользователей читается за один запрос при экспорте.
+const exportBatch = 500
+
+type RepositoryInterface interface {
+	Users(ctx context.Context, refs []string) ([]transfer.Ref, error)
+	Projects(ctx context.Context, refs []string) ([]transfer.Ref, error)
+	Milestones(ctx context.Context, refs []string) ([]transfer.Ref, error)
+	Emails(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]string, error)
+	UsersAfter(ctx context.Context, after uuid.UUID, limit int) ([]*user.User, error)
+}
+
+type Tasks interface {
+	Create(ctx context.Context, t *task.Task) (uuid.UUID, error)
+	Each(ctx context.Context, q string, fn func(tasks []*task.Task) error) error
+}
+
+type Users interface {
+	Create(ctx context.Context, email, name string) (uuid.UUID, error)
+}
+
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Config struct {
+	MaxRows int
+}
+
+type Service struct {
+	repo  RepositoryInterface
+	tasks Tasks
+	users Users
+	tx    Transactor
+	cfg   Config
+}
+
+func NewTransferService(repo RepositoryInterface, tasks Tasks, users Users, tx Transactor, cfg Config) *Service {
+	return &Service{
+		repo:  repo,
+		tasks: tasks,
+		users: users,
+		tx:    tx,
+		cfg:   cfg,
+	}
+}
+
+var (
+	// errRollback откатывает транзакцию пробного импорта
+	errRollback = errors.New("dry run")
+	// errRowFailed откатывает импорт, если строка не создалась
+	errRowFailed = errors.New("row failed")
+)
+
+type taskRow struct {
+	row       int
+	task      *task.Task
+	assignees []string
+	project   string
+	milestone string
+}
+
+// ImportTasks создаёт задачи из записей файла. Сначала проверяются все
+// строки и разрешаются ссылки на пользователей, проекты и вехи; задачи
+// создаются одной транзакцией, только если ошибок нет.
+func (s *Service) ImportTasks(ctx context.Context, records []transfer.Record, opts transfer.Options) (*transfer.Report, error) {
+	if err := s.checkSize(records); err != nil {
+		return nil, err
+	}
+	column, err := opts.Columns(transfer.TaskFields)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &transfer.Report{Rows: len(records), DryRun: opts.DryRun}
+	rows := make([]taskRow, len(records))
+	for i, rec := range records {
+		rows[i] = parseTask(rec, column, report)
+	}
+
+	if err := s.resolveTasks(ctx, rows, report); err != nil {
+		return nil, err
+	}
+	if failed(report) {
+		return report, nil
+	}
+
+	err = s.commit(ctx, report, func(ctx context.Context) error {
+		for _, r := range rows {
+			id, err := s.tasks.Create(ctx, r.task)
+			if err != nil {
+				return rowFailed(report, r.row, err)
+			}
+			report.Created = append(report.Created, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func parseTask(rec transfer.Record, column func(string) string, report *transfer.Report) taskRow {
+	v := values(rec, column, report)
+	fail := func(field, msg string) {
+		report.Errors = append(report.Errors, transfer.RowError{Row: rec.Row, Field: field, Message: msg})
+	}
+
+	r := taskRow{
+		row: rec.Row,
+		task: &task.Task{
+			Title:       v[transfer.FieldTitle],
+			Description: v[transfer.FieldDescription],
+			Status:      v[transfer.FieldStatus],
+		},
+		assignees: transfer.SplitList(v[transfer.FieldAssignees]),
+		project:   v[transfer.FieldProject],
+		milestone: v[transfer.FieldMilestone],
+	}
+
+	if r.task.Title == "" {
+		fail(transfer.FieldTitle, "is required")
+	}
+	if r.task.Status == "" {
+		r.task.Status = task.StatusTodo
+	}
+	if !task.ValidStatus(r.task.Status) {
+		fail(transfer.FieldStatus, "must be one of todo, in_progress, done")
+	}
+	if sp := v[transfer.FieldStoryPoints]; sp != "" {
+		n, err := strconv.Atoi(sp)
+		if err != nil || n < 0 {
+			fail(transfer.FieldStoryPoints, "must be a non-negative integer")
+		}
+		r.task.StoryPoints = n
+	}
+	if due := v[transfer.FieldDueAt]; due != "" {
+		t, err := parseTime(due)
+		if err != nil {
+			fail(transfer.FieldDueAt, "must be RFC 3339 time or YYYY-MM-DD")
+		}
+		r.task.DueAt = &t
+	}
+	labels, err := task.NormalizeLabels(transfer.SplitList(v[transfer.FieldLabels]))
+	if err != nil {
+		fail(transfer.FieldLabels, err.Error())
+	}
+	r.task.Labels = labels
+	if len(r.assignees) == 0 {
+		fail(transfer.FieldAssignees, "at least one assignee is required")
+	}
+
+	return r
+}
+
+// values переводит колонки записи в поля. Несколько колонок на одно поле -
+// ошибка строки, иначе победила бы случайная.
+func values(rec transfer.Record, column func(string) string, report *transfer.Report) map[string]string {
+	res := make(map[string]string, len(rec.Values))
+	for k, val := range rec.Values {
+		f := column(k)
+		if f == "" {
+			continue
+		}
+		if _, dup := res[f]; dup {
+			report.Errors = append(report.Errors, transfer.RowError{
+				Row: rec.Row, Field: f, Message: "several columns map to this field",
+			})
+		}
+		res[f] = strings.TrimSpace(val)
+	}
+	return res
+}
+
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation(time.DateOnly, s, time.Local)
+}
+
+// resolveTasks заменяет email, названия и UUID из файла на id существующих
+// записей, по одному запросу на каждый вид ссылок.
+func (s *Service) resolveTasks(ctx context.Context, rows []taskRow, report *transfer.Report) error {
+	var userRefs, projectRefs, milestoneRefs []string
+	for _, r := range rows {
+		userRefs = append(userRefs, r.assignees...)
+		if r.project != "" {
+			projectRefs = append(projectRefs, r.project)
+		}
+		if r.milestone != "" {
+			milestoneRefs = append(milestoneRefs, r.milestone)
+		}
+	}
+
+	users, err := lookup(ctx, s.repo.Users, userRefs)
+	if err != nil {
+		return err
+	}
+	projects, err := lookup(ctx, s.repo.Projects, projectRefs)
+	if err != nil {
+		return err
+	}
+	milestones, err := lookup(ctx, s.repo.Milestones, milestoneRefs)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range rows {
+		fail := func(field string, err error) {
+			report.Errors = append(report.Errors, transfer.RowError{Row: r.row, Field: field, Message: err.Error()})
+		}
+
+		for _, ref := range r.assignees {
+			id, err := users.resolve("user", ref)
+			if err != nil {
+				fail(transfer.FieldAssignees, err)
+				continue
+			}
+			// Один человек может быть указан и email, и UUID
+			if !slices.Contains(r.task.Assignees, id) {
+				r.task.Assignees = append(r.task.Assignees, id)
+			}
+		}
+		if r.project != "" {
+			id, err := projects.resolve("project", r.project)
+			if err != nil {
+				fail(transfer.FieldProject, err)
+			}
+			r.task.ProjectID = &id
+		}
+		if r.milestone != "" {
+			id, err := milestones.resolve("milestone", r.milestone)
+			if err != nil {
+				fail(transfer.FieldMilestone, err)
+			}
+			r.task.MilestoneID = &id
+		}
+	}
+
+	return nil
+}
+
+type index struct {
+	ids  map[uuid.UUID]bool
+	keys map[string][]uuid.UUID
+}
+
+func lookup(ctx context.Context, find func(context.Context, []string) ([]transfer.Ref, error), refs []string) (*index, error) {
+	found, err := find(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	ix := &index{ids: make(map[uuid.UUID]bool), keys: make(map[string][]uuid.UUID)}
+	for _, ref := range found {
+		ix.ids[ref.ID] = true
+		ix.keys[ref.Key] = append(ix.keys[ref.Key], ref.ID)
+	}
+	return ix, nil
+}
+
+// resolve ищет ссылку сначала как UUID, затем как имя без учёта регистра.
+// Имена проектов и вех не уникальны: при совпадении нескольких нужен UUID.
+func (ix *index) resolve(kind, ref string) (uuid.UUID, error) {
+	if id, err := uuid.Parse(ref); err == nil {
+		if ix.ids[id] {
+			return id, nil
+		}
+		return uuid.Nil, fmt.Errorf("%s %s not found", kind, ref)
+	}
+
+	switch ids := ix.keys[strings.ToLower(ref)]; len(ids) {
+	case 0:
+		return uuid.Nil, fmt.Errorf("%s %q not found", kind, ref)
+	case 1:
+		return ids[0], nil
+	default:
+		return uuid.Nil, fmt.Errorf("several %ss match %q, use id", kind, ref)
+	}
+}
+
+// ImportUsers создаёт пользователей из записей файла по тем же правилам,
+// что и ImportTasks. Email не должен повторяться ни в файле, ни в базе.
+func (s *Service) ImportUsers(ctx context.Context, records []transfer.Record, opts transfer.Options) (*transfer.Report, error) {
+	if err := s.checkSize(records); err != nil {
+		return nil, err
+	}
+	column, err := opts.Columns(transfer.UserFields)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &transfer.Report{Rows: len(records), DryRun: opts.DryRun}
+	users := make([]user.User, len(records))
+	rowOf := make(map[string]int, len(records))
+	emails := make([]string, 0, len(records))
+	for i, rec := range records {
+		v := values(rec, column, report)
+		fail := func(field, msg string) {
+			report.Errors = append(report.Errors, transfer.RowError{Row: rec.Row, Field: field, Message: msg})
+		}
+
+		users[i] = user.User{Email: v[transfer.FieldEmail], Name: v[transfer.FieldName]}
+		if users[i].Name == "" {
+			fail(transfer.FieldName, "is required")
+		}
+		if users[i].Email == "" {
+			fail(transfer.FieldEmail, "is required")
+			continue
+		}
+		if addr, err := mail.ParseAddress(users[i].Email); err != nil || addr.Address != users[i].Email {
+			fail(transfer.FieldEmail, "is not a valid email")
+			continue
+		}
+
+		key := strings.ToLower(users[i].Email)
+		if first, ok := rowOf[key]; ok {
+			fail(transfer.FieldEmail, fmt.Sprintf("duplicates row %d", first))
+			continue
+		}
+		rowOf[key] = rec.Row
+		emails = append(emails, users[i].Email)
+	}
+
+	existing, err := s.repo.Users(ctx, emails)
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range existing {
+		if row, ok := rowOf[ref.Key]; ok {
+			report.Errors = append(report.Errors, transfer.RowError{
+				Row: row, Field: transfer.FieldEmail, Message: user.ErrEmailAlreadyExists.Error(),
+			})
+		}
+	}
+	if failed(report) {
+		return report, nil
+	}
+
+	err = s.commit(ctx, report, func(ctx context.Context) error {
+		for i, u := range users {
+			id, err := s.users.Create(ctx, u.Email, u.Name)
+			if err != nil {
+				return rowFailed(report, records[i].Row, err)
+			}
+			report.Created = append(report.Created, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// failed упорядочивает ошибки по строкам и сообщает, есть ли они.
+func failed(report *transfer.Report) bool {
+	slices.SortStableFunc(report.Errors, func(a, b transfer.RowError) int { return cmp.Compare(a.Row, b.Row) })
+	return len(report.Errors) > 0
+}
+
+func (s *Service) checkSize(records []transfer.Record) error {
+	if len(records) == 0 {
+		return transfer.ErrNoRows
+	}
+	if s.cfg.MaxRows > 0 && len(records) > s.cfg.MaxRows {
+		return fmt.Errorf("%w: at most %d", transfer.ErrTooManyRows, s.cfg.MaxRows)
+	}
+	return nil
+}
+
+// commit выполняет fn в транзакции. Пробный импорт проходит те же шаги,
+// включая ограничения базы, и откатывается в конце.
+func (s *Service) commit(ctx context.Context, report *transfer.Report, fn func(ctx context.Context) error) error {
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := fn(ctx); err != nil {
+			return err
+		}
+		if report.DryRun {
+			return errRollback
+		}
+		return nil
+	})
+
+	switch {
+	case errors.Is(err, errRollback), errors.Is(err, errRowFailed):
+		report.Created = nil
+		return nil
+	case err != nil:
+		return err
+	}
+
+	report.Committed = true
+	return nil
+}
+
+// rowFailed превращает ошибку создания в ошибку строки, если она вызвана
+// данными строки; прочие ошибки прерывают импорт.
+func rowFailed(report *transfer.Report, row int, err error) error {
+	for _, known := range []error{
+		task.ErrInvalidTitle, task.ErrNoAssignees, task.ErrInvalidStatus, task.ErrInvalidStoryPoints,
+		task.ErrInvalidLabel, task.ErrTooManyLabels, task.ErrAssigneeNotFound, user.ErrEmailAlreadyExists,
+		project.ErrProjectNotFound, milestone.ErrMilestoneNotFound,
+	} {
+		if errors.Is(err, known) {
+			report.Errors = append(report.Errors, transfer.RowError{Row: row, Message: err.Error()})
+			return errRowFailed
+		}
+	}
+	return err
+}
+
+// ExportTasks передаёт в fn задачи по запросу на языке фильтров порциями,
+// с email исполнителей вместо их id.
+func (s *Service) ExportTasks(ctx context.Context, q string, fn func(rows []transfer.TaskRow) error) error {
+	return s.tasks.Each(ctx, q, func(tasks []*task.Task) error {
+		var ids []uuid.UUID
+		for _, t := range tasks {
+			ids = append(ids, t.Assignees...)
+		}
+		emails, err := s.repo.Emails(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		rows := make([]transfer.TaskRow, len(tasks))
+		for i, t := range tasks {
+			rows[i] = transfer.TaskRow{Task: t, Assignees: make([]string, len(t.Assignees))}
+			for j, id := range t.Assignees {
+				if e, ok := emails[id]; ok {
+					rows[i].Assignees[j] = e
+				} else {
+					rows[i].Assignees[j] = id.String()
+				}
+			}
+		}
+		return fn(rows)
+	})
+}
+
+// ExportUsers передаёт в fn всех пользователей порциями по возрастанию id.
+func (s *Service) ExportUsers(ctx context.Context, fn func(users []*user.User) error) error {
+	var after uuid.UUID
+	for {
+		users, err := s.repo.UsersAfter(ctx, after, exportBatch)
+		if err != nil {
+			return err
+		}
+		if len(users) > 0 {
+			if err := fn(users); err != nil {
+				return err
+			}
+		}
+		if len(users) < exportBatch {
+			return nil
+		}
+		after = users[len(users)-1].ID
+	}
+}
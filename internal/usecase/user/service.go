@@ -1,6 +1,8 @@
 package user
 
 import (
+	"ProjectManagementAPI/internal/domain/audit"
+	"ProjectManagementAPI/internal/domain/event"
 	"ProjectManagementAPI/internal/domain/user"
 	"context"
 
@@ -10,15 +12,36 @@ import (
 type RepositoryInterface interface {
 	Create(ctx context.Context, u *user.User) error
 	GetByID(ctx context.Context, id uuid.UUID) (*user.User, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*user.User, error)
 	DeleteByID(ctx context.Context, id uuid.UUID) error
 }
 
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, entityType string, entityID uuid.UUID, action string, before, after any) error
+}
+
+type EventEmitter interface {
+	Emit(ctx context.Context, e event.Event) error
+}
+
 type Service struct {
-	repo RepositoryInterface
+	repo   RepositoryInterface
+	tx     Transactor
+	audit  AuditRecorder
+	events EventEmitter
 }
 
-func NewUserService(repo RepositoryInterface) *Service {
-	return &Service{repo: repo}
+func NewUserService(repo RepositoryInterface, tx Transactor, audit AuditRecorder, events EventEmitter) *Service {
+	return &Service{
+		repo:   repo,
+		tx:     tx,
+		audit:  audit,
+		events: events,
+	}
 }
 
 func (s *Service) Create(ctx context.Context, email, name string) (uuid.UUID, error) {
@@ -28,7 +51,16 @@ func (s *Service) Create(ctx context.Context, email, name string) (uuid.UUID, er
 		Name:  name,
 	}
 
-	if err := s.repo.Create(ctx, u); err != nil {
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, u); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, audit.EntityUser, u.ID, audit.ActionCreate, nil, u); err != nil {
+			return err
+		}
+		return s.emit(ctx, event.UserCreated, u)
+	})
+	if err != nil {
 		return uuid.Nil, err
 	}
 
@@ -39,6 +71,33 @@ func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (*user.User, error)
 	return s.repo.GetByID(ctx, id)
 }
 
+// GetByIDs возвращает найденных пользователей в произвольном порядке, отсутствующие пропускаются.
+func (s *Service) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*user.User, error) {
+	return s.repo.GetByIDs(ctx, ids)
+}
+
 func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
-	return s.repo.DeleteByID(ctx, id)
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.DeleteByID(ctx, id); err != nil {
+			return err
+		}
+
+		if err := s.audit.Record(ctx, audit.EntityUser, id, audit.ActionDelete, before, nil); err != nil {
+			return err
+		}
+		return s.emit(ctx, event.UserDeleted, before)
+	})
+}
+
+func (s *Service) emit(ctx context.Context, eventType string, u *user.User) error {
+	e, err := event.NewUserEvent(eventType, u)
+	if err != nil {
+		return err
+	}
+	return s.events.Emit(ctx, e)
 }
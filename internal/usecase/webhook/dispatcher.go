@@ -0,0 +1,144 @@
+package webhook
+
+import (
+	"ProjectManagementAPI/internal/domain/webhook"
+	"ProjectManagementAPI/internal/lib/backoff"
+	"ProjectManagementAPI/internal/lib/logger/sl"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+type DeliveryQueue interface {
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]webhook.PendingDelivery, error)
+	MarkSucceeded(ctx context.Context, id int64, responseCode int) error
+	MarkFailed(ctx context.Context, id int64, responseCode int, lastError string, retryIn time.Duration, dead bool) error
+}
+
+type DispatcherConfig struct {
+	BatchSize   int
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+type Dispatcher struct {
+	log    *slog.Logger
+	queue  DeliveryQueue
+	client *http.Client
+	cfg    DispatcherConfig
+}
+
+func NewDispatcher(log *slog.Logger, queue DeliveryQueue, client *http.Client, cfg DispatcherConfig) *Dispatcher {
+	return &Dispatcher{
+		log:    log.With(slog.String("component", "webhook/dispatcher")),
+		queue:  queue,
+		client: client,
+		cfg:    cfg,
+	}
+}
+
+// leaseMargin - запас аренды сверх таймаута запроса на отметку результата.
+const leaseMargin = 10 * time.Second
+
+// DispatchDue отправляет до BatchSize готовых доставок, вызывается
+// периодическим заданием webhooks.dispatch. Доставки забираются
+// по одной: аренда покрывает один запрос, и медленный подписчик не даёт
+// другому экземпляру забрать ещё не отправленные доставки пачки.
+func (d *Dispatcher) DispatchDue(ctx context.Context) error {
+	for range d.cfg.BatchSize {
+		deliveries, err := d.queue.ClaimDue(ctx, 1, d.client.Timeout+leaseMargin)
+		if err != nil {
+			return err
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		d.deliver(ctx, deliveries[0])
+	}
+
+	return nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery webhook.PendingDelivery) {
+	log := d.log.With(
+		slog.Int64("delivery_id", delivery.ID),
+		slog.String("event_type", delivery.EventType),
+	)
+
+	code, err := d.send(ctx, delivery)
+	if err == nil {
+		if err := d.queue.MarkSucceeded(ctx, delivery.ID, code); err != nil {
+			log.Error("failed to mark delivery succeeded", sl.Err(err))
+		}
+		return
+	}
+
+	attempts := delivery.Attempts + 1
+	dead := attempts >= d.cfg.MaxAttempts
+	retryIn := backoff.Exponential(attempts, d.cfg.BaseBackoff, d.cfg.MaxBackoff)
+
+	log.Warn("delivery failed",
+		sl.Err(err),
+		slog.Int("attempts", attempts),
+		slog.Bool("dead", dead),
+	)
+
+	if err := d.queue.MarkFailed(ctx, delivery.ID, code, err.Error(), retryIn, dead); err != nil {
+		log.Error("failed to mark delivery failed", sl.Err(err))
+	}
+}
+
+func (d *Dispatcher) send(ctx context.Context, delivery webhook.PendingDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, delivery.EventID.String())
+	req.Header.Set(HeaderTimestamp, ts)
+	req.Header.Set(HeaderSignature, "sha256="+Sign(delivery.Secret, ts, delivery.Payload))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
+
+// Sign считает HMAC-SHA256 от "<timestamp>.<body>". Подписчик проверяет
+// подпись тем же секретом и отбрасывает запросы со старым timestamp.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
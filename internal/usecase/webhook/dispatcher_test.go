@@ -0,0 +1,336 @@
+package webhook
+
+import (
+	"ProjectManagementAPI/internal/domain/webhook"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeQueue - очередь из одной доставки: ClaimDue отдаёт её раз за проход,
+// пока она не доставлена и не мертва, время повтора только запоминается.
+type fakeQueue struct {
+	mu        sync.Mutex
+	delivery  webhook.PendingDelivery
+	claimed   bool
+	succeeded bool
+	dead      bool
+	retries   []time.Duration
+	codes     []int
+}
+
+func (q *fakeQueue) ClaimDue(_ context.Context, _ int, _ time.Duration) ([]webhook.PendingDelivery, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.succeeded || q.dead || q.claimed {
+		return nil, nil
+	}
+	q.claimed = true
+	return []webhook.PendingDelivery{q.delivery}, nil
+}
+
+// dispatch - проход диспетчера после того, как время повтора наступило.
+func dispatch(t *testing.T, d *Dispatcher, q *fakeQueue) {
+	t.Helper()
+
+	q.mu.Lock()
+	q.claimed = false
+	q.mu.Unlock()
+
+	if err := d.DispatchDue(context.Background()); err != nil {
+		t.Fatalf("DispatchDue: %v", err)
+	}
+}
+
+func (q *fakeQueue) MarkSucceeded(_ context.Context, _ int64, code int) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.succeeded = true
+	q.codes = append(q.codes, code)
+	return nil
+}
+
+func (q *fakeQueue) MarkFailed(_ context.Context, _ int64, code int, _ string, retryIn time.Duration, dead bool) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.delivery.Attempts++
+	q.dead = dead
+	q.retries = append(q.retries, retryIn)
+	q.codes = append(q.codes, code)
+	return nil
+}
+
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+// newServer отвечает статусами из codes по очереди, дальше - 200.
+func newServer(t *testing.T, codes ...int) (*httptest.Server, func() []received) {
+	t.Helper()
+
+	var (
+		mu   sync.Mutex
+		reqs []received
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		reqs = append(reqs, received{header: r.Header.Clone(), body: body})
+		n := len(reqs)
+		mu.Unlock()
+
+		if n <= len(codes) {
+			w.WriteHeader(codes[n-1])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, func() []received {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]received(nil), reqs...)
+	}
+}
+
+func newDispatcher(queue DeliveryQueue, maxAttempts int) *Dispatcher {
+	return NewDispatcher(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		queue,
+		&http.Client{Timeout: time.Second},
+		DispatcherConfig{
+			BatchSize:   10,
+			MaxAttempts: maxAttempts,
+			BaseBackoff: time.Second,
+			MaxBackoff:  5 * time.Second,
+		},
+	)
+}
+
+func newDelivery(url string) webhook.PendingDelivery {
+	return webhook.PendingDelivery{
+		Delivery: webhook.Delivery{
+			ID:        1,
+			EventID:   uuid.New(),
+			EventType: "task.created",
+			Payload:   []byte(`{"id":"42"}`),
+		},
+		URL:    url,
+		Secret: "s3cret",
+	}
+}
+
+func TestDispatcherSignsRequest(t *testing.T) {
+	srv, reqs := newServer(t)
+	queue := &fakeQueue{delivery: newDelivery(srv.URL)}
+
+	dispatch(t, newDispatcher(queue, 3), queue)
+
+	got := reqs()
+	if len(got) != 1 {
+		t.Fatalf("requests = %d, want 1", len(got))
+	}
+	h := got[0].header
+
+	if h.Get(HeaderEvent) != "task.created" {
+		t.Errorf("%s = %q", HeaderEvent, h.Get(HeaderEvent))
+	}
+	if h.Get(HeaderDelivery) != queue.delivery.EventID.String() {
+		t.Errorf("%s = %q, want %s", HeaderDelivery, h.Get(HeaderDelivery), queue.delivery.EventID)
+	}
+	if _, err := strconv.ParseInt(h.Get(HeaderTimestamp), 10, 64); err != nil {
+		t.Errorf("%s = %q: %v", HeaderTimestamp, h.Get(HeaderTimestamp), err)
+	}
+
+	want := "sha256=" + Sign("s3cret", h.Get(HeaderTimestamp), got[0].body)
+	if h.Get(HeaderSignature) != want {
+		t.Errorf("%s = %q, want %q", HeaderSignature, h.Get(HeaderSignature), want)
+	}
+	if string(got[0].body) != `{"id":"42"}` {
+		t.Errorf("body = %s", got[0].body)
+	}
+	if !queue.succeeded || queue.codes[0] != http.StatusOK {
+		t.Errorf("succeeded = %v, codes = %v", queue.succeeded, queue.codes)
+	}
+}
+
+func TestSign(t *testing.T) {
+	// printf '1700000000.{"id":"42"}' | openssl dgst -sha256 -hmac s3cret
+	const want = "041737741a580850c2cce0662af5e9827297958cdcd253eda0a5d56df3ca89dd"
+
+	if got := Sign("s3cret", "1700000000", []byte(`{"id":"42"}`)); got != want {
+		t.Errorf("Sign = %q, want %q", got, want)
+	}
+}
+
+func TestDispatcherRetriesWithBackoff(t *testing.T) {
+	srv, reqs := newServer(t, http.StatusInternalServerError, http.StatusBadGateway)
+	queue := &fakeQueue{delivery: newDelivery(srv.URL)}
+	d := newDispatcher(queue, 5)
+
+	for range 3 {
+		dispatch(t, d, queue)
+	}
+
+	if n := len(reqs()); n != 3 {
+		t.Fatalf("requests = %d, want 3", n)
+	}
+	if !queue.succeeded || queue.dead {
+		t.Fatalf("succeeded = %v, dead = %v", queue.succeeded, queue.dead)
+	}
+
+	wantRetries := []time.Duration{time.Second, 2 * time.Second}
+	if !slices.Equal(queue.retries, wantRetries) {
+		t.Errorf("retries = %v, want %v", queue.retries, wantRetries)
+	}
+	wantCodes := []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK}
+	if !slices.Equal(queue.codes, wantCodes) {
+		t.Errorf("codes = %v, want %v", queue.codes, wantCodes)
+	}
+}
+
+func TestDispatcherDeadLettersAfterMaxAttempts(t *testing.T) {
+	srv, reqs := newServer(t,
+		http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError,
+		http.StatusInternalServerError, http.StatusInternalServerError)
+	queue := &fakeQueue{delivery: newDelivery(srv.URL)}
+	d := newDispatcher(queue, 4)
+
+	for range 6 {
+		dispatch(t, d, queue)
+	}
+
+	if n := len(reqs()); n != 4 {
+		t.Fatalf("requests = %d, want 4", n)
+	}
+	if !queue.dead || queue.succeeded {
+		t.Fatalf("dead = %v, succeeded = %v", queue.dead, queue.succeeded)
+	}
+
+	// задержка растёт вдвое и упирается в MaxBackoff
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}
+	if !slices.Equal(queue.retries, want) {
+		t.Errorf("retries = %v, want %v", queue.retries, want)
+	}
+}
+
+func TestDispatcherNetworkErrorIsRetried(t *testing.T) {
+	srv, _ := newServer(t)
+	url := srv.URL
+	srv.Close()
+
+	queue := &fakeQueue{delivery: newDelivery(url)}
+
+	dispatch(t, newDispatcher(queue, 3), queue)
+
+	if queue.succeeded || queue.dead {
+		t.Fatalf("succeeded = %v, dead = %v", queue.succeeded, queue.dead)
+	}
+	if len(queue.codes) != 1 || queue.codes[0] != 0 {
+		t.Errorf("codes = %v, want [0]", queue.codes)
+	}
+}
+
+// leaseQueue - несколько доставок с арендой по реальным часам: ClaimDue
+// отдаёт только доставки с истёкшей арендой, отметка после её конца
+// значит, что другой экземпляр уже мог отправить доставку повторно.
+type leaseQueue struct {
+	mu       sync.Mutex
+	pending  []webhook.PendingDelivery
+	leased   map[int64]time.Time
+	done     map[int64]bool
+	overdue  []int64
+	maxClaim int
+}
+
+func (q *leaseQueue) ClaimDue(_ context.Context, limit int, lease time.Duration) ([]webhook.PendingDelivery, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	var res []webhook.PendingDelivery
+	for _, d := range q.pending {
+		if len(res) == limit {
+			break
+		}
+		if q.done[d.ID] || now.Before(q.leased[d.ID]) {
+			continue
+		}
+		q.leased[d.ID] = now.Add(lease)
+		res = append(res, d)
+	}
+	q.maxClaim = max(q.maxClaim, len(res))
+	return res, nil
+}
+
+func (q *leaseQueue) mark(id int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if time.Now().After(q.leased[id]) {
+		q.overdue = append(q.overdue, id)
+	}
+	q.done[id] = true
+}
+
+func (q *leaseQueue) MarkSucceeded(_ context.Context, id int64, _ int) error {
+	q.mark(id)
+	return nil
+}
+
+func (q *leaseQueue) MarkFailed(_ context.Context, id int64, _ int, _ string, _ time.Duration, _ bool) error {
+	q.mark(id)
+	return nil
+}
+
+func TestDispatcherSlowReceiverStaysWithinLease(t *testing.T) {
+	const timeout = 300 * time.Millisecond
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(timeout * 3 / 4)
+	}))
+	t.Cleanup(srv.Close)
+
+	queue := &leaseQueue{leased: map[int64]time.Time{}, done: map[int64]bool{}}
+	for i := range 4 {
+		d := newDelivery(srv.URL)
+		d.ID = int64(i + 1)
+		queue.pending = append(queue.pending, d)
+	}
+
+	d := NewDispatcher(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		queue,
+		&http.Client{Timeout: timeout},
+		DispatcherConfig{BatchSize: 10, MaxAttempts: 3, BaseBackoff: time.Second, MaxBackoff: time.Minute},
+	)
+	if err := d.DispatchDue(context.Background()); err != nil {
+		t.Fatalf("DispatchDue: %v", err)
+	}
+
+	if len(queue.done) != 4 {
+		t.Errorf("delivered = %d, want 4", len(queue.done))
+	}
+	if len(queue.overdue) > 0 {
+		t.Errorf("deliveries %v finished after their lease expired", queue.overdue)
+	}
+	if queue.maxClaim != 1 {
+		t.Errorf("claimed %d deliveries at once, want 1", queue.maxClaim)
+	}
+}
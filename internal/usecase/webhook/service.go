@@ -0,0 +1,110 @@
+package webhook
+
+import (
+	"ProjectManagementAPI/internal/domain/event"
+	"ProjectManagementAPI/internal/domain/webhook"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 500
+)
+
+type RepositoryInterface interface {
+	CreateSubscription(ctx context.Context, s *webhook.Subscription) error
+	GetSubscription(ctx context.Context, id uuid.UUID) (*webhook.Subscription, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	Enqueue(ctx context.Context, e event.Event) error
+	ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit, offset int) ([]webhook.Delivery, error)
+	Redeliver(ctx context.Context, subscriptionID uuid.UUID, id int64) error
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+type Service struct {
+	repo RepositoryInterface
+}
+
+func NewWebhookService(repo RepositoryInterface) *Service {
+	return &Service{repo: repo}
+}
+
+// Create регистрирует подписку. Если секрет не передан, он генерируется.
+func (s *Service) Create(ctx context.Context, rawURL, secret string, eventTypes []string) (*webhook.Subscription, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, webhook.ErrInvalidURL
+	}
+
+	for _, t := range eventTypes {
+		if !event.ValidType(t) {
+			return nil, webhook.ErrInvalidEventType
+		}
+	}
+
+	if secret == "" {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+		secret = hex.EncodeToString(buf)
+	}
+
+	sub := &webhook.Subscription{
+		ID:         uuid.New(),
+		URL:        rawURL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Active:     true,
+	}
+
+	if err := s.repo.CreateSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (*webhook.Subscription, error) {
+	return s.repo.GetSubscription(ctx, id)
+}
+
+func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.repo.DeleteSubscription(ctx, id)
+}
+
+func (s *Service) Deliveries(ctx context.Context, subscriptionID uuid.UUID, limit, offset int) ([]webhook.Delivery, error) {
+	if _, err := s.repo.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	return s.repo.ListDeliveries(ctx, subscriptionID, limit, offset)
+}
+
+func (s *Service) Redeliver(ctx context.Context, subscriptionID uuid.UUID, deliveryID int64) error {
+	return s.repo.Redeliver(ctx, subscriptionID, deliveryID)
+}
+
+// Purge удаляет завершённые доставки старше retention: успешные и из dead-letter.
+func (s *Service) Purge(ctx context.Context, retention time.Duration) (int64, error) {
+	return s.repo.Purge(ctx, time.Now().Add(-retention))
+}
+
+// Handle ставит событие в очередь доставки подписчикам.
+// Доставки уникальны по (подписка, событие), поэтому повторный вызов безопасен.
+func (s *Service) Handle(ctx context.Context, e event.Event) error {
+	return s.repo.Enqueue(ctx, e)
+}
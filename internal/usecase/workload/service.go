@@ -0,0 +1,166 @@
+package workload
+
+import (
+	"ProjectManagementAPI/internal/domain/task"
+	"ProjectManagementAPI/internal/domain/workload"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// defaultWeeks - длина отчёта без to.
+const defaultWeeks = 4
+
+type RepositoryInterface interface {
+	Users(ctx context.Context) ([]workload.User, error)
+	Assignments(ctx context.Context, from, to time.Time) ([]workload.Assignment, error)
+	SetCapacity(ctx context.Context, userID uuid.UUID, capacity *int) error
+}
+
+type Config struct {
+	// DefaultCapacity - недельная ёмкость в story points для пользователей без личной
+	DefaultCapacity int
+	MaxWeeks        int
+}
+
+type Service struct {
+	repo RepositoryInterface
+	cfg  Config
+}
+
+func NewWorkloadService(repo RepositoryInterface, cfg Config) *Service {
+	return &Service{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+// Report строит нагрузку по неделям [from, to). Границы расширяются до целых
+// недель с понедельника; без from отчёт начинается с текущей недели,
+// без to - длится defaultWeeks недель.
+func (s *Service) Report(ctx context.Context, from, to *time.Time) (*workload.Report, error) {
+	start := weekStart(time.Now())
+	if from != nil {
+		start = weekStart(*from)
+	}
+
+	end := start.AddDate(0, 0, 7*defaultWeeks)
+	if to != nil {
+		if end = weekStart(*to); end.Before(*to) {
+			end = end.AddDate(0, 0, 7)
+		}
+	}
+
+	if !start.Before(end) {
+		return nil, workload.ErrInvalidRange
+	}
+	if start.AddDate(0, 0, 7*s.cfg.MaxWeeks).Before(end) {
+		return nil, workload.ErrPeriodTooLong
+	}
+
+	users, err := s.repo.Users(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.repo.Assignments(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return build(start, end, users, assignments, s.cfg.DefaultCapacity), nil
+}
+
+// SetCapacity задаёт личную недельную ёмкость; nil возвращает ёмкость по умолчанию.
+func (s *Service) SetCapacity(ctx context.Context, userID uuid.UUID, capacity *int) error {
+	if capacity != nil && *capacity < 0 {
+		return workload.ErrInvalidCapacity
+	}
+	return s.repo.SetCapacity(ctx, userID, capacity)
+}
+
+// DefaultCapacity - ёмкость пользователей без личной.
+func (s *Service) DefaultCapacity() int {
+	return s.cfg.DefaultCapacity
+}
+
+// build раскладывает задачи по пользователям, статусам и неделям. Оценка задачи
+// делится поровну между исполнителями; пользователь перегружен, если хотя бы
+// на одной неделе оценка незавершённых задач больше ёмкости.
+func build(from, to time.Time, users []workload.User, assignments []workload.Assignment,
+	defaultCapacity int) *workload.Report {
+	report := &workload.Report{From: from, To: to, Users: make([]workload.UserLoad, len(users))}
+
+	// weeks - номер недели по Unix-времени её начала
+	weeks := make(map[int64]int)
+	var starts []time.Time
+	for start := from; start.Before(to); start = start.AddDate(0, 0, 7) {
+		weeks[start.Unix()] = len(starts)
+		starts = append(starts, start)
+	}
+
+	byID := make(map[uuid.UUID]*workload.UserLoad, len(users))
+	for i, u := range users {
+		l := &report.Users[i]
+		l.User = u
+		l.Capacity = defaultCapacity
+		if u.Capacity != nil {
+			l.Capacity = *u.Capacity
+		}
+		l.ByStatus = make(map[string]workload.Load)
+		l.Weeks = make([]workload.Week, len(starts))
+		for w, start := range starts {
+			l.Weeks[w].Start = start
+		}
+		byID[u.ID] = l
+	}
+
+	for _, a := range assignments {
+		l, ok := byID[a.UserID]
+		if !ok {
+			continue
+		}
+
+		effort := float64(a.StoryPoints) / float64(max(a.Assignees, 1))
+
+		s := l.ByStatus[a.Status]
+		add(&s, effort)
+		l.ByStatus[a.Status] = s
+
+		if a.Status == task.StatusDone {
+			continue
+		}
+		if a.DueAt == nil {
+			add(&l.Unscheduled, effort)
+			continue
+		}
+		if w, ok := weeks[weekStart(*a.DueAt).Unix()]; ok {
+			add(&l.Weeks[w].Load, effort)
+		}
+	}
+
+	for i := range report.Users {
+		l := &report.Users[i]
+		for w := range l.Weeks {
+			if l.Weeks[w].Effort > float64(l.Capacity) {
+				l.Weeks[w].Overallocated = true
+				l.Overallocated = true
+			}
+		}
+	}
+
+	return report
+}
+
+func add(l *workload.Load, effort float64) {
+	l.Tasks++
+	l.Effort += effort
+}
+
+// weekStart - полночь понедельника недели t в зоне сервера.
+func weekStart(t time.Time) time.Time {
+	y, m, d := t.Local().Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
@@ -0,0 +1,179 @@
+package workload
+
+import (
+	"ProjectManagementAPI/internal/domain/task"
+	"ProjectManagementAPI/internal/domain/workload"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// date - полдень дня в зоне сервера, в ней считаются недели.
+func date(month time.Month, day int) time.Time {
+	return time.Date(2026, month, day, 12, 0, 0, 0, time.Local)
+}
+
+func TestWeekStart(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	for _, in := range []time.Time{monday, date(3, 2), date(3, 5), date(3, 8), monday.AddDate(0, 0, 7).Add(-time.Nanosecond)} {
+		if got := weekStart(in); !got.Equal(monday) {
+			t.Errorf("weekStart(%v) = %v, want %v", in, got, monday)
+		}
+	}
+	if got := weekStart(date(3, 9)); !got.Equal(monday.AddDate(0, 0, 7)) {
+		t.Errorf("weekStart(9 March) = %v", got)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	from := weekStart(date(3, 2))
+	to := from.AddDate(0, 0, 14)
+
+	five := 5
+	ann := workload.User{ID: uuid.New(), Name: "Ann", Capacity: &five}
+	bob := workload.User{ID: uuid.New(), Name: "Bob"}
+	due := func(month time.Month, day int) *time.Time {
+		d := date(month, day)
+		return &d
+	}
+
+	assignments := []workload.Assignment{
+		// общая задача: по 4 points каждому
+		{UserID: ann.ID, Status: task.StatusTodo, StoryPoints: 8, Assignees: 2, DueAt: due(3, 4)},
+		{UserID: bob.ID, Status: task.StatusTodo, StoryPoints: 8, Assignees: 2, DueAt: due(3, 4)},
+		{UserID: ann.ID, Status: task.StatusInProgress, StoryPoints: 3, Assignees: 1, DueAt: due(3, 6)},
+		{UserID: ann.ID, Status: task.StatusTodo, StoryPoints: 2, Assignees: 1, DueAt: due(3, 10)},
+		{UserID: ann.ID, Status: task.StatusTodo, StoryPoints: 1, Assignees: 1},
+		// выполненные не загружают недели
+		{UserID: ann.ID, Status: task.StatusDone, StoryPoints: 13, Assignees: 1, DueAt: due(3, 11)},
+		// срок вне отчёта учитывается только в статусах
+		{UserID: bob.ID, Status: task.StatusTodo, StoryPoints: 2, Assignees: 1, DueAt: due(4, 20)},
+		// Assignees = 0 не делит на ноль
+		{UserID: bob.ID, Status: task.StatusTodo, StoryPoints: 3, DueAt: due(3, 12)},
+		// неизвестный пользователь пропускается
+		{UserID: uuid.New(), Status: task.StatusTodo, StoryPoints: 100, Assignees: 1, DueAt: due(3, 4)},
+	}
+
+	report := build(from, to, []workload.User{ann, bob}, assignments, 10)
+	if len(report.Users) != 2 {
+		t.Fatalf("users = %d, want 2", len(report.Users))
+	}
+
+	a, b := report.Users[0], report.Users[1]
+	if a.Capacity != 5 || b.Capacity != 10 {
+		t.Errorf("capacity = %d, %d, want 5 and default 10", a.Capacity, b.Capacity)
+	}
+
+	if len(a.Weeks) != 2 || !a.Weeks[0].Start.Equal(from) || !a.Weeks[1].Start.Equal(from.AddDate(0, 0, 7)) {
+		t.Fatalf("weeks = %+v", a.Weeks)
+	}
+	if w := a.Weeks[0]; w.Tasks != 2 || w.Effort != 7 || !w.Overallocated {
+		t.Errorf("ann week 1 = %+v, want 2 tasks, 7 points, overallocated", w)
+	}
+	if w := a.Weeks[1]; w.Tasks != 1 || w.Effort != 2 || w.Overallocated {
+		t.Errorf("ann week 2 = %+v, want 1 task, 2 points", w)
+	}
+	if !a.Overallocated {
+		t.Error("ann is not overallocated")
+	}
+	if a.Unscheduled != (workload.Load{Tasks: 1, Effort: 1}) {
+		t.Errorf("ann unscheduled = %+v", a.Unscheduled)
+	}
+	if got := a.ByStatus[task.StatusTodo]; got != (workload.Load{Tasks: 3, Effort: 7}) {
+		t.Errorf("ann todo = %+v", got)
+	}
+	if got := a.ByStatus[task.StatusDone]; got != (workload.Load{Tasks: 1, Effort: 13}) {
+		t.Errorf("ann done = %+v", got)
+	}
+
+	if b.Weeks[0].Effort != 4 || b.Weeks[1].Effort != 3 || b.Overallocated {
+		t.Errorf("bob weeks = %+v, overallocated %v", b.Weeks, b.Overallocated)
+	}
+	if got := b.ByStatus[task.StatusTodo]; got != (workload.Load{Tasks: 3, Effort: 9}) {
+		t.Errorf("bob todo = %+v", got)
+	}
+}
+
+// Нагрузка, равная ёмкости, - ещё не перегрузка; нулевая ёмкость
+// перегружается любой задачей.
+func TestBuildCapacityBoundary(t *testing.T) {
+	from := weekStart(date(3, 2))
+	zero := 0
+	full := workload.User{ID: uuid.New()}
+	none := workload.User{ID: uuid.New(), Capacity: &zero}
+	d := date(3, 3)
+
+	report := build(from, from.AddDate(0, 0, 7), []workload.User{full, none}, []workload.Assignment{
+		{UserID: full.ID, Status: task.StatusTodo, StoryPoints: 10, Assignees: 1, DueAt: &d},
+		{UserID: none.ID, Status: task.StatusTodo, StoryPoints: 1, Assignees: 3, DueAt: &d},
+	}, 10)
+
+	if report.Users[0].Overallocated {
+		t.Error("load equal to capacity is overallocated")
+	}
+	if !report.Users[1].Overallocated {
+		t.Error("zero capacity is not overallocated")
+	}
+}
+
+type repo struct {
+	RepositoryInterface
+	from, to time.Time
+}
+
+func (r *repo) Users(context.Context) ([]workload.User, error) {
+	return nil, nil
+}
+
+func (r *repo) Assignments(_ context.Context, from, to time.Time) ([]workload.Assignment, error) {
+	r.from, r.to = from, to
+	return nil, nil
+}
+
+func TestReportRange(t *testing.T) {
+	r := &repo{}
+	svc := NewWorkloadService(r, Config{DefaultCapacity: 10, MaxWeeks: 4})
+	ctx := context.Background()
+
+	from, to := date(3, 4), date(3, 17)
+	report, err := svc.Report(ctx, &from, &to)
+	if err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+	// границы расширяются до целых недель
+	if want := weekStart(from); !report.From.Equal(want) || !r.from.Equal(want) {
+		t.Errorf("from = %v, want %v", report.From, want)
+	}
+	if want := weekStart(to).AddDate(0, 0, 7); !report.To.Equal(want) || !r.to.Equal(want) {
+		t.Errorf("to = %v, want %v", report.To, want)
+	}
+
+	// to ровно на понедельник не добавляет неделю
+	monday := weekStart(date(3, 16))
+	if report, err := svc.Report(ctx, &from, &monday); err != nil || !report.To.Equal(monday) {
+		t.Errorf("Report to Monday = %v, %v", report, err)
+	}
+
+	if report, err := svc.Report(ctx, &from, nil); err != nil || !report.To.Equal(weekStart(from).AddDate(0, 0, 7*defaultWeeks)) {
+		t.Errorf("Report without to = %v, %v", report, err)
+	}
+
+	if _, err := svc.Report(ctx, &to, &from); !errors.Is(err, workload.ErrInvalidRange) {
+		t.Errorf("reversed range error = %v", err)
+	}
+	tooLong := from.AddDate(0, 0, 7*5)
+	if _, err := svc.Report(ctx, &from, &tooLong); !errors.Is(err, workload.ErrPeriodTooLong) {
+		t.Errorf("long range error = %v", err)
+	}
+}
+
+func TestSetCapacityRejectsNegative(t *testing.T) {
+	svc := NewWorkloadService(&repo{}, Config{})
+	negative := -1
+	if err := svc.SetCapacity(context.Background(), uuid.New(), &negative); !errors.Is(err, workload.ErrInvalidCapacity) {
+		t.Errorf("error = %v, want ErrInvalidCapacity", err)
+	}
+}
@@ -0,0 +1,116 @@
+package worklog
+
+import (
+	"ProjectManagementAPI/internal/domain/worklog"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+type RepositoryInterface interface {
+	Create(ctx context.Context, w *worklog.Worklog) error
+	StopTimer(ctx context.Context, userID uuid.UUID, note *string) (*worklog.Worklog, error)
+	RunningTimer(ctx context.Context, userID uuid.UUID) (*worklog.Worklog, error)
+	List(ctx context.Context, f worklog.Filter) ([]worklog.Worklog, error)
+	DeleteByID(ctx context.Context, userID, id uuid.UUID) error
+	Totals(ctx context.Context, groupBy string, f worklog.Filter) ([]worklog.Total, error)
+}
+
+type Service struct {
+	repo RepositoryInterface
+}
+
+func NewWorklogService(repo RepositoryInterface) *Service {
+	return &Service{repo: repo}
+}
+
+// StartTimer запускает таймер по задаче. У пользователя может быть только
+// один запущенный таймер.
+func (s *Service) StartTimer(ctx context.Context, userID, taskID uuid.UUID, note string) (*worklog.Worklog, error) {
+	w := &worklog.Worklog{
+		UserID:    userID,
+		TaskID:    taskID,
+		StartedAt: time.Now(),
+		Note:      note,
+		Source:    worklog.SourceTimer,
+	}
+	if err := s.repo.Create(ctx, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (s *Service) StopTimer(ctx context.Context, userID uuid.UUID, note *string) (*worklog.Worklog, error) {
+	return s.repo.StopTimer(ctx, userID, note)
+}
+
+func (s *Service) RunningTimer(ctx context.Context, userID uuid.UUID) (*worklog.Worklog, error) {
+	return s.repo.RunningTimer(ctx, userID)
+}
+
+// AddManual добавляет запись о времени, учтённом без таймера.
+func (s *Service) AddManual(ctx context.Context, userID, taskID uuid.UUID, startedAt time.Time, duration time.Duration,
+	note string) (*worklog.Worklog, error) {
+	if duration <= 0 {
+		return nil, worklog.ErrInvalidDuration
+	}
+	if startedAt.After(time.Now()) {
+		return nil, worklog.ErrStartedInFuture
+	}
+
+	duration = duration.Truncate(time.Second)
+	w := &worklog.Worklog{
+		UserID:    userID,
+		TaskID:    taskID,
+		StartedAt: startedAt,
+		Duration:  &duration,
+		Note:      note,
+		Source:    worklog.SourceManual,
+	}
+	if err := s.repo.Create(ctx, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (s *Service) List(ctx context.Context, f worklog.Filter) ([]worklog.Worklog, error) {
+	if err := validRange(f); err != nil {
+		return nil, err
+	}
+	if f.Limit <= 0 {
+		f.Limit = defaultLimit
+	}
+	if f.Limit > maxLimit {
+		f.Limit = maxLimit
+	}
+	return s.repo.List(ctx, f)
+}
+
+func (s *Service) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	return s.repo.DeleteByID(ctx, userID, id)
+}
+
+// Totals возвращает время по задачам, пользователям или проектам за период.
+// Запущенные таймеры не учитываются.
+func (s *Service) Totals(ctx context.Context, groupBy string, f worklog.Filter) ([]worklog.Total, error) {
+	if !worklog.ValidGroupBy(groupBy) {
+		return nil, worklog.ErrInvalidGroupBy
+	}
+	if err := validRange(f); err != nil {
+		return nil, err
+	}
+	return s.repo.Totals(ctx, groupBy, f)
+}
+
+func validRange(f worklog.Filter) error {
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return worklog.ErrInvalidRange
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package worklog
+
+import (
+	"ProjectManagementAPI/internal/domain/worklog"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// memRepo повторяет уникальный индекс idx_worklogs_running_timer: не больше
+// одной записи без Duration на пользователя.
+type memRepo struct {
+	RepositoryInterface
+	logs []*worklog.Worklog
+}
+
+func (r *memRepo) Create(_ context.Context, w *worklog.Worklog) error {
+	if w.Duration == nil {
+		if _, err := r.running(w.UserID); err == nil {
+			return worklog.ErrTimerRunning
+		}
+	}
+	w.ID = uuid.New()
+	r.logs = append(r.logs, w)
+	return nil
+}
+
+func (r *memRepo) running(userID uuid.UUID) (*worklog.Worklog, error) {
+	for _, w := range r.logs {
+		if w.UserID == userID && w.Duration == nil {
+			return w, nil
+		}
+	}
+	return nil, worklog.ErrNoRunningTimer
+}
+
+func (r *memRepo) StopTimer(_ context.Context, userID uuid.UUID, _ *string) (*worklog.Worklog, error) {
+	w, err := r.running(userID)
+	if err != nil {
+		return nil, err
+	}
+	d := time.Since(w.StartedAt).Truncate(time.Second)
+	w.Duration = &d
+	return w, nil
+}
+
+func TestOneRunningTimerPerUser(t *testing.T) {
+	repo := &memRepo{}
+	svc := NewWorklogService(repo)
+	ctx := context.Background()
+	user, task := uuid.New(), uuid.New()
+
+	w, err := svc.StartTimer(ctx, user, task, "")
+	if err != nil {
+		t.Fatalf("StartTimer: %v", err)
+	}
+	if w.Duration != nil || w.Source != worklog.SourceTimer {
+		t.Errorf("timer = %+v, want running timer", w)
+	}
+
+	// второй таймер - даже по другой задаче - запрещён
+	if _, err := svc.StartTimer(ctx, user, uuid.New(), ""); !errors.Is(err, worklog.ErrTimerRunning) {
+		t.Errorf("second StartTimer error = %v, want ErrTimerRunning", err)
+	}
+
+	// ручные записи не считаются таймером
+	if _, err := svc.AddManual(ctx, user, task, time.Now().Add(-time.Hour), 30*time.Minute, ""); err != nil {
+		t.Errorf("AddManual while timer runs: %v", err)
+	}
+
+	// у другого пользователя свой таймер
+	if _, err := svc.StartTimer(ctx, uuid.New(), task, ""); err != nil {
+		t.Errorf("StartTimer for another user: %v", err)
+	}
+
+	if _, err := svc.StopTimer(ctx, user, nil); err != nil {
+		t.Fatalf("StopTimer: %v", err)
+	}
+	if _, err := svc.StopTimer(ctx, user, nil); !errors.Is(err, worklog.ErrNoRunningTimer) {
+		t.Errorf("second StopTimer error = %v, want ErrNoRunningTimer", err)
+	}
+	if _, err := svc.StartTimer(ctx, user, task, ""); err != nil {
+		t.Errorf("StartTimer after stop: %v", err)
+	}
+}
+
+func TestAddManual(t *testing.T) {
+	svc := NewWorklogService(&memRepo{})
+	ctx := context.Background()
+	past := time.Now().Add(-2 * time.Hour)
+
+	w, err := svc.AddManual(ctx, uuid.New(), uuid.New(), past, 90*time.Minute+500*time.Millisecond, "review")
+	if err != nil {
+		t.Fatalf("AddManual: %v", err)
+	}
+	if w.Duration == nil || *w.Duration != 90*time.Minute || w.Source != worklog.SourceManual {
+		t.Errorf("worklog = %+v, want 1h30m manual entry", w)
+	}
+
+	for _, d := range []time.Duration{0, -time.Minute} {
+		if _, err := svc.AddManual(ctx, uuid.New(), uuid.New(), past, d, ""); !errors.Is(err, worklog.ErrInvalidDuration) {
+			t.Errorf("AddManual(%v) error = %v, want ErrInvalidDuration", d, err)
+		}
+	}
+	if _, err := svc.AddManual(ctx, uuid.New(), uuid.New(), time.Now().Add(time.Hour), time.Minute, ""); !errors.Is(err, worklog.ErrStartedInFuture) {
+		t.Errorf("future AddManual error = %v, want ErrStartedInFuture", err)
+	}
+}
+
+func TestTotalsValidation(t *testing.T) {
+	svc := NewWorklogService(&memRepo{})
+	ctx := context.Background()
+
+	if _, err := svc.Totals(ctx, "day", worklog.Filter{}); !errors.Is(err, worklog.ErrInvalidGroupBy) {
+		t.Errorf("group_by=day error = %v", err)
+	}
+	from := time.Now()
+	if _, err := svc.Totals(ctx, worklog.GroupByTask, worklog.Filter{From: &from, To: &from}); !errors.Is(err, worklog.ErrInvalidRange) {
+		t.Errorf("empty range error = %v", err)
+	}
+}
@@ -0,0 +1,15 @@
+CREATE TABLE milestones (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    title TEXT NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (end_date >= start_date)
+);
+
+ALTER TABLE tasks
+    ADD COLUMN milestone_id UUID REFERENCES milestones(id) ON DELETE SET NULL,
+    ADD COLUMN story_points INT NOT NULL DEFAULT 0 CHECK (story_points >= 0),
+    ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+CREATE INDEX idx_tasks_milestone_id ON tasks(milestone_id);
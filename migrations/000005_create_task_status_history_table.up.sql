@@ -0,0 +1,12 @@
+CREATE TABLE task_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    task_id UUID NOT NULL,
+    status TEXT NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_task_status_history_task_id ON task_status_history(task_id, changed_at);
+
+INSERT INTO task_status_history(task_id, status, changed_at)
+SELECT id, status, created_at FROM tasks;
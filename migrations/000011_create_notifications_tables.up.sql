@@ -0,0 +1,33 @@
+CREATE TABLE notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    type TEXT NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    task_id UUID,
+    dedupe_key TEXT NOT NULL,
+    in_app BOOLEAN NOT NULL,
+    email BOOLEAN NOT NULL,
+    read_at TIMESTAMP,
+    email_status TEXT,
+    email_attempts INT NOT NULL DEFAULT 0,
+    email_next_attempt_at TIMESTAMP,
+    email_error TEXT,
+    emailed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, dedupe_key),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_notifications_inbox ON notifications(user_id, created_at DESC) WHERE in_app;
+CREATE INDEX idx_notifications_email_due ON notifications(email_next_attempt_at) WHERE email_status = 'pending';
+
+CREATE TABLE notification_preferences (
+    user_id UUID NOT NULL,
+    type TEXT NOT NULL,
+    in_app BOOLEAN NOT NULL,
+    email BOOLEAN NOT NULL,
+    PRIMARY KEY (user_id, type),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
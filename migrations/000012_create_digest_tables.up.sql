@@ -0,0 +1,24 @@
+CREATE TABLE digest_settings (
+    user_id UUID PRIMARY KEY,
+    frequency TEXT NOT NULL DEFAULT 'off' CHECK (frequency IN ('off', 'daily', 'weekly')),
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    send_hour INT NOT NULL DEFAULT 9 CHECK (send_hour BETWEEN 0 AND 23),
+    weekday INT NOT NULL DEFAULT 1 CHECK (weekday BETWEEN 0 AND 6),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE digest_runs (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL,
+    frequency TEXT NOT NULL,
+    period_key TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('sending', 'sent', 'empty', 'failed')),
+    items INT NOT NULL DEFAULT 0,
+    attempts INT NOT NULL DEFAULT 1,
+    error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMP,
+    UNIQUE (user_id, frequency, period_key),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,33 @@
+CREATE TABLE jobs (
+    id BIGSERIAL PRIMARY KEY,
+    kind TEXT NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    status TEXT NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'running', 'succeeded', 'failed', 'cancelled')),
+    unique_key TEXT,
+    run_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    attempts INT NOT NULL DEFAULT 0,
+    max_attempts INT NOT NULL DEFAULT 5,
+    last_error TEXT,
+    locked_by TEXT,
+    locked_until TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX idx_jobs_due ON jobs(run_at) WHERE status IN ('pending', 'running');
+CREATE INDEX idx_jobs_status ON jobs(status, id DESC);
+
+-- Пока задание не завершено, второе с тем же ключом не создаётся
+CREATE UNIQUE INDEX idx_jobs_unique_key ON jobs(unique_key)
+    WHERE unique_key IS NOT NULL AND status IN ('pending', 'running');
+
+CREATE TABLE job_schedules (
+    name TEXT PRIMARY KEY,
+    spec TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    next_run_at TIMESTAMP NOT NULL,
+    last_run_at TIMESTAMP
+);
@@ -0,0 +1,27 @@
+CREATE TABLE task_series (
+    id UUID PRIMARY KEY,
+    rrule TEXT NOT NULL,
+    dtstart TIMESTAMP NOT NULL,
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    title TEXT NOT NULL,
+    description TEXT NOT NULL,
+    story_points INT NOT NULL DEFAULT 0,
+    milestone_id UUID REFERENCES milestones(id) ON DELETE SET NULL,
+    project_id UUID REFERENCES projects(id) ON DELETE SET NULL,
+    assignees UUID[] NOT NULL,
+    last_occurrence_at TIMESTAMP NOT NULL,
+    next_occurrence_at TIMESTAMP,
+    -- Повторения начиная с ends_before не создаются (серия остановлена или разделена)
+    ends_before TIMESTAMP,
+    finished BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_task_series_next ON task_series(next_occurrence_at) WHERE NOT finished;
+
+ALTER TABLE tasks
+    ADD COLUMN series_id UUID REFERENCES task_series(id) ON DELETE SET NULL,
+    ADD COLUMN occurrence_at TIMESTAMP;
+
+CREATE UNIQUE INDEX idx_tasks_series_occurrence ON tasks(series_id, occurrence_at) WHERE series_id IS NOT NULL;
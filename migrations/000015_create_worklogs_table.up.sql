@@ -0,0 +1,18 @@
+CREATE TABLE worklogs (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    started_at TIMESTAMP NOT NULL,
+    -- NULL - таймер ещё идёт
+    duration_seconds INT CHECK (duration_seconds >= 0),
+    note TEXT NOT NULL DEFAULT '',
+    source TEXT NOT NULL CHECK (source IN ('timer', 'manual')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_worklogs_task ON worklogs(task_id, started_at);
+CREATE INDEX idx_worklogs_started_at ON worklogs(started_at);
+
+-- Не больше одного запущенного таймера на пользователя
+CREATE UNIQUE INDEX idx_worklogs_running_timer ON worklogs(user_id) WHERE duration_seconds IS NULL;
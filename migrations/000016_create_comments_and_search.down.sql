@@ -0,0 +1,12 @@
+DROP TRIGGER IF EXISTS tasks_search_update ON tasks;
+DROP INDEX IF EXISTS idx_tasks_title_trgm;
+DROP INDEX IF EXISTS idx_tasks_search;
+ALTER TABLE tasks DROP COLUMN IF EXISTS search_vector;
+
+DROP TABLE IF EXISTS comments;
+
+DROP FUNCTION IF EXISTS comments_search_trigger();
+DROP FUNCTION IF EXISTS tasks_search_trigger();
+DROP FUNCTION IF EXISTS task_search_vector(TEXT, TEXT);
+DROP FUNCTION IF EXISTS search_language();
+DROP TABLE IF EXISTS search_settings;
@@ -0,0 +1,58 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE TABLE comments (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    author_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_comments_task ON comments(task_id, created_at);
+
+-- Язык полнотекстового поиска; приложение записывает сюда search.language при старте
+CREATE TABLE search_settings (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    language REGCONFIG NOT NULL
+);
+
+INSERT INTO search_settings(language) VALUES ('simple');
+
+CREATE FUNCTION search_language() RETURNS REGCONFIG LANGUAGE sql STABLE AS $$
+    SELECT language FROM search_settings
+$$;
+
+CREATE FUNCTION task_search_vector(title TEXT, description TEXT) RETURNS TSVECTOR LANGUAGE sql STABLE AS $$
+    SELECT setweight(to_tsvector(search_language(), COALESCE(title, '')), 'A') ||
+           setweight(to_tsvector(search_language(), COALESCE(description, '')), 'B')
+$$;
+
+CREATE FUNCTION tasks_search_trigger() RETURNS TRIGGER LANGUAGE plpgsql AS $$
+BEGIN
+    NEW.search_vector := task_search_vector(NEW.title, NEW.description);
+    RETURN NEW;
+END
+$$;
+
+CREATE FUNCTION comments_search_trigger() RETURNS TRIGGER LANGUAGE plpgsql AS $$
+BEGIN
+    NEW.search_vector := to_tsvector(search_language(), NEW.body);
+    RETURN NEW;
+END
+$$;
+
+ALTER TABLE tasks ADD COLUMN search_vector TSVECTOR;
+ALTER TABLE comments ADD COLUMN search_vector TSVECTOR;
+
+UPDATE tasks SET search_vector = task_search_vector(title, description);
+
+CREATE TRIGGER tasks_search_update BEFORE INSERT OR UPDATE OF title, description ON tasks
+    FOR EACH ROW EXECUTE FUNCTION tasks_search_trigger();
+CREATE TRIGGER comments_search_update BEFORE INSERT OR UPDATE OF body ON comments
+    FOR EACH ROW EXECUTE FUNCTION comments_search_trigger();
+
+CREATE INDEX idx_tasks_search ON tasks USING GIN (search_vector);
+CREATE INDEX idx_comments_search ON comments USING GIN (search_vector);
+-- Нечёткий поиск по названию, когда полнотекстовый ничего не нашёл
+CREATE INDEX idx_tasks_title_trgm ON tasks USING GIN (title gin_trgm_ops);
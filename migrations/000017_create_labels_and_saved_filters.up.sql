@@ -0,0 +1,21 @@
+CREATE TABLE task_labels (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    label TEXT NOT NULL,
+    PRIMARY KEY (task_id, label)
+);
+
+CREATE INDEX idx_task_labels_label ON task_labels(label);
+
+CREATE TABLE saved_filters (
+    id UUID PRIMARY KEY,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    query TEXT NOT NULL,
+    -- shared - фильтр виден всем пользователям, менять его может только владелец
+    shared BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT saved_filters_owner_name_key UNIQUE (owner_id, name)
+);
+
+CREATE INDEX idx_saved_filters_shared ON saved_filters(name) WHERE shared;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS import_refs;
+DROP TABLE IF EXISTS imports;
@@ -0,0 +1,23 @@
+CREATE TABLE imports (
+    id UUID PRIMARY KEY,
+    -- source - формат файла: jira_xml, jira_csv, trello, github
+    source TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'running', 'succeeded', 'failed')),
+    options JSONB NOT NULL DEFAULT '{}',
+    -- file хранится до завершения импорта
+    file BYTEA,
+    report JSONB,
+    error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP
+);
+
+-- Перенесённые элементы внешних систем: повторный импорт их пропускает
+CREATE TABLE import_refs (
+    system TEXT NOT NULL,
+    external_id TEXT NOT NULL,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    import_id UUID REFERENCES imports(id) ON DELETE SET NULL,
+    PRIMARY KEY (system, external_id)
+);
@@ -0,0 +1,7 @@
+CREATE TABLE calendar_tokens (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    -- token_hash - SHA-256 токена из ссылки, сам токен не хранится
+    token_hash TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT calendar_tokens_token_hash_key UNIQUE (token_hash)
+);
@@ -0,0 +1,2 @@
+-- weekly_capacity - недельная ёмкость в story points, NULL - значение из конфига
+ALTER TABLE users ADD COLUMN weekly_capacity INTEGER CHECK (weekly_capacity >= 0);
@@ -0,0 +1,2 @@
+-- Исходные значения статусов не восстанавливаются.
+ALTER TABLE tasks DROP CONSTRAINT tasks_status_check;
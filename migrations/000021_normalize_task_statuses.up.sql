@@ -0,0 +1,28 @@
+-- До milestones статус задачи был произвольной строкой. Прежние значения
+-- приводятся к todo, in_progress, done по тем же названиям, что и при импорте,
+-- остальные становятся todo.
+CREATE FUNCTION normalize_task_status(s TEXT) RETURNS TEXT AS $$
+    SELECT CASE regexp_replace(lower(trim(s)), '[-_ ]+', ' ', 'g')
+        WHEN 'in progress' THEN 'in_progress'
+        WHEN 'doing' THEN 'in_progress'
+        WHEN 'in review' THEN 'in_progress'
+        WHEN 'review' THEN 'in_progress'
+        WHEN 'testing' THEN 'in_progress'
+        WHEN 'done' THEN 'done'
+        WHEN 'closed' THEN 'done'
+        WHEN 'resolved' THEN 'done'
+        WHEN 'complete' THEN 'done'
+        WHEN 'completed' THEN 'done'
+        ELSE 'todo'
+    END
+$$ LANGUAGE SQL IMMUTABLE;
+
+UPDATE tasks SET status = normalize_task_status(status)
+WHERE status NOT IN ('todo', 'in_progress', 'done');
+
+UPDATE task_status_history SET status = normalize_task_status(status)
+WHERE status NOT IN ('todo', 'in_progress', 'done');
+
+DROP FUNCTION normalize_task_status(TEXT);
+
+ALTER TABLE tasks ADD CONSTRAINT tasks_status_check CHECK (status IN ('todo', 'in_progress', 'done'));
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_outbox_pending_aggregate;
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX idx_outbox_unpublished ON outbox(id) WHERE published_at IS NULL;
+
+ALTER TABLE outbox
+    DROP COLUMN next_attempt_at,
+    DROP COLUMN status;
@@ -0,0 +1,10 @@
+-- status: pending, published, dead (попытки исчерпаны)
+ALTER TABLE outbox
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'pending',
+    ADD COLUMN next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+UPDATE outbox SET status = 'published' WHERE published_at IS NOT NULL;
+
+DROP INDEX idx_outbox_unpublished;
+CREATE INDEX idx_outbox_pending ON outbox(id) WHERE status = 'pending';
+CREATE INDEX idx_outbox_pending_aggregate ON outbox(aggregate_id, id) WHERE status = 'pending';
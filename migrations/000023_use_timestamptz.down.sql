@@ -0,0 +1,84 @@
+-- Времена возвращаются к местному времени TimeZone сессии, outbox.occurred_at - к UTC.
+
+ALTER TABLE tasks
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN updated_at TYPE TIMESTAMP,
+    ALTER COLUMN due_at TYPE TIMESTAMP,
+    ALTER COLUMN occurrence_at TYPE TIMESTAMP;
+
+ALTER TABLE milestones
+    ALTER COLUMN created_at TYPE TIMESTAMP;
+
+ALTER TABLE task_status_history
+    ALTER COLUMN changed_at TYPE TIMESTAMP;
+
+ALTER TABLE audit_events
+    ALTER COLUMN created_at TYPE TIMESTAMP;
+
+ALTER TABLE webhook_subscriptions
+    ALTER COLUMN created_at TYPE TIMESTAMP;
+
+ALTER TABLE webhook_deliveries
+    ALTER COLUMN next_attempt_at TYPE TIMESTAMP,
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN delivered_at TYPE TIMESTAMP;
+
+ALTER TABLE outbox
+    ALTER COLUMN published_at TYPE TIMESTAMP,
+    ALTER COLUMN next_attempt_at TYPE TIMESTAMP,
+    ALTER COLUMN occurred_at TYPE TIMESTAMP USING occurred_at AT TIME ZONE 'UTC';
+
+ALTER TABLE projects
+    ALTER COLUMN created_at TYPE TIMESTAMP;
+
+ALTER TABLE notifications
+    ALTER COLUMN read_at TYPE TIMESTAMP,
+    ALTER COLUMN email_next_attempt_at TYPE TIMESTAMP,
+    ALTER COLUMN emailed_at TYPE TIMESTAMP,
+    ALTER COLUMN created_at TYPE TIMESTAMP;
+
+ALTER TABLE digest_settings
+    ALTER COLUMN updated_at TYPE TIMESTAMP;
+
+ALTER TABLE digest_runs
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN sent_at TYPE TIMESTAMP;
+
+ALTER TABLE jobs
+    ALTER COLUMN run_at TYPE TIMESTAMP,
+    ALTER COLUMN locked_until TYPE TIMESTAMP,
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN updated_at TYPE TIMESTAMP,
+    ALTER COLUMN finished_at TYPE TIMESTAMP;
+
+ALTER TABLE job_schedules
+    ALTER COLUMN next_run_at TYPE TIMESTAMP,
+    ALTER COLUMN last_run_at TYPE TIMESTAMP;
+
+ALTER TABLE task_series
+    ALTER COLUMN dtstart TYPE TIMESTAMP,
+    ALTER COLUMN last_occurrence_at TYPE TIMESTAMP,
+    ALTER COLUMN next_occurrence_at TYPE TIMESTAMP,
+    ALTER COLUMN ends_before TYPE TIMESTAMP,
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN updated_at TYPE TIMESTAMP;
+
+ALTER TABLE worklogs
+    ALTER COLUMN started_at TYPE TIMESTAMP,
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN updated_at TYPE TIMESTAMP;
+
+ALTER TABLE comments
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN updated_at TYPE TIMESTAMP;
+
+ALTER TABLE saved_filters
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN updated_at TYPE TIMESTAMP;
+
+ALTER TABLE imports
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN finished_at TYPE TIMESTAMP;
+
+ALTER TABLE calendar_tokens
+    ALTER COLUMN created_at TYPE TIMESTAMP;
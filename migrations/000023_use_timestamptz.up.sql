@@ -0,0 +1,87 @@
+-- Все времена хранятся как TIMESTAMPTZ: момент времени не зависит от зоны
+-- сервера приложения и сессии БД. Раньше TIMESTAMP хранил местное время
+-- сервера без зоны, поэтому миграцию нужно запускать с TimeZone сессии,
+-- равной зоне сервера приложения (PGTZ). outbox.occurred_at писался в UTC.
+
+ALTER TABLE tasks
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN due_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN occurrence_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE milestones
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE task_status_history
+    ALTER COLUMN changed_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE audit_events
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE webhook_subscriptions
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE webhook_deliveries
+    ALTER COLUMN next_attempt_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN delivered_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE outbox
+    ALTER COLUMN published_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN next_attempt_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN occurred_at TYPE TIMESTAMPTZ USING occurred_at AT TIME ZONE 'UTC';
+
+ALTER TABLE projects
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE notifications
+    ALTER COLUMN read_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN email_next_attempt_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN emailed_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE digest_settings
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE digest_runs
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN sent_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE jobs
+    ALTER COLUMN run_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN locked_until TYPE TIMESTAMPTZ,
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN finished_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE job_schedules
+    ALTER COLUMN next_run_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN last_run_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE task_series
+    ALTER COLUMN dtstart TYPE TIMESTAMPTZ,
+    ALTER COLUMN last_occurrence_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN next_occurrence_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN ends_before TYPE TIMESTAMPTZ,
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE worklogs
+    ALTER COLUMN started_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE comments
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE saved_filters
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE imports
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN finished_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE calendar_tokens
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;